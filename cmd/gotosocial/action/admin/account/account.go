@@ -204,6 +204,43 @@ var Disable action.GTSAction = func(ctx context.Context) error {
 	return dbConn.Stop(ctx)
 }
 
+// ResetTwoFactor turns off two-factor authentication for a user, for when they've lost their authenticator and recovery codes.
+var ResetTwoFactor action.GTSAction = func(ctx context.Context) error {
+	dbConn, err := bundb.NewBunDBService(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	username := viper.GetString(config.Keys.AdminAccountUsername)
+	if username == "" {
+		return errors.New("no username set")
+	}
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	a, err := dbConn.GetLocalAccountByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	u := &gtsmodel.User{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, u); err != nil {
+		return err
+	}
+	u.TwoFactorSecret = ""
+	u.TwoFactorEnabledAt = time.Time{}
+	u.TwoFactorLastStep = 0
+	u.TwoFactorRecoveryCodes = nil
+	u.TwoFactorAttempts = 0
+	u.TwoFactorLockedUntil = time.Time{}
+	if err := dbConn.UpdateByPrimaryKey(ctx, u); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
 // Suspend suspends the target account, cleanly removing all of its media, followers, following, likes, statuses, etc.
 var Suspend action.GTSAction = func(ctx context.Context) error {
 	// TODO
@@ -24,11 +24,9 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"path"
 	"syscall"
+	"time"
 
-	"codeberg.org/gruf/go-store/kv"
-	"codeberg.org/gruf/go-store/storage"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
@@ -38,6 +36,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/emoji"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
@@ -45,11 +45,18 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/poll"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatus"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
 	userClient "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
@@ -67,13 +74,21 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Start creates and starts a gotosocial server
 var Start action.GTSAction = func(ctx context.Context) error {
+	switch federationMode := viper.GetString(config.Keys.FederationMode); federationMode {
+	case config.FederationModeBlocklist, config.FederationModeAllowlist, config.FederationModeNone:
+	default:
+		return fmt.Errorf("federation mode %s not recognised, must be one of %s, %s or %s", federationMode, config.FederationModeBlocklist, config.FederationModeAllowlist, config.FederationModeNone)
+	}
+
 	dbService, err := bundb.NewBunDBService(ctx)
 	if err != nil {
 		return fmt.Errorf("error creating dbservice: %s", err)
@@ -98,15 +113,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	typeConverter := typeutils.NewConverter(dbService)
 
 	// Open the storage backend
-	storageBasePath := viper.GetString(config.Keys.StorageLocalBasePath)
-	storage, err := kv.OpenFile(storageBasePath, &storage.DiskConfig{
-		// Put the store lockfile in the storage dir itself.
-		// Normally this would not be safe, since we could end up
-		// overwriting the lockfile if we store a file called 'store.lock'.
-		// However, in this case it's OK because the keys are set by
-		// GtS and not the user, so we know we're never going to overwrite it.
-		LockFile: path.Join(storageBasePath, "store.lock"),
-	})
+	storage, err := gtsstorage.AutoConfig()
 	if err != nil {
 		return fmt.Errorf("error creating storage backend: %s", err)
 	}
@@ -119,6 +126,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	oauthServer := oauth.New(ctx, dbService)
 	transportController := transport.NewController(dbService, federatingDB, &federation.Clock{}, http.DefaultClient)
 	federator := federation.NewFederator(dbService, federatingDB, transportController, typeConverter, mediaManager)
+	if err := transportController.Start(ctx); err != nil {
+		return fmt.Errorf("error starting transport controller: %s", err)
+	}
 
 	// decide whether to create a noop email sender (won't send emails) or a real one
 	var emailSender email.Sender
@@ -137,8 +147,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
+	// build web push sender for delivering notifications to push subscriptions
+	webPushSender := webpush.NewSender(dbService, &http.Client{Timeout: 30 * time.Second})
+
 	// create and start the message processor using the other services we've created so far
-	processor := processing.NewProcessor(typeConverter, federator, oauthServer, mediaManager, storage, dbService, emailSender)
+	processor := processing.NewProcessor(typeConverter, federator, oauthServer, mediaManager, storage, dbService, emailSender, webPushSender)
 	if err := processor.Start(ctx); err != nil {
 		return fmt.Errorf("error starting processor: %s", err)
 	}
@@ -155,7 +168,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}
 
 	// build client api modules
-	authModule := auth.New(dbService, oauthServer, idp)
+	authModule := auth.New(dbService, oauthServer, idp, processor)
 	accountModule := account.New(processor)
 	instanceModule := instance.New(processor)
 	appsModule := app.New(processor)
@@ -165,10 +178,13 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	usersModule := user.New(processor)
 	timelineModule := timeline.New(processor)
 	notificationModule := notification.New(processor)
+	pollModule := poll.New(processor)
 	searchModule := search.New(processor)
 	filtersModule := filter.New(processor)
 	emojiModule := emoji.New(processor)
 	listsModule := list.New(processor)
+	reportsModule := reports.New(processor)
+	scheduledStatusesModule := scheduledstatus.New(processor)
 	mm := mediaModule.New(processor)
 	fileServerModule := fileserver.New(processor)
 	adminModule := admin.New(processor)
@@ -177,6 +193,12 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	streamingModule := streaming.New(processor)
 	favouritesModule := favourites.New(processor)
 	blocksModule := blocks.New(processor)
+	bookmarksModule := bookmarks.New(processor)
+	mutesModule := mutes.New(processor)
+	tagsModule := tags.New(processor)
+	conversationsModule := conversations.New(processor)
+	markersModule := markers.New(processor)
+	pushModule := push.New(processor)
 	userClientModule := userClient.New(processor)
 
 	apis := []api.ClientModule{
@@ -201,13 +223,22 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		usersModule,
 		timelineModule,
 		notificationModule,
+		pollModule,
 		searchModule,
 		filtersModule,
 		emojiModule,
 		listsModule,
+		reportsModule,
+		scheduledStatusesModule,
 		streamingModule,
 		favouritesModule,
 		blocksModule,
+		bookmarksModule,
+		mutesModule,
+		tagsModule,
+		conversationsModule,
+		markersModule,
+		pushModule,
 		userClientModule,
 	}
 
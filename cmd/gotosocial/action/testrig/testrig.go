@@ -36,6 +36,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/emoji"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
@@ -43,11 +45,18 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/poll"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatus"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
 	userClient "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
@@ -82,6 +91,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}), dbService)
 	mediaManager := testrig.NewTestMediaManager(dbService, storageBackend)
 	federator := testrig.NewTestFederator(dbService, transportController, storageBackend, mediaManager)
+	if err := transportController.Start(ctx); err != nil {
+		return fmt.Errorf("error starting transport controller: %s", err)
+	}
 
 	emailSender := testrig.NewEmailSender("./web/template/", nil)
 
@@ -102,7 +114,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}
 
 	// build client api modules
-	authModule := auth.New(dbService, oauthServer, idp)
+	authModule := auth.New(dbService, oauthServer, idp, processor)
 	accountModule := account.New(processor)
 	instanceModule := instance.New(processor)
 	appsModule := app.New(processor)
@@ -112,10 +124,13 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	usersModule := user.New(processor)
 	timelineModule := timeline.New(processor)
 	notificationModule := notification.New(processor)
+	pollModule := poll.New(processor)
 	searchModule := search.New(processor)
 	filtersModule := filter.New(processor)
 	emojiModule := emoji.New(processor)
 	listsModule := list.New(processor)
+	reportsModule := reports.New(processor)
+	scheduledStatusesModule := scheduledstatus.New(processor)
 	mm := mediaModule.New(processor)
 	fileServerModule := fileserver.New(processor)
 	adminModule := admin.New(processor)
@@ -124,6 +139,12 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	streamingModule := streaming.New(processor)
 	favouritesModule := favourites.New(processor)
 	blocksModule := blocks.New(processor)
+	bookmarksModule := bookmarks.New(processor)
+	mutesModule := mutes.New(processor)
+	tagsModule := tags.New(processor)
+	conversationsModule := conversations.New(processor)
+	markersModule := markers.New(processor)
+	pushModule := push.New(processor)
 	userClientModule := userClient.New(processor)
 
 	apis := []api.ClientModule{
@@ -148,13 +169,22 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		usersModule,
 		timelineModule,
 		notificationModule,
+		pollModule,
 		searchModule,
 		filtersModule,
 		emojiModule,
 		listsModule,
+		reportsModule,
+		scheduledStatusesModule,
 		streamingModule,
 		favouritesModule,
 		blocksModule,
+		bookmarksModule,
+		mutesModule,
+		tagsModule,
+		conversationsModule,
+		markersModule,
+		pushModule,
 		userClientModule,
 	}
 
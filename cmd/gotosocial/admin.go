@@ -107,6 +107,19 @@ func adminCommands() *cobra.Command {
 	flag.AdminAccount(adminAccountDisableCmd, config.Defaults)
 	adminAccountCmd.AddCommand(adminAccountDisableCmd)
 
+	adminAccountResetTwoFactorCmd := &cobra.Command{
+		Use:   "reset-two-factor",
+		Short: "turn off two-factor authentication for an account, so it can sign in with just its password",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.ResetTwoFactor)
+		},
+	}
+	flag.AdminAccount(adminAccountResetTwoFactorCmd, config.Defaults)
+	adminAccountCmd.AddCommand(adminAccountResetTwoFactorCmd)
+
 	adminAccountSuspendCmd := &cobra.Command{
 		Use:   "suspend",
 		Short: "completely remove an account and all of its posts, media, etc",
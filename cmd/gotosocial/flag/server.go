@@ -35,6 +35,8 @@ func Server(cmd *cobra.Command, values config.Values) {
 	SMTP(cmd, values)
 	Router(cmd, values)
 	Syslog(cmd, values)
+	RateLimit(cmd, values)
+	Federation(cmd, values)
 }
 
 // Router attaches flags pertaining to the gin router.
@@ -70,6 +72,13 @@ func Media(cmd *cobra.Command, values config.Values) {
 func Storage(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().String(config.Keys.StorageBackend, values.StorageBackend, usage.StorageBackend)
 	cmd.Flags().String(config.Keys.StorageLocalBasePath, values.StorageLocalBasePath, usage.StorageLocalBasePath)
+	cmd.Flags().String(config.Keys.StorageS3Endpoint, values.StorageS3Endpoint, usage.StorageS3Endpoint)
+	cmd.Flags().String(config.Keys.StorageS3Region, values.StorageS3Region, usage.StorageS3Region)
+	cmd.Flags().String(config.Keys.StorageS3Bucket, values.StorageS3Bucket, usage.StorageS3Bucket)
+	cmd.Flags().String(config.Keys.StorageS3AccessKey, values.StorageS3AccessKey, usage.StorageS3AccessKey)
+	cmd.Flags().String(config.Keys.StorageS3SecretKey, values.StorageS3SecretKey, usage.StorageS3SecretKey)
+	cmd.Flags().Bool(config.Keys.StorageS3UseSSL, values.StorageS3UseSSL, usage.StorageS3UseSSL)
+	cmd.Flags().String(config.Keys.StorageS3ServeMode, values.StorageS3ServeMode, usage.StorageS3ServeMode)
 }
 
 // Statuses attaches flags pertaining to statuses config.
@@ -79,6 +88,7 @@ func Statuses(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().Int(config.Keys.StatusesPollMaxOptions, values.StatusesPollMaxOptions, usage.StatusesPollMaxOptions)
 	cmd.Flags().Int(config.Keys.StatusesPollOptionMaxChars, values.StatusesPollOptionMaxChars, usage.StatusesPollOptionMaxChars)
 	cmd.Flags().Int(config.Keys.StatusesMediaMaxFiles, values.StatusesMediaMaxFiles, usage.StatusesMediaMaxFiles)
+	cmd.Flags().Int(config.Keys.StatusesMaxPinned, values.StatusesMaxPinned, usage.StatusesMaxPinned)
 }
 
 // LetsEncrypt attaches flags pertaining to letsencrypt config.
@@ -115,3 +125,18 @@ func Syslog(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().String(config.Keys.SyslogProtocol, values.SyslogProtocol, usage.SyslogProtocol)
 	cmd.Flags().String(config.Keys.SyslogAddress, values.SyslogAddress, usage.SyslogAddress)
 }
+
+// RateLimit attaches flags pertaining to rate limiting.
+func RateLimit(cmd *cobra.Command, values config.Values) {
+	cmd.Flags().Bool(config.Keys.RateLimitEnabled, values.RateLimitEnabled, usage.RateLimitEnabled)
+	cmd.Flags().Int(config.Keys.RateLimitRequests, values.RateLimitRequests, usage.RateLimitRequests)
+	cmd.Flags().Int(config.Keys.RateLimitDomainRequests, values.RateLimitDomainRequests, usage.RateLimitDomainRequests)
+	cmd.Flags().Int(config.Keys.RateLimitSignInRequests, values.RateLimitSignInRequests, usage.RateLimitSignInRequests)
+	cmd.Flags().Int(config.Keys.RateLimitSignUpRequests, values.RateLimitSignUpRequests, usage.RateLimitSignUpRequests)
+	cmd.Flags().Int(config.Keys.RateLimitMediaRequests, values.RateLimitMediaRequests, usage.RateLimitMediaRequests)
+}
+
+// Federation attaches flags pertaining to federation.
+func Federation(cmd *cobra.Command, values config.Values) {
+	cmd.Flags().String(config.Keys.FederationMode, values.FederationMode, usage.FederationMode)
+}
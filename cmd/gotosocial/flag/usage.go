@@ -51,11 +51,19 @@ var usage = config.KeyNames{
 	MediaRemoteCacheDays:       "Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely.",
 	StorageBackend:             "Storage backend to use for media attachments",
 	StorageLocalBasePath:       "Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir.",
+	StorageS3Endpoint:          "Endpoint of the s3-compatible api to use for storage, as host[:port]",
+	StorageS3Region:            "Region of the s3 bucket",
+	StorageS3Bucket:            "Name of an already-created s3 bucket in which to store media files",
+	StorageS3AccessKey:         "Access key to use for s3 requests",
+	StorageS3SecretKey:         "Secret key to use for s3 requests",
+	StorageS3UseSSL:            "Use https when talking to the s3 endpoint",
+	StorageS3ServeMode:         "How to serve media stored in s3: 'proxy' it through gts, 'redirect' to the object, or redirect to a 'presigned' url",
 	StatusesMaxChars:           "Max permitted characters for posted statuses",
 	StatusesCWMaxChars:         "Max permitted characters for content/spoiler warnings on statuses",
 	StatusesPollMaxOptions:     "Max amount of options permitted on a poll",
 	StatusesPollOptionMaxChars: "Max amount of characters for a poll option",
 	StatusesMediaMaxFiles:      "Maximum number of media files/attachments per status",
+	StatusesMaxPinned:          "Maximum number of statuses an account can have pinned to its profile at once",
 	LetsEncryptEnabled:         "Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default).",
 	LetsEncryptPort:            "Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port.",
 	LetsEncryptCertDir:         "Directory to store acquired letsencrypt certificates.",
@@ -75,6 +83,13 @@ var usage = config.KeyNames{
 	SyslogEnabled:              "Enable the syslog logging hook. Logs will be mirrored to the configured destination.",
 	SyslogProtocol:             "Protocol to use when directing logs to syslog. Leave empty to connect to local syslog.",
 	SyslogAddress:              "Address:port to send syslog logs to. Leave empty to connect to local syslog.",
+	RateLimitEnabled:           "Limit how many requests can be made by the same IP address, account, or remote server in a period of time.",
+	RateLimitRequests:          "Number of requests allowed per IP address or account every 5 minutes.",
+	RateLimitDomainRequests:    "Number of signed requests allowed per remote domain every 5 minutes.",
+	RateLimitSignInRequests:    "Number of sign in attempts allowed per IP address every 5 minutes.",
+	RateLimitSignUpRequests:    "Number of sign ups allowed per IP address every 5 minutes.",
+	RateLimitMediaRequests:     "Number of media uploads allowed per account every 30 minutes.",
+	FederationMode:             "Which domains to federate with. blocklist: all except blocked domains; allowlist: only allowed domains; none: no other domains.",
 	AdminAccountUsername:       "the username to create/delete/etc",
 	AdminAccountEmail:          "the email address of this account",
 	AdminAccountPassword:       "the password to set for this account",
@@ -167,6 +167,17 @@ func ExtractPublished(i WithPublished) (time.Time, error) {
 	return t, nil
 }
 
+// ExtractUpdated extracts the updated time from an activity, if it has one.
+// A zero time will be returned if the activity has never been updated.
+func ExtractUpdated(i WithUpdated) time.Time {
+	updatedProp := i.GetActivityStreamsUpdated()
+	if updatedProp == nil || !updatedProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+
+	return updatedProp.Get()
+}
+
 // ExtractIconURL extracts a URL to a supported image file from something like:
 //   "icon": {
 //     "mediaType": "image/jpeg",
@@ -580,6 +591,97 @@ func ExtractMention(i Mentionable) (*gtsmodel.Mention, error) {
 	return mention, nil
 }
 
+// ExtractPoll extracts a gts model poll from a Pollable.
+//
+// The returned poll will have its options, vote counts, expiry and closed time set,
+// but it won't have an ID or a status ID yet, since these are up to the caller.
+func ExtractPoll(i Pollable) (*gtsmodel.Poll, error) {
+	poll := &gtsmodel.Poll{}
+
+	// a poll should have either oneOf or anyOf set:
+	// anyOf means more than one option can be chosen
+	options := []PollOptionable{}
+	if oneOfProp := i.GetActivityStreamsOneOf(); oneOfProp != nil {
+		for iter := oneOfProp.Begin(); iter != oneOfProp.End(); iter = iter.Next() {
+			if iter.IsActivityStreamsNote() {
+				options = append(options, iter.GetActivityStreamsNote())
+			}
+		}
+	}
+
+	if anyOfProp := i.GetActivityStreamsAnyOf(); anyOfProp != nil {
+		for iter := anyOfProp.Begin(); iter != anyOfProp.End(); iter = iter.Next() {
+			if iter.IsActivityStreamsNote() {
+				options = append(options, iter.GetActivityStreamsNote())
+				poll.Multiple = true
+			}
+		}
+	}
+
+	if len(options) == 0 {
+		return nil, errors.New("no poll options found")
+	}
+
+	voteTotal := 0
+	for _, option := range options {
+		name, err := ExtractName(option)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting poll option name: %s", err)
+		}
+		votes := extractRepliesTotalItems(option)
+		poll.Options = append(poll.Options, name)
+		poll.Votes = append(poll.Votes, votes)
+		voteTotal += votes
+	}
+
+	// voters count is a mastodon extension, so
+	// fall back to the vote total if it's not set
+	poll.Voters = voteTotal
+	if votersCountProp := i.GetTootVotersCount(); votersCountProp != nil && votersCountProp.IsXMLSchemaNonNegativeInteger() {
+		poll.Voters = votersCountProp.Get()
+	}
+
+	if endTimeProp := i.GetActivityStreamsEndTime(); endTimeProp != nil && endTimeProp.IsXMLSchemaDateTime() {
+		poll.ExpiresAt = endTimeProp.Get()
+	}
+
+	// closed can be either a datetime or a boolean
+	if closedProp := i.GetActivityStreamsClosed(); closedProp != nil {
+		for iter := closedProp.Begin(); iter != closedProp.End(); iter = iter.Next() {
+			if iter.IsXMLSchemaDateTime() {
+				poll.ClosedAt = iter.GetXMLSchemaDateTime()
+				break
+			}
+			if iter.IsXMLSchemaBoolean() && iter.GetXMLSchemaBoolean() {
+				poll.ClosedAt = poll.ExpiresAt
+				if poll.ClosedAt.IsZero() {
+					poll.ClosedAt = time.Now()
+				}
+				break
+			}
+		}
+	}
+
+	return poll, nil
+}
+
+// extractRepliesTotalItems returns the totalItems of the replies collection
+// of the given item, or 0 if this isn't set. For poll options, this is the
+// number of votes that the option has received.
+func extractRepliesTotalItems(i WithReplies) int {
+	repliesProp := i.GetActivityStreamsReplies()
+	if repliesProp == nil || !repliesProp.IsActivityStreamsCollection() {
+		return 0
+	}
+
+	totalItemsProp := repliesProp.GetActivityStreamsCollection().GetActivityStreamsTotalItems()
+	if totalItemsProp == nil || !totalItemsProp.IsXMLSchemaNonNegativeInteger() {
+		return 0
+	}
+
+	return totalItemsProp.Get()
+}
+
 // ExtractActor extracts the actor ID/IRI from an interface WithActor.
 func ExtractActor(i WithActor) (*url.URL, error) {
 	actorProp := i.GetActivityStreamsActor()
@@ -608,6 +710,89 @@ func ExtractObject(i WithObject) (*url.URL, error) {
 	return nil, errors.New("no iri found for object prop")
 }
 
+// ExtractObjects extracts all object IDs/IRIs from an interface WithObject.
+func ExtractObjects(i WithObject) ([]*url.URL, error) {
+	objectProp := i.GetActivityStreamsObject()
+	if objectProp == nil {
+		return nil, errors.New("object property was nil")
+	}
+	objects := []*url.URL{}
+	for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			objects = append(objects, iter.GetIRI())
+		}
+	}
+	if len(objects) == 0 {
+		return nil, errors.New("no iris found for object prop")
+	}
+	return objects, nil
+}
+
+// ExtractTarget extracts the target ID/IRI from an interface WithTarget.
+func ExtractTarget(i WithTarget) (*url.URL, error) {
+	targetProp := i.GetActivityStreamsTarget()
+	if targetProp == nil {
+		return nil, errors.New("target property was nil")
+	}
+	for iter := targetProp.Begin(); iter != targetProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			return iter.GetIRI(), nil
+		}
+	}
+	return nil, errors.New("no iri found for target prop")
+}
+
+// ExtractAlsoKnownAs extracts the alsoKnownAs URIs of an account from its unknown properties.
+// The property can be either a single IRI, or an array of IRIs; anything that isn't an IRI is skipped.
+func ExtractAlsoKnownAs(i WithUnknownProperties) []*url.URL {
+	uris := []*url.URL{}
+
+	var values []interface{}
+	switch v := i.GetUnknownProperties()[PropertyAlsoKnownAs].(type) {
+	case []interface{}:
+		values = v
+	case nil:
+		return uris
+	default:
+		values = []interface{}{v}
+	}
+
+	for _, v := range values {
+		if uri := unknownPropertyIRI(v); uri != nil {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+// ExtractMovedTo extracts the movedTo URI of an account from its unknown properties.
+// Returns nil if the account hasn't moved.
+func ExtractMovedTo(i WithUnknownProperties) *url.URL {
+	return unknownPropertyIRI(i.GetUnknownProperties()[PropertyMovedTo])
+}
+
+// unknownPropertyIRI parses an unknown property value as an IRI. The value can be either
+// a plain string, or an object with an id, since that's how json-ld might represent it.
+func unknownPropertyIRI(v interface{}) *url.URL {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case map[string]interface{}:
+		s, _ = v["id"].(string)
+	}
+
+	if s == "" {
+		return nil
+	}
+
+	uri, err := url.Parse(s)
+	if err != nil || uri.Scheme == "" || uri.Host == "" {
+		return nil
+	}
+	return uri
+}
+
 // ExtractVisibility extracts the gtsmodel.Visibility of a given addressable with a To and CC property.
 //
 // ActorFollowersURI is needed to check whether the visibility is FollowersOnly or not. The passed-in value
@@ -0,0 +1,154 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ap_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+func questionFromJSON(questionJSON []byte) vocab.ActivityStreamsQuestion {
+	var jsonAsMap map[string]interface{}
+	if err := json.Unmarshal(questionJSON, &jsonAsMap); err != nil {
+		panic(err)
+	}
+
+	t, err := streams.ToType(context.Background(), jsonAsMap)
+	if err != nil {
+		panic(err)
+	}
+
+	return t.(vocab.ActivityStreamsQuestion)
+}
+
+type ExtractPollTestSuite struct {
+	ExtractTestSuite
+}
+
+func (suite *ExtractPollTestSuite) TestExtractPollOneOf() {
+	// question as serialized by mastodon
+	question := questionFromJSON([]byte(`
+{
+	"@context": [
+		"https://www.w3.org/ns/activitystreams",
+		{
+			"toot": "http://joinmastodon.org/ns#",
+			"votersCount": "toot:votersCount"
+		}
+	],
+	"id": "https://example.org/users/someone/statuses/107043888547829808",
+	"type": "Question",
+	"attributedTo": "https://example.org/users/someone",
+	"content": "<p>which is better?</p>",
+	"endTime": "2021-10-05T15:08:35Z",
+	"votersCount": 3,
+	"oneOf": [
+		{
+			"type": "Note",
+			"name": "tea",
+			"replies": {
+				"type": "Collection",
+				"totalItems": 2
+			}
+		},
+		{
+			"type": "Note",
+			"name": "coffee",
+			"replies": {
+				"type": "Collection",
+				"totalItems": 1
+			}
+		}
+	]
+}`))
+
+	poll, err := ap.ExtractPoll(question)
+	suite.NoError(err)
+	suite.False(poll.Multiple)
+	suite.Equal([]string{"tea", "coffee"}, poll.Options)
+	suite.Equal([]int{2, 1}, poll.Votes)
+	suite.Equal(3, poll.Voters)
+	suite.Equal(time.Date(2021, 10, 5, 15, 8, 35, 0, time.UTC), poll.ExpiresAt.UTC())
+	suite.True(poll.ClosedAt.IsZero())
+}
+
+func (suite *ExtractPollTestSuite) TestExtractPollAnyOfClosed() {
+	question := questionFromJSON([]byte(`
+{
+	"@context": "https://www.w3.org/ns/activitystreams",
+	"id": "https://example.org/users/someone/statuses/107043888547829809",
+	"type": "Question",
+	"attributedTo": "https://example.org/users/someone",
+	"content": "<p>pick as many as you like</p>",
+	"endTime": "2021-10-05T15:08:35Z",
+	"closed": "2021-10-05T15:08:36Z",
+	"anyOf": [
+		{
+			"type": "Note",
+			"name": "cats",
+			"replies": {
+				"type": "Collection",
+				"totalItems": 4
+			}
+		},
+		{
+			"type": "Note",
+			"name": "dogs",
+			"replies": {
+				"type": "Collection",
+				"totalItems": 5
+			}
+		}
+	]
+}`))
+
+	poll, err := ap.ExtractPoll(question)
+	suite.NoError(err)
+	suite.True(poll.Multiple)
+	suite.Equal([]string{"cats", "dogs"}, poll.Options)
+	suite.Equal([]int{4, 5}, poll.Votes)
+	suite.Equal(9, poll.Voters) // no votersCount set so we just fall back to the vote total
+	suite.Equal(time.Date(2021, 10, 5, 15, 8, 36, 0, time.UTC), poll.ClosedAt.UTC())
+}
+
+func (suite *ExtractPollTestSuite) TestExtractPollNoOptions() {
+	question := questionFromJSON([]byte(`
+{
+	"@context": "https://www.w3.org/ns/activitystreams",
+	"id": "https://example.org/users/someone/statuses/107043888547829810",
+	"type": "Question",
+	"attributedTo": "https://example.org/users/someone",
+	"content": "<p>this isn't really a poll</p>"
+}`))
+
+	poll, err := ap.ExtractPoll(question)
+	suite.EqualError(err, "no poll options found")
+	suite.Nil(poll)
+}
+
+func TestExtractPollTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractPollTestSuite{})
+}
@@ -40,6 +40,7 @@ type Accountable interface {
 	WithFollowers
 	WithFeatured
 	WithManuallyApprovesFollowers
+	WithUnknownProperties
 }
 
 // Statusable represents the minimum activitypub interface for representing a 'status'.
@@ -51,6 +52,7 @@ type Statusable interface {
 	WithSummary
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -63,6 +65,23 @@ type Statusable interface {
 	WithReplies
 }
 
+// Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
+// This interface is fulfilled by: Question
+type Pollable interface {
+	WithOneOf
+	WithAnyOf
+	WithEndTime
+	WithClosed
+	WithVotersCount
+}
+
+// PollOptionable represents the minimum activitypub interface for representing a poll option, or a vote in a poll.
+// This interface is fulfilled by: Note
+type PollOptionable interface {
+	WithName
+	WithReplies
+}
+
 // Attachmentable represents the minimum activitypub interface for representing a 'mediaAttachment'.
 // This interface is fulfilled by: Audio, Document, Image, Video
 type Attachmentable interface {
@@ -134,6 +153,16 @@ type Announceable interface {
 	WithCC
 }
 
+// Flaggable represents the minimum interface for an activitystreams 'flag' activity.
+type Flaggable interface {
+	WithJSONLDId
+	WithTypeName
+
+	WithActor
+	WithObject
+	WithContent
+}
+
 // Addressable represents the minimum interface for an addressed activity.
 type Addressable interface {
 	WithTo
@@ -314,6 +343,11 @@ type WithObject interface {
 	GetActivityStreamsObject() vocab.ActivityStreamsObjectProperty
 }
 
+// WithTarget represents an activity with ActivityStreamsTargetProperty
+type WithTarget interface {
+	GetActivityStreamsTarget() vocab.ActivityStreamsTargetProperty
+}
+
 // WithNext represents an activity with ActivityStreamsNextProperty
 type WithNext interface {
 	GetActivityStreamsNext() vocab.ActivityStreamsNextProperty
@@ -333,3 +367,34 @@ type WithItems interface {
 type WithManuallyApprovesFollowers interface {
 	GetActivityStreamsManuallyApprovesFollowers() vocab.ActivityStreamsManuallyApprovesFollowersProperty
 }
+
+// WithOneOf represents a Question with ActivityStreamsOneOfProperty
+type WithOneOf interface {
+	GetActivityStreamsOneOf() vocab.ActivityStreamsOneOfProperty
+}
+
+// WithAnyOf represents a Question with ActivityStreamsAnyOfProperty
+type WithAnyOf interface {
+	GetActivityStreamsAnyOf() vocab.ActivityStreamsAnyOfProperty
+}
+
+// WithEndTime represents an activity with ActivityStreamsEndTimeProperty
+type WithEndTime interface {
+	GetActivityStreamsEndTime() vocab.ActivityStreamsEndTimeProperty
+}
+
+// WithClosed represents a Question with ActivityStreamsClosedProperty
+type WithClosed interface {
+	GetActivityStreamsClosed() vocab.ActivityStreamsClosedProperty
+}
+
+// WithVotersCount represents a Question with TootVotersCountProperty
+type WithVotersCount interface {
+	GetTootVotersCount() vocab.TootVotersCountProperty
+}
+
+// WithUnknownProperties represents a type with properties that aren't part of the
+// vocabulary we know about, such as alsoKnownAs and movedTo on accounts.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
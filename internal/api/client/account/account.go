@@ -24,6 +24,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 
 	"github.com/superseriousbusiness/gotosocial/internal/router"
@@ -73,8 +74,16 @@ const (
 	BlockPath = BasePathWithID + "/block"
 	// UnblockPath is for removing a block of an account
 	UnblockPath = BasePathWithID + "/unblock"
+	// MutePath is for creating or updating a mute of an account
+	MutePath = BasePathWithID + "/mute"
+	// UnmutePath is for removing a mute of an account
+	UnmutePath = BasePathWithID + "/unmute"
 	// DeleteAccountPath is for deleting one's account via the API
 	DeleteAccountPath = BasePath + "/delete"
+	// AliasPath is for setting the aliases of one's account
+	AliasPath = BasePath + "/alias"
+	// MovePath is for moving one's account to another account
+	MovePath = BasePath + "/move"
 )
 
 // Module implements the ClientAPIModule interface for account-related actions
@@ -92,34 +101,42 @@ func New(processor processing.Processor) api.ClientModule {
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	// create account
-	r.AttachHandler(http.MethodPost, BasePath, m.AccountCreatePOSTHandler)
+	r.AttachHandler(http.MethodPost, BasePath, oauth.Scoped(oauth.ScopeWriteAccounts, m.AccountCreatePOSTHandler))
 
 	// delete account
-	r.AttachHandler(http.MethodPost, DeleteAccountPath, m.AccountDeletePOSTHandler)
+	r.AttachHandler(http.MethodPost, DeleteAccountPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.AccountDeletePOSTHandler))
+
+	// alias and move account
+	r.AttachHandler(http.MethodPost, AliasPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.AccountAliasPOSTHandler))
+	r.AttachHandler(http.MethodPost, MovePath, oauth.Scoped(oauth.ScopeWriteAccounts, m.AccountMovePOSTHandler))
 
 	// get account
-	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, oauth.Scoped(oauth.ScopeReadAccounts, m.muxHandler))
 
 	// modify account
-	r.AttachHandler(http.MethodPatch, BasePathWithID, m.muxHandler)
+	r.AttachHandler(http.MethodPatch, BasePathWithID, oauth.Scoped(oauth.ScopeWriteAccounts, m.muxHandler))
 
 	// get account's statuses
-	r.AttachHandler(http.MethodGet, GetStatusesPath, m.AccountStatusesGETHandler)
+	r.AttachHandler(http.MethodGet, GetStatusesPath, oauth.Scoped(oauth.ScopeReadStatuses, m.AccountStatusesGETHandler))
 
 	// get following or followers
-	r.AttachHandler(http.MethodGet, GetFollowersPath, m.AccountFollowersGETHandler)
-	r.AttachHandler(http.MethodGet, GetFollowingPath, m.AccountFollowingGETHandler)
+	r.AttachHandler(http.MethodGet, GetFollowersPath, oauth.Scoped(oauth.ScopeReadAccounts, m.AccountFollowersGETHandler))
+	r.AttachHandler(http.MethodGet, GetFollowingPath, oauth.Scoped(oauth.ScopeReadAccounts, m.AccountFollowingGETHandler))
 
 	// get relationship with account
-	r.AttachHandler(http.MethodGet, GetRelationshipsPath, m.AccountRelationshipsGETHandler)
+	r.AttachHandler(http.MethodGet, GetRelationshipsPath, oauth.Scoped(oauth.ScopeReadFollows, m.AccountRelationshipsGETHandler))
 
 	// follow or unfollow account
-	r.AttachHandler(http.MethodPost, FollowPath, m.AccountFollowPOSTHandler)
-	r.AttachHandler(http.MethodPost, UnfollowPath, m.AccountUnfollowPOSTHandler)
+	r.AttachHandler(http.MethodPost, FollowPath, oauth.Scoped(oauth.ScopeWriteFollows, m.AccountFollowPOSTHandler))
+	r.AttachHandler(http.MethodPost, UnfollowPath, oauth.Scoped(oauth.ScopeWriteFollows, m.AccountUnfollowPOSTHandler))
 
 	// block or unblock account
-	r.AttachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
-	r.AttachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
+	r.AttachHandler(http.MethodPost, BlockPath, oauth.Scoped(oauth.ScopeWriteBlocks, m.AccountBlockPOSTHandler))
+	r.AttachHandler(http.MethodPost, UnblockPath, oauth.Scoped(oauth.ScopeWriteBlocks, m.AccountUnblockPOSTHandler))
+
+	// mute or unmute account
+	r.AttachHandler(http.MethodPost, MutePath, oauth.Scoped(oauth.ScopeWriteMutes, m.AccountMutePOSTHandler))
+	r.AttachHandler(http.MethodPost, UnmutePath, oauth.Scoped(oauth.ScopeWriteMutes, m.AccountUnmutePOSTHandler))
 
 	return nil
 }
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountMovePOSTHandler swagger:operation POST /api/v1/accounts/move accountMove
+//
+// Move your account to another account.
+//
+// The account being moved to must already have your account as an alias.
+// Your followers will be moved over to the other account, and your profile will point to it.
+//
+// ---
+// tags:
+// - accounts
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: password
+//   in: formData
+//   description: Password of the account user, for confirmation.
+//   type: string
+//   required: true
+// - name: moved_to_uri
+//   in: formData
+//   description: ActivityPub URI of the account to move to.
+//   type: string
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: "The account, which has now moved."
+//     schema:
+//       "$ref": "#/definitions/account"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '404':
+//      description: not found
+func (m *Module) AccountMovePOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AccountMovePOSTHandler")
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.AccountMoveRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if form.Password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no password provided in account move request"})
+		return
+	}
+
+	if form.MovedToURI == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no moved_to_uri provided in account move request"})
+		return
+	}
+
+	acctSensitive, errWithCode := m.processor.AccountMove(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("could not move account: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, acctSensitive)
+}
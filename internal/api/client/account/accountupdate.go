@@ -88,6 +88,22 @@ import (
 //   in: formData
 //   description: Default language to use for authored statuses (ISO 6391).
 //   type: string
+// - name: source[excluded_notifications][]
+//   in: formData
+//   description: |-
+//     Types of notification that shouldn't be streamed or pushed to this account's clients.
+//     Provide a single empty value to receive all types again.
+//   type: array
+//   items:
+//     type: string
+//     enum:
+//     - follow
+//     - follow_request
+//     - mention
+//     - reblog
+//     - favourite
+//     - poll
+//     - status
 //
 // security:
 // - OAuth2 Bearer:
@@ -134,6 +150,7 @@ func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 		form.Source.Privacy == nil &&
 		form.Source.Sensitive == nil &&
 		form.Source.Language == nil &&
+		form.Source.ExcludedNotifications == nil &&
 		form.FieldsAttributes == nil {
 		l.Debugf("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "empty form submitted"})
@@ -179,5 +196,20 @@ func parseUpdateAccountForm(c *gin.Context) (*model.UpdateCredentialsRequest, er
 		form.Source.Language = &language
 	}
 
+	// excluded notification types may be given with or without array brackets,
+	// and an empty value clears them so that every type is streamed again
+	excludedKey := "source[excluded_notifications]"
+	excludedWithBrackets, withBrackets := c.GetPostFormArray(excludedKey + "[]")
+	excludedWithoutBrackets, withoutBrackets := c.GetPostFormArray(excludedKey)
+	if withBrackets || withoutBrackets {
+		excludedNotifications := []string{}
+		for _, e := range append(excludedWithBrackets, excludedWithoutBrackets...) {
+			if e != "" {
+				excludedNotifications = append(excludedNotifications, e)
+			}
+		}
+		form.Source.ExcludedNotifications = &excludedNotifications
+	}
+
 	return form, nil
 }
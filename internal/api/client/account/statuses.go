@@ -100,7 +100,7 @@ import (
 //
 // security:
 // - OAuth2 Bearer:
-//   - read:accounts
+//   - read:statuses
 //
 // responses:
 //   '200':
@@ -58,6 +58,10 @@ import (
 //   in: formData
 //   description: Optional text describing why this action was taken.
 //   type: string
+// - name: report_id
+//   in: formData
+//   description: ID of a report about this account to resolve along with this action.
+//   type: string
 //
 // security:
 // - OAuth2 Bearer:
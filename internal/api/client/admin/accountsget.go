@@ -0,0 +1,218 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountsGETHandler swagger:operation GET /api/v1/admin/accounts adminAccounts
+//
+// View local and remote accounts known to this instance, with admin-only details.
+//
+// Accounts are returned newest first. The instance account is left out.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: local
+//   type: boolean
+//   description: Show only local accounts.
+//   in: query
+//   required: false
+// - name: remote
+//   type: boolean
+//   description: Show only remote accounts.
+//   in: query
+//   required: false
+// - name: by_domain
+//   type: string
+//   description: Show only accounts from the given domain.
+//   in: query
+//   required: false
+// - name: active
+//   type: boolean
+//   description: Show only accounts that aren't pending, disabled, silenced or suspended.
+//   in: query
+//   required: false
+// - name: pending
+//   type: boolean
+//   description: Show only local accounts that are waiting for their sign-up to be approved.
+//   in: query
+//   required: false
+// - name: disabled
+//   type: boolean
+//   description: Show only local accounts that have been disabled.
+//   in: query
+//   required: false
+// - name: silenced
+//   type: boolean
+//   description: Show only accounts that have been silenced.
+//   in: query
+//   required: false
+// - name: suspended
+//   type: boolean
+//   description: Show only accounts that have been suspended.
+//   in: query
+//   required: false
+// - name: username
+//   type: string
+//   description: Show only accounts with a username containing the given string.
+//   in: query
+//   required: false
+// - name: email
+//   type: string
+//   description: Show only local accounts with an email address containing the given string.
+//   in: query
+//   required: false
+// - name: ip
+//   type: string
+//   description: Show only local accounts that signed up or most recently signed in from the given IP address.
+//   in: query
+//   required: false
+// - name: max_id
+//   type: string
+//   description: Return only accounts *OLDER* than the given max ID. The account with the specified ID will not be included in the response.
+//   in: query
+//   required: false
+// - name: limit
+//   type: integer
+//   description: Number of accounts to return.
+//   default: 100
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: Accounts.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '500':
+//      description: internal error
+func (m *Module) AccountsGETHandler(c *gin.Context) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":        "AccountsGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	// local and remote narrow down the origin of accounts, and the rest narrow down
+	// their status; the names of the params double as the origin and status values
+	origin, err := parseAccountsFlags(c, LocalKey, RemoteKey)
+	if err != nil {
+		l.Debugf("error parsing origin: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := parseAccountsFlags(c, ActiveKey, PendingKey, DisabledKey, SilencedKey, SuspendedKey)
+	if err != nil {
+		l.Debugf("error parsing status: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := 100
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.Debugf("error parsing limit string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	accounts, errWithCode := m.processor.AdminAccountsGet(c.Request.Context(), authed, origin, status, c.Query(ByDomainKey), c.Query(UsernameKey), c.Query(EmailKey), c.Query(IPKey), c.Query(MaxIDKey), limit)
+	if errWithCode != nil {
+		l.Debugf("error getting accounts: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+// parseAccountsFlags returns which of the given boolean query params is set to true, if any.
+// An error is returned if any of them can't be parsed, or if more than one of them is true.
+func parseAccountsFlags(c *gin.Context, keys ...string) (string, error) {
+	set := ""
+	for _, key := range keys {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("couldn't parse %s query param", key)
+		}
+		if !b {
+			continue
+		}
+
+		if set != "" {
+			return "", fmt.Errorf("%s and %s query params can't both be set", set, key)
+		}
+		set = key
+	}
+	return set, nil
+}
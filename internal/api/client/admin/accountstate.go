@@ -0,0 +1,305 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountApprovePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/approve adminAccountApprove
+//
+// Approve the sign-up of a local account that's waiting for approval, so that it can sign in.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The approved account.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountApprovePOSTHandler(c *gin.Context) {
+	m.accountStateAction(c, "AccountApprovePOSTHandler", "approving account", m.processor.AdminAccountApprove)
+}
+
+// AccountRejectPOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/reject adminAccountReject
+//
+// Reject the sign-up of a local account that's waiting for approval.
+//
+// The account and its user are removed, so the username and email address can be used to sign up again.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The rejected account, as it was before it was removed.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountRejectPOSTHandler(c *gin.Context) {
+	m.accountStateAction(c, "AccountRejectPOSTHandler", "rejecting account", m.processor.AdminAccountReject)
+}
+
+// AccountEnablePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/enable adminAccountEnable
+//
+// Re-enable the user of a local account that was disabled, so that it can sign in again.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The enabled account.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountEnablePOSTHandler(c *gin.Context) {
+	m.accountStateAction(c, "AccountEnablePOSTHandler", "enabling account", m.processor.AdminAccountEnable)
+}
+
+// AccountUnsilencePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsilence adminAccountUnsilence
+//
+// Lift the silence on an account, so that its statuses are shown in public timelines again.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The unsilenced account.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountUnsilencePOSTHandler(c *gin.Context) {
+	m.accountStateAction(c, "AccountUnsilencePOSTHandler", "unsilencing account", m.processor.AdminAccountUnsilence)
+}
+
+// AccountUnsuspendPOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsuspend adminAccountUnsuspend
+//
+// Lift the suspension of an account.
+//
+// Statuses, media and relationships removed when the account was suspended are not restored.
+// Remote accounts can't be unsuspended while their domain is blocked, and local accounts can't
+// be unsuspended once their user has been removed by the suspension.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The unsuspended account.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountUnsuspendPOSTHandler(c *gin.Context) {
+	m.accountStateAction(c, "AccountUnsuspendPOSTHandler", "unsuspending account", m.processor.AdminAccountUnsuspend)
+}
+
+// AccountResetTwoFactorPOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/reset_two_factor adminAccountResetTwoFactor
+//
+// Turn off two-factor authentication for the user of a local account.
+//
+// This is for users who have lost both their authenticator app and their recovery codes.
+// They can sign in with just their password afterwards, and enroll again if they want to.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The account whose two-factor authentication was reset.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountResetTwoFactorPOSTHandler(c *gin.Context) {
+	m.accountStateAction(c, "AccountResetTwoFactorPOSTHandler", "resetting two-factor authentication", m.processor.AdminAccountResetTwoFactor)
+}
+
+// accountStateAction handles a POST to one of the account state paths, using the given processor function to act on the account.
+func (m *Module) accountStateAction(c *gin.Context, funcName string, verb string, action func(context.Context, *oauth.Auth, string) (*apimodel.AdminAccountInfo, gtserror.WithCode)) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":        funcName,
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID := c.Param(IDKey)
+	if accountID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no account id provided"})
+		return
+	}
+
+	account, errWithCode := action(c.Request.Context(), authed, accountID)
+	if errWithCode != nil {
+		l.Debugf("error %s: %s", verb, errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
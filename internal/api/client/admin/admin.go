@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -35,12 +36,42 @@ const (
 	DomainBlocksPath = BasePath + "/domain_blocks"
 	// DomainBlocksPathWithID is used for interacting with a single domain block.
 	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
+	// DomainAllowsPath is used for posting domain allows.
+	DomainAllowsPath = BasePath + "/domain_allows"
+	// DomainAllowsPathWithID is used for interacting with a single domain allow.
+	DomainAllowsPathWithID = DomainAllowsPath + "/:" + IDKey
 	// AccountsPath is used for listing + acting on accounts.
 	AccountsPath = BasePath + "/accounts"
 	// AccountsPathWithID is used for interacting with a single account.
 	AccountsPathWithID = AccountsPath + "/:" + IDKey
 	// AccountsActionPath is used for taking action on a single account.
 	AccountsActionPath = AccountsPathWithID + "/action"
+	// AccountsApprovePath is used for approving the sign-up of a pending account.
+	AccountsApprovePath = AccountsPathWithID + "/approve"
+	// AccountsRejectPath is used for rejecting the sign-up of a pending account.
+	AccountsRejectPath = AccountsPathWithID + "/reject"
+	// AccountsEnablePath is used for re-enabling a disabled account.
+	AccountsEnablePath = AccountsPathWithID + "/enable"
+	// AccountsUnsilencePath is used for lifting the silence on an account.
+	AccountsUnsilencePath = AccountsPathWithID + "/unsilence"
+	// AccountsUnsuspendPath is used for lifting the suspension of an account.
+	AccountsUnsuspendPath = AccountsPathWithID + "/unsuspend"
+	// AccountsResetTwoFactorPath is used for turning off two-factor authentication for a local account.
+	AccountsResetTwoFactorPath = AccountsPathWithID + "/reset_two_factor"
+	// DeliveriesPath is used for viewing queued outgoing deliveries.
+	DeliveriesPath = BasePath + "/deliveries"
+	// ReportsPath is used for viewing reports.
+	ReportsPath = BasePath + "/reports"
+	// ReportsPathWithID is used for viewing a single report.
+	ReportsPathWithID = ReportsPath + "/:" + IDKey
+	// ReportAssignPath is used for assigning a report to the requesting admin.
+	ReportAssignPath = ReportsPathWithID + "/assign_to_self"
+	// ReportUnassignPath is used for removing the assigned admin from a report.
+	ReportUnassignPath = ReportsPathWithID + "/unassign"
+	// ReportResolvePath is used for marking a report as resolved.
+	ReportResolvePath = ReportsPathWithID + "/resolve"
+	// ReportReopenPath is used for marking a report as unresolved again.
+	ReportReopenPath = ReportsPathWithID + "/reopen"
 
 	// ExportQueryKey is for requesting a public export of some data.
 	ExportQueryKey = "export"
@@ -48,6 +79,40 @@ const (
 	ImportQueryKey = "import"
 	// IDKey specifies the ID of a single item being interacted with.
 	IDKey = "id"
+	// StateKey is for filtering deliveries by their state.
+	StateKey = "state"
+	// ResolvedKey is for filtering reports by whether they've been resolved.
+	ResolvedKey = "resolved"
+	// AccountIDKey is for filtering reports by the account that filed them.
+	AccountIDKey = "account_id"
+	// TargetAccountIDKey is for filtering reports by the account they're about.
+	TargetAccountIDKey = "target_account_id"
+	// MaxIDKey is for paging down through results.
+	MaxIDKey = "max_id"
+	// LimitKey is for limiting the number of results returned.
+	LimitKey = "limit"
+	// LocalKey is for filtering accounts to only local ones.
+	LocalKey = "local"
+	// RemoteKey is for filtering accounts to only remote ones.
+	RemoteKey = "remote"
+	// ByDomainKey is for filtering accounts by their domain.
+	ByDomainKey = "by_domain"
+	// ActiveKey is for filtering accounts to only ones that aren't pending, disabled, silenced or suspended.
+	ActiveKey = "active"
+	// PendingKey is for filtering accounts to only ones waiting for their sign-up to be approved.
+	PendingKey = "pending"
+	// DisabledKey is for filtering accounts to only disabled ones.
+	DisabledKey = "disabled"
+	// SilencedKey is for filtering accounts to only silenced ones.
+	SilencedKey = "silenced"
+	// SuspendedKey is for filtering accounts to only suspended ones.
+	SuspendedKey = "suspended"
+	// UsernameKey is for filtering accounts by their username.
+	UsernameKey = "username"
+	// EmailKey is for filtering accounts by the email address of their user.
+	EmailKey = "email"
+	// IPKey is for filtering accounts by the IP addresses used by their user.
+	IPKey = "ip"
 )
 
 // Module implements the ClientAPIModule interface for admin-related actions (reports, emojis, etc)
@@ -64,11 +129,30 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodPost, EmojiPath, m.EmojiCreatePOSTHandler)
-	r.AttachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
-	r.AttachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
-	r.AttachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
-	r.AttachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
-	r.AttachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	r.AttachHandler(http.MethodPost, EmojiPath, oauth.Scoped(oauth.ScopeAdminWrite, m.EmojiCreatePOSTHandler))
+	r.AttachHandler(http.MethodPost, DomainBlocksPath, oauth.Scoped(oauth.ScopeAdminWriteDomainBlocks, m.DomainBlocksPOSTHandler))
+	r.AttachHandler(http.MethodGet, DomainBlocksPath, oauth.Scoped(oauth.ScopeAdminReadDomainBlocks, m.DomainBlocksGETHandler))
+	r.AttachHandler(http.MethodGet, DomainBlocksPathWithID, oauth.Scoped(oauth.ScopeAdminReadDomainBlocks, m.DomainBlockGETHandler))
+	r.AttachHandler(http.MethodDelete, DomainBlocksPathWithID, oauth.Scoped(oauth.ScopeAdminWriteDomainBlocks, m.DomainBlockDELETEHandler))
+	r.AttachHandler(http.MethodPost, DomainAllowsPath, oauth.Scoped(oauth.ScopeAdminWriteDomainAllows, m.DomainAllowsPOSTHandler))
+	r.AttachHandler(http.MethodGet, DomainAllowsPath, oauth.Scoped(oauth.ScopeAdminReadDomainAllows, m.DomainAllowsGETHandler))
+	r.AttachHandler(http.MethodGet, DomainAllowsPathWithID, oauth.Scoped(oauth.ScopeAdminReadDomainAllows, m.DomainAllowGETHandler))
+	r.AttachHandler(http.MethodDelete, DomainAllowsPathWithID, oauth.Scoped(oauth.ScopeAdminWriteDomainAllows, m.DomainAllowDELETEHandler))
+	r.AttachHandler(http.MethodGet, AccountsPath, oauth.Scoped(oauth.ScopeAdminReadAccounts, m.AccountsGETHandler))
+	r.AttachHandler(http.MethodGet, AccountsPathWithID, oauth.Scoped(oauth.ScopeAdminReadAccounts, m.AccountGETHandler))
+	r.AttachHandler(http.MethodPost, AccountsActionPath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountActionPOSTHandler))
+	r.AttachHandler(http.MethodPost, AccountsApprovePath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountApprovePOSTHandler))
+	r.AttachHandler(http.MethodPost, AccountsRejectPath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountRejectPOSTHandler))
+	r.AttachHandler(http.MethodPost, AccountsEnablePath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountEnablePOSTHandler))
+	r.AttachHandler(http.MethodPost, AccountsUnsilencePath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountUnsilencePOSTHandler))
+	r.AttachHandler(http.MethodPost, AccountsUnsuspendPath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountUnsuspendPOSTHandler))
+	r.AttachHandler(http.MethodPost, AccountsResetTwoFactorPath, oauth.Scoped(oauth.ScopeAdminWriteAccounts, m.AccountResetTwoFactorPOSTHandler))
+	r.AttachHandler(http.MethodGet, DeliveriesPath, oauth.Scoped(oauth.ScopeAdminRead, m.DeliveriesGETHandler))
+	r.AttachHandler(http.MethodGet, ReportsPath, oauth.Scoped(oauth.ScopeAdminReadReports, m.ReportsGETHandler))
+	r.AttachHandler(http.MethodGet, ReportsPathWithID, oauth.Scoped(oauth.ScopeAdminReadReports, m.ReportGETHandler))
+	r.AttachHandler(http.MethodPost, ReportAssignPath, oauth.Scoped(oauth.ScopeAdminWriteReports, m.ReportAssignPOSTHandler))
+	r.AttachHandler(http.MethodPost, ReportUnassignPath, oauth.Scoped(oauth.ScopeAdminWriteReports, m.ReportUnassignPOSTHandler))
+	r.AttachHandler(http.MethodPost, ReportResolvePath, oauth.Scoped(oauth.ScopeAdminWriteReports, m.ReportResolvePOSTHandler))
+	r.AttachHandler(http.MethodPost, ReportReopenPath, oauth.Scoped(oauth.ScopeAdminWriteReports, m.ReportReopenPOSTHandler))
 	return nil
 }
@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DeliveriesGETHandler swagger:operation GET /api/v1/admin/deliveries deliveriesGet
+//
+// View outgoing federation deliveries that are waiting to be sent, or that have been given up on.
+//
+// Deliveries are removed once they succeed, so only pending and failed deliveries will be shown, newest first.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: state
+//   type: string
+//   description: Show only deliveries in the given state, either 'pending' or 'failed'. If not set, all deliveries will be shown.
+//   in: query
+//   required: false
+// - name: max_id
+//   type: string
+//   description: Return only deliveries *OLDER* than the given max ID. The delivery with the specified ID will not be included in the response.
+//   in: query
+//   required: false
+// - name: limit
+//   type: integer
+//   description: Number of deliveries to return.
+//   default: 20
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: Queued deliveries.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/delivery"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '500':
+//      description: internal error
+func (m *Module) DeliveriesGETHandler(c *gin.Context) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":        "DeliveriesGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := 20
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.Debugf("error parsing limit string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	deliveries, errWithCode := m.processor.AdminDeliveriesGet(c.Request.Context(), authed, c.Query(StateKey), c.Query(MaxIDKey), limit)
+	if errWithCode != nil {
+		l.Debugf("error getting deliveries: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,112 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainAllowTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainAllowTestSuite) createAllow(domain string) *apimodel.DomainAllow {
+	form := url.Values{
+		"domain":          {domain},
+		"private_comment": {"seem nice"},
+	}
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.DomainAllowsPath, "application/x-www-form-urlencoded")
+	suite.adminModule.DomainAllowsPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	apiDomainAllow := &apimodel.DomainAllow{}
+	suite.NoError(json.Unmarshal(b, apiDomainAllow))
+	return apiDomainAllow
+}
+
+func (suite *DomainAllowTestSuite) TestDomainAllowCreate() {
+	apiDomainAllow := suite.createAllow("example.org")
+	suite.NotEmpty(apiDomainAllow.ID)
+	suite.Equal("example.org", apiDomainAllow.Domain)
+	suite.Equal("seem nice", apiDomainAllow.PrivateComment)
+	suite.Equal(suite.testAccounts["admin_account"].ID, apiDomainAllow.CreatedBy)
+
+	// the domain should be allowed now
+	allowed, err := suite.db.IsDomainAllowed(context.Background(), "example.org")
+	suite.NoError(err)
+	suite.True(allowed)
+
+	// allowing it again should just return the existing allow
+	suite.Equal(apiDomainAllow.ID, suite.createAllow("EXAMPLE.org").ID)
+}
+
+func (suite *DomainAllowTestSuite) TestDomainAllowCreateNoDomain() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte("private_comment=hmm"), admin.DomainAllowsPath, "application/x-www-form-urlencoded")
+	suite.adminModule.DomainAllowsPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *DomainAllowTestSuite) TestDomainAllowsGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.DomainAllowsPath+"?export=true", "")
+	suite.adminModule.DomainAllowsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`[{"domain":"fossbros-anonymous.io","public_comment":"open source enthusiasts"}]`, recorder.Body.String())
+}
+
+func (suite *DomainAllowTestSuite) TestDomainAllowDelete() {
+	testAllow := testrig.NewTestDomainAllows()["fossbros-anonymous.io"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, admin.DomainAllowsPath+"/"+testAllow.ID, "")
+	ctx.Params = gin.Params{{Key: admin.IDKey, Value: testAllow.ID}}
+	suite.adminModule.DomainAllowDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	allowed, err := suite.db.IsDomainAllowed(context.Background(), testAllow.Domain)
+	suite.NoError(err)
+	suite.False(allowed)
+
+	// it's gone now
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, nil, admin.DomainAllowsPath+"/"+testAllow.ID, "")
+	ctx.Params = gin.Params{{Key: admin.IDKey, Value: testAllow.ID}}
+	suite.adminModule.DomainAllowGETHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestDomainAllowTestSuite(t *testing.T) {
+	suite.Run(t, &DomainAllowTestSuite{})
+}
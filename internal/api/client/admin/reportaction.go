@@ -0,0 +1,222 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReportAssignPOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/assign_to_self adminReportAssign
+//
+// Assign a report to the requesting admin, to show that they're handling it.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportAssignPOSTHandler(c *gin.Context) {
+	m.reportAction(c, "ReportAssignPOSTHandler", "assigning report", m.processor.AdminReportAssign)
+}
+
+// ReportUnassignPOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/unassign adminReportUnassign
+//
+// Remove the assigned admin from a report, so that someone else can pick it up.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportUnassignPOSTHandler(c *gin.Context) {
+	m.reportAction(c, "ReportUnassignPOSTHandler", "unassigning report", m.processor.AdminReportUnassign)
+}
+
+// ReportResolvePOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/resolve adminReportResolve
+//
+// Mark a report as resolved by the requesting admin.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
+	m.reportAction(c, "ReportResolvePOSTHandler", "resolving report", m.processor.AdminReportResolve)
+}
+
+// ReportReopenPOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/reopen adminReportReopen
+//
+// Mark a resolved report as unresolved again.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportReopenPOSTHandler(c *gin.Context) {
+	m.reportAction(c, "ReportReopenPOSTHandler", "reopening report", m.processor.AdminReportReopen)
+}
+
+// reportAction handles a POST to one of the report action paths, using the given processor function to act on the report.
+func (m *Module) reportAction(c *gin.Context, funcName string, verb string, action func(context.Context, *oauth.Auth, string) (*apimodel.AdminReportInfo, gtserror.WithCode)) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":        funcName,
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	reportID := c.Param(IDKey)
+	if reportID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no report id provided"})
+		return
+	}
+
+	report, errWithCode := action(c.Request.Context(), authed, reportID)
+	if errWithCode != nil {
+		l.Debugf("error %s: %s", verb, errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
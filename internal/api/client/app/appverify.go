@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AppVerifyCredentialsGETHandler swagger:operation GET /api/v1/apps/verify_credentials appVerifyCredentials
+//
+// Verify that the credentials of an application work.
+//
+// Returns the application that the token used for the request belongs to.
+// Both application tokens and user tokens can be used.
+//
+// ---
+// tags:
+// - apps
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer: []
+//
+// responses:
+//   '200':
+//     description: "The application of the token."
+//     schema:
+//       "$ref": "#/definitions/application"
+//   '401':
+//      description: unauthorized
+//   '406':
+//      description: not acceptable
+//   '500':
+//      description: internal error
+func (m *Module) AppVerifyCredentialsGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AppVerifyCredentialsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, false, false)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiApp, errWithCode := m.processor.AppVerifyCredentials(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error verifying app credentials: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, apiApp)
+}
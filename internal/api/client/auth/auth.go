@@ -25,6 +25,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
@@ -33,6 +34,9 @@ const (
 	// AuthSignInPath is the API path for users to sign in through
 	AuthSignInPath = "/auth/sign_in"
 
+	// AuthTwoFactorPath is where users who have two-factor authentication enabled give a code, after their password
+	AuthTwoFactorPath = "/auth/two_factor"
+
 	// CheckYourEmailPath users land here after registering a new account, instructs them to confirm thier email
 	CheckYourEmailPath = "/check_your_email"
 
@@ -49,9 +53,19 @@ const (
 	// OauthAuthorizePath is the API path for authorization requests (eg., authorize this app to act on my behalf as a user)
 	OauthAuthorizePath = "/oauth/authorize"
 
+	// OauthRevokePath is the API path for clients to revoke their access tokens (RFC 7009)
+	OauthRevokePath = "/oauth/revoke"
+
 	// CallbackPath is the API path for receiving callback tokens from external OIDC providers
 	CallbackPath = oidc.CallbackPath
 
+	// AuthorizedAppsPath is the web page where users can see and revoke the applications that hold tokens for their account
+	AuthorizedAppsPath = "/settings/applications"
+	// AuthorizedAppRevokePath is where the revoke buttons on the authorized applications page POST to
+	AuthorizedAppRevokePath = AuthorizedAppsPath + "/:" + tokenIDKey + "/revoke"
+
+	tokenIDKey = "id"
+
 	callbackStateParam = "state"
 	callbackCodeParam  = "code"
 
@@ -62,21 +76,27 @@ const (
 	sessionResponseType = "response_type"
 	sessionScope        = "scope"
 	sessionState        = "state"
+	sessionReturnTo     = "return_to"
+
+	// sessionTwoFactorUserID holds the user whose password was right, until they've given a code too
+	sessionTwoFactorUserID = "two_factor_userid"
 )
 
 // Module implements the ClientAPIModule interface for
 type Module struct {
-	db     db.DB
-	server oauth.Server
-	idp    oidc.IDP
+	db        db.DB
+	server    oauth.Server
+	idp       oidc.IDP
+	processor processing.Processor
 }
 
 // New returns a new auth module
-func New(db db.DB, server oauth.Server, idp oidc.IDP) api.ClientModule {
+func New(db db.DB, server oauth.Server, idp oidc.IDP, processor processing.Processor) api.ClientModule {
 	return &Module{
-		db:     db,
-		server: server,
-		idp:    idp,
+		db:        db,
+		server:    server,
+		idp:       idp,
+		processor: processor,
 	}
 }
 
@@ -84,12 +104,18 @@ func New(db db.DB, server oauth.Server, idp oidc.IDP) api.ClientModule {
 func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	s.AttachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
+	s.AttachHandler(http.MethodGet, AuthTwoFactorPath, m.TwoFactorGETHandler)
+	s.AttachHandler(http.MethodPost, AuthTwoFactorPath, m.TwoFactorPOSTHandler)
 
 	s.AttachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
+	s.AttachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
 
 	s.AttachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	s.AttachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 
 	s.AttachHandler(http.MethodGet, CallbackPath, m.CallbackGETHandler)
+
+	s.AttachHandler(http.MethodGet, AuthorizedAppsPath, m.AuthorizedAppsGETHandler)
+	s.AttachHandler(http.MethodPost, AuthorizedAppRevokePath, m.AuthorizedAppRevokePOSTHandler)
 	return nil
 }
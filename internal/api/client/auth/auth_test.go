@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -43,6 +44,7 @@ type AuthStandardTestSuite struct {
 	db          db.DB
 	idp         oidc.IDP
 	oauthServer oauth.Server
+	processor   processing.Processor
 
 	// standard suite models
 	testTokens       map[string]*gtsmodel.Token
@@ -58,6 +60,9 @@ type AuthStandardTestSuite struct {
 const (
 	sessionUserID   = "userid"
 	sessionClientID = "client_id"
+	sessionReturnTo = "return_to"
+
+	sessionTwoFactorUserID = "two_factor_userid"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -69,6 +74,9 @@ func (suite *AuthStandardTestSuite) SetupSuite() {
 }
 
 func (suite *AuthStandardTestSuite) SetupTest() {
+	// the email sender changes the template dir, so do this before setting up the config that the templates are loaded with
+	emailSender := testrig.NewEmailSender("../../../../web/template/", nil)
+
 	testrig.InitTestConfig()
 	suite.db = testrig.NewTestDB()
 	testrig.InitTestLog()
@@ -79,7 +87,11 @@ func (suite *AuthStandardTestSuite) SetupTest() {
 	if err != nil {
 		panic(err)
 	}
-	suite.authModule = auth.New(suite.db, suite.oauthServer, suite.idp).(*auth.Module)
+	storage := testrig.NewTestStorage()
+	mediaManager := testrig.NewTestMediaManager(suite.db, storage)
+	federator := testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), storage, mediaManager)
+	suite.processor = testrig.NewTestProcessor(suite.db, storage, federator, emailSender, mediaManager)
+	suite.authModule = auth.New(suite.db, suite.oauthServer, suite.idp, suite.processor).(*auth.Module)
 	testrig.StandardDBSetup(suite.db, nil)
 }
 
@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 // AuthorizeGETHandler should be served as GET at https://example.org/oauth/authorize
@@ -227,7 +228,11 @@ func extractAuthForm(s sessions.Session, form *model.OAuthAuthorize) error {
 
 	// set default scope to read
 	if form.Scope == "" {
-		form.Scope = "read"
+		form.Scope = oauth.DefaultScope
+	}
+
+	if err := oauth.ValidateScopes(form.Scope); err != nil {
+		return err
 	}
 
 	// save these values from the form so we can use them elsewhere in the session
@@ -237,6 +242,7 @@ func extractAuthForm(s sessions.Session, form *model.OAuthAuthorize) error {
 	s.Set(sessionRedirectURI, form.RedirectURI)
 	s.Set(sessionScope, form.Scope)
 	s.Set(sessionState, uuid.NewString())
+	s.Delete(sessionReturnTo)
 	return s.Save()
 }
 
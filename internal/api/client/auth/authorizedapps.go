@@ -0,0 +1,121 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AuthorizedAppsGETHandler should be served at https://example.org/settings/applications.
+// The idea is to show the signed in user which applications hold tokens for their account,
+// when each token was last used, and a button next to each to revoke it.
+func (m *Module) AuthorizedAppsGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AuthorizedAppsGETHandler")
+	s := sessions.Default(c)
+
+	if _, err := api.NegotiateAccept(c, api.HTMLAcceptHeaders...); err != nil {
+		c.HTML(http.StatusNotAcceptable, "error.tmpl", gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := m.settingsUser(c, s)
+	if !ok {
+		return
+	}
+
+	apps, errWithCode := m.processor.AuthorizedAppsGet(c.Request.Context(), user)
+	if errWithCode != nil {
+		l.Debugf("error getting authorized apps: %s", errWithCode.Error())
+		c.HTML(errWithCode.Code(), "error.tmpl", gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "authorized-apps.tmpl", gin.H{
+		"user": user.Email,
+		"apps": apps,
+	})
+}
+
+// AuthorizedAppRevokePOSTHandler should be served as a POST at https://example.org/settings/applications/:id/revoke.
+// It revokes the token with the given id, which immediately closes any streams that were opened with it,
+// and then sends the user back to the authorized applications page.
+func (m *Module) AuthorizedAppRevokePOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AuthorizedAppRevokePOSTHandler")
+	s := sessions.Default(c)
+
+	user, ok := m.settingsUser(c, s)
+	if !ok {
+		return
+	}
+
+	if errWithCode := m.processor.AuthorizedAppRevoke(c.Request.Context(), user, c.Param(tokenIDKey)); errWithCode != nil {
+		l.Debugf("error revoking authorized app: %s", errWithCode.Error())
+		c.HTML(errWithCode.Code(), "error.tmpl", gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, AuthorizedAppsPath)
+}
+
+// settingsUser returns the user who is signed in with the given session, so that they can manage their settings.
+// If nobody is signed in, the user is redirected to the sign in page, which will send them back to the settings
+// afterwards, and false is returned. False is also returned if the user isn't allowed to sign in at the moment.
+func (m *Module) settingsUser(c *gin.Context, s sessions.Session) (*gtsmodel.User, bool) {
+	userID, ok := s.Get(sessionUserID).(string)
+	if !ok || userID == "" {
+		s.Set(sessionReturnTo, AuthorizedAppsPath)
+		if _, ok := s.Get(sessionState).(string); !ok {
+			// signing in with an external provider needs a state to check the callback against
+			s.Set(sessionState, uuid.NewString())
+		}
+		if err := s.Save(); err != nil {
+			c.HTML(http.StatusInternalServerError, "error.tmpl", gin.H{"error": err.Error()})
+			return nil, false
+		}
+		c.Redirect(http.StatusSeeOther, AuthSignInPath)
+		return nil, false
+	}
+
+	user := &gtsmodel.User{}
+	if err := m.db.GetByID(c.Request.Context(), userID, user); err != nil {
+		m.clearSession(s)
+		c.HTML(http.StatusInternalServerError, "error.tmpl", gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	acct, err := m.db.GetAccountByID(c.Request.Context(), user.AccountID)
+	if err != nil {
+		m.clearSession(s)
+		c.HTML(http.StatusInternalServerError, "error.tmpl", gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if !ensureUserIsAuthorizedOrRedirect(c, user, acct) {
+		return nil, false
+	}
+
+	return user, true
+}
@@ -0,0 +1,90 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AuthAuthorizedAppsTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *AuthAuthorizedAppsTestSuite) TestAuthorizedAppsSignInFirst() {
+	// without a signed in user, the page sends us to sign in first
+	ctx, recorder := suite.newContext(http.MethodGet, "settings/applications")
+	suite.authModule.AuthorizedAppsGETHandler(ctx)
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal(auth.AuthSignInPath, recorder.Header().Get("Location"))
+	suite.Equal(auth.AuthorizedAppsPath, sessions.Default(ctx).Get(sessionReturnTo))
+}
+
+func (suite *AuthAuthorizedAppsTestSuite) TestSignInReturnsToAuthorizedApps() {
+	ctx, recorder := suite.newContext(http.MethodPost, "auth/sign_in")
+	form := url.Values{"username": {"zork@example.org"}, "password": {"password"}}
+	ctx.Request.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s := sessions.Default(ctx)
+	s.Set(sessionReturnTo, auth.AuthorizedAppsPath)
+	suite.NoError(s.Save())
+
+	// once signed in, we're sent back to the page instead of to the oauth authorize page
+	suite.authModule.SignInPOSTHandler(ctx)
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal(auth.AuthorizedAppsPath, recorder.Header().Get("Location"))
+	suite.Equal(suite.testUsers["local_account_1"].ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionReturnTo))
+}
+
+func (suite *AuthAuthorizedAppsTestSuite) TestAuthorizedAppsRevoke() {
+	token := suite.testTokens["local_account_1"]
+
+	ctx, recorder := suite.newContext(http.MethodPost, "settings/applications/"+token.ID+"/revoke")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   "id",
+			Value: token.ID,
+		},
+	}
+	s := sessions.Default(ctx)
+	s.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+	suite.NoError(s.Save())
+
+	suite.authModule.AuthorizedAppRevokePOSTHandler(ctx)
+	suite.Equal(http.StatusSeeOther, ctx.Writer.Status())
+	suite.Equal(auth.AuthorizedAppsPath, recorder.Header().Get("Location"))
+
+	err := suite.db.GetByID(context.Background(), token.ID, &gtsmodel.Token{})
+	suite.Error(err)
+}
+
+func TestAuthAuthorizedAppsTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthAuthorizedAppsTestSuite))
+}
@@ -71,6 +71,28 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
+	// users who are signing in to change their settings aren't authorizing an app, so there's no client_id
+	// on the session, and only users who already exist can do that
+	returnTo := m.popReturnTo(s)
+	if returnTo != "" {
+		user := &gtsmodel.User{}
+		if err := m.db.GetWhere(c.Request.Context(), []db.Where{{Key: "email", Value: claims.Email}}, user); err != nil {
+			m.clearSession(s)
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("no user found for email %s", claims.Email)})
+			return
+		}
+
+		s.Set(sessionUserID, user.ID)
+		if err := s.Save(); err != nil {
+			m.clearSession(s)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Redirect(http.StatusFound, returnTo)
+		return
+	}
+
 	// We can use the client_id on the session to retrieve info about the app associated with the client_id
 	clientID, ok := s.Get(sessionClientID).(string)
 	if !ok || clientID == "" {
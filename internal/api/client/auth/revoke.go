@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	oautherrors "github.com/superseriousbusiness/oauth2/v4/errors"
+)
+
+// RevokePOSTHandler should be served as a POST at https://example.org/oauth/revoke
+// The idea here is to let a client revoke one of its access tokens when it doesn't need it anymore,
+// for example because the user logged out, as described in RFC 7009.
+//
+// The client can authenticate with its client_id and client_secret either in the form, or with basic auth.
+// Revoking a token that doesn't exist is not an error, so that clients can safely retry.
+func (m *Module) RevokePOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "RevokePOSTHandler")
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.OAuthRevoke{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": oautherrors.ErrInvalidRequest.Error()})
+		return
+	}
+
+	if form.ClientID == "" {
+		if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+			form.ClientID = clientID
+			form.ClientSecret = clientSecret
+		}
+	}
+
+	if form.ClientID == "" || form.ClientSecret == "" || form.Token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": oautherrors.ErrInvalidRequest.Error()})
+		return
+	}
+
+	if err := m.processor.OAuthRevoke(c.Request.Context(), form); err != nil {
+		l.Debugf("error revoking token: %s", err)
+		code, ok := oautherrors.StatusCodes[err]
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": oautherrors.ErrServerError.Error()})
+			return
+		}
+		c.JSON(code, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
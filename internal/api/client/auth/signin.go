@@ -91,13 +91,50 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	user := &gtsmodel.User{}
+	if err := m.db.GetByID(c.Request.Context(), userid, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	if !user.TwoFactorEnabledAt.IsZero() {
+		// the user isn't signed in until they've given a code from their authenticator too
+		s.Delete(sessionUserID)
+		s.Set(sessionTwoFactorUserID, userid)
+		if err := s.Save(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			m.clearSession(s)
+			return
+		}
+
+		l.Trace("redirecting to two-factor page")
+		c.Redirect(http.StatusFound, AuthTwoFactorPath)
+		return
+	}
+
+	m.signIn(c, s, userid)
+}
+
+// signIn stores the given user in the session, and sends them on to wherever they were going
+// when they were asked to sign in, which is normally the oauth authorize page.
+func (m *Module) signIn(c *gin.Context, s sessions.Session, userid string) {
+	l := logrus.WithField("func", "signIn")
+
 	s.Set(sessionUserID, userid)
+	returnTo := m.popReturnTo(s)
 	if err := s.Save(); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		m.clearSession(s)
 		return
 	}
 
+	if returnTo != "" {
+		l.Tracef("redirecting back to %s", returnTo)
+		c.Redirect(http.StatusFound, returnTo)
+		return
+	}
+
 	l.Trace("redirecting to auth page")
 	c.Redirect(http.StatusFound, OauthAuthorizePath)
 }
@@ -106,6 +143,8 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 // The goal is to authenticate the password against the one for that email
 // address stored in the database. If OK, we return the userid (a ulid) for that user,
 // so that it can be used in further Oauth flows to generate a token/retreieve an oauth client from the db.
+//
+// Users whose sign-up hasn't been approved by an admin yet get an error explaining that, instead of a userid.
 func (m *Module) ValidatePassword(ctx context.Context, email string, password string) (userid string, err error) {
 	l := logrus.WithField("func", "ValidatePassword")
 
@@ -135,6 +174,12 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 		return incorrectPassword()
 	}
 
+	// the email/password is correct, but the user can't sign in until an admin has approved their sign-up
+	if !gtsUser.Approved {
+		l.Debugf("user %s tried to sign in before their sign-up was approved", gtsUser.Email)
+		return awaitingApproval()
+	}
+
 	// If we've made it this far the email/password is correct, so we can just return the id of the user.
 	userid = gtsUser.ID
 	l.Tracef("returning (%s, %s)", userid, err)
@@ -145,3 +190,8 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 func incorrectPassword() (string, error) {
 	return "", errors.New("password/email combination was incorrect")
 }
+
+// awaitingApproval is just a little helper function to use in the ValidatePassword function
+func awaitingApproval() (string, error) {
+	return "", errors.New("your sign-up is still waiting for approval by an admin of this instance; you'll receive an email once it's been reviewed")
+}
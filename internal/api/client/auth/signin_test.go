@@ -0,0 +1,64 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthSignInTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePassword() {
+	userID, err := suite.authModule.ValidatePassword(context.Background(), "zork@example.org", "password")
+	suite.NoError(err)
+	suite.Equal(suite.testUsers["local_account_1"].ID, userID)
+
+	_, err = suite.authModule.ValidatePassword(context.Background(), "zork@example.org", "not the password")
+	suite.EqualError(err, "password/email combination was incorrect")
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordAwaitingApproval() {
+	ctx := context.Background()
+
+	// weed_lord420 has confirmed their email, but their sign-up hasn't been approved yet
+	user := suite.testUsers["unconfirmed_account"]
+	user.Email = user.UnconfirmedEmail
+	user.ConfirmedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	err := suite.db.UpdateByPrimaryKey(ctx, user)
+	suite.NoError(err)
+
+	userID, err := suite.authModule.ValidatePassword(ctx, "weed_lord420@example.org", "password")
+	suite.EqualError(err, "your sign-up is still waiting for approval by an admin of this instance; you'll receive an email once it's been reviewed")
+	suite.Empty(userID)
+
+	// a wrong password shouldn't reveal that the sign-up is pending
+	_, err = suite.authModule.ValidatePassword(ctx, "weed_lord420@example.org", "not the password")
+	suite.EqualError(err, "password/email combination was incorrect")
+}
+
+func TestAuthSignInTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthSignInTestSuite))
+}
@@ -0,0 +1,118 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// twoFactor just wraps a form-submitted code from an authenticator app, or a recovery code
+type twoFactor struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/two_factor.
+// Users who have two-factor authentication enabled are sent here after giving the right password,
+// to enter a code from their authenticator app. The form will then POST to TwoFactorPOSTHandler.
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	if _, err := api.NegotiateAccept(c, api.HTMLAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := s.Get(sessionTwoFactorUserID).(string); !ok {
+		// the password has to come first
+		c.Redirect(http.StatusSeeOther, AuthSignInPath)
+		return
+	}
+
+	c.HTML(http.StatusOK, "two-factor.tmpl", gin.H{})
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/two_factor.
+// If the code is right, the user is signed in and sent on the same way as after SignInPOSTHandler.
+// Too many wrong codes and the user is locked out for a while, and has to start again from the sign in page.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "TwoFactorPOSTHandler")
+	s := sessions.Default(c)
+
+	userid, ok := s.Get(sessionTwoFactorUserID).(string)
+	if !ok || userid == "" {
+		c.Redirect(http.StatusSeeOther, AuthSignInPath)
+		return
+	}
+
+	form := &twoFactor{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	user := &gtsmodel.User{}
+	if err := m.db.GetByID(c.Request.Context(), userid, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	if user.TwoFactorEnabledAt.IsZero() {
+		// two-factor authentication was reset in the meantime, so the password is enough
+		m.twoFactorDone(s)
+		m.signIn(c, s, userid)
+		return
+	}
+
+	ok, err := m.processor.UserTwoFactorVerify(c.Request.Context(), user, form.Code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	if !ok {
+		if time.Now().Before(user.TwoFactorLockedUntil) {
+			l.Debugf("two-factor codes for user %s are locked until %s", userid, user.TwoFactorLockedUntil)
+			m.clearSession(s)
+			c.HTML(http.StatusForbidden, "error.tmpl", gin.H{"error": "too many incorrect codes; please try again later"})
+			return
+		}
+
+		l.Debugf("wrong two-factor code for user %s, attempt %d", userid, user.TwoFactorAttempts)
+		c.HTML(http.StatusForbidden, "two-factor.tmpl", gin.H{"error": "code was incorrect"})
+		return
+	}
+
+	m.twoFactorDone(s)
+	m.signIn(c, s, userid)
+}
+
+// twoFactorDone removes the two-factor sign in state from the session.
+func (m *Module) twoFactorDone(s sessions.Session) {
+	s.Delete(sessionTwoFactorUserID)
+}
@@ -0,0 +1,180 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testTwoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type AuthTwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+// enableTwoFactor turns on two-factor authentication for zork, with one recovery code.
+func (suite *AuthTwoFactorTestSuite) enableTwoFactor(recoveryCode string) *gtsmodel.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(recoveryCode), bcrypt.MinCost)
+	suite.NoError(err)
+
+	user := suite.testUsers["local_account_1"]
+	user.TwoFactorSecret = testTwoFactorSecret
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorRecoveryCodes = []string{string(hash)}
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), user))
+	return user
+}
+
+// newTwoFactorPOST returns a context posting the given code, for a user who has already given the right password.
+func (suite *AuthTwoFactorTestSuite) newTwoFactorPOST(userID string, code string) (*gin.Context, sessions.Session) {
+	ctx, _ := suite.newContext(http.MethodPost, "auth/two_factor")
+	form := url.Values{"code": {code}}
+	ctx.Request.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(sessionTwoFactorUserID, userID)
+	suite.NoError(s.Save())
+	return ctx, s
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInAsksForCode() {
+	suite.enableTwoFactor("0123456789")
+
+	ctx, recorder := suite.newContext(http.MethodPost, "auth/sign_in")
+	form := url.Values{"username": {"zork@example.org"}, "password": {"password"}}
+	ctx.Request.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// the password is right, but we're not signed in until we've given a code
+	suite.authModule.SignInPOSTHandler(ctx)
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal(auth.AuthTwoFactorPath, recorder.Header().Get("Location"))
+
+	s := sessions.Default(ctx)
+	suite.Nil(s.Get(sessionUserID))
+	suite.Equal(suite.testUsers["local_account_1"].ID, s.Get(sessionTwoFactorUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInWithoutTwoFactor() {
+	ctx, recorder := suite.newContext(http.MethodPost, "auth/sign_in")
+	form := url.Values{"username": {"zork@example.org"}, "password": {"password"}}
+	ctx.Request.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	suite.authModule.SignInPOSTHandler(ctx)
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal(auth.OauthAuthorizePath, recorder.Header().Get("Location"))
+	suite.Equal(suite.testUsers["local_account_1"].ID, sessions.Default(ctx).Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorPageNeedsPassword() {
+	ctx, recorder := suite.newContext(http.MethodGet, "auth/two_factor")
+	suite.authModule.TwoFactorGETHandler(ctx)
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal(auth.AuthSignInPath, recorder.Header().Get("Location"))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorCode() {
+	user := suite.enableTwoFactor("0123456789")
+
+	code, err := totp.Code(testTwoFactorSecret, time.Now())
+	suite.NoError(err)
+
+	ctx, s := suite.newTwoFactorPOST(user.ID, code)
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal(auth.OauthAuthorizePath, ctx.Writer.Header().Get("Location"))
+	suite.Equal(user.ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionTwoFactorUserID))
+
+	// the same code can't be used to sign in again
+	ctx, s = suite.newTwoFactorPOST(user.ID, code)
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	suite.Equal(http.StatusForbidden, ctx.Writer.Status())
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorRecoveryCode() {
+	user := suite.enableTwoFactor("0123456789")
+
+	ctx, s := suite.newTwoFactorPOST(user.ID, "01234-56789")
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal(user.ID, s.Get(sessionUserID))
+
+	// the recovery code is used up
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), user.ID, dbUser))
+	suite.Empty(dbUser.TwoFactorRecoveryCodes)
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorTooManyWrongCodes() {
+	user := suite.enableTwoFactor("0123456789")
+
+	// each wrong code comes from a fresh sign in, which mustn't reset the count
+	for i := 1; i < 5; i++ {
+		ctx, s := suite.newTwoFactorPOST(user.ID, "not a code")
+		suite.authModule.TwoFactorPOSTHandler(ctx)
+		suite.Equal(http.StatusForbidden, ctx.Writer.Status())
+		suite.Equal(user.ID, s.Get(sessionTwoFactorUserID))
+
+		dbUser := &gtsmodel.User{}
+		suite.NoError(suite.db.GetByID(context.Background(), user.ID, dbUser))
+		suite.Equal(i, dbUser.TwoFactorAttempts)
+	}
+
+	// the fifth wrong code locks the user out, and means starting again with the password
+	ctx, s := suite.newTwoFactorPOST(user.ID, "not a code")
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	suite.Equal(http.StatusForbidden, ctx.Writer.Status())
+	suite.Nil(s.Get(sessionTwoFactorUserID))
+	suite.Nil(s.Get(sessionUserID))
+
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), user.ID, dbUser))
+	suite.True(dbUser.TwoFactorLockedUntil.After(time.Now()))
+
+	// while locked out, even the right code doesn't work
+	code, err := totp.Code(testTwoFactorSecret, time.Now())
+	suite.NoError(err)
+
+	ctx, s = suite.newTwoFactorPOST(user.ID, code)
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	suite.Equal(http.StatusForbidden, ctx.Writer.Status())
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func TestAuthTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthTwoFactorTestSuite))
+}
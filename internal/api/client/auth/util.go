@@ -29,3 +29,14 @@ func (m *Module) clearSession(s sessions.Session) {
 		panic(err)
 	}
 }
+
+// popReturnTo removes and returns the path that the user should be sent back to after signing in,
+// if they were sent to sign in from somewhere other than the oauth authorize flow.
+func (m *Module) popReturnTo(s sessions.Session) string {
+	returnTo, ok := s.Get(sessionReturnTo).(string)
+	if !ok {
+		return ""
+	}
+	s.Delete(sessionReturnTo)
+	return returnTo
+}
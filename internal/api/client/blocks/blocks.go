@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -52,6 +53,6 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePath, m.BlocksGETHandler)
+	r.AttachHandler(http.MethodGet, BasePath, oauth.Scoped(oauth.ScopeReadBlocks, m.BlocksGETHandler))
 	return nil
 }
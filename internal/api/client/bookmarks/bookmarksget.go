@@ -0,0 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bookmarks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// BookmarksGETHandler swagger:operation GET /api/v1/bookmarks bookmarksGet
+//
+// Get an array of statuses bookmarked by the requesting account, most recently bookmarked first.
+//
+// The returned Link header can be used to generate the previous and next queries when paging through the results.
+//
+// ---
+// tags:
+// - bookmarks
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: max_id
+//   type: string
+//   description: Return only statuses bookmarked *before* the bookmark with the given ID.
+//   in: query
+//   required: false
+// - name: min_id
+//   type: string
+//   description: Return only statuses bookmarked immediately *after* the bookmark with the given ID.
+//   in: query
+//   required: false
+// - name: limit
+//   type: integer
+//   description: Number of statuses to return.
+//   default: 20
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:bookmarks
+//
+// responses:
+//   '200':
+//     description: Array of bookmarked statuses.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/status"
+//     headers:
+//       Link:
+//         type: string
+//         description: Links to the next and previous queries.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) BookmarksGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "BookmarksGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := 20
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.Debugf("error parsing limit string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	resp, errWithCode := m.processor.BookmarkedTimelineGet(c.Request.Context(), authed, c.Query(MaxIDKey), c.Query(MinIDKey), limit)
+	if errWithCode != nil {
+		l.Debugf("error from processor BookmarkedTimelineGet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+	c.JSON(http.StatusOK, resp.Statuses)
+}
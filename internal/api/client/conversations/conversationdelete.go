@@ -0,0 +1,92 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package conversations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ConversationDELETEHandler swagger:operation DELETE /api/v1/conversations/{id} conversationDelete
+//
+// Remove a conversation from the requesting account's conversations.
+//
+// The statuses in the conversation will not be deleted. If a new status is posted to the thread,
+// the conversation will be created again.
+//
+// ---
+// tags:
+// - conversations
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the conversation.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:conversations
+//
+// responses:
+//   '200':
+//     description: The conversation was deleted.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ConversationDELETEHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ConversationDELETEHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversationID := c.Param(IDKey)
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no conversation id provided"})
+		return
+	}
+
+	if errWithCode := m.processor.ConversationDelete(c.Request.Context(), authed, conversationID); errWithCode != nil {
+		l.Debugf("error from processor ConversationDelete: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
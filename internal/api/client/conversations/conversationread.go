@@ -0,0 +1,92 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package conversations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ConversationReadPOSTHandler swagger:operation POST /api/v1/conversations/{id}/read conversationRead
+//
+// Mark a conversation as read.
+//
+// ---
+// tags:
+// - conversations
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the conversation.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:conversations
+//
+// responses:
+//   '200':
+//     description: The updated conversation.
+//     schema:
+//       "$ref": "#/definitions/conversation"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ConversationReadPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ConversationReadPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversationID := c.Param(IDKey)
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no conversation id provided"})
+		return
+	}
+
+	apiConversation, errWithCode := m.processor.ConversationRead(c.Request.Context(), authed, conversationID)
+	if errWithCode != nil {
+		l.Debugf("error from processor ConversationRead: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, apiConversation)
+}
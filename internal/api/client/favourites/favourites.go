@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -56,6 +57,6 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePath, m.FavouritesGETHandler)
+	r.AttachHandler(http.MethodGet, BasePath, oauth.Scoped(oauth.ScopeReadFavourites, m.FavouritesGETHandler))
 	return nil
 }
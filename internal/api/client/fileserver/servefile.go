@@ -91,6 +91,12 @@ func (m *FileServer) ServeFile(c *gin.Context) {
 		return
 	}
 
+	if content.URL != nil {
+		// the storage backend is serving this file itself, so send the caller there
+		c.Redirect(http.StatusFound, content.URL.String())
+		return
+	}
+
 	defer func() {
 		// if the content is a ReadCloser, close it when we're done
 		if closer, ok := content.Content.(io.ReadCloser); ok {
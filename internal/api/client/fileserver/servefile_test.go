@@ -24,9 +24,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
@@ -38,6 +38,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -46,7 +47,7 @@ type ServeFileTestSuite struct {
 	// standard suite interfaces
 	suite.Suite
 	db           db.DB
-	storage      *kv.KVStore
+	storage      *storage.Driver
 	federator    federation.Federator
 	tc           typeutils.TypeConverter
 	processor    processing.Processor
@@ -206,6 +207,80 @@ func (suite *ServeFileTestSuite) TestServeSmallFileSuccessful() {
 	suite.Equal(b, fileInStorage)
 }
 
+func (suite *ServeFileTestSuite) TestServeOriginalFileRedirectS3Presigned() {
+	targetAttachment, ok := suite.testAttachments["admin_account_status_1_attachment_1"]
+	suite.True(ok)
+	suite.NotNil(targetAttachment)
+
+	// swap out the in-memory storage for s3 storage that gives out presigned urls
+	s3Server := testrig.NewTestS3Server("gotosocial")
+	defer s3Server.Close()
+
+	s3, err := storage.NewS3(&storage.S3Config{
+		Endpoint:  strings.TrimPrefix(s3Server.URL, "http://"),
+		Bucket:    "gotosocial",
+		AccessKey: "access",
+		SecretKey: "secret",
+		ServeMode: storage.S3ServeModePresigned,
+	})
+	suite.NoError(err)
+
+	s3Storage, err := storage.NewDriver(s3)
+	suite.NoError(err)
+	testrig.StandardStorageSetup(s3Storage, "../../../../testrig/media")
+
+	processor := testrig.NewTestProcessor(suite.db, s3Storage, suite.federator, suite.emailSender, testrig.NewTestMediaManager(suite.db, s3Storage))
+	fileServer := fileserver.New(processor).(*fileserver.FileServer)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetAttachment.URL, nil)
+	ctx.Request.Header.Set("accept", "*/*")
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the ServeFile function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   fileserver.AccountIDKey,
+			Value: targetAttachment.AccountID,
+		},
+		gin.Param{
+			Key:   fileserver.MediaTypeKey,
+			Value: string(media.TypeAttachment),
+		},
+		gin.Param{
+			Key:   fileserver.MediaSizeKey,
+			Value: string(media.SizeOriginal),
+		},
+		gin.Param{
+			Key:   fileserver.FileNameKey,
+			Value: fmt.Sprintf("%s.jpeg", targetAttachment.ID),
+		},
+	}
+
+	// call the function we're testing: we should be redirected to s3 rather than served the file
+	fileServer.ServeFile(ctx)
+	suite.EqualValues(http.StatusFound, recorder.Code)
+
+	location := recorder.Header().Get("location")
+	suite.True(strings.HasPrefix(location, s3Server.URL+"/gotosocial/"+targetAttachment.File.Path+"?"))
+	suite.Contains(location, "X-Amz-Signature=")
+
+	// following the redirect should give us the file
+	resp, err := http.Get(location)
+	suite.NoError(err)
+	defer resp.Body.Close()
+	suite.EqualValues(http.StatusOK, resp.StatusCode)
+	suite.EqualValues("image/jpeg", resp.Header.Get("content-type"))
+
+	b, err := ioutil.ReadAll(resp.Body)
+	suite.NoError(err)
+
+	fileInStorage, err := s3Storage.Get(targetAttachment.File.Path)
+	suite.NoError(err)
+	suite.Equal(fileInStorage, b)
+}
+
 func TestServeFileTestSuite(t *testing.T) {
 	suite.Run(t, new(ServeFileTestSuite))
 }
@@ -22,13 +22,26 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
 const (
-	// BasePath is the base path for serving the filter API
-	BasePath = "/api/v1/filters"
+	// IDKey is for filter and filter keyword UUIDs
+	IDKey = "id"
+	// BasePathV1 is the base path for serving v1 of the filter API
+	BasePathV1 = "/api/v1/filters"
+	// BasePathV1WithID is just the v1 base path with the ID key in it.
+	BasePathV1WithID = BasePathV1 + "/:" + IDKey
+	// BasePathV2 is the base path for serving v2 of the filter API
+	BasePathV2 = "/api/v2/filters"
+	// BasePathV2WithID is just the v2 base path with the ID key in it.
+	BasePathV2WithID = BasePathV2 + "/:" + IDKey
+	// KeywordsPath is for viewing and adding the keywords of a filter
+	KeywordsPath = BasePathV2WithID + "/keywords"
+	// KeywordPathWithID is for viewing, changing, and removing one filter keyword
+	KeywordPathWithID = BasePathV2 + "/keywords/:" + IDKey
 )
 
 // Module implements the ClientAPIModule interface for every related to filters
@@ -45,6 +58,21 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePath, m.FiltersGETHandler)
+	r.AttachHandler(http.MethodGet, BasePathV1, oauth.Scoped(oauth.ScopeReadFilters, m.FiltersV1GETHandler))
+	r.AttachHandler(http.MethodPost, BasePathV1, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterV1POSTHandler))
+	r.AttachHandler(http.MethodGet, BasePathV1WithID, oauth.Scoped(oauth.ScopeReadFilters, m.FilterV1GETHandler))
+	r.AttachHandler(http.MethodPut, BasePathV1WithID, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterV1PUTHandler))
+	r.AttachHandler(http.MethodDelete, BasePathV1WithID, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterV1DELETEHandler))
+
+	r.AttachHandler(http.MethodGet, BasePathV2, oauth.Scoped(oauth.ScopeReadFilters, m.FiltersV2GETHandler))
+	r.AttachHandler(http.MethodPost, BasePathV2, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterV2POSTHandler))
+	r.AttachHandler(http.MethodGet, BasePathV2WithID, oauth.Scoped(oauth.ScopeReadFilters, m.FilterV2GETHandler))
+	r.AttachHandler(http.MethodPut, BasePathV2WithID, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterV2PUTHandler))
+	r.AttachHandler(http.MethodDelete, BasePathV2WithID, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterV2DELETEHandler))
+	r.AttachHandler(http.MethodGet, KeywordsPath, oauth.Scoped(oauth.ScopeReadFilters, m.FilterKeywordsGETHandler))
+	r.AttachHandler(http.MethodPost, KeywordsPath, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterKeywordPOSTHandler))
+	r.AttachHandler(http.MethodGet, KeywordPathWithID, oauth.Scoped(oauth.ScopeReadFilters, m.FilterKeywordGETHandler))
+	r.AttachHandler(http.MethodPut, KeywordPathWithID, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterKeywordPUTHandler))
+	r.AttachHandler(http.MethodDelete, KeywordPathWithID, oauth.Scoped(oauth.ScopeWriteFilters, m.FilterKeywordDELETEHandler))
 	return nil
 }
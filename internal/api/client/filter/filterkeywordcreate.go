@@ -0,0 +1,118 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package filter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FilterKeywordPOSTHandler swagger:operation POST /api/v2/filters/{id}/keywords filterKeywordCreate
+//
+// Add a keyword to a filter of the requesting account.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - filters
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the filter.
+//   in: path
+//   required: true
+// - name: keyword
+//   type: string
+//   description: The keyword to be matched.
+//   in: formData
+//   required: true
+// - name: whole_word
+//   type: boolean
+//   description: Should the keyword consider word boundaries?
+//   in: formData
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:filters
+//
+// responses:
+//   '200':
+//     description: The newly created filter keyword.
+//     schema:
+//       "$ref": "#/definitions/filterKeyword"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) FilterKeywordPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FilterKeywordPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	filterID := c.Param(IDKey)
+	if filterID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no filter id provided"})
+		return
+	}
+
+	form := &model.FilterKeywordRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	keyword, errWithCode := m.processor.FilterKeywordCreate(c.Request.Context(), authed, filterID, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor FilterKeywordCreate: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, keyword)
+}
@@ -1,18 +0,0 @@
-package filter
-
-import (
-	"net/http"
-
-	"github.com/gin-gonic/gin"
-	"github.com/superseriousbusiness/gotosocial/internal/api"
-)
-
-// FiltersGETHandler returns a list of filters set by/for the authed account
-func (m *Module) FiltersGETHandler(c *gin.Context) {
-	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
-		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, []string{})
-}
@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package filter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FiltersV1GETHandler swagger:operation GET /api/v1/filters filtersV1Get
+//
+// Get all v1 filters of the requesting account.
+//
+// Each keyword of the account's filters is returned as a separate v1 filter.
+//
+// ---
+// tags:
+// - filters
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:filters
+//
+// responses:
+//   '200':
+//     description: Array of all v1 filters of the requesting account.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/filterV1"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) FiltersV1GETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FiltersV1GETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	filters, errWithCode := m.processor.FiltersV1Get(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor FiltersV1Get: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filters)
+}
@@ -0,0 +1,141 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package filter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FilterV1PUTHandler swagger:operation PUT /api/v1/filters/{id} filterV1Update
+//
+// Replace an existing v1 filter of the requesting account.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - filters
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the filter.
+//   in: path
+//   required: true
+// - name: phrase
+//   type: string
+//   description: The text to be filtered.
+//   in: formData
+//   required: true
+// - name: context[]
+//   type: array
+//   items:
+//     type: string
+//     enum:
+//     - home
+//     - notifications
+//     - public
+//     - thread
+//     - account
+//   description: The contexts in which the filter should be applied.
+//   in: formData
+//   required: true
+// - name: irreversible
+//   type: boolean
+//   description: Should matching statuses be dropped by the server, rather than shown behind a warning?
+//   in: formData
+//   required: false
+// - name: whole_word
+//   type: boolean
+//   description: Should the filter consider word boundaries?
+//   in: formData
+//   required: false
+// - name: expires_in
+//   type: integer
+//   description: Number of seconds from now that the filter should expire. If not set or 0, the filter will not expire.
+//   in: formData
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:filters
+//
+// responses:
+//   '200':
+//     description: The updated v1 filter.
+//     schema:
+//       "$ref": "#/definitions/filterV1"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) FilterV1PUTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FilterV1PUTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	filterID := c.Param(IDKey)
+	if filterID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no filter id provided"})
+		return
+	}
+
+	form := &model.FilterV1Request{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, errWithCode := m.processor.FilterV1Update(c.Request.Context(), authed, filterID, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor FilterV1Update: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
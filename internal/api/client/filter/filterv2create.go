@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package filter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FilterV2POSTHandler swagger:operation POST /api/v2/filters filterV2Create
+//
+// Create a new filter for the requesting account.
+//
+// Keywords can be added to the filter at the same time by giving the request body as JSON, with a
+// `keywords_attributes` array of objects with `keyword` and `whole_word` fields.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - filters
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: title
+//   type: string
+//   description: The name of the filter.
+//   in: formData
+//   required: true
+// - name: context[]
+//   type: array
+//   items:
+//     type: string
+//     enum:
+//     - home
+//     - notifications
+//     - public
+//     - thread
+//     - account
+//   description: The contexts in which the filter should be applied.
+//   in: formData
+//   required: true
+// - name: filter_action
+//   type: string
+//   description: |-
+//     What should be done with statuses that match the filter.
+//
+//     `warn`: show the status behind a warning that identifies the filter.
+//     `hide`: do not show the status at all.
+//   enum:
+//   - warn
+//   - hide
+//   in: formData
+//   required: false
+// - name: expires_in
+//   type: integer
+//   description: Number of seconds from now that the filter should expire. If 0, the filter will not expire.
+//   in: formData
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:filters
+//
+// responses:
+//   '200':
+//     description: The newly created filter.
+//     schema:
+//       "$ref": "#/definitions/filterV2"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) FilterV2POSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FilterV2POSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.FilterV2CreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, errWithCode := m.processor.FilterV2Create(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor FilterV2Create: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
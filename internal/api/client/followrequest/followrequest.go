@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -54,8 +55,8 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePath, m.FollowRequestGETHandler)
-	r.AttachHandler(http.MethodPost, AuthorizePath, m.FollowRequestAuthorizePOSTHandler)
-	r.AttachHandler(http.MethodPost, RejectPath, m.FollowRequestRejectPOSTHandler)
+	r.AttachHandler(http.MethodGet, BasePath, oauth.Scoped(oauth.ScopeReadFollows, m.FollowRequestGETHandler))
+	r.AttachHandler(http.MethodPost, AuthorizePath, oauth.Scoped(oauth.ScopeWriteFollows, m.FollowRequestAuthorizePOSTHandler))
+	r.AttachHandler(http.MethodPost, RejectPath, oauth.Scoped(oauth.ScopeWriteFollows, m.FollowRequestRejectPOSTHandler))
 	return nil
 }
@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"net/http/httptest"
 
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
@@ -36,13 +35,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type FollowRequestStandardTestSuite struct {
 	suite.Suite
 	db           db.DB
-	storage      *kv.KVStore
+	storage      *storage.Driver
 	mediaManager media.Manager
 	federator    federation.Federator
 	processor    processing.Processor
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -28,6 +29,6 @@ func New(processor processing.Processor) api.ClientModule {
 // Route satisfies the ClientModule interface
 func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, InstanceInformationPath, m.InstanceInformationGETHandler)
-	s.AttachHandler(http.MethodPatch, InstanceInformationPath, m.InstanceUpdatePATCHHandler)
+	s.AttachHandler(http.MethodPatch, InstanceInformationPath, oauth.Scoped(oauth.ScopeAdminWrite, m.InstanceUpdatePATCHHandler))
 	return nil
 }
@@ -22,13 +22,30 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
 const (
+	// IDKey is for list UUIDs
+	IDKey = "id"
 	// BasePath is the base path for serving the lists API
 	BasePath = "/api/v1/lists"
+	// BasePathWithID is just the base path with the ID key in it.
+	// Use this anywhere you need to know the ID of the list being queried.
+	BasePathWithID = BasePath + "/:" + IDKey
+	// AccountsPath is for viewing and changing the accounts that are members of a list
+	AccountsPath = BasePathWithID + "/accounts"
+
+	// MaxIDKey is the url query for setting a max list entry ID to return
+	MaxIDKey = "max_id"
+	// SinceIDKey is the url query for returning results newer than the given ID
+	SinceIDKey = "since_id"
+	// MinIDKey is the url query for returning results immediately newer than the given ID
+	MinIDKey = "min_id"
+	// LimitKey is for specifying maximum number of results to return.
+	LimitKey = "limit"
 )
 
 // Module implements the ClientAPIModule interface for everything related to lists
@@ -45,6 +62,13 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePath, m.ListsGETHandler)
+	r.AttachHandler(http.MethodGet, BasePath, oauth.Scoped(oauth.ScopeReadLists, m.ListsGETHandler))
+	r.AttachHandler(http.MethodPost, BasePath, oauth.Scoped(oauth.ScopeWriteLists, m.ListCreatePOSTHandler))
+	r.AttachHandler(http.MethodGet, BasePathWithID, oauth.Scoped(oauth.ScopeReadLists, m.ListGETHandler))
+	r.AttachHandler(http.MethodPut, BasePathWithID, oauth.Scoped(oauth.ScopeWriteLists, m.ListUpdatePUTHandler))
+	r.AttachHandler(http.MethodDelete, BasePathWithID, oauth.Scoped(oauth.ScopeWriteLists, m.ListDELETEHandler))
+	r.AttachHandler(http.MethodGet, AccountsPath, oauth.Scoped(oauth.ScopeReadLists, m.ListAccountsGETHandler))
+	r.AttachHandler(http.MethodPost, AccountsPath, oauth.Scoped(oauth.ScopeWriteLists, m.ListAccountsPOSTHandler))
+	r.AttachHandler(http.MethodDelete, AccountsPath, oauth.Scoped(oauth.ScopeWriteLists, m.ListAccountsDELETEHandler))
 	return nil
 }
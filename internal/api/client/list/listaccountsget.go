@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package list
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ListAccountsGETHandler swagger:operation GET /api/v1/lists/{id}/accounts listAccounts
+//
+// See the accounts that are members of a list, newest additions first.
+//
+// The returned Link header can be used to generate the previous and next queries when paging through the list.
+//
+// ---
+// tags:
+// - lists
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the list.
+//   in: path
+//   required: true
+// - name: max_id
+//   type: string
+//   description: Return only accounts added to the list *before* the given max list entry ID.
+//   in: query
+//   required: false
+// - name: since_id
+//   type: string
+//   description: Return only accounts added to the list *after* the given since list entry ID.
+//   in: query
+//   required: false
+// - name: min_id
+//   type: string
+//   description: Return only accounts added to the list immediately *after* the given min list entry ID.
+//   in: query
+//   required: false
+// - name: limit
+//   type: integer
+//   description: Number of accounts to return. If 0, all accounts in the list will be returned.
+//   default: 40
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:lists
+//
+// responses:
+//   '200':
+//     description: Array of accounts in the list.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/account"
+//     headers:
+//       Link:
+//         type: string
+//         description: Links to the next and previous queries.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ListAccountsGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ListAccountsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	listID := c.Param(IDKey)
+	if listID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no list id provided"})
+		return
+	}
+
+	limit := 40
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.Debugf("error parsing limit string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	resp, errWithCode := m.processor.ListAccountsGet(c.Request.Context(), authed, listID, c.Query(MaxIDKey), c.Query(SinceIDKey), c.Query(MinIDKey), limit)
+	if errWithCode != nil {
+		l.Debugf("error from processor ListAccountsGet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+	c.JSON(http.StatusOK, resp.Accounts)
+}
@@ -0,0 +1,112 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package list
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ListAccountsDELETEHandler swagger:operation DELETE /api/v1/lists/{id}/accounts listAccountsRemove
+//
+// Remove accounts from a list.
+//
+// Accounts removed from a list will not be unfollowed.
+//
+// The account IDs can be given as query parameters, or in the body of the request as JSON or XML.
+//
+// ---
+// tags:
+// - lists
+//
+// consumes:
+// - application/json
+// - application/xml
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the list.
+//   in: path
+//   required: true
+// - name: account_ids[]
+//   type: array
+//   items:
+//     type: string
+//   description: IDs of the accounts to remove from the list.
+//   in: query
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:lists
+//
+// responses:
+//   '200':
+//     description: The accounts were removed from the list.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ListAccountsDELETEHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ListAccountsDELETEHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	listID := c.Param(IDKey)
+	if listID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no list id provided"})
+		return
+	}
+
+	form := &model.ListAccountsChangeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if errWithCode := m.processor.ListAccountsRemove(c.Request.Context(), authed, listID, form); errWithCode != nil {
+		l.Debugf("error from processor ListAccountsRemove: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -1,18 +1,79 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
 package list
 
 import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-// ListsGETHandler returns a list of lists created by/for the authed account
+// ListsGETHandler swagger:operation GET /api/v1/lists listsGet
+//
+// Get all lists created by the requesting account.
+//
+// ---
+// tags:
+// - lists
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:lists
+//
+// responses:
+//   '200':
+//     description: Array of all lists owned by the requesting account.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/list"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
 func (m *Module) ListsGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ListsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, []string{})
+	lists, errWithCode := m.processor.ListsGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor ListsGet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
 }
@@ -0,0 +1,118 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package markers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MarkersPOSTHandler swagger:operation POST /api/v1/markers markersPost
+//
+// Update the requesting account's read positions in one or more timelines.
+//
+// Each update increments the version of the marker. If a marker is updated by another
+// request at the same time, a 409 Conflict is returned and the update can be retried.
+// The updated markers are streamed as a `marker` event to the account's other open user streams.
+//
+// ---
+// tags:
+// - markers
+//
+// consumes:
+// - application/json
+// - application/x-www-form-urlencoded
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: home[last_read_id]
+//   type: string
+//   description: ID of the last status read in the home timeline.
+//   in: formData
+// - name: notifications[last_read_id]
+//   type: string
+//   description: ID of the last notification read.
+//   in: formData
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:statuses
+//
+// responses:
+//   '200':
+//     description: The updated markers.
+//     schema:
+//       "$ref": "#/definitions/markers"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '409':
+//      description: conflict, the marker was updated by another request
+func (m *Module) MarkersPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "MarkersPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.MarkerPostRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// form-encoded requests nest the positions as home[last_read_id], which doesn't bind to the form struct
+	if form.Home == nil {
+		if lastReadID, ok := c.PostFormMap("home")["last_read_id"]; ok {
+			form.Home = &model.MarkerTimelineRequest{LastReadID: lastReadID}
+		}
+	}
+	if form.Notifications == nil {
+		if lastReadID, ok := c.PostFormMap("notifications")["last_read_id"]; ok {
+			form.Notifications = &model.MarkerTimelineRequest{LastReadID: lastReadID}
+		}
+	}
+
+	marker, errWithCode := m.processor.MarkersSet(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor MarkersSet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, marker)
+}
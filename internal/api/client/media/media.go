@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -49,8 +50,8 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route satisfies the RESTAPIModule interface
 func (m *Module) Route(s router.Router) error {
-	s.AttachHandler(http.MethodPost, BasePath, m.MediaCreatePOSTHandler)
-	s.AttachHandler(http.MethodGet, BasePathWithID, m.MediaGETHandler)
-	s.AttachHandler(http.MethodPut, BasePathWithID, m.MediaPUTHandler)
+	s.AttachHandler(http.MethodPost, BasePath, oauth.Scoped(oauth.ScopeWriteMedia, m.MediaCreatePOSTHandler))
+	s.AttachHandler(http.MethodGet, BasePathWithID, oauth.Scoped(oauth.ScopeWriteMedia, m.MediaGETHandler))
+	s.AttachHandler(http.MethodPut, BasePathWithID, oauth.Scoped(oauth.ScopeWriteMedia, m.MediaPUTHandler))
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -34,6 +35,12 @@ const (
 	// BasePathWithID is just the base path with the ID key in it.
 	// Use this anywhere you need to know the ID of the notification being queried.
 	BasePathWithID = BasePath + "/:" + IDKey
+	// DismissPath is for removing a single notification
+	DismissPath = BasePathWithID + "/dismiss"
+	// ClearPath is for removing all notifications
+	ClearPath = BasePath + "/clear"
+	// UnreadCountPath is for counting notifications that haven't been read yet
+	UnreadCountPath = BasePath + "/unread_count"
 
 	// MaxIDKey is the url query for setting a max notification ID to return
 	MaxIDKey = "max_id"
@@ -41,6 +48,14 @@ const (
 	LimitKey = "limit"
 	// SinceIDKey is for specifying the minimum notification ID to return.
 	SinceIDKey = "since_id"
+	// MinIDKey is for specifying the notification ID to return notifications immediately newer than.
+	MinIDKey = "min_id"
+	// TypesKey is for specifying which types of notification to return. It can be given more than once.
+	TypesKey = "types"
+	// ExcludeTypesKey is for specifying which types of notification not to return. It can be given more than once.
+	ExcludeTypesKey = "exclude_types"
+	// AccountIDKey is for only returning notifications caused by the account with the given ID.
+	AccountIDKey = "account_id"
 )
 
 // Module implements the ClientAPIModule interface for every related to posting/deleting/interacting with notifications
@@ -57,6 +72,9 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
+	r.AttachHandler(http.MethodGet, BasePath, oauth.Scoped(oauth.ScopeReadNotifications, m.NotificationsGETHandler))
+	r.AttachHandler(http.MethodGet, UnreadCountPath, oauth.Scoped(oauth.ScopeReadNotifications, m.NotificationsUnreadCountGETHandler))
+	r.AttachHandler(http.MethodPost, ClearPath, oauth.Scoped(oauth.ScopeWriteNotifications, m.NotificationsClearPOSTHandler))
+	r.AttachHandler(http.MethodPost, DismissPath, oauth.Scoped(oauth.ScopeWriteNotifications, m.NotificationDismissPOSTHandler))
 	return nil
 }
@@ -28,7 +28,68 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-// NotificationsGETHandler serves a list of notifications to the caller, with the desired query parameters
+// NotificationsGETHandler swagger:operation GET /api/v1/notifications notificationsGet
+//
+// Get notifications for the requesting account.
+//
+// ---
+// tags:
+// - notifications
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: limit
+//   type: integer
+//   description: Number of notifications to return.
+//   default: 20
+//   in: query
+// - name: max_id
+//   type: string
+//   description: Return only notifications *OLDER* than the given notification ID.
+//   in: query
+// - name: since_id
+//   type: string
+//   description: Return only notifications *NEWER* than the given notification ID.
+//   in: query
+// - name: min_id
+//   type: string
+//   description: Return only notifications immediately *NEWER* than the given notification ID.
+//   in: query
+// - name: types[]
+//   type: array
+//   items:
+//     type: string
+//   description: Return only notifications of the given types.
+//   in: query
+// - name: exclude_types[]
+//   type: array
+//   items:
+//     type: string
+//   description: Don't return notifications of the given types.
+//   in: query
+// - name: account_id
+//   type: string
+//   description: Return only notifications caused by the account with the given ID.
+//   in: query
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:notifications
+//
+// responses:
+//   '200':
+//     name: notifications
+//     description: Array of notifications.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/notification"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
 func (m *Module) NotificationsGETHandler(c *gin.Context) {
 	l := logrus.WithFields(logrus.Fields{
 		"func":        "NotificationsGETHandler",
@@ -74,7 +135,12 @@ func (m *Module) NotificationsGETHandler(c *gin.Context) {
 		sinceID = sinceIDString
 	}
 
-	notifs, errWithCode := m.processor.NotificationsGet(c.Request.Context(), authed, limit, maxID, sinceID)
+	minID := c.Query(MinIDKey)
+	types := append(c.QueryArray(TypesKey+"[]"), c.QueryArray(TypesKey)...)
+	excludeTypes := append(c.QueryArray(ExcludeTypesKey+"[]"), c.QueryArray(ExcludeTypesKey)...)
+	accountID := c.Query(AccountIDKey)
+
+	notifs, errWithCode := m.processor.NotificationsGet(c.Request.Context(), authed, types, excludeTypes, accountID, limit, maxID, sinceID, minID)
 	if errWithCode != nil {
 		l.Debugf("error processing notifications get: %s", errWithCode.Error())
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
@@ -0,0 +1,121 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package notification
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// NotificationsUnreadCountGETHandler swagger:operation GET /api/v1/notifications/unread_count notificationsUnreadCount
+//
+// Count the notifications of the requesting account that haven't been read yet.
+//
+// Notifications are unread if they're newer than the last read notification set in the notifications marker.
+// If the notifications marker hasn't been set, all notifications are unread.
+//
+// ---
+// tags:
+// - notifications
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: limit
+//   type: integer
+//   description: Maximum number of notifications to count, up to 1000.
+//   default: 100
+//   in: query
+// - name: types[]
+//   type: array
+//   items:
+//     type: string
+//   description: Count only notifications of the given types.
+//   in: query
+// - name: exclude_types[]
+//   type: array
+//   items:
+//     type: string
+//   description: Don't count notifications of the given types.
+//   in: query
+// - name: account_id
+//   type: string
+//   description: Count only notifications caused by the account with the given ID.
+//   in: query
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:notifications
+//
+// responses:
+//   '200':
+//     description: The number of unread notifications.
+//     schema:
+//       "$ref": "#/definitions/notificationsUnreadCount"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) NotificationsUnreadCountGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "NotificationsUnreadCountGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the processor picks a default if no limit is given
+	limit := 0
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.Debugf("error parsing limit string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	types := append(c.QueryArray(TypesKey+"[]"), c.QueryArray(TypesKey)...)
+	excludeTypes := append(c.QueryArray(ExcludeTypesKey+"[]"), c.QueryArray(ExcludeTypesKey)...)
+	accountID := c.Query(AccountIDKey)
+
+	count, errWithCode := m.processor.NotificationsUnreadCount(c.Request.Context(), authed, types, excludeTypes, accountID, limit)
+	if errWithCode != nil {
+		l.Debugf("error from processor NotificationsUnreadCount: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, count)
+}
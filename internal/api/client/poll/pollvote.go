@@ -0,0 +1,122 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package poll
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PollVotePOSTHandler swagger:operation POST /api/v1/polls/{id}/votes pollVote
+//
+// Vote in the poll with the given ID.
+//
+// ---
+// tags:
+// - polls
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: Target poll ID.
+//   in: path
+//   required: true
+// - name: choices[]
+//   type: array
+//   items:
+//     type: integer
+//   description: Indices of the poll options to vote for.
+//   in: formData
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:statuses
+//
+// responses:
+//   '200':
+//     description: The poll, updated with the new vote.
+//     schema:
+//       "$ref": "#/definitions/poll"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '404':
+//      description: not found
+//   '409':
+//      description: conflict (already voted)
+func (m *Module) PollVotePOSTHandler(c *gin.Context) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":        "PollVotePOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+	l.Debugf("entering function")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debug("not authed so can't vote in poll")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	targetPollID := c.Param(IDKey)
+	if targetPollID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no poll id provided"})
+		return
+	}
+
+	form := &model.PollVoteRequest{}
+	if err := c.ShouldBind(form); err != nil || len(form.Choices) == 0 {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no choices provided"})
+		return
+	}
+
+	apiPoll, errWithCode := m.processor.PollVote(c.Request.Context(), authed, targetPollID, form.Choices)
+	if errWithCode != nil {
+		l.Debugf("error processing poll vote: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, apiPoll)
+}
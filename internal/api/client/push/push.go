@@ -0,0 +1,92 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package push
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// BasePath is the base URI path for managing the push subscription of the requesting token
+	BasePath = "/api/v1/push/subscription"
+)
+
+// Module implements the ClientAPIModule interface for everything relating to web push subscriptions
+type Module struct {
+	processor processing.Processor
+}
+
+// New returns a new push module
+func New(processor processing.Processor) api.ClientModule {
+	return &Module{
+		processor: processor,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, BasePath, oauth.Scoped(oauth.ScopePush, m.PushSubscriptionGETHandler))
+	r.AttachHandler(http.MethodPost, BasePath, oauth.Scoped(oauth.ScopePush, m.PushSubscriptionPOSTHandler))
+	r.AttachHandler(http.MethodPut, BasePath, oauth.Scoped(oauth.ScopePush, m.PushSubscriptionPUTHandler))
+	r.AttachHandler(http.MethodDelete, BasePath, oauth.Scoped(oauth.ScopePush, m.PushSubscriptionDELETEHandler))
+	return nil
+}
+
+// parseFormAlerts reads alerts sent form-encoded as data[alerts][follow]=true etc., which
+// don't bind to the request structs. It returns nil if no alerts were sent this way.
+func parseFormAlerts(c *gin.Context) (*model.PushSubscriptionRequestData, error) {
+	alerts := &model.PushSubscriptionRequestAlerts{}
+	found := false
+
+	for key, field := range map[string]**bool{
+		"follow":         &alerts.Follow,
+		"follow_request": &alerts.FollowRequest,
+		"favourite":      &alerts.Favourite,
+		"mention":        &alerts.Mention,
+		"reblog":         &alerts.Reblog,
+		"poll":           &alerts.Poll,
+		"status":         &alerts.Status,
+	} {
+		value, ok := c.GetPostForm("data[alerts][" + key + "]")
+		if !ok {
+			continue
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("data[alerts][%s] must be true or false", key)
+		}
+		*field = &b
+		found = true
+	}
+
+	if !found {
+		return nil, nil
+	}
+	return &model.PushSubscriptionRequestData{Alerts: alerts}, nil
+}
@@ -0,0 +1,132 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPUTHandler swagger:operation PUT /api/v1/push/subscription pushSubscriptionUpdate
+//
+// Change which notifications are pushed to the subscription of the token used for the request.
+//
+// Alerts that are left out of the request are not changed.
+//
+// ---
+// tags:
+// - push
+//
+// consumes:
+// - application/json
+// - application/x-www-form-urlencoded
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: data[alerts][follow]
+//   type: boolean
+//   description: Push notifications of new follows.
+//   in: formData
+// - name: data[alerts][follow_request]
+//   type: boolean
+//   description: Push notifications of new follow requests.
+//   in: formData
+// - name: data[alerts][favourite]
+//   type: boolean
+//   description: Push notifications of faves.
+//   in: formData
+// - name: data[alerts][mention]
+//   type: boolean
+//   description: Push notifications of mentions.
+//   in: formData
+// - name: data[alerts][reblog]
+//   type: boolean
+//   description: Push notifications of boosts.
+//   in: formData
+// - name: data[alerts][poll]
+//   type: boolean
+//   description: Push notifications of ended polls.
+//   in: formData
+// - name: data[alerts][status]
+//   type: boolean
+//   description: Push notifications of new statuses from accounts notifications were enabled for.
+//   in: formData
+//
+// security:
+// - OAuth2 Bearer:
+//   - push
+//
+// responses:
+//   '200':
+//     description: The updated push subscription.
+//     schema:
+//       "$ref": "#/definitions/pushSubscription"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: the token has no push subscription
+func (m *Module) PushSubscriptionPUTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "PushSubscriptionPUTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.PushSubscriptionUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if form.Data == nil {
+		form.Data, err = parseFormAlerts(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	subscription, errWithCode := m.processor.PushSubscriptionUpdate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor PushSubscriptionUpdate: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
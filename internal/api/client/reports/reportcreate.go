@@ -0,0 +1,132 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package reports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReportCreatePOSTHandler swagger:operation POST /api/v1/reports reportCreate
+//
+// Report an account, and optionally some of its statuses, to the admins of this instance.
+//
+// If the reported account is on another instance, the report can also be forwarded there.
+// Forwarded reports are sent by this instance, so they don't reveal who made the report.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - reports
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: account_id
+//   type: string
+//   description: ID of the account to report.
+//   in: formData
+//   required: true
+// - name: status_ids[]
+//   type: array
+//   items:
+//     type: string
+//   description: IDs of statuses posted by the reported account to attach to the report.
+//   in: formData
+//   required: false
+// - name: comment
+//   type: string
+//   description: Reason for the report, up to 1000 characters.
+//   in: formData
+//   required: false
+// - name: forward
+//   type: boolean
+//   description: If the reported account is remote, whether the report should be forwarded to its instance.
+//   default: false
+//   in: formData
+//   required: false
+// - name: category
+//   type: string
+//   description: Why the account is being reported.
+//   enum:
+//   - spam
+//   - other
+//   default: other
+//   in: formData
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:reports
+//
+// responses:
+//   '200':
+//     description: The newly created report.
+//     schema:
+//       "$ref": "#/definitions/report"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: not found
+func (m *Module) ReportCreatePOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ReportCreatePOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.ReportCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, errWithCode := m.processor.ReportCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor ReportCreate: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,120 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduledstatus
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ScheduledStatusUpdatePUTHandler swagger:operation PUT /api/v1/scheduled_statuses/{id} scheduledStatusUpdate
+//
+// Move a scheduled status to a different time.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - scheduled statuses
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: ID of the scheduled status.
+//   in: path
+//   required: true
+// - name: scheduled_at
+//   type: string
+//   description: ISO 8601 datetime at which the status should be published. Must be at least 5 minutes in the future.
+//   in: formData
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:statuses
+//
+// responses:
+//   '200':
+//     description: The updated scheduled status.
+//     schema:
+//       "$ref": "#/definitions/scheduledStatus"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+//   '422':
+//      description: scheduled_at is too soon, or too many statuses are already scheduled for that day
+func (m *Module) ScheduledStatusUpdatePUTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "ScheduledStatusUpdatePUTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	scheduledStatusID := c.Param(IDKey)
+	if scheduledStatusID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no scheduled status id provided"})
+		return
+	}
+
+	form := &model.ScheduledStatusUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("could not parse form from request: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if form.ScheduledAt == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no scheduled_at provided"})
+		return
+	}
+
+	scheduledStatus, errWithCode := m.processor.ScheduledStatusUpdate(c.Request.Context(), authed, scheduledStatusID, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor ScheduledStatusUpdate: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, scheduledStatus)
+}
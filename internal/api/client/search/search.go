@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -76,7 +77,7 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, BasePathV1, m.SearchGETHandler)
-	r.AttachHandler(http.MethodGet, BasePathV2, m.SearchGETHandler)
+	r.AttachHandler(http.MethodGet, BasePathV1, oauth.Scoped(oauth.ScopeReadSearch, m.SearchGETHandler))
+	r.AttachHandler(http.MethodGet, BasePathV2, oauth.Scoped(oauth.ScopeReadSearch, m.SearchGETHandler))
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -42,6 +43,11 @@ const (
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
 
+	// HistoryPath is for fetching previous versions of an edited status
+	HistoryPath = BasePathWithID + "/history"
+	// SourcePath is for fetching the plain-text source of a status for editing
+	SourcePath = BasePathWithID + "/source"
+
 	// FavouritedPath is for seeing who's faved a given status
 	FavouritedPath = BasePathWithID + "/favourited_by"
 	// FavouritePath is for posting a fave on a status
@@ -86,20 +92,32 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
-	r.AttachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
+	r.AttachHandler(http.MethodPost, BasePath, oauth.Scoped(oauth.ScopeWriteStatuses, m.StatusCreatePOSTHandler))
+	r.AttachHandler(http.MethodDelete, BasePathWithID, oauth.Scoped(oauth.ScopeWriteStatuses, m.StatusDELETEHandler))
+	r.AttachHandler(http.MethodPut, BasePathWithID, oauth.Scoped(oauth.ScopeWriteStatuses, m.StatusEditPUTHandler))
+
+	r.AttachHandler(http.MethodPost, FavouritePath, oauth.Scoped(oauth.ScopeWriteFavourites, m.StatusFavePOSTHandler))
+	r.AttachHandler(http.MethodPost, UnfavouritePath, oauth.Scoped(oauth.ScopeWriteFavourites, m.StatusUnfavePOSTHandler))
+	r.AttachHandler(http.MethodGet, FavouritedPath, oauth.Scoped(oauth.ScopeReadAccounts, m.StatusFavedByGETHandler))
+
+	r.AttachHandler(http.MethodPost, ReblogPath, oauth.Scoped(oauth.ScopeWriteStatuses, m.StatusBoostPOSTHandler))
+	r.AttachHandler(http.MethodPost, UnreblogPath, oauth.Scoped(oauth.ScopeWriteStatuses, m.StatusUnboostPOSTHandler))
+	r.AttachHandler(http.MethodGet, RebloggedPath, oauth.Scoped(oauth.ScopeReadAccounts, m.StatusBoostedByGETHandler))
+
+	r.AttachHandler(http.MethodPost, BookmarkPath, oauth.Scoped(oauth.ScopeWriteBookmarks, m.StatusBookmarkPOSTHandler))
+	r.AttachHandler(http.MethodPost, UnbookmarkPath, oauth.Scoped(oauth.ScopeWriteBookmarks, m.StatusUnbookmarkPOSTHandler))
 
-	r.AttachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
-	r.AttachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
-	r.AttachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
+	r.AttachHandler(http.MethodPost, MutePath, oauth.Scoped(oauth.ScopeWriteMutes, m.StatusMutePOSTHandler))
+	r.AttachHandler(http.MethodPost, UnmutePath, oauth.Scoped(oauth.ScopeWriteMutes, m.StatusUnmutePOSTHandler))
 
-	r.AttachHandler(http.MethodPost, ReblogPath, m.StatusBoostPOSTHandler)
-	r.AttachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
-	r.AttachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
+	r.AttachHandler(http.MethodPost, PinPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.StatusPinPOSTHandler))
+	r.AttachHandler(http.MethodPost, UnpinPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.StatusUnpinPOSTHandler))
 
-	r.AttachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	r.AttachHandler(http.MethodGet, ContextPath, oauth.Scoped(oauth.ScopeReadStatuses, m.StatusContextGETHandler))
+	r.AttachHandler(http.MethodGet, HistoryPath, oauth.Scoped(oauth.ScopeReadStatuses, m.StatusHistoryGETHandler))
+	r.AttachHandler(http.MethodGet, SourcePath, oauth.Scoped(oauth.ScopeReadStatuses, m.StatusSourceGETHandler))
 
-	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, oauth.Scoped(oauth.ScopeReadStatuses, m.muxHandler))
 	return nil
 }
 
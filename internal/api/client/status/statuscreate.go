@@ -41,6 +41,9 @@ import (
 // The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
 //
+// If scheduled_at is set, the status is not created straight away. Instead, a scheduled status
+// is returned, and the status will be created once the scheduled time has passed.
+//
 // ---
 // tags:
 // - statuses
@@ -59,7 +62,7 @@ import (
 //
 // responses:
 //   '200':
-//     description: "The newly created status."
+//     description: "The newly created status, or the newly scheduled status if scheduled_at was set."
 //     schema:
 //       "$ref": "#/definitions/status"
 //   '401':
@@ -68,6 +71,8 @@ import (
 //      description: bad request
 //   '404':
 //      description: not found
+//   '422':
+//      description: scheduled_at is too soon, or too many statuses are already scheduled
 //   '500':
 //      description: internal error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -110,6 +115,18 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		apiScheduledStatus, errWithCode := m.processor.ScheduledStatusCreate(c.Request.Context(), authed, form)
+		if errWithCode != nil {
+			l.Debugf("error processing scheduled status create: %s", errWithCode.Error())
+			c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+			return
+		}
+
+		c.JSON(http.StatusOK, apiScheduledStatus)
+		return
+	}
+
 	apiStatus, err := m.processor.StatusCreate(c.Request.Context(), authed, form)
 	if err != nil {
 		l.Debugf("error processing status create: %s", err)
@@ -154,6 +171,9 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm) error {
 		if form.Poll.Options == nil {
 			return errors.New("poll with no options")
 		}
+		if len(form.Poll.Options) < 2 {
+			return fmt.Errorf("too few poll options provided, %d provided but at least 2 are required", len(form.Poll.Options))
+		}
 		if len(form.Poll.Options) > maxPollOptions {
 			return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
 		}
@@ -162,6 +182,9 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm) error {
 				return fmt.Errorf("poll option too long, %d characters provided but limit is %d", len(p), maxPollChars)
 			}
 		}
+		if form.Poll.ExpiresIn <= 0 {
+			return errors.New("poll expires_in must be a positive number of seconds")
+		}
 	}
 
 	// validate spoiler text/cw
@@ -27,6 +27,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
@@ -357,6 +358,72 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
 	suite.Equal(statusResponse.ID, gtsAttachment.StatusID)
 }
 
+func (suite *StatusCreateTestSuite) TestPostScheduledStatus() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", status.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this will be posted later"},
+		"visibility":   {string(model.VisibilityPrivate)},
+		"scheduled_at": {scheduledAt},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// we should get a scheduled status back rather than a status
+	scheduledStatusReply := &model.ScheduledStatus{}
+	err = json.Unmarshal(b, scheduledStatusReply)
+	suite.NoError(err)
+
+	suite.NotEmpty(scheduledStatusReply.ID)
+	suite.Equal(scheduledAt, scheduledStatusReply.ScheduledAt)
+	suite.Equal("this will be posted later", scheduledStatusReply.Params.Text)
+	suite.Equal(model.VisibilityPrivate, scheduledStatusReply.Params.Visibility)
+	suite.Equal(suite.testApplications["application_1"].ID, scheduledStatusReply.Params.ApplicationID)
+
+	// nothing should have been posted yet
+	_, err = suite.db.GetStatusByID(context.Background(), scheduledStatusReply.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *StatusCreateTestSuite) TestPostScheduledStatusTooSoon() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", status.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this will be posted almost straight away"},
+		"scheduled_at": {time.Now().Add(time.Minute).UTC().Format(time.RFC3339)},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusUnprocessableEntity, recorder.Code)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
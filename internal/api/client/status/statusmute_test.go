@@ -0,0 +1,108 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type StatusMuteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusMuteTestSuite) postMute(path string, handler gin.HandlerFunc, targetStatusID string) *model.Status {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":id", targetStatusID, 1)), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   status.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	handler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &model.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+
+	return statusReply
+}
+
+func (suite *StatusMuteTestSuite) TestPostMuteUnmute() {
+	// this is a reply in a thread started by local_account_1_status_1
+	targetStatus := suite.testStatuses["admin_account_status_3"]
+
+	statusReply := suite.postMute(status.MutePath, suite.statusModule.StatusMutePOSTHandler, targetStatus.ID)
+	suite.Equal(targetStatus.ID, statusReply.ID)
+	suite.True(statusReply.Muted)
+
+	// muting again is harmless
+	statusReply = suite.postMute(status.MutePath, suite.statusModule.StatusMutePOSTHandler, targetStatus.ID)
+	suite.True(statusReply.Muted)
+
+	// the mute applies to the whole thread
+	rootStatus, err := suite.db.GetStatusByID(context.Background(), suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(err)
+	muted, err := suite.db.IsStatusMutedBy(context.Background(), rootStatus, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	statusReply = suite.postMute(status.UnmutePath, suite.statusModule.StatusUnmutePOSTHandler, targetStatus.ID)
+	suite.Equal(targetStatus.ID, statusReply.ID)
+	suite.False(statusReply.Muted)
+}
+
+func TestStatusMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusMuteTestSuite))
+}
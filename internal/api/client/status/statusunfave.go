@@ -47,7 +47,7 @@ import (
 //
 // security:
 // - OAuth2 Bearer:
-//   - write:statuses
+//   - write:favourites
 //
 // responses:
 //   '200':
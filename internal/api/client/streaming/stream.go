@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 // StreamGETHandler swagger:operation GET /api/v1/streaming streamGet
@@ -56,9 +57,19 @@ import (
 //     `direct`: receive updates for direct messages.
 //   in: query
 //   required: true
+// - name: list
+//   type: string
+//   description: ID of the list to receive updates for. Required if `stream` is `list`.
+//   in: query
+//   required: false
+// - name: tag
+//   type: string
+//   description: Name of the hashtag to receive updates for, without the `#` symbol. Required if `stream` is `hashtag` or `hashtag:local`.
+//   in: query
+//   required: false
 // security:
 // - OAuth2 Bearer:
-//   - read:streaming
+//   - read:statuses
 //
 // responses:
 //   '101':
@@ -116,6 +127,28 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		return
 	}
 
+	if streamType == stream.TimelineList {
+		listID := c.Query(ListQueryKey)
+		if listID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("no list id provided under query key %s", ListQueryKey)})
+			return
+		}
+		streamType = stream.ListTimeline(listID)
+	}
+
+	if streamType == stream.TimelineHashtag || streamType == stream.TimelineHashtagLocal {
+		tagName := c.Query(TagQueryKey)
+		if tagName == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("no hashtag provided under query key %s", TagQueryKey)})
+			return
+		}
+		if streamType == stream.TimelineHashtag {
+			streamType = stream.HashtagTimeline(tagName)
+		} else {
+			streamType = stream.LocalHashtagTimeline(tagName)
+		}
+	}
+
 	accessToken := c.Query(AccessTokenQueryKey)
 	if accessToken == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("no access token provided under query key %s", AccessTokenQueryKey)})
@@ -148,7 +181,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	defer conn.Close() // whatever happens, when we leave this function we want to close the websocket connection
 
 	// inform the processor that we have a new connection and want a s for it
-	s, errWithCode := m.processor.OpenStreamForAccount(c.Request.Context(), account, streamType)
+	s, errWithCode := m.processor.OpenStreamForAccount(c.Request.Context(), account, accessToken, streamType)
 	if errWithCode != nil {
 		c.JSON(errWithCode.Code(), errWithCode.Safe())
 		return
@@ -162,7 +195,13 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 sendLoop:
 	for {
 		select {
-		case m := <-s.Messages:
+		case m, ok := <-s.Messages:
+			if !ok {
+				// the stream was closed on our side, eg., because the token it was opened with was revoked
+				l.Debug("stream was closed")
+				break sendLoop
+			}
+
 			// we've got a streaming message!!
 			l.Trace("received message from stream")
 			if err := conn.WriteJSON(m); err != nil {
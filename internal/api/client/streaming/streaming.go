@@ -33,6 +33,12 @@ const (
 	// StreamQueryKey is the query key for the type of stream being requested
 	StreamQueryKey = "stream"
 
+	// ListQueryKey is the query key for the ID of the list to stream, when the list stream type is requested.
+	ListQueryKey = "list"
+
+	// TagQueryKey is the query key for the hashtag to stream, when the hashtag or local hashtag stream type is requested.
+	TagQueryKey = "tag"
+
 	// AccessTokenQueryKey is the query key for an oauth access token that should be passed in streaming requests.
 	AccessTokenQueryKey = "access_token"
 )
@@ -0,0 +1,62 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tags
+
+import (
+	"net/http"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// NameKey is for hashtag names
+	NameKey = "name"
+	// BasePath is the base URI path for serving hashtags
+	BasePath = "/api/v1/tags"
+	// BasePathWithName is the base path with the name key in it.
+	// Use this anywhere you need to know the name of the hashtag being queried.
+	BasePathWithName = BasePath + "/:" + NameKey
+	// FollowPath is for following a hashtag
+	FollowPath = BasePathWithName + "/follow"
+	// UnfollowPath is for unfollowing a hashtag
+	UnfollowPath = BasePathWithName + "/unfollow"
+)
+
+// Module implements the ClientAPIModule interface for everything relating to hashtags
+type Module struct {
+	processor processing.Processor
+}
+
+// New returns a new tags module
+func New(processor processing.Processor) api.ClientModule {
+	return &Module{
+		processor: processor,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, BasePathWithName, oauth.Scoped(oauth.ScopeReadFollows, m.TagGETHandler))
+	r.AttachHandler(http.MethodPost, FollowPath, oauth.Scoped(oauth.ScopeWriteFollows, m.TagFollowPOSTHandler))
+	r.AttachHandler(http.MethodPost, UnfollowPath, oauth.Scoped(oauth.ScopeWriteFollows, m.TagUnfollowPOSTHandler))
+	return nil
+}
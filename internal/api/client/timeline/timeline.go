@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -33,6 +34,14 @@ const (
 	HomeTimeline = BasePath + "/home"
 	// PublicTimeline is the path for the public (and public local) timeline
 	PublicTimeline = BasePath + "/public"
+	// ListIDKey is for list UUIDs
+	ListIDKey = "id"
+	// ListTimeline is the path for the timeline of one list
+	ListTimeline = BasePath + "/list/:" + ListIDKey
+	// HashtagKey is for hashtag names
+	HashtagKey = "hashtag"
+	// TagTimeline is the path for the timeline of one hashtag
+	TagTimeline = BasePath + "/tag/:" + HashtagKey
 	// MaxIDKey is the url query for setting a max status ID to return
 	MaxIDKey = "max_id"
 	// SinceIDKey is the url query for returning results newer than the given ID
@@ -43,6 +52,12 @@ const (
 	LimitKey = "limit"
 	// LocalKey is for specifying whether only local statuses should be returned
 	LocalKey = "local"
+	// AnyKey is for specifying additional hashtags that statuses may use instead of the main one
+	AnyKey = "any"
+	// AllKey is for specifying additional hashtags that statuses must also use
+	AllKey = "all"
+	// NoneKey is for specifying hashtags that statuses must not use
+	NoneKey = "none"
 )
 
 // Module implements the ClientAPIModule interface for everything relating to viewing timelines
@@ -59,7 +74,9 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodGet, HomeTimeline, m.HomeTimelineGETHandler)
-	r.AttachHandler(http.MethodGet, PublicTimeline, m.PublicTimelineGETHandler)
+	r.AttachHandler(http.MethodGet, HomeTimeline, oauth.Scoped(oauth.ScopeReadStatuses, m.HomeTimelineGETHandler))
+	r.AttachHandler(http.MethodGet, PublicTimeline, oauth.Scoped(oauth.ScopeReadStatuses, m.PublicTimelineGETHandler))
+	r.AttachHandler(http.MethodGet, ListTimeline, oauth.Scoped(oauth.ScopeReadLists, m.ListTimelineGETHandler))
+	r.AttachHandler(http.MethodGet, TagTimeline, oauth.Scoped(oauth.ScopeReadStatuses, m.TagTimelineGETHandler))
 	return nil
 }
@@ -0,0 +1,153 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AuthorizedAppsGETHandler swagger:operation GET /api/v1/user/authorized_apps userAuthorizedApps
+//
+// See which applications hold tokens for the authenticated user's account.
+//
+// There is one entry per token, including the token used for this request. Each entry says when its
+// token was last used, accurate to within a few minutes.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: The applications holding tokens for the account, most recently authorized first.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/authorizedApp"
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '406':
+//      description: not acceptable
+//   '500':
+//      description: internal error
+func (m *Module) AuthorizedAppsGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AuthorizedAppsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	apps, errWithCode := m.processor.AuthorizedAppsGet(c.Request.Context(), authed.User)
+	if errWithCode != nil {
+		l.Debugf("error getting authorized apps: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, apps)
+}
+
+// AuthorizedAppDELETEHandler swagger:operation DELETE /api/v1/user/authorized_apps/{id} userAuthorizedAppRevoke
+//
+// Revoke a token held by an application, signing the application out of the authenticated user's account.
+//
+// Any streams that were opened with the token are closed immediately.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the token to revoke, as given by /api/v1/user/authorized_apps.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The token was revoked.
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '404':
+//      description: not found
+//   '406':
+//      description: not acceptable
+//   '500':
+//      description: internal error
+func (m *Module) AuthorizedAppDELETEHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AuthorizedAppDELETEHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokenID := c.Param(IDKey)
+	if tokenID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no token id specified"})
+		return
+	}
+
+	if errWithCode := m.processor.AuthorizedAppRevoke(c.Request.Context(), authed.User, tokenID); errWithCode != nil {
+		l.Debugf("error revoking authorized app: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,297 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TwoFactorGETHandler swagger:operation GET /api/v1/user/two_factor userTwoFactor
+//
+// See whether two-factor authentication is enabled for the authenticated user.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: Whether two-factor authentication is enabled.
+//     schema:
+//       "$ref": "#/definitions/twoFactor"
+//   '401':
+//      description: unauthorized
+//   '406':
+//      description: not acceptable
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "TwoFactorGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	twoFactor, errWithCode := m.processor.UserTwoFactorGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error getting two-factor status: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, twoFactor)
+}
+
+// TwoFactorEnrollPOSTHandler swagger:operation POST /api/v1/user/two_factor/enroll userTwoFactorEnroll
+//
+// Start enrolling the authenticated user in two-factor authentication.
+//
+// A new TOTP secret is generated, and returned along with a QR code to scan into an authenticator app.
+// Two-factor authentication isn't enabled until a code from the app is sent to /api/v1/user/two_factor/confirm.
+// Starting again before then replaces the secret.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The secret to add to an authenticator app.
+//     schema:
+//       "$ref": "#/definitions/twoFactorEnrollment"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '406':
+//      description: not acceptable
+//   '409':
+//      description: two-factor authentication is already enabled
+func (m *Module) TwoFactorEnrollPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "TwoFactorEnrollPOSTHandler")
+
+	authed, ok := m.activeUser(c, l)
+	if !ok {
+		return
+	}
+
+	form := &model.TwoFactorEnrollRequest{}
+	if err := c.ShouldBind(form); err != nil || form.Password == "" {
+		if err != nil {
+			l.Debugf("could not parse form from request: %s", err)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
+		return
+	}
+
+	enrollment, errWithCode := m.processor.UserTwoFactorEnroll(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error starting two-factor enrollment: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// TwoFactorConfirmPOSTHandler swagger:operation POST /api/v1/user/two_factor/confirm userTwoFactorConfirm
+//
+// Finish enrolling the authenticated user in two-factor authentication, by giving a code from the authenticator app.
+//
+// From then on, signing in takes a code from the app as well as the password. The response contains
+// recovery codes that can each be used once instead of a code from the app. They are only shown this once.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: Two-factor authentication is enabled. Contains the recovery codes.
+//     schema:
+//       "$ref": "#/definitions/twoFactorRecoveryCodes"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '406':
+//      description: not acceptable
+//   '409':
+//      description: two-factor authentication is already enabled
+func (m *Module) TwoFactorConfirmPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "TwoFactorConfirmPOSTHandler")
+
+	authed, ok := m.activeUser(c, l)
+	if !ok {
+		return
+	}
+
+	form := &model.TwoFactorConfirmRequest{}
+	if err := c.ShouldBind(form); err != nil || form.Code == "" {
+		if err != nil {
+			l.Debugf("could not parse form from request: %s", err)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
+		return
+	}
+
+	recoveryCodes, errWithCode := m.processor.UserTwoFactorConfirm(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error confirming two-factor enrollment: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recoveryCodes)
+}
+
+// TwoFactorDisablePOSTHandler swagger:operation POST /api/v1/user/two_factor/disable userTwoFactorDisable
+//
+// Turn off two-factor authentication for the authenticated user.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: Two-factor authentication is turned off.
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '406':
+//      description: not acceptable
+func (m *Module) TwoFactorDisablePOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "TwoFactorDisablePOSTHandler")
+
+	authed, ok := m.activeUser(c, l)
+	if !ok {
+		return
+	}
+
+	form := &model.TwoFactorDisableRequest{}
+	if err := c.ShouldBind(form); err != nil || form.Password == "" || form.Code == "" {
+		if err != nil {
+			l.Debugf("could not parse form from request: %s", err)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
+		return
+	}
+
+	if errWithCode := m.processor.UserTwoFactorDisable(c.Request.Context(), authed, form); errWithCode != nil {
+		l.Debugf("error disabling two-factor authentication: %s", errWithCode.Error())
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// activeUser authenticates the request, and checks that the user can make changes to their sign-in settings.
+func (m *Module) activeUser(c *gin.Context, l *logrus.Entry) (*oauth.Auth, bool) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if authed.User.Disabled || !authed.User.Approved || !authed.Account.SuspendedAt.IsZero() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled, not yet approved, or suspended"})
+		return nil, false
+	}
+
+	return authed, true
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -31,6 +32,21 @@ const (
 	BasePath = "/api/v1/user"
 	// PasswordChangePath is the path for POSTing a password change request.
 	PasswordChangePath = BasePath + "/password_change"
+	// AuthorizedAppsPath is the path for seeing which applications hold tokens for the account.
+	AuthorizedAppsPath = BasePath + "/authorized_apps"
+	// AuthorizedAppPath is the path for revoking the token of one of those applications.
+	AuthorizedAppPath = AuthorizedAppsPath + "/:" + IDKey
+	// TwoFactorPath is the path for seeing whether two-factor authentication is enabled.
+	TwoFactorPath = BasePath + "/two_factor"
+	// TwoFactorEnrollPath is the path for starting to enroll in two-factor authentication.
+	TwoFactorEnrollPath = TwoFactorPath + "/enroll"
+	// TwoFactorConfirmPath is the path for finishing enrolling in two-factor authentication.
+	TwoFactorConfirmPath = TwoFactorPath + "/confirm"
+	// TwoFactorDisablePath is the path for turning off two-factor authentication.
+	TwoFactorDisablePath = TwoFactorPath + "/disable"
+
+	// IDKey is the key for the id of a token in the path.
+	IDKey = "id"
 )
 
 // Module implements the ClientAPIModule interface
@@ -47,6 +63,12 @@ func New(processor processing.Processor) api.ClientModule {
 
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
-	r.AttachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
+	r.AttachHandler(http.MethodPost, PasswordChangePath, oauth.Scoped(oauth.ScopeWriteAccounts, m.PasswordChangePOSTHandler))
+	r.AttachHandler(http.MethodGet, AuthorizedAppsPath, oauth.Scoped(oauth.ScopeReadAccounts, m.AuthorizedAppsGETHandler))
+	r.AttachHandler(http.MethodDelete, AuthorizedAppPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.AuthorizedAppDELETEHandler))
+	r.AttachHandler(http.MethodGet, TwoFactorPath, oauth.Scoped(oauth.ScopeReadAccounts, m.TwoFactorGETHandler))
+	r.AttachHandler(http.MethodPost, TwoFactorEnrollPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.TwoFactorEnrollPOSTHandler))
+	r.AttachHandler(http.MethodPost, TwoFactorConfirmPath, oauth.Scoped(oauth.ScopeWriteAccounts, m.TwoFactorConfirmPOSTHandler))
+	r.AttachHandler(http.MethodPost, TwoFactorDisablePath, oauth.Scoped(oauth.ScopeWriteAccounts, m.TwoFactorDisablePOSTHandler))
 	return nil
 }
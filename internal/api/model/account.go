@@ -88,6 +88,8 @@ type Account struct {
 	// If this account has been muted, when will the mute expire (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	MuteExpiresAt string `json:"mute_expires_at,omitempty"`
+	// If this account has moved to another account, the account it has moved to.
+	Moved *Account `json:"moved,omitempty"`
 	// Extra profile information. Shown only if the requester owns the account being requested.
 	Source *Source `json:"source,omitempty"`
 }
@@ -163,6 +165,9 @@ type UpdateSource struct {
 	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Default language to use for authored statuses. (ISO 6391)
 	Language *string `form:"language" json:"language" xml:"language"`
+	// Types of notification that shouldn't be streamed or pushed to this account's clients.
+	// Provide an empty value to receive all types again.
+	ExcludedNotifications *[]string `form:"excluded_notifications" json:"excluded_notifications" xml:"excluded_notifications"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -188,6 +193,18 @@ type AccountFollowRequest struct {
 	Notify *bool `form:"notify" json:"notify" xml:"notify"`
 }
 
+// AccountMuteRequest models a request to mute an account.
+//
+// swagger:ignore
+type AccountMuteRequest struct {
+	// The id of the account to mute.
+	ID string `form:"-" json:"-" xml:"-"`
+	// Mute notifications from this account as well as its statuses.
+	Notifications *bool `form:"notifications" json:"notifications" xml:"notifications"`
+	// How long the mute should last, in seconds. 0 means the mute doesn't expire.
+	Duration int `form:"duration" json:"duration" xml:"duration"`
+}
+
 // AccountDeleteRequest models a request to delete an account.
 //
 // swagger:ignore
@@ -198,3 +215,22 @@ type AccountDeleteRequest struct {
 	// Can be the ID of the account owner, or the ID of an admin account.
 	DeleteOriginID string `form:"-" json:"-" xml:"-"`
 }
+
+// AccountAliasRequest models a request to set the aliases of an account.
+//
+// swagger:ignore
+type AccountAliasRequest struct {
+	// ActivityPub URIs of accounts that this account is also known as.
+	// Replaces any previously set aliases; an empty list removes all aliases.
+	AlsoKnownAsURIs []string `form:"also_known_as_uris[]" json:"also_known_as_uris" xml:"also_known_as_uris"`
+}
+
+// AccountMoveRequest models a request to move an account to another account.
+//
+// swagger:ignore
+type AccountMoveRequest struct {
+	// Password of the account's user, for confirmation.
+	Password string `form:"password" json:"password" xml:"password"`
+	// ActivityPub URI of the account to move to.
+	MovedToURI string `form:"moved_to_uri" json:"moved_to_uri" xml:"moved_to_uri"`
+}
@@ -19,6 +19,8 @@
 package model
 
 // AdminAccountInfo models the admin view of an account's details.
+//
+// swagger:model adminAccountInfo
 type AdminAccountInfo struct {
 	// The ID of the account in the database.
 	ID string `json:"id"`
@@ -32,6 +34,8 @@ type AdminAccountInfo struct {
 	Email string `json:"email"`
 	// The IP address last used to login to this account.
 	IP string `json:"ip"`
+	// IP addresses used by the account's user to sign in and sign up, most recent first.
+	IPs []AdminAccountIP `json:"ips"`
 	// The locale of the account. (ISO 639 Part 1 two-letter language code)
 	Locale string `json:"locale"`
 	// Invite request text
@@ -56,14 +60,37 @@ type AdminAccountInfo struct {
 	InvitedByAccountID string `json:"invited_by_account_id"`
 }
 
+// AdminAccountIP is an IP address used by an account's user, and when it was used.
+//
+// swagger:model adminAccountIP
+type AdminAccountIP struct {
+	// The IP address.
+	// example: 192.0.2.1
+	IP string `json:"ip"`
+	// When the IP address was used. (ISO 8601 Datetime)
+	UsedAt string `json:"used_at"`
+}
+
 // AdminReportInfo models the admin view of a report.
+//
+// swagger:model adminReport
 type AdminReportInfo struct {
 	// The ID of the report in the database.
+	// example: 01G2HMGBG0CYS1QVSFP6KHTN8T
 	ID string `json:"id"`
-	// The action taken to resolve this report.
-	ActionTaken string `json:"action_taken"`
+	// Whether an admin has taken action on this report.
+	// example: false
+	ActionTaken bool `json:"action_taken"`
+	// When an admin took action on this report (ISO 8601 Datetime). Null if no action has been taken yet.
+	// example: 2021-07-30T09:20:25+00:00
+	ActionTakenAt *string `json:"action_taken_at"`
+	// Why the account was reported: spam or other.
+	// example: other
+	Category string `json:"category"`
 	// An optional reason for reporting.
 	Comment string `json:"comment"`
+	// Whether the report was forwarded to the reported account's instance.
+	Forwarded bool `json:"forwarded"`
 	// The time the report was filed. (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
 	// The time of last action on this report. (ISO 8601 Datetime)
@@ -74,10 +101,12 @@ type AdminReportInfo struct {
 	TargetAccount *Account `json:"target_account"`
 	// The account of the moderator assigned to this report.
 	AssignedAccount *Account `json:"assigned_account"`
-	// The action taken by the moderator who handled the report.
-	ActionTakenByAccount string `json:"action_taken_by_account"`
+	// The account of the moderator who handled the report.
+	ActionTakenByAccount *Account `json:"action_taken_by_account"`
 	// Statuses attached to the report, for context.
-	Statuses []Status `json:"statuses"`
+	Statuses []*Status `json:"statuses"`
+	// Instance rules attached to the report. Always empty for now.
+	Rules []interface{} `json:"rules"`
 }
 
 // AdminAccountActionRequest models the admin view of an account's details.
@@ -88,6 +117,8 @@ type AdminAccountActionRequest struct {
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// ID of a report to resolve along with this action, if any.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
 	// ID of the account to be acted on.
 	TargetAccountID string `form:"-" json:"-" xml:"-"`
 }
@@ -42,6 +42,27 @@ type Application struct {
 	VapidKey string `json:"vapid_key,omitempty"`
 }
 
+// AuthorizedApp models an application that holds a token for the requesting account.
+//
+// swagger:model authorizedApp
+type AuthorizedApp struct {
+	// The ID of the token held by the application. Use this ID to revoke the token.
+	// example: 01F8MGTQW4DKTDF8SW5CT9HYGA
+	ID string `json:"id"`
+	// The application holding the token.
+	Application *Application `json:"application"`
+	// The scopes that the token grants.
+	// example: ["read","write","push"]
+	Scopes []string `json:"scopes"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the token was last used (ISO 8601 Datetime), accurate to within a few minutes.
+	// Null if the token has never been used.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt *string `json:"last_used_at"`
+}
+
 // ApplicationCreateRequest models app create parameters.
 //
 // swagger:parameters appCreate
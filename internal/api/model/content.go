@@ -18,7 +18,10 @@
 
 package model
 
-import "io"
+import (
+	"io"
+	"net/url"
+)
 
 // Content wraps everything needed to serve a blob of content (some kind of media) through the API.
 type Content struct {
@@ -28,6 +31,9 @@ type Content struct {
 	ContentLength int64
 	// Actual content
 	Content io.Reader
+	// URL at which the content can be fetched directly; if this is set, Content will be nil
+	// and the caller should be redirected here rather than served the content itself
+	URL *url.URL
 }
 
 // GetContentRequestForm describes a piece of content desired by the caller of the fileserver API.
@@ -0,0 +1,57 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Delivery represents one outgoing federation delivery that is waiting to be sent, or has been given up on.
+//
+// swagger:model delivery
+type Delivery struct {
+	// The ID of the delivery.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// Time at which this delivery was queued (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The remote inbox this delivery is addressed to.
+	// example: https://example.org/users/some_user/inbox
+	Inbox string `json:"inbox"`
+	// The host of the remote inbox.
+	// example: example.org
+	Host string `json:"host"`
+	// The ActivityPub key ID of the local account sending this delivery.
+	// example: https://our.instance/users/some_user/main-key
+	KeyID string `json:"key_id"`
+	// Whether this delivery is still being attempted (pending), or has been given up on (failed).
+	// example: pending
+	State string `json:"state"`
+	// Number of times delivery has been attempted so far.
+	// example: 3
+	Attempts int `json:"attempts"`
+	// Time at which delivery was last attempted (ISO 8601 Datetime). Omitted if it hasn't been attempted yet.
+	// example: 2021-07-30T09:20:25+00:00
+	LastAttemptAt string `json:"last_attempt_at,omitempty"`
+	// Time at which delivery will next be attempted (ISO 8601 Datetime). Omitted if the delivery has failed.
+	// example: 2021-07-30T09:20:25+00:00
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	// Error returned by the last attempt, if any.
+	// example: POST request to https://example.org/users/some_user/inbox failed (502): 502 Bad Gateway
+	LastError string `json:"last_error,omitempty"`
+	// The serialized ActivityStreams activity being delivered.
+	Payload string `json:"payload"`
+}
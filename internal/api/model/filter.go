@@ -18,29 +18,178 @@
 
 package model
 
-// Filter represents a user-defined filter for determining which statuses should not be shown to the user.
-// If whole_word is true , client app should do:
+// FilterV1 represents a user-defined filter for determining which statuses should not be shown to the user.
+//
+// In v1 of the filters API, each filter has exactly one keyword or phrase, so a v1 filter
+// corresponds to one keyword of a v2 filter, and has the same ID as that keyword.
+//
+// If whole_word is true, client app should do:
 // Define ‘word constituent character’ for your app. In the official implementation, it’s [A-Za-z0-9_] in JavaScript, and [[:word:]] in Ruby.
 // Ruby uses the POSIX character class (Letter | Mark | Decimal_Number | Connector_Punctuation).
 // If the phrase starts with a word character, and if the previous character before matched range is a word character, its matched range should be treated to not match.
 // If the phrase ends with a word character, and if the next character after matched range is a word character, its matched range should be treated to not match.
 // Please check app/javascript/mastodon/selectors/index.js and app/lib/feed_manager.rb in the Mastodon source code for more details.
-type Filter struct {
+//
+// swagger:model filterV1
+type FilterV1 struct {
 	// The ID of the filter in the database.
+	// example: 01G1XMVDHYQ50PWG7C7N2ZHWTZ
 	ID string `json:"id"`
 	// The text to be filtered.
-	Phrase string `json:"text"`
+	// example: fnord
+	Phrase string `json:"phrase"`
 	// The contexts in which the filter should be applied.
 	// Array of String (Enumerable anyOf)
 	// 	home = home timeline and lists
 	// 	notifications = notifications timeline
 	// 	public = public timelines
 	// 	thread = expanded thread of a detailed status
+	// 	account = statuses of an account
 	Context []string `json:"context"`
 	// Should the filter consider word boundaries?
 	WholeWord bool `json:"whole_word"`
 	// When the filter should no longer be applied (ISO 8601 Datetime), or null if the filter does not expire
-	ExpiresAt string `json:"expires_at,omitempty"`
+	ExpiresAt *string `json:"expires_at"`
 	// Should matching entities in home and notifications be dropped by the server?
 	Irreversible bool `json:"irreversible"`
 }
+
+// FilterV2 represents a user-defined filter for determining which statuses should not be shown to the user,
+// or should be shown behind a warning.
+//
+// swagger:model filterV2
+type FilterV2 struct {
+	// The ID of the filter in the database.
+	// example: 01G1XMV0ARAWV4MT2SQ1W8GDM8
+	ID string `json:"id"`
+	// The user-defined title of the filter.
+	// example: fnords
+	Title string `json:"title"`
+	// The contexts in which the filter should be applied.
+	// Array of String (Enumerable anyOf)
+	// 	home = home timeline and lists
+	// 	notifications = notifications timeline
+	// 	public = public timelines
+	// 	thread = expanded thread of a detailed status
+	// 	account = statuses of an account
+	Context []string `json:"context"`
+	// When the filter should no longer be applied (ISO 8601 Datetime), or null if the filter does not expire
+	ExpiresAt *string `json:"expires_at"`
+	// What should be done with statuses that match the filter.
+	// 	warn = show a warning that identifies the matching filter
+	// 	hide = do not show the status at all
+	// example: warn
+	FilterAction string `json:"filter_action"`
+	// The keywords grouped under this filter.
+	Keywords []FilterKeyword `json:"keywords"`
+	// The statuses grouped under this filter. Always empty, since filtering of individual statuses isn't supported.
+	Statuses []FilterStatus `json:"statuses"`
+}
+
+// FilterKeyword represents a keyword that, if matched, should cause a filter action to be taken.
+//
+// swagger:model filterKeyword
+type FilterKeyword struct {
+	// The ID of the keyword in the database.
+	// example: 01G1XMVDHYQ50PWG7C7N2ZHWTZ
+	ID string `json:"id"`
+	// The phrase to be matched against.
+	// example: fnord
+	Keyword string `json:"keyword"`
+	// Should the filter consider word boundaries?
+	WholeWord bool `json:"whole_word"`
+}
+
+// FilterStatus represents a single status that, if matched, should cause a filter action to be taken.
+//
+// swagger:model filterStatus
+type FilterStatus struct {
+	// The ID of the filter status in the database.
+	ID string `json:"id"`
+	// The ID of the filtered status.
+	StatusID string `json:"status_id"`
+}
+
+// FilterResult represents a filter whose keywords matched a given status.
+//
+// swagger:model filterResult
+type FilterResult struct {
+	// The filter that was matched.
+	Filter FilterV2 `json:"filter"`
+	// The keywords within the filter that were matched.
+	KeywordMatches []string `json:"keyword_matches"`
+	// The status IDs within the filter that were matched.
+	StatusMatches []string `json:"status_matches"`
+}
+
+// FilterV1Request models a request to create or replace a v1 filter.
+//
+// swagger:ignore
+type FilterV1Request struct {
+	// The text to be filtered.
+	Phrase string `form:"phrase" json:"phrase" xml:"phrase"`
+	// The contexts in which the filter should be applied.
+	Context []string `form:"context[]" json:"context" xml:"context"`
+	// Should matching entities in home and notifications be dropped by the server?
+	Irreversible bool `form:"irreversible" json:"irreversible" xml:"irreversible"`
+	// Should the filter consider word boundaries?
+	WholeWord bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+	// Number of seconds from now that the filter should expire. If not set or 0, the filter will not expire.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
+
+// FilterV2CreateRequest models a request to create a v2 filter.
+//
+// swagger:ignore
+type FilterV2CreateRequest struct {
+	// The name of the filter group.
+	Title string `form:"title" json:"title" xml:"title"`
+	// The contexts in which the filter should be applied.
+	Context []string `form:"context[]" json:"context" xml:"context"`
+	// The policy to be applied when the filter is matched: warn or hide. Defaults to warn.
+	FilterAction string `form:"filter_action" json:"filter_action" xml:"filter_action"`
+	// Number of seconds from now that the filter should expire. If not set or 0, the filter will not expire.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+	// Keywords to be added to the newly created filter.
+	KeywordsAttributes []FilterKeywordAttributes `form:"-" json:"keywords_attributes" xml:"keywords_attributes"`
+}
+
+// FilterV2UpdateRequest models a request to update an existing v2 filter. Fields that are not set are left unchanged.
+//
+// swagger:ignore
+type FilterV2UpdateRequest struct {
+	// The name of the filter group.
+	Title *string `form:"title" json:"title" xml:"title"`
+	// The contexts in which the filter should be applied.
+	Context []string `form:"context[]" json:"context" xml:"context"`
+	// The policy to be applied when the filter is matched: warn or hide.
+	FilterAction *string `form:"filter_action" json:"filter_action" xml:"filter_action"`
+	// Number of seconds from now that the filter should expire. If 0, the filter will not expire.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+	// Keywords to be added to, changed in, or removed from the filter.
+	KeywordsAttributes []FilterKeywordAttributes `form:"-" json:"keywords_attributes" xml:"keywords_attributes"`
+}
+
+// FilterKeywordAttributes models one keyword to be added to, changed in, or removed from a v2 filter.
+//
+// swagger:ignore
+type FilterKeywordAttributes struct {
+	// ID of an existing keyword to change or remove. Leave empty to add a new keyword.
+	ID string `json:"id" xml:"id"`
+	// The keyword to be added to the filter.
+	Keyword string `json:"keyword" xml:"keyword"`
+	// Whether the keyword should consider word boundaries.
+	WholeWord bool `json:"whole_word" xml:"whole_word"`
+	// If true, the keyword with the given ID will be removed from the filter.
+	Destroy bool `json:"_destroy" xml:"_destroy"`
+}
+
+// FilterKeywordRequest models a request to add a keyword to a v2 filter, or to change an existing keyword.
+//
+// swagger:ignore
+type FilterKeywordRequest struct {
+	// The keyword to be matched.
+	Keyword string `form:"keyword" json:"keyword" xml:"keyword"`
+	// Whether the keyword should consider word boundaries.
+	WholeWord bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+}
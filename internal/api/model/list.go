@@ -18,14 +18,56 @@
 
 package model
 
-// List represents a list of some users that the authenticated user follows.
+// List represents a list of followed accounts, created by the requesting account.
+//
+// swagger:model list
 type List struct {
-	// The internal database ID of the list.
+	// The ID of the list in the database.
+	// example: 01G1CNBTNXGQNRHCM3WVSQHP8T
 	ID string `json:"id"`
 	// The user-defined title of the list.
+	// example: cool people
 	Title string `json:"title"`
-	// followed = Show replies to any followed user
-	//	list = Show replies to members of the list
-	//	none = Show replies to no one
+	// Which replies should be shown in the list timeline.
+	//
+	// followed = replies to any followed account
+	// list = replies to other members of the list
+	// none = no replies
+	// example: list
 	RepliesPolicy string `json:"replies_policy"`
 }
+
+// ListCreateRequest models a request to create a list.
+//
+// swagger:ignore
+type ListCreateRequest struct {
+	// Title of the new list.
+	Title string `form:"title" json:"title" xml:"title"`
+	// Replies policy of the new list: followed, list, or none. Defaults to list.
+	RepliesPolicy string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+}
+
+// ListUpdateRequest models a request to update an existing list.
+//
+// swagger:ignore
+type ListUpdateRequest struct {
+	// New title of the list.
+	Title *string `form:"title" json:"title" xml:"title"`
+	// New replies policy of the list: followed, list, or none.
+	RepliesPolicy *string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+}
+
+// ListAccountsChangeRequest models a request to add accounts to, or remove accounts from, a list.
+//
+// swagger:ignore
+type ListAccountsChangeRequest struct {
+	// IDs of the accounts to add or remove.
+	AccountIDs []string `form:"account_ids[]" json:"account_ids" xml:"account_ids"`
+}
+
+// ListAccountsResponse wraps a slice of accounts that are members of a list,
+// along with the Link header for the previous and next queries.
+type ListAccountsResponse struct {
+	Accounts   []*Account
+	LinkHeader string
+}
@@ -19,19 +19,47 @@
 package model
 
 // Marker represents the last read position within a user's timelines.
+//
+// Timelines that no marker has been set for yet are left out.
+//
+// swagger:model markers
 type Marker struct {
 	// Information about the user's position in the home timeline.
-	Home *TimelineMarker `json:"home"`
+	Home *TimelineMarker `json:"home,omitempty"`
 	// Information about the user's position in their notifications.
-	Notifications *TimelineMarker `json:"notifications"`
+	Notifications *TimelineMarker `json:"notifications,omitempty"`
 }
 
 // TimelineMarker contains information about a user's progress through a specific timeline.
+//
+// swagger:model timelineMarker
 type TimelineMarker struct {
 	// The ID of the most recently viewed entity.
+	// example: 01FVW7JHQFSFK166WWKR8CBA6M
 	LastReadID string `json:"last_read_id"`
 	// The timestamp of when the marker was set (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
 	UpdatedAt string `json:"updated_at"`
 	// Used for locking to prevent write conflicts.
-	Version string `json:"version"`
+	// Incremented every time the marker is updated.
+	// example: 3
+	Version int `json:"version"`
+}
+
+// MarkerPostRequest models a request to update the markers of one or more timelines.
+//
+// swagger:ignore
+type MarkerPostRequest struct {
+	// New position in the home timeline.
+	Home *MarkerTimelineRequest `form:"-" json:"home" xml:"home"`
+	// New position in the notifications timeline.
+	Notifications *MarkerTimelineRequest `form:"-" json:"notifications" xml:"notifications"`
+}
+
+// MarkerTimelineRequest models the new position in one timeline, as part of a MarkerPostRequest.
+//
+// swagger:ignore
+type MarkerTimelineRequest struct {
+	// ID of the last status or notification that was read.
+	LastReadID string `form:"last_read_id" json:"last_read_id" xml:"last_read_id"`
 }
@@ -19,6 +19,8 @@
 package model
 
 // Notification represents a notification of an event relevant to the user.
+//
+// swagger:model notification
 type Notification struct {
 	// REQUIRED
 
@@ -43,3 +45,12 @@ type Notification struct {
 	// Status that was the object of the notification, e.g. in mentions, reblogs, favourites, or polls.
 	Status *Status `json:"status,omitempty"`
 }
+
+// NotificationsUnreadCount is the number of notifications the user hasn't read yet.
+//
+// swagger:model notificationsUnreadCount
+type NotificationsUnreadCount struct {
+	// Number of notifications newer than the notifications marker, up to the requested limit.
+	// example: 3
+	Count int `json:"count"`
+}
@@ -34,3 +34,13 @@ type OAuthAuthorize struct {
 	// Must be a subset of scopes declared during app registration. If not provided, defaults to read.
 	Scope string `form:"scope" json:"scope"`
 }
+
+// OAuthRevoke represents a request sent to https://example.org/oauth/revoke
+type OAuthRevoke struct {
+	// Client ID, obtained during app registration.
+	ClientID string `form:"client_id" json:"client_id" xml:"client_id"`
+	// Client secret, obtained during app registration.
+	ClientSecret string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+	// The access or refresh token to revoke.
+	Token string `form:"token" json:"token" xml:"token"`
+}
@@ -19,21 +19,30 @@
 package model
 
 // PushSubscription represents a subscription to the push streaming server.
+//
+// swagger:model pushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
 	ID string `json:"id"`
 	// Where push alerts will be sent to.
+	// example: https://push.example.org/send/yw8Hq0z9w4M
 	Endpoint string `json:"endpoint"`
 	// The streaming server's VAPID key.
+	// example: BCk-QqERU0q-CfYZjcuB6lnyyOYfJ2AifKqfeGIm7Z-HiTU5T9eTG5GxVA0_OH5mMlI4UkkDTpaZwozy0TzdZ2M=
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
 	Alerts *PushSubscriptionAlerts `json:"alerts"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model pushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -42,4 +51,87 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when someone you enabled notifications for has posted a status?
+	Status bool `json:"status"`
+}
+
+// PushSubscriptionCreateRequest models a request to subscribe the requesting token to push notifications.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	// The push service endpoint, and the keys to encrypt notifications with.
+	Subscription *PushSubscriptionRequestSubscription `form:"-" json:"subscription" xml:"subscription"`
+	// Which notifications should be pushed.
+	Data *PushSubscriptionRequestData `form:"-" json:"data" xml:"data"`
+}
+
+// PushSubscriptionUpdateRequest models a request to change which notifications are pushed to an existing subscription.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	// Which notifications should be pushed.
+	Data *PushSubscriptionRequestData `form:"-" json:"data" xml:"data"`
+}
+
+// PushSubscriptionRequestSubscription models the push service endpoint and keys of a PushSubscriptionCreateRequest.
+//
+// swagger:ignore
+type PushSubscriptionRequestSubscription struct {
+	// Endpoint URL of the push service.
+	Endpoint string `form:"endpoint" json:"endpoint" xml:"endpoint"`
+	// Keys that notifications are encrypted with.
+	Keys *PushSubscriptionRequestKeys `form:"-" json:"keys" xml:"keys"`
+}
+
+// PushSubscriptionRequestKeys models the encryption keys of a PushSubscriptionCreateRequest.
+//
+// swagger:ignore
+type PushSubscriptionRequestKeys struct {
+	// Base64url encoded public key of the P-256 ECDH keypair of the client.
+	P256dh string `form:"p256dh" json:"p256dh" xml:"p256dh"`
+	// Base64url encoded authentication secret of the client.
+	Auth string `form:"auth" json:"auth" xml:"auth"`
+}
+
+// PushSubscriptionRequestData models the alert settings of a push subscription request.
+//
+// swagger:ignore
+type PushSubscriptionRequestData struct {
+	// Which notifications should be pushed.
+	Alerts *PushSubscriptionRequestAlerts `form:"-" json:"alerts" xml:"alerts"`
+}
+
+// PushSubscriptionRequestAlerts models the alert toggles of a push subscription request.
+// Alerts that are left out are not changed, or are off for a new subscription.
+//
+// swagger:ignore
+type PushSubscriptionRequestAlerts struct {
+	Follow        *bool `form:"follow" json:"follow" xml:"follow"`
+	FollowRequest *bool `form:"follow_request" json:"follow_request" xml:"follow_request"`
+	Favourite     *bool `form:"favourite" json:"favourite" xml:"favourite"`
+	Mention       *bool `form:"mention" json:"mention" xml:"mention"`
+	Reblog        *bool `form:"reblog" json:"reblog" xml:"reblog"`
+	Poll          *bool `form:"poll" json:"poll" xml:"poll"`
+	Status        *bool `form:"status" json:"status" xml:"status"`
+}
+
+// WebPushNotification is the payload of a push message sent to a push subscription,
+// which the client decrypts and uses to display a notification.
+//
+// swagger:ignore
+type WebPushNotification struct {
+	// The token the subscription was created with, so the client can fetch more details.
+	AccessToken string `json:"access_token"`
+	// The language the notification texts are in.
+	PreferredLocale string `json:"preferred_locale"`
+	// The id of the notification.
+	NotificationID string `json:"notification_id"`
+	// The type of the notification.
+	NotificationType string `json:"notification_type"`
+	// Avatar of the account that caused the notification.
+	Icon string `json:"icon"`
+	// Short title of the notification.
+	Title string `json:"title"`
+	// Plaintext excerpt of the status the notification is about, if any.
+	Body string `json:"body"`
 }
@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Report represents a report of an account, filed by the requesting account.
+//
+// swagger:model report
+type Report struct {
+	// The ID of the report.
+	// example: 01G2HMGBG0CYS1QVSFP6KHTN8T
+	ID string `json:"id"`
+	// Whether an admin has taken action on this report.
+	// example: false
+	ActionTaken bool `json:"action_taken"`
+	// When an admin took action on this report (ISO 8601 Datetime). Null if no action has been taken yet.
+	// example: 2021-07-30T09:20:25+00:00
+	ActionTakenAt *string `json:"action_taken_at"`
+	// Why the account was reported: spam or other.
+	// example: other
+	Category string `json:"category"`
+	// The reason given for the report.
+	// example: dark souls sucks, please yeet this nerd
+	Comment string `json:"comment"`
+	// Whether the report was forwarded to the reported account's instance.
+	// example: true
+	Forwarded bool `json:"forwarded"`
+	// When the report was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// IDs of the statuses attached to the report.
+	StatusIDs []string `json:"status_ids"`
+	// IDs of the instance rules broken by the reported account. Always empty for now.
+	RuleIDs []string `json:"rule_ids"`
+	// The account that was reported.
+	TargetAccount *Account `json:"target_account"`
+}
+
+// ReportCreateRequest models a request to report an account.
+//
+// swagger:ignore
+type ReportCreateRequest struct {
+	// ID of the account to report.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// IDs of statuses by the reported account to attach to the report.
+	StatusIDs []string `form:"status_ids[]" json:"status_ids" xml:"status_ids"`
+	// Reason for the report.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+	// If the reported account is remote, whether the report should be forwarded to its instance.
+	Forward bool `form:"forward" json:"forward" xml:"forward"`
+	// Why the account is being reported: spam or other. Defaults to other.
+	Category string `form:"category" json:"category" xml:"category"`
+}
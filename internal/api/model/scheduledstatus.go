@@ -19,21 +19,57 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// The ID of the scheduled status in the database.
+	// example: 01G36SF3V6Y6V5BF9P4R7PQG7G
+	ID string `json:"id"`
+	// When the status will be published (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt string `json:"scheduled_at"`
+	// The parameters that will be used to create the status.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached when the status is published.
+	MediaAttachments []Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model statusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	// Text of the status.
+	Text string `json:"text"`
+	// ID of the status being replied to, if any.
+	InReplyToID string `json:"in_reply_to_id,omitempty"`
+	// IDs of media attachments to attach to the status.
+	MediaIDs []string `json:"media_ids,omitempty"`
+	// Mark the status and its media as sensitive.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	// Visibility of the status.
+	Visibility Visibility `json:"visibility"`
+	// When the status will be published (ISO 8601 Datetime).
+	ScheduledAt string `json:"scheduled_at,omitempty"`
+	// ID of the application used to schedule the status.
+	ApplicationID string `json:"application_id"`
+	// ISO 639 language code for the status.
+	Language string `json:"language,omitempty"`
+	// Poll to attach to the status, if any.
+	Poll *PollRequest `json:"poll,omitempty"`
+}
+
+// ScheduledStatusUpdateRequest models a request to change the publishing time of a scheduled status.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which the status will be published. Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
+}
+
+// ScheduledStatusesResponse wraps a slice of scheduled statuses, along with the Link header for the previous and next queries.
+type ScheduledStatusesResponse struct {
+	ScheduledStatuses []*ScheduledStatus
+	LinkHeader        string
 }
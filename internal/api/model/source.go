@@ -37,4 +37,9 @@ type Source struct {
 	Fields []Field `json:"fields"`
 	// The number of pending follow requests.
 	FollowRequestsCount int `json:"follow_requests_count,omitempty"`
+	// ActivityPub URIs of accounts that this account is also known as.
+	AlsoKnownAsURIs []string `json:"also_known_as_uris,omitempty"`
+	// Types of notification that won't be streamed or pushed to this account's clients.
+	// They can still be fetched from the notifications endpoint.
+	ExcludedNotifications []string `json:"excluded_notifications,omitempty"`
 }
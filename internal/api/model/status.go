@@ -28,6 +28,10 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited (ISO 8601 Datetime).
+	// Omitted if the status has never been edited.
+	// example: 2021-07-30T09:25:25+00:00
+	EditedAt string `json:"edited_at,omitempty"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	InReplyToID string `json:"in_reply_to_id,omitempty"`
@@ -90,6 +94,8 @@ type Status struct {
 	Card *Card `json:"card"`
 	// The poll attached to the status.
 	Poll *Poll `json:"poll"`
+	// Filters of the requesting account that matched this status, if any.
+	Filtered []FilterResult `json:"filtered,omitempty"`
 	// Plain-text source of a status. Returned instead of content when status is deleted,
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
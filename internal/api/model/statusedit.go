@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// StatusEdit models one revision of an edited status.
+//
+// swagger:model statusEdit
+type StatusEdit struct {
+	// The content of this revision. Should be HTML, but might also be plaintext in some cases.
+	// example: <p>Hey this is a status!</p>
+	Content string `json:"content"`
+	// Subject, summary, or content warning for this revision.
+	// example: warning nsfw
+	SpoilerText string `json:"spoiler_text"`
+	// This revision contains sensitive content.
+	// example: false
+	Sensitive bool `json:"sensitive"`
+	// The date when this revision was written (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The account that authored the status.
+	Account *Account `json:"account"`
+	// Media that was attached to this revision.
+	MediaAttachments []Attachment `json:"media_attachments"`
+	// Custom emoji to be used when rendering this revision.
+	Emojis []Emoji `json:"emojis"`
+}
+
+// StatusSource models the plain-text source of a status, for use when editing it.
+//
+// swagger:model statusSource
+type StatusSource struct {
+	// ID of the status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Plain-text source of the status.
+	Text string `json:"text"`
+	// Plain-text version of the spoiler text.
+	SpoilerText string `json:"spoiler_text"`
+}
+
+// StatusEditRequest models status edit parameters.
+//
+// swagger:parameters statusEdit
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	// in: formData
+	Status string `form:"status" json:"status" xml:"status"`
+	// Array of Attachment ids to be attached as media.
+	// Attachments already on the status must be included here to keep them.
+	// in: formData
+	MediaIDs []string `form:"media_ids" json:"media_ids" xml:"media_ids"`
+	// Status and attached media should be marked as sensitive.
+	// in: formData
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	// in: formData
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// ISO 639 language code for this status.
+	// in: formData
+	Language string `form:"language" json:"language" xml:"language"`
+	// Format to use when parsing this status.
+	// enum:
+	// - markdown
+	// - plain
+	// in: formData
+	Format StatusFormat `form:"format" json:"format" xml:"format"`
+}
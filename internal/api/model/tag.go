@@ -28,4 +28,7 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
+	// Whether the requesting account follows this hashtag.
+	// Only set when the hashtag is looked up directly, not when it's part of a status.
+	Following *bool `json:"following,omitempty"`
 }
@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// TwoFactor represents whether two-factor authentication is enabled for the authenticated user.
+//
+// swagger:model twoFactor
+type TwoFactor struct {
+	// Whether codes from an authenticator app are required to sign in.
+	// example: true
+	Enabled bool `json:"enabled"`
+	// When two-factor authentication was enabled (ISO 8601 Datetime). Omitted if it's not enabled.
+	// example: 2021-07-30T09:20:25+00:00
+	EnabledAt string `json:"enabled_at,omitempty"`
+	// How many unused recovery codes are left.
+	// example: 10
+	RecoveryCodesLeft int `json:"recovery_codes_left"`
+}
+
+// TwoFactorEnrollment contains what the user needs to add their account to an authenticator app.
+//
+// swagger:model twoFactorEnrollment
+type TwoFactorEnrollment struct {
+	// The TOTP secret, encoded as base32, for typing into an authenticator app by hand.
+	// example: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	Secret string `json:"secret"`
+	// The otpauth:// key URI containing the secret.
+	// example: otpauth://totp/GoToSocial%20example.org:zork@example.org?algorithm=SHA1&digits=6&issuer=GoToSocial+example.org&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	URI string `json:"uri"`
+	// The key URI as a QR code, for scanning with an authenticator app. A PNG image, as a data URI.
+	// example: data:image/png;base64,iVBORw0KGgo...
+	QRCode string `json:"qr_code"`
+}
+
+// TwoFactorRecoveryCodes are the one-time codes a user can sign in with instead of a code from their authenticator app.
+//
+// swagger:model twoFactorRecoveryCodes
+type TwoFactorRecoveryCodes struct {
+	// The recovery codes. They're only shown once, so the user should write them down somewhere safe.
+	// example: ["0a1b2-c3d4e","f5a6b-7c8d9"]
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorEnrollRequest models the parameters for starting to enroll in two-factor authentication.
+//
+// swagger:parameters userTwoFactorEnroll
+type TwoFactorEnrollRequest struct {
+	// The user's current password.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
+
+// TwoFactorConfirmRequest models the parameters for finishing enrolling in two-factor authentication.
+//
+// swagger:parameters userTwoFactorConfirm
+type TwoFactorConfirmRequest struct {
+	// A code from the authenticator app, to show that it was set up correctly.
+	//
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" validation:"required"`
+}
+
+// TwoFactorDisableRequest models the parameters for turning off two-factor authentication.
+//
+// swagger:parameters userTwoFactorDisable
+type TwoFactorDisableRequest struct {
+	// The user's current password.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+	// A code from the authenticator app, or one of the recovery codes.
+	//
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" validation:"required"`
+}
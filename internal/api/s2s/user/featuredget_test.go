@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FeaturedGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *FeaturedGetTestSuite) TestGetFeatured() {
+	// the dereference we're gonna use
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests["foss_satan_dereference_zork_featured"]
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	// pin a public status and a followers-only status: only the public one should be featured
+	for _, k := range []string{"local_account_1_status_1", "local_account_1_status_5"} {
+		status, err := suite.db.GetStatusByID(context.Background(), suite.testStatuses[k].ID)
+		suite.NoError(err)
+		status.Pinned = true
+		suite.NoError(suite.db.UpdateStatus(context.Background(), status, nil))
+	}
+
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db)
+	federator := testrig.NewTestFederator(suite.db, tc, suite.storage, suite.mediaManager)
+	emailSender := testrig.NewEmailSender("../../../../web/template/", nil)
+	processor := testrig.NewTestProcessor(suite.db, suite.storage, federator, emailSender, suite.mediaManager)
+	userModule := user.New(processor).(*user.Module)
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetAccount.FeaturedCollectionURI, nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/activity+json")
+	ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+	ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.securityModule.SignatureCheck(ctx)
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	// trigger the function being tested
+	userModule.FeaturedGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	m := make(map[string]interface{})
+	err = json.Unmarshal(b, &m)
+	suite.NoError(err)
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	collection, ok := t.(vocab.ActivityStreamsOrderedCollection)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+	suite.Equal(targetAccount.FeaturedCollectionURI, collection.GetJSONLDId().GetIRI().String())
+	suite.Equal(1, collection.GetActivityStreamsTotalItems().Get())
+
+	items := collection.GetActivityStreamsOrderedItems()
+	suite.Equal(1, items.Len())
+	note := items.At(0).GetActivityStreamsNote()
+	if note == nil {
+		suite.FailNow("featured item was not a note")
+	}
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].URI, note.GetJSONLDId().GetIRI().String())
+}
+
+func TestFeaturedGetTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedGetTestSuite))
+}
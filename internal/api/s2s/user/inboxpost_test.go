@@ -328,7 +328,7 @@ func (suite *InboxPostTestSuite) TestPostUpdate() {
 	suite.EqualValues(updatedAccount.HeaderRemoteURL, dbUpdatedAccount.HeaderRemoteURL)
 	suite.EqualValues(updatedAccount.Note, dbUpdatedAccount.Note)
 	suite.EqualValues(updatedAccount.Memorial, dbUpdatedAccount.Memorial)
-	suite.EqualValues(updatedAccount.AlsoKnownAs, dbUpdatedAccount.AlsoKnownAs)
+	suite.EqualValues(updatedAccount.AlsoKnownAsURIs, dbUpdatedAccount.AlsoKnownAsURIs)
 	suite.EqualValues(updatedAccount.MovedToAccountID, dbUpdatedAccount.MovedToAccountID)
 	suite.EqualValues(updatedAccount.Bot, dbUpdatedAccount.Bot)
 	suite.EqualValues(updatedAccount.Reason, dbUpdatedAccount.Reason)
@@ -57,6 +57,8 @@ const (
 	UsersFollowersPath = UsersBasePathWithUsername + "/" + uris.FollowersPath
 	// UsersFollowingPath is for serving GET request's to a user's following list, with the given username key.
 	UsersFollowingPath = UsersBasePathWithUsername + "/" + uris.FollowingPath
+	// UsersFeaturedPath is for serving GET requests to a user's featured collection of pinned statuses, with the given username key.
+	UsersFeaturedPath = UsersBasePathWithUsername + "/" + uris.CollectionsPath + "/" + uris.FeaturedPath
 	// UsersStatusPath is for serving GET requests to a particular status by a user, with the given username key and status ID
 	UsersStatusPath = UsersBasePathWithUsername + "/" + uris.StatusesPath + "/:" + StatusIDKey
 	// UsersStatusRepliesPath is for serving the replies collection of a status.
@@ -85,5 +87,6 @@ func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, UsersPublicKeyPath, m.PublicKeyGETHandler)
 	s.AttachHandler(http.MethodGet, UsersStatusRepliesPath, m.StatusRepliesGETHandler)
 	s.AttachHandler(http.MethodGet, UsersOutboxPath, m.OutboxGETHandler)
+	s.AttachHandler(http.MethodGet, UsersFeaturedPath, m.FeaturedGETHandler)
 	return nil
 }
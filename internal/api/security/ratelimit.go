@@ -0,0 +1,201 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-fed/httpsig"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// rateLimitResetFormat is the format of the X-RateLimit-Reset header, which is the same as Mastodon's.
+const rateLimitResetFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// rateLimiters holds the buckets for each kind of rate limit.
+type rateLimiters struct {
+	// general limits all requests, per IP address or authenticated account
+	general *rateLimiter
+	// domain limits signed requests, per remote domain
+	domain *rateLimiter
+	// signIn limits attempts to sign in, per IP address
+	signIn *rateLimiter
+	// signUp limits attempts to sign up, per IP address
+	signUp *rateLimiter
+	// media limits media uploads, per authenticated account
+	media *rateLimiter
+}
+
+// newRateLimiters returns rate limiters set up according to the config,
+// or nil if rate limiting is disabled.
+func newRateLimiters() *rateLimiters {
+	if !viper.GetBool(config.Keys.RateLimitEnabled) {
+		return nil
+	}
+
+	return &rateLimiters{
+		general: newRateLimiter(viper.GetInt(config.Keys.RateLimitRequests), rateLimitPeriod),
+		domain:  newRateLimiter(viper.GetInt(config.Keys.RateLimitDomainRequests), rateLimitPeriod),
+		signIn:  newRateLimiter(viper.GetInt(config.Keys.RateLimitSignInRequests), rateLimitPeriod),
+		signUp:  newRateLimiter(viper.GetInt(config.Keys.RateLimitSignUpRequests), rateLimitPeriod),
+		media:   newRateLimiter(viper.GetInt(config.Keys.RateLimitMediaRequests), mediaRateLimitPeriod),
+	}
+}
+
+// RateLimit limits how many requests can be made in a period of time by the same IP address,
+// authenticated account, or remote domain signing the requests. Stricter limits apply to signing in,
+// signing up, and uploading media.
+//
+// Requests over the limit are aborted with a 429, and every limited response carries X-RateLimit headers
+// describing the tightest limit that applied to it, in the same way as Mastodon.
+//
+// Client IP addresses are taken from c.ClientIP(), so X-Forwarded-For is only trusted from trusted proxies.
+func (m *Module) RateLimit(c *gin.Context) {
+	if m.rateLimiters == nil {
+		return
+	}
+
+	// static assets and media files are requested a lot when
+	// rendering pages and timelines, so don't count them
+	path := c.Request.URL.Path
+	if strings.HasPrefix(path, "/assets/") || strings.HasPrefix(path, fileserver.FileServeBasePath+"/") {
+		return
+	}
+
+	now := time.Now()
+	ipKey := "ip:" + c.ClientIP()
+	limits := []rateLimit{}
+
+	var accountKey string
+	if i, ok := c.Get(oauth.SessionAuthorizedAccount); ok {
+		if acct, ok := i.(*gtsmodel.Account); ok {
+			accountKey = "account:" + acct.ID
+		}
+	}
+	domainKey := signingDomainKey(c)
+
+	switch {
+	case accountKey != "":
+		// requests made with a token count against the account, so
+		// that people sharing an IP address don't get in each other's way
+		limits = append(limits, m.rateLimiters.general.take(accountKey, now))
+	case domainKey != "":
+		// the signature hasn't been checked yet at this point, so we only peek at the buckets here,
+		// and take a token once we know whether the request was really made by the remote domain
+		limits = append(limits, m.rateLimiters.domain.peek(domainKey, now), m.rateLimiters.general.peek(ipKey, now))
+	default:
+		limits = append(limits, m.rateLimiters.general.take(ipKey, now))
+	}
+
+	if allowed(limits) && c.Request.Method == http.MethodPost {
+		switch c.FullPath() {
+		case auth.AuthSignInPath, auth.AuthTwoFactorPath, auth.OauthTokenPath:
+			limits = append(limits, m.rateLimiters.signIn.take(ipKey, now))
+		case account.BasePath:
+			limits = append(limits, m.rateLimiters.signUp.take(ipKey, now))
+		case media.BasePath:
+			mediaKey := accountKey
+			if mediaKey == "" {
+				mediaKey = ipKey
+			}
+			limits = append(limits, m.rateLimiters.media.take(mediaKey, now))
+		}
+	}
+
+	limit := tightest(limits)
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit.limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(limit.remaining))
+	c.Header("X-RateLimit-Reset", limit.reset.UTC().Format(rateLimitResetFormat))
+
+	if !limit.allowed {
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+		return
+	}
+
+	if accountKey == "" && domainKey != "" {
+		c.Next()
+
+		// a request that failed authentication might have been made by anyone claiming
+		// to be the remote domain, so count it against the IP address instead
+		if status := c.Writer.Status(); status == http.StatusUnauthorized || status == http.StatusForbidden {
+			m.rateLimiters.general.take(ipKey, now)
+		} else {
+			m.rateLimiters.domain.take(domainKey, now)
+		}
+	}
+}
+
+// signingDomainKey returns the bucket key for the domain that claims
+// to have signed the request, or an empty string if it isn't signed.
+func signingDomainKey(c *gin.Context) string {
+	i, ok := c.Get(string(ap.ContextRequestingPublicKeyVerifier))
+	if !ok {
+		return ""
+	}
+
+	verifier, ok := i.(httpsig.Verifier)
+	if !ok {
+		return ""
+	}
+
+	keyID, err := url.Parse(verifier.KeyId())
+	if err != nil || keyID.Host == "" {
+		return ""
+	}
+
+	return "domain:" + strings.ToLower(keyID.Host)
+}
+
+// allowed returns true if none of the given limits were hit.
+func allowed(limits []rateLimit) bool {
+	for _, l := range limits {
+		if !l.allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// tightest returns the first limit that was hit, or if none
+// were hit, the one with the fewest requests remaining.
+func tightest(limits []rateLimit) rateLimit {
+	t := limits[0]
+	for _, l := range limits[1:] {
+		if !t.allowed {
+			break
+		}
+		if !l.allowed || l.remaining < t.remaining {
+			t = l
+		}
+	}
+	return t
+}
@@ -0,0 +1,200 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security
+
+import (
+	"crypto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-fed/httpsig"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+// fakeVerifier claims a request was signed with the given key, without checking anything.
+type fakeVerifier string
+
+func (f fakeVerifier) KeyId() string { return string(f) }
+
+func (f fakeVerifier) Verify(crypto.PublicKey, httpsig.Algorithm) error { return nil }
+
+func (suite *RateLimitTestSuite) engine(m *Module, status int, before gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	suite.NoError(engine.SetTrustedProxies([]string{"127.0.0.1/32"}))
+	if before != nil {
+		engine.Use(before)
+	}
+	engine.Use(m.RateLimit)
+
+	handler := func(c *gin.Context) { c.Status(status) }
+	engine.GET("/api/v1/timelines/home", handler)
+	engine.POST(auth.AuthSignInPath, handler)
+	engine.POST("/users/:username/inbox", handler)
+	return engine
+}
+
+func (suite *RateLimitTestSuite) do(engine *gin.Engine, method string, path string, forwardedFor string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	r.RemoteAddr = "127.0.0.1:54321"
+	if forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	return w
+}
+
+func (suite *RateLimitTestSuite) TestBucket() {
+	l := newRateLimiter(3, time.Minute)
+	now := time.Now()
+
+	for i := 2; i >= 0; i-- {
+		r := l.take("key", now)
+		suite.True(r.allowed)
+		suite.Equal(i, r.remaining)
+	}
+
+	r := l.take("key", now)
+	suite.False(r.allowed)
+	suite.Equal(0, r.remaining)
+	suite.WithinDuration(now.Add(time.Minute), r.reset, time.Millisecond)
+
+	// other keys have their own bucket
+	suite.True(l.take("other", now).allowed)
+
+	// a third of the period later, one token has come back
+	now = now.Add(20 * time.Second)
+	suite.True(l.peek("key", now).allowed)
+	suite.True(l.take("key", now).allowed)
+	suite.False(l.take("key", now).allowed)
+
+	// after a whole period of no use the buckets are dropped
+	now = now.Add(2 * time.Minute)
+	l.take("key", now)
+	suite.Len(l.buckets, 1)
+}
+
+func (suite *RateLimitTestSuite) TestLimitPerIP() {
+	m := &Module{rateLimiters: &rateLimiters{
+		general: newRateLimiter(2, rateLimitPeriod),
+		signIn:  newRateLimiter(1, rateLimitPeriod),
+	}}
+	engine := suite.engine(m, http.StatusOK, nil)
+
+	w := suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "192.0.2.1")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("2", w.Header().Get("X-RateLimit-Limit"))
+	suite.Equal("1", w.Header().Get("X-RateLimit-Remaining"))
+	_, err := time.Parse(rateLimitResetFormat, w.Header().Get("X-RateLimit-Reset"))
+	suite.NoError(err)
+
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "192.0.2.1").Code)
+
+	w = suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "192.0.2.1")
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+	suite.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+	suite.Equal(`{"error":"Too many requests"}`, w.Body.String())
+
+	// a different client behind the same trusted proxy has its own limit
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "192.0.2.2").Code)
+
+	// the stricter sign in limit applies as well as the general one
+	w = suite.do(engine, http.MethodPost, auth.AuthSignInPath, "192.0.2.3")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("1", w.Header().Get("X-RateLimit-Limit"))
+	suite.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+	suite.Equal(http.StatusTooManyRequests, suite.do(engine, http.MethodPost, auth.AuthSignInPath, "192.0.2.3").Code)
+}
+
+func (suite *RateLimitTestSuite) TestUntrustedForwardedFor() {
+	m := &Module{rateLimiters: &rateLimiters{general: newRateLimiter(1, rateLimitPeriod)}}
+	engine := suite.engine(m, http.StatusOK, nil)
+	suite.NoError(engine.SetTrustedProxies(nil))
+
+	// X-Forwarded-For from an untrusted address is ignored, so it can't be used to get a fresh bucket
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "192.0.2.1").Code)
+	suite.Equal(http.StatusTooManyRequests, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "192.0.2.2").Code)
+}
+
+func (suite *RateLimitTestSuite) TestLimitPerAccount() {
+	m := &Module{rateLimiters: &rateLimiters{general: newRateLimiter(1, rateLimitPeriod)}}
+	engine := suite.engine(m, http.StatusOK, func(c *gin.Context) {
+		c.Set(oauth.SessionAuthorizedAccount, &gtsmodel.Account{ID: c.GetHeader("X-Test-Account")})
+	})
+
+	do := func(accountID string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/timelines/home", nil)
+		r.Header.Set("X-Test-Account", accountID)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	suite.Equal(http.StatusOK, do("01F8MH1H7YV1Z7D2C8K2730QBF"))
+	suite.Equal(http.StatusTooManyRequests, do("01F8MH1H7YV1Z7D2C8K2730QBF"))
+	// same IP address, different account
+	suite.Equal(http.StatusOK, do("01F8MH17FWEB39HZJ76B6VXSKF"))
+}
+
+func (suite *RateLimitTestSuite) TestLimitPerDomain() {
+	signed := func(c *gin.Context) {
+		c.Set(string(ap.ContextRequestingPublicKeyVerifier), fakeVerifier("https://fossbros-anonymous.io/users/foss_satan/main-key"))
+	}
+
+	m := &Module{rateLimiters: &rateLimiters{
+		general: newRateLimiter(1, rateLimitPeriod),
+		domain:  newRateLimiter(2, rateLimitPeriod),
+	}}
+	engine := suite.engine(m, http.StatusAccepted, signed)
+
+	// requests from the domain count against the domain rather than the IP address
+	suite.Equal(http.StatusAccepted, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "").Code)
+	suite.Equal(http.StatusAccepted, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "").Code)
+	w := suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "")
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+	suite.Equal("2", w.Header().Get("X-RateLimit-Limit"))
+
+	// requests that fail authentication count against the IP address instead,
+	// so they can't be used to use up the domain's limit
+	m = &Module{rateLimiters: &rateLimiters{
+		general: newRateLimiter(1, rateLimitPeriod),
+		domain:  newRateLimiter(2, rateLimitPeriod),
+	}}
+	engine = suite.engine(m, http.StatusUnauthorized, signed)
+	suite.Equal(http.StatusUnauthorized, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "").Code)
+	suite.Equal(http.StatusTooManyRequests, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "").Code)
+	// the domain's bucket is still full, so taking one token would leave one
+	suite.Equal(1, m.rateLimiters.domain.peek("domain:fossbros-anonymous.io", time.Now()).remaining)
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitPeriod is the period over which the general, domain, sign in and sign up limits apply.
+	rateLimitPeriod = 5 * time.Minute
+	// mediaRateLimitPeriod is the period over which the media upload limit applies.
+	mediaRateLimitPeriod = 30 * time.Minute
+)
+
+// rateLimiter keeps a token bucket for each key it's asked about. Each bucket holds up to limit tokens,
+// and refills at a steady rate so that an empty bucket is full again after one period.
+type rateLimiter struct {
+	limit  int
+	period time.Duration
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimit describes the state of a bucket after a request, as reported in the X-RateLimit headers.
+type rateLimit struct {
+	limit     int
+	remaining int
+	reset     time.Time
+	allowed   bool
+}
+
+func newRateLimiter(limit int, period time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		period:  period,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// take spends a token from the bucket for the given key, if there's one left.
+func (l *rateLimiter) take(key string, now time.Time) rateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(key, now)
+	if b.tokens < 1 {
+		return l.state(b, now, false)
+	}
+	b.tokens--
+	return l.state(b, now, true)
+}
+
+// peek reports what would happen if a token was taken from the bucket for the given key, without taking it.
+func (l *rateLimiter) peek(key string, now time.Time) rateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(key, now)
+	if b.tokens < 1 {
+		return l.state(b, now, false)
+	}
+	return l.state(&bucket{tokens: b.tokens - 1}, now, true)
+}
+
+// refill brings the bucket for the given key up to date, creating a full one if there isn't one yet.
+// It must be called with the lock held.
+func (l *rateLimiter) refill(key string, now time.Time) *bucket {
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit), updated: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(l.limit), b.tokens+float64(l.limit)*elapsed.Seconds()/l.period.Seconds())
+		b.updated = now
+	}
+	return b
+}
+
+// sweep drops buckets which haven't been touched for a whole period, since they'd be full again anyway.
+// It only does any work once per period, and must be called with the lock held.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.period {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.period {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// state reports the given bucket as a rateLimit, with reset set to when the bucket will be full again.
+func (l *rateLimiter) state(b *bucket, now time.Time, allowed bool) rateLimit {
+	missing := float64(l.limit) - b.tokens
+	return rateLimit{
+		limit:     l.limit,
+		remaining: int(math.Floor(b.tokens)),
+		reset:     now.Add(time.Duration(missing / float64(l.limit) * float64(l.period))),
+		allowed:   allowed,
+	}
+}
@@ -31,15 +31,17 @@ const robotsPath = "/robots.txt"
 
 // Module implements the ClientAPIModule interface for security middleware
 type Module struct {
-	db     db.DB
-	server oauth.Server
+	db           db.DB
+	server       oauth.Server
+	rateLimiters *rateLimiters
 }
 
 // New returns a new security module
 func New(db db.DB, server oauth.Server) api.ClientModule {
 	return &Module{
-		db:     db,
-		server: server,
+		db:           db,
+		server:       server,
+		rateLimiters: newRateLimiters(),
 	}
 }
 
@@ -50,6 +52,7 @@ func (m *Module) Route(s router.Router) error {
 	s.AttachMiddleware(m.ExtraHeaders)
 	s.AttachMiddleware(m.UserAgentBlock)
 	s.AttachMiddleware(m.TokenCheck)
+	s.AttachMiddleware(m.RateLimit)
 	s.AttachHandler(http.MethodGet, robotsPath, m.RobotsGETHandler)
 	return nil
 }
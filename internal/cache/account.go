@@ -117,6 +117,19 @@ func (c *AccountCache) Put(account *gtsmodel.Account) {
 	c.mutex.Unlock()
 }
 
+// Invalidate removes the account with the given ID from the cache, if it's there
+func (c *AccountCache) Invalidate(id string) {
+	c.mutex.Lock()
+	if v, ok := c.cache.Get(id); ok {
+		if account, ok := v.(*gtsmodel.Account); ok {
+			delete(c.urls, account.URL)
+			delete(c.uris, account.URI)
+		}
+		c.cache.Remove(id)
+	}
+	c.mutex.Unlock()
+}
+
 // copyAccount performs a surface-level copy of account, only keeping attached IDs intact, not the objects.
 // due to all the data being copied being 99% primitive types or strings (which are immutable and passed by ptr)
 // this should be a relatively cheap process
@@ -135,6 +148,7 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		Fields:                  account.Fields,
 		Note:                    account.Note,
 		Memorial:                account.Memorial,
+		MovedToURI:              account.MovedToURI,
 		MovedToAccountID:        account.MovedToAccountID,
 		CreatedAt:               account.CreatedAt,
 		UpdatedAt:               account.UpdatedAt,
@@ -145,6 +159,7 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		Privacy:                 account.Privacy,
 		Sensitive:               account.Sensitive,
 		Language:                account.Language,
+		ExcludedNotifications:   account.ExcludedNotifications,
 		URI:                     account.URI,
 		URL:                     account.URL,
 		LastWebfingeredAt:       account.LastWebfingeredAt,
@@ -154,7 +169,7 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		FollowersURI:            account.FollowersURI,
 		FeaturedCollectionURI:   account.FeaturedCollectionURI,
 		ActorType:               account.ActorType,
-		AlsoKnownAs:             account.AlsoKnownAs,
+		AlsoKnownAsURIs:         account.AlsoKnownAsURIs,
 		PrivateKey:              account.PrivateKey,
 		PublicKey:               account.PublicKey,
 		PublicKeyURI:            account.PublicKeyURI,
@@ -54,6 +54,20 @@ func (suite *AccountCacheTestSuite) TestAccountCache() {
 	}
 }
 
+func (suite *AccountCacheTestSuite) TestAccountCacheInvalidate() {
+	account := testrig.NewTestAccounts()["local_account_1"]
+	suite.cache.Put(account)
+
+	suite.cache.Invalidate(account.ID)
+
+	_, ok := suite.cache.GetByID(account.ID)
+	suite.False(ok)
+	_, ok = suite.cache.GetByURI(account.URI)
+	suite.False(ok)
+	_, ok = suite.cache.GetByURL(account.URL)
+	suite.False(ok)
+}
+
 func TestAccountCache(t *testing.T) {
 	suite.Run(t, &AccountCacheTestSuite{})
 }
@@ -134,6 +134,8 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		Mentions:                 nil,
 		EmojiIDs:                 status.EmojiIDs,
 		Emojis:                   nil,
+		PollID:                   status.PollID,
+		Poll:                     nil,
 		CreatedAt:                status.CreatedAt,
 		UpdatedAt:                status.UpdatedAt,
 		Local:                    status.Local,
@@ -161,5 +163,6 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		ActivityStreamsType:      status.ActivityStreamsType,
 		Text:                     status.Text,
 		Pinned:                   status.Pinned,
+		EditedAt:                 status.EditedAt,
 	}
 }
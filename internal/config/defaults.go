@@ -58,12 +58,20 @@ var Defaults = Values{
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
+	StorageS3Endpoint:    "",
+	StorageS3Region:      "us-east-1",
+	StorageS3Bucket:      "",
+	StorageS3AccessKey:   "",
+	StorageS3SecretKey:   "",
+	StorageS3UseSSL:      true,
+	StorageS3ServeMode:   "proxy",
 
 	StatusesMaxChars:           5000,
 	StatusesCWMaxChars:         100,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesMaxPinned:          5,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -87,4 +95,13 @@ var Defaults = Values{
 	SyslogEnabled:  false,
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
+
+	RateLimitEnabled:        true,
+	RateLimitRequests:       300,
+	RateLimitDomainRequests: 1500,
+	RateLimitSignInRequests: 25,
+	RateLimitSignUpRequests: 25,
+	RateLimitMediaRequests:  30,
+
+	FederationMode: FederationModeBlocklist,
 }
@@ -0,0 +1,29 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// Federation modes, which decide which other domains this instance federates with.
+const (
+	// FederationModeBlocklist federates with every domain that hasn't been blocked.
+	FederationModeBlocklist = "blocklist"
+	// FederationModeAllowlist only federates with domains that have been allowed, and haven't been blocked.
+	FederationModeAllowlist = "allowlist"
+	// FederationModeNone doesn't federate with any other domains, insulating this instance from the Fediverse.
+	FederationModeNone = "none"
+)
@@ -64,6 +64,13 @@ type KeyNames struct {
 	// storage
 	StorageBackend       string
 	StorageLocalBasePath string
+	StorageS3Endpoint    string
+	StorageS3Region      string
+	StorageS3Bucket      string
+	StorageS3AccessKey   string
+	StorageS3SecretKey   string
+	StorageS3UseSSL      string
+	StorageS3ServeMode   string
 
 	// statuses
 	StatusesMaxChars           string
@@ -71,6 +78,7 @@ type KeyNames struct {
 	StatusesPollMaxOptions     string
 	StatusesPollOptionMaxChars string
 	StatusesMediaMaxFiles      string
+	StatusesMaxPinned          string
 
 	// letsencrypt
 	LetsEncryptEnabled      string
@@ -99,6 +107,17 @@ type KeyNames struct {
 	SyslogProtocol string
 	SyslogAddress  string
 
+	// rate limiting
+	RateLimitEnabled        string
+	RateLimitRequests       string
+	RateLimitDomainRequests string
+	RateLimitSignInRequests string
+	RateLimitSignUpRequests string
+	RateLimitMediaRequests  string
+
+	// federation
+	FederationMode string
+
 	// admin
 	AdminAccountUsername string
 	AdminAccountEmail    string
@@ -145,12 +164,20 @@ var Keys = KeyNames{
 
 	StorageBackend:       "storage-backend",
 	StorageLocalBasePath: "storage-local-base-path",
+	StorageS3Endpoint:    "storage-s3-endpoint",
+	StorageS3Region:      "storage-s3-region",
+	StorageS3Bucket:      "storage-s3-bucket",
+	StorageS3AccessKey:   "storage-s3-access-key",
+	StorageS3SecretKey:   "storage-s3-secret-key",
+	StorageS3UseSSL:      "storage-s3-use-ssl",
+	StorageS3ServeMode:   "storage-s3-serve-mode",
 
 	StatusesMaxChars:           "statuses-max-chars",
 	StatusesCWMaxChars:         "statuses-cw-max-chars",
 	StatusesPollMaxOptions:     "statuses-poll-max-options",
 	StatusesPollOptionMaxChars: "statuses-poll-option-max-chars",
 	StatusesMediaMaxFiles:      "statuses-media-max-files",
+	StatusesMaxPinned:          "statuses-max-pinned",
 
 	LetsEncryptEnabled:      "letsencrypt-enabled",
 	LetsEncryptPort:         "letsencrypt-port",
@@ -175,6 +202,15 @@ var Keys = KeyNames{
 	SyslogProtocol: "syslog-protocol",
 	SyslogAddress:  "syslog-address",
 
+	RateLimitEnabled:        "rate-limit-enabled",
+	RateLimitRequests:       "rate-limit-requests",
+	RateLimitDomainRequests: "rate-limit-domain-requests",
+	RateLimitSignInRequests: "rate-limit-sign-in-requests",
+	RateLimitSignUpRequests: "rate-limit-sign-up-requests",
+	RateLimitMediaRequests:  "rate-limit-media-requests",
+
+	FederationMode: "federation-mode",
+
 	AdminAccountUsername: "username",
 	AdminAccountEmail:    "email",
 	AdminAccountPassword: "password",
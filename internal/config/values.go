@@ -56,12 +56,20 @@ type Values struct {
 
 	StorageBackend       string
 	StorageLocalBasePath string
+	StorageS3Endpoint    string
+	StorageS3Region      string
+	StorageS3Bucket      string
+	StorageS3AccessKey   string
+	StorageS3SecretKey   string
+	StorageS3UseSSL      bool
+	StorageS3ServeMode   string
 
 	StatusesMaxChars           int
 	StatusesCWMaxChars         int
 	StatusesPollMaxOptions     int
 	StatusesPollOptionMaxChars int
 	StatusesMediaMaxFiles      int
+	StatusesMaxPinned          int
 
 	LetsEncryptEnabled      bool
 	LetsEncryptCertDir      string
@@ -86,6 +94,15 @@ type Values struct {
 	SyslogProtocol string
 	SyslogAddress  string
 
+	RateLimitEnabled        bool
+	RateLimitRequests       int
+	RateLimitDomainRequests int
+	RateLimitSignInRequests int
+	RateLimitSignUpRequests int
+	RateLimitMediaRequests  int
+
+	FederationMode string
+
 	AdminAccountUsername string
 	AdminAccountEmail    string
 	AdminAccountPassword string
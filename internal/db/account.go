@@ -39,6 +39,13 @@ type Account interface {
 	// UpdateAccount updates one account by ID.
 	UpdateAccount(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.Account, Error)
 
+	// DeleteAccount removes the account with the given id from the database entirely.
+	//
+	// This should only be used for accounts that never did anything, such as rejected sign-ups.
+	// Other accounts should be deleted through the account processor, which leaves a stub behind
+	// so that the account can't be created again.
+	DeleteAccount(ctx context.Context, id string) Error
+
 	// GetLocalAccountByUsername returns an account on this instance by its username.
 	GetLocalAccountByUsername(ctx context.Context, username string) (*gtsmodel.Account, Error)
 
@@ -48,6 +55,9 @@ type Account interface {
 	// GetAccountStatusesCount is a shortcut for the common action of counting statuses produced by accountID.
 	CountAccountStatuses(ctx context.Context, accountID string) (int, Error)
 
+	// CountAccountPinned counts the statuses that accountID currently has pinned to its profile.
+	CountAccountPinned(ctx context.Context, accountID string) (int, Error)
+
 	// GetAccountStatuses is a shortcut for getting the most recent statuses. accountID is optional, if not provided
 	// then all statuses will be returned. If limit is set to 0, the size of the returned slice will not be limited. This can
 	// be very memory intensive so you probably shouldn't do this!
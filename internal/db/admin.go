@@ -42,6 +42,17 @@ type Admin interface {
 	// By the time this function is called, it should be assumed that all the parameters have passed validation!
 	NewSignup(ctx context.Context, username string, reason string, requireApproval bool, email string, password string, signUpIP net.IP, locale string, appID string, emailVerified bool, admin bool) (*gtsmodel.User, Error)
 
+	// GetAdminAccounts gets accounts newest first, for viewing by instance admins.
+	// The instance account is left out, since it can't be moderated.
+	//
+	// If origin is set, it should be local or remote, to only return local or remote accounts.
+	// If status is set, it should be one of active, pending, disabled, silenced or suspended.
+	// Pending and disabled only ever match local accounts, since they depend on the account's user.
+	// If domain is set, only accounts from that domain will be returned.
+	// If username or email are set, only accounts with a username or user email containing them will be returned.
+	// If ip is set, only accounts with a user that signed up or signed in from that ip will be returned.
+	GetAdminAccounts(ctx context.Context, origin string, status string, domain string, username string, email string, ip string, maxID string, limit int) ([]*gtsmodel.Account, Error)
+
 	// CreateInstanceAccount creates an account in the database with the same username as the instance host value.
 	// Ie., if the instance is hosted at 'example.org' the instance user will have a username of 'example.org'.
 	// This is needed for things like serving files that belong to the instance and not an individual user/account.
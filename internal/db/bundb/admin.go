@@ -38,6 +38,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/uptrace/bun"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -177,6 +178,91 @@ func (a *adminDB) NewSignup(ctx context.Context, username string, reason string,
 	return u, nil
 }
 
+func (a *adminDB) GetAdminAccounts(ctx context.Context, origin string, status string, domain string, username string, email string, ip string, maxID string, limit int) ([]*gtsmodel.Account, db.Error) {
+	accounts := []*gtsmodel.Account{}
+
+	// remote accounts don't have a user, so join users loosely
+	q := a.conn.
+		NewSelect().
+		Model(&accounts).
+		Join("LEFT JOIN users AS u ON u.account_id = account.id").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// leave out the instance account
+			return q.
+				Where("account.domain IS NOT NULL").
+				WhereOr("account.username != ?", viper.GetString(config.Keys.Host))
+		}).
+		Order("account.id DESC")
+
+	switch origin {
+	case "local":
+		q = q.Where("account.domain IS NULL")
+	case "remote":
+		q = q.Where("account.domain IS NOT NULL")
+	}
+
+	switch status {
+	case "active":
+		q = q.
+			Where("account.suspended_at IS NULL").
+			Where("account.silenced_at IS NULL").
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				// remote accounts are active if they're not suspended or silenced
+				return q.
+					Where("u.id IS NULL").
+					WhereOr("u.approved = ? AND u.disabled = ?", true, false)
+			})
+	case "pending":
+		q = q.Where("u.approved = ?", false)
+	case "disabled":
+		q = q.Where("u.disabled = ?", true)
+	case "silenced":
+		q = q.Where("account.silenced_at IS NOT NULL")
+	case "suspended":
+		q = q.Where("account.suspended_at IS NOT NULL")
+	}
+
+	if domain != "" {
+		q = q.Where("account.domain = ?", domain)
+	}
+
+	if username != "" {
+		q = q.Where("LOWER(account.username) LIKE ?", "%"+strings.ToLower(username)+"%")
+	}
+
+	if email != "" {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// users that haven't confirmed their email yet only have an unconfirmed one
+			return q.
+				Where("LOWER(u.email) LIKE ?", "%"+strings.ToLower(email)+"%").
+				WhereOr("LOWER(u.unconfirmed_email) LIKE ?", "%"+strings.ToLower(email)+"%")
+		})
+	}
+
+	if ip != "" {
+		parsedIP := net.ParseIP(ip)
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("u.sign_up_ip = ?", parsedIP).
+				WhereOr("u.current_sign_in_ip = ?", parsedIP).
+				WhereOr("u.last_sign_in_ip = ?", parsedIP)
+		})
+	}
+
+	if maxID != "" {
+		q = q.Where("account.id < ?", maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return accounts, nil
+}
+
 func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 	username := viper.GetString(config.Keys.Host)
 
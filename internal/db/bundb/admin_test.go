@@ -21,6 +21,7 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -51,6 +52,82 @@ func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	suite.NotNil(acct)
 }
 
+func (suite *AdminTestSuite) TestGetAdminAccounts() {
+	ctx := context.Background()
+
+	accounts, err := suite.db.GetAdminAccounts(ctx, "", "", "", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, len(suite.testAccounts))
+	for i := 1; i < len(accounts); i++ {
+		suite.Greater(accounts[i-1].ID, accounts[i].ID)
+	}
+
+	accounts, err = suite.db.GetAdminAccounts(ctx, "local", "", "", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 4)
+	for _, a := range accounts {
+		suite.Empty(a.Domain)
+	}
+
+	accounts, err = suite.db.GetAdminAccounts(ctx, "remote", "", "fossbros-anonymous.io", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["remote_account_1"].ID, accounts[0].ID)
+}
+
+func (suite *AdminTestSuite) TestGetAdminAccountsByStatus() {
+	ctx := context.Background()
+
+	accounts, err := suite.db.GetAdminAccounts(ctx, "", "pending", "", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["unconfirmed_account"].ID, accounts[0].ID)
+
+	silenced := suite.testAccounts["remote_account_2"]
+	silenced.SilencedAt = time.Now()
+	_, err = suite.db.UpdateAccount(ctx, silenced)
+	suite.NoError(err)
+
+	accounts, err = suite.db.GetAdminAccounts(ctx, "", "silenced", "", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(silenced.ID, accounts[0].ID)
+
+	// everyone except the pending and silenced accounts is active
+	accounts, err = suite.db.GetAdminAccounts(ctx, "", "active", "", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, len(suite.testAccounts)-2)
+
+	accounts, err = suite.db.GetAdminAccounts(ctx, "", "suspended", "", "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
+func (suite *AdminTestSuite) TestGetAdminAccountsByUserDetails() {
+	ctx := context.Background()
+
+	accounts, err := suite.db.GetAdminAccounts(ctx, "", "", "", "ZORK", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, accounts[0].ID)
+
+	// unconfirmed emails are searched too
+	accounts, err = suite.db.GetAdminAccounts(ctx, "", "", "", "", "weed_lord", "", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["unconfirmed_account"].ID, accounts[0].ID)
+
+	// local_account_1 and local_account_2 signed up from the same ip
+	accounts, err = suite.db.GetAdminAccounts(ctx, "", "", "", "", "", "59.99.19.172", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 2)
+
+	accounts, err = suite.db.GetAdminAccounts(ctx, "", "", "", "", "", "88.234.118.16", "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, accounts[0].ID)
+}
+
 func TestAdminTestSuite(t *testing.T) {
 	suite.Run(t, new(AdminTestSuite))
 }
@@ -138,11 +138,28 @@ func (b *basicDB) CreateAllTables(ctx context.Context) db.Error {
 		&gtsmodel.StatusFave{},
 		&gtsmodel.StatusBookmark{},
 		&gtsmodel.StatusMute{},
+		&gtsmodel.Conversation{},
+		&gtsmodel.ConversationToStatus{},
+		&gtsmodel.Marker{},
+		&gtsmodel.PushSubscription{},
+		&gtsmodel.VAPIDKeyPair{},
+		&gtsmodel.UserMute{},
 		&gtsmodel.Tag{},
+		&gtsmodel.TagFollow{},
 		&gtsmodel.User{},
 		&gtsmodel.Emoji{},
 		&gtsmodel.Instance{},
 		&gtsmodel.Notification{},
+		&gtsmodel.Poll{},
+		&gtsmodel.PollVote{},
+		&gtsmodel.StatusEdit{},
+		&gtsmodel.Delivery{},
+		&gtsmodel.List{},
+		&gtsmodel.ListEntry{},
+		&gtsmodel.Filter{},
+		&gtsmodel.FilterKeyword{},
+		&gtsmodel.Report{},
+		&gtsmodel.ScheduledStatus{},
 		&gtsmodel.RouterSession{},
 		&gtsmodel.Token{},
 		&gtsmodel.Client{},
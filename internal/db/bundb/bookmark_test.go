@@ -0,0 +1,73 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type BookmarkTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *BookmarkTestSuite) TestGetBookmarkedTimeline() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	statuses, nextMaxID, prevMinID, err := suite.db.GetBookmarkedTimeline(ctx, account.ID, "", "", 20)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+	suite.Equal("01G3AQTRYT0CXT7SF9X4KBCNYQ", nextMaxID)
+	suite.Equal("01G3AQTRYT0CXT7SF9X4KBCNYQ", prevMinID)
+
+	// add a newer bookmark, which should come first
+	newBookmark := &gtsmodel.StatusBookmark{
+		ID:              "01G3AR1Q8ZGTE2YQ9NHXGB2M4K",
+		AccountID:       account.ID,
+		TargetAccountID: suite.testAccounts["local_account_2"].ID,
+		StatusID:        suite.testStatuses["local_account_2_status_1"].ID,
+	}
+	suite.NoError(suite.db.Put(ctx, newBookmark))
+
+	statuses, nextMaxID, prevMinID, err = suite.db.GetBookmarkedTimeline(ctx, account.ID, "", "", 20)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+	suite.Equal(newBookmark.StatusID, statuses[0].ID)
+	suite.Equal("01G3AQTRYT0CXT7SF9X4KBCNYQ", nextMaxID)
+	suite.Equal(newBookmark.ID, prevMinID)
+
+	// page past the newest bookmark
+	statuses, _, _, err = suite.db.GetBookmarkedTimeline(ctx, account.ID, newBookmark.ID, "", 20)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+
+	_, _, _, err = suite.db.GetBookmarkedTimeline(ctx, suite.testAccounts["local_account_2"].ID, "", "", 20)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestBookmarkTestSuite(t *testing.T) {
+	suite.Run(t, new(BookmarkTestSuite))
+}
@@ -76,15 +76,25 @@ type bunDBService struct {
 	db.Account
 	db.Admin
 	db.Basic
+	db.Conversation
+	db.Delivery
 	db.Domain
+	db.Filter
 	db.Instance
+	db.List
+	db.Marker
 	db.Media
 	db.Mention
 	db.Notification
+	db.Poll
 	db.Relationship
+	db.Report
+	db.ScheduledStatus
 	db.Session
 	db.Status
+	db.Tag
 	db.Timeline
+	db.WebPush
 	conn *DBConn
 }
 
@@ -165,12 +175,27 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 		Basic: &basicDB{
 			conn: conn,
 		},
+		Conversation: &conversationDB{
+			conn: conn,
+		},
+		Delivery: &deliveryDB{
+			conn: conn,
+		},
 		Domain: &domainDB{
 			conn: conn,
 		},
+		Filter: &filterDB{
+			conn: conn,
+		},
 		Instance: &instanceDB{
 			conn: conn,
 		},
+		List: &listDB{
+			conn: conn,
+		},
+		Marker: &markerDB{
+			conn: conn,
+		},
 		Media: &mediaDB{
 			conn: conn,
 		},
@@ -182,9 +207,18 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 			conn:  conn,
 			cache: ttlcache.NewCache(),
 		},
+		Poll: &pollDB{
+			conn: conn,
+		},
 		Relationship: &relationshipDB{
 			conn: conn,
 		},
+		Report: &reportDB{
+			conn: conn,
+		},
+		ScheduledStatus: &scheduledStatusDB{
+			conn: conn,
+		},
 		Session: &sessionDB{
 			conn: conn,
 		},
@@ -193,9 +227,15 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 			cache:    cache.NewStatusCache(),
 			accounts: accounts,
 		},
+		Tag: &tagDB{
+			conn: conn,
+		},
 		Timeline: &timelineDB{
 			conn: conn,
 		},
+		WebPush: &webPushDB{
+			conn: conn,
+		},
 		conn: conn,
 	}
 
@@ -0,0 +1,205 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type conversationDB struct {
+	conn *DBConn
+}
+
+func (c *conversationDB) GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, db.Error) {
+	conversation := &gtsmodel.Conversation{}
+
+	q := c.conn.
+		NewSelect().
+		Model(conversation).
+		Where("conversation.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversation, nil
+}
+
+func (c *conversationDB) GetConversationByThreadAndOtherAccounts(ctx context.Context, accountID string, threadID string, otherAccountsKey string) (*gtsmodel.Conversation, db.Error) {
+	conversation := &gtsmodel.Conversation{}
+
+	q := c.conn.
+		NewSelect().
+		Model(conversation).
+		Where("conversation.account_id = ?", accountID).
+		Where("conversation.thread_id = ?", threadID).
+		Where("conversation.other_accounts_key = ?", otherAccountsKey)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversation, nil
+}
+
+func (c *conversationDB) GetAccountConversations(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Conversation, db.Error) {
+	conversations := []*gtsmodel.Conversation{}
+
+	q := c.conn.
+		NewSelect().
+		Model(&conversations).
+		Where("conversation.account_id = ?", accountID).
+		Order("conversation.last_status_id DESC")
+
+	if maxID != "" {
+		q = q.Where("conversation.last_status_id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("conversation.last_status_id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("conversation.last_status_id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversations, nil
+}
+
+func (c *conversationDB) PutConversationStatus(ctx context.Context, conversation *gtsmodel.Conversation, statusID string) db.Error {
+	return c.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		conversation.UpdatedAt = time.Now()
+
+		if _, err := tx.
+			NewInsert().
+			Model(conversation).
+			On("CONFLICT (id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Set("last_status_id = EXCLUDED.last_status_id").
+			Set("read = EXCLUDED.read").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewInsert().
+			Model(&gtsmodel.ConversationToStatus{
+				ConversationID: conversation.ID,
+				StatusID:       statusID,
+			}).
+			On("CONFLICT (conversation_id, status_id) DO NOTHING").
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *conversationDB) DeleteConversationByID(ctx context.Context, id string) db.Error {
+	return c.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Model((*gtsmodel.ConversationToStatus)(nil)).
+			Where("conversation_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			Model((*gtsmodel.Conversation)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *conversationDB) DeleteStatusFromConversations(ctx context.Context, statusID string) db.Error {
+	return c.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		conversationIDs := []string{}
+		if err := tx.
+			NewSelect().
+			Model((*gtsmodel.ConversationToStatus)(nil)).
+			Column("conversation_id").
+			Where("status_id = ?", statusID).
+			Scan(ctx, &conversationIDs); err != nil {
+			return err
+		}
+
+		if len(conversationIDs) == 0 {
+			return nil
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Model((*gtsmodel.ConversationToStatus)(nil)).
+			Where("status_id = ?", statusID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, conversationID := range conversationIDs {
+			// find the newest status left in the conversation
+			var lastStatusID string
+			err := tx.
+				NewSelect().
+				Model((*gtsmodel.ConversationToStatus)(nil)).
+				Column("status_id").
+				Where("conversation_id = ?", conversationID).
+				Order("status_id DESC").
+				Limit(1).
+				Scan(ctx, &lastStatusID)
+
+			switch err {
+			case nil:
+				if _, err := tx.
+					NewUpdate().
+					Model((*gtsmodel.Conversation)(nil)).
+					Set("last_status_id = ?", lastStatusID).
+					Set("updated_at = ?", time.Now()).
+					Where("id = ?", conversationID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			case sql.ErrNoRows:
+				// nothing left in the conversation so get rid of it
+				if _, err := tx.
+					NewDelete().
+					Model((*gtsmodel.Conversation)(nil)).
+					Where("id = ?", conversationID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			default:
+				return err
+			}
+		}
+
+		return nil
+	})
+}
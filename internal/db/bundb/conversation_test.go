@@ -0,0 +1,138 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ConversationTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ConversationTestSuite) TestGetConversationByThreadAndOtherAccounts() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	dbConversation, err := suite.db.GetConversationByThreadAndOtherAccounts(context.Background(), conversation.AccountID, conversation.ThreadID, conversation.OtherAccountsKey)
+	suite.NoError(err)
+	suite.Equal(conversation.ID, dbConversation.ID)
+	suite.Equal(conversation.OtherAccountIDs, dbConversation.OtherAccountIDs)
+
+	_, err = suite.db.GetConversationByThreadAndOtherAccounts(context.Background(), conversation.AccountID, conversation.ThreadID, "")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ConversationTestSuite) TestGetAccountConversations() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	conversations, err := suite.db.GetAccountConversations(context.Background(), conversation.AccountID, "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(conversations, 1)
+	suite.Equal(conversation.ID, conversations[0].ID)
+
+	// paging past the last status leaves nothing
+	conversations, err = suite.db.GetAccountConversations(context.Background(), conversation.AccountID, conversation.LastStatusID, "", "", 20)
+	suite.NoError(err)
+	suite.Empty(conversations)
+}
+
+func (suite *ConversationTestSuite) TestPutConversationStatus() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+	status := suite.testStatuses["local_account_2_status_1"]
+
+	conversation.LastStatusID = status.ID
+	err := suite.db.PutConversationStatus(context.Background(), conversation, status.ID)
+	suite.NoError(err)
+
+	// putting the same status again should be a no-op for the links
+	err = suite.db.PutConversationStatus(context.Background(), conversation, status.ID)
+	suite.NoError(err)
+
+	dbConversation, err := suite.db.GetConversationByID(context.Background(), conversation.ID)
+	suite.NoError(err)
+	suite.Equal(status.ID, dbConversation.LastStatusID)
+
+	links := []*gtsmodel.ConversationToStatus{}
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "conversation_id", Value: conversation.ID}}, &links)
+	suite.NoError(err)
+	suite.Len(links, 2)
+}
+
+func (suite *ConversationTestSuite) TestDeleteStatusFromConversations() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	err := suite.db.DeleteStatusFromConversations(context.Background(), conversation.LastStatusID)
+	suite.NoError(err)
+
+	// the conversations only contained that status, so they should be gone now
+	_, err = suite.db.GetConversationByID(context.Background(), conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	conversations := []*gtsmodel.Conversation{}
+	err = suite.db.GetAll(context.Background(), &conversations)
+	suite.NoError(err)
+	suite.Empty(conversations)
+
+	links := []*gtsmodel.ConversationToStatus{}
+	err = suite.db.GetAll(context.Background(), &links)
+	suite.NoError(err)
+	suite.Empty(links)
+}
+
+func (suite *ConversationTestSuite) TestDeleteStatusFromConversationsKeepsOthers() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+	status := suite.testStatuses["local_account_2_status_1"]
+
+	// add a newer status to the conversation and then remove it again
+	conversation.LastStatusID = status.ID
+	err := suite.db.PutConversationStatus(context.Background(), conversation, status.ID)
+	suite.NoError(err)
+
+	err = suite.db.DeleteStatusFromConversations(context.Background(), status.ID)
+	suite.NoError(err)
+
+	dbConversation, err := suite.db.GetConversationByID(context.Background(), conversation.ID)
+	suite.NoError(err)
+	suite.Equal(testrig.NewTestConversations()["local_account_1_local_account_2_status_6"].LastStatusID, dbConversation.LastStatusID)
+}
+
+func (suite *ConversationTestSuite) TestDeleteConversationByID() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	err := suite.db.DeleteConversationByID(context.Background(), conversation.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetConversationByID(context.Background(), conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	links := []*gtsmodel.ConversationToStatus{}
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "conversation_id", Value: conversation.ID}}, &links)
+	suite.NoError(err)
+	suite.Empty(links)
+}
+
+func TestConversationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTestSuite))
+}
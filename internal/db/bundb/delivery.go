@@ -0,0 +1,103 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type deliveryDB struct {
+	conn *DBConn
+}
+
+func (d *deliveryDB) PutDeliveries(ctx context.Context, deliveries []*gtsmodel.Delivery) db.Error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return d.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(&deliveries).Exec(ctx)
+		return err
+	})
+}
+
+func (d *deliveryDB) GetDueDeliveries(ctx context.Context, dueBefore time.Time, limit int) ([]*gtsmodel.Delivery, db.Error) {
+	deliveries := []*gtsmodel.Delivery{}
+
+	q := d.conn.
+		NewSelect().
+		Model(&deliveries).
+		Where("delivery.state = ?", gtsmodel.DeliveryStatePending).
+		Where("delivery.next_attempt_at <= ?", dueBefore).
+		Order("delivery.next_attempt_at ASC").
+		Order("delivery.id ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+	return deliveries, nil
+}
+
+func (d *deliveryDB) GetDeliveries(ctx context.Context, state gtsmodel.DeliveryState, maxID string, limit int) ([]*gtsmodel.Delivery, db.Error) {
+	deliveries := []*gtsmodel.Delivery{}
+
+	q := d.conn.
+		NewSelect().
+		Model(&deliveries).
+		Order("delivery.id DESC")
+
+	if state != "" {
+		q = q.Where("delivery.state = ?", state)
+	}
+
+	if maxID != "" {
+		q = q.Where("delivery.id < ?", maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+	return deliveries, nil
+}
+
+func (d *deliveryDB) DeferDeliveries(ctx context.Context, host string, until time.Time) db.Error {
+	_, err := d.conn.
+		NewUpdate().
+		Model(&gtsmodel.Delivery{}).
+		Set("next_attempt_at = ?", until).
+		Set("updated_at = ?", time.Now()).
+		Where("host = ?", host).
+		Where("state = ?", gtsmodel.DeliveryStatePending).
+		Where("next_attempt_at < ?", until).
+		Exec(ctx)
+	return d.conn.ProcessError(err)
+}
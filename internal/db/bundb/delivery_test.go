@@ -0,0 +1,153 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DeliveryTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DeliveryTestSuite) putDeliveries() []*gtsmodel.Delivery {
+	now := time.Now()
+	deliveries := []*gtsmodel.Delivery{
+		{
+			ID:            "01G0JZ8X0T7MBXGEX3KB3ZD1ZM",
+			PubKeyID:      "http://localhost:8080/users/the_mighty_zork/main-key",
+			InboxURI:      "https://fossbros-anonymous.io/users/foss_satan/inbox",
+			Host:          "fossbros-anonymous.io",
+			Payload:       []byte(`{"type":"Create"}`),
+			State:         gtsmodel.DeliveryStatePending,
+			NextAttemptAt: now.Add(-1 * time.Minute),
+		},
+		{
+			ID:            "01G0JZ9DGF1FRYV3A7Y5M5FRT6",
+			PubKeyID:      "http://localhost:8080/users/the_mighty_zork/main-key",
+			InboxURI:      "https://example.org/users/some_user/inbox",
+			Host:          "example.org",
+			Payload:       []byte(`{"type":"Create"}`),
+			State:         gtsmodel.DeliveryStatePending,
+			NextAttemptAt: now.Add(1 * time.Hour),
+		},
+		{
+			ID:            "01G0JZA0V0B6RX5A6V7JRC1DBN",
+			PubKeyID:      "http://localhost:8080/users/the_mighty_zork/main-key",
+			InboxURI:      "https://example.org/users/another_user/inbox",
+			Host:          "example.org",
+			Payload:       []byte(`{"type":"Create"}`),
+			State:         gtsmodel.DeliveryStateFailed,
+			Attempts:      15,
+			NextAttemptAt: now.Add(-1 * time.Hour),
+		},
+	}
+
+	err := suite.db.PutDeliveries(context.Background(), deliveries)
+	suite.NoError(err)
+	return deliveries
+}
+
+func (suite *DeliveryTestSuite) TestGetDueDeliveries() {
+	deliveries := suite.putDeliveries()
+
+	due, err := suite.db.GetDueDeliveries(context.Background(), time.Now(), 0)
+	suite.NoError(err)
+	suite.Len(due, 1)
+	suite.Equal(deliveries[0].ID, due[0].ID)
+	suite.Equal(`{"type":"Create"}`, string(due[0].Payload))
+}
+
+func (suite *DeliveryTestSuite) TestGetDueDeliveriesSameTime() {
+	// deferred deliveries to a host all share the same next attempt time,
+	// so they should come back in the order they were queued
+	nextAttemptAt := time.Now().Add(-1 * time.Minute)
+	deliveries := []*gtsmodel.Delivery{}
+	for _, id := range []string{"01G0JZCSX2Q4S7E0PZ2CR4Y1DS", "01G0JZB2H5JQYV0A0MPPNXTXJ6", "01G0JZC4HQ2KDV9XS6YH4KMS1Q"} {
+		deliveries = append(deliveries, &gtsmodel.Delivery{
+			ID:            id,
+			PubKeyID:      "http://localhost:8080/users/the_mighty_zork/main-key",
+			InboxURI:      "https://fossbros-anonymous.io/users/foss_satan/inbox",
+			Host:          "fossbros-anonymous.io",
+			Payload:       []byte(`{"type":"Create"}`),
+			State:         gtsmodel.DeliveryStatePending,
+			NextAttemptAt: nextAttemptAt,
+		})
+	}
+	suite.NoError(suite.db.PutDeliveries(context.Background(), deliveries))
+
+	due, err := suite.db.GetDueDeliveries(context.Background(), time.Now(), 0)
+	suite.NoError(err)
+	suite.Len(due, 3)
+	suite.Equal("01G0JZB2H5JQYV0A0MPPNXTXJ6", due[0].ID)
+	suite.Equal("01G0JZC4HQ2KDV9XS6YH4KMS1Q", due[1].ID)
+	suite.Equal("01G0JZCSX2Q4S7E0PZ2CR4Y1DS", due[2].ID)
+}
+
+func (suite *DeliveryTestSuite) TestGetDeliveries() {
+	deliveries := suite.putDeliveries()
+
+	all, err := suite.db.GetDeliveries(context.Background(), "", "", 10)
+	suite.NoError(err)
+	suite.Len(all, 3)
+	// newest first
+	suite.Equal(deliveries[2].ID, all[0].ID)
+
+	failed, err := suite.db.GetDeliveries(context.Background(), gtsmodel.DeliveryStateFailed, "", 10)
+	suite.NoError(err)
+	suite.Len(failed, 1)
+	suite.Equal(deliveries[2].ID, failed[0].ID)
+
+	paged, err := suite.db.GetDeliveries(context.Background(), "", deliveries[1].ID, 10)
+	suite.NoError(err)
+	suite.Len(paged, 1)
+	suite.Equal(deliveries[0].ID, paged[0].ID)
+}
+
+func (suite *DeliveryTestSuite) TestDeferDeliveries() {
+	deliveries := suite.putDeliveries()
+	until := time.Now().Add(30 * time.Minute)
+
+	err := suite.db.DeferDeliveries(context.Background(), "fossbros-anonymous.io", until)
+	suite.NoError(err)
+
+	due, err := suite.db.GetDueDeliveries(context.Background(), time.Now(), 0)
+	suite.NoError(err)
+	suite.Empty(due)
+
+	deferred := &gtsmodel.Delivery{}
+	err = suite.db.GetByID(context.Background(), deliveries[0].ID, deferred)
+	suite.NoError(err)
+	suite.WithinDuration(until, deferred.NextAttemptAt, time.Second)
+
+	// deliveries due later than the deferral shouldn't be brought forward
+	later := &gtsmodel.Delivery{}
+	err = suite.db.GetByID(context.Background(), deliveries[1].ID, later)
+	suite.NoError(err)
+	suite.WithinDuration(deliveries[1].NextAttemptAt, later.NextAttemptAt, time.Second)
+}
+
+func TestDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTestSuite))
+}
@@ -20,8 +20,12 @@ package bundb
 
 import (
 	"context"
+	"net"
 	"net/url"
+	"strings"
 
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -32,10 +36,24 @@ type domainDB struct {
 }
 
 func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, db.Error) {
-	if domain == "" {
+	// we never refuse to federate with ourselves
+	if domain == "" || isOwnDomain(domain) {
 		return false, nil
 	}
 
+	switch viper.GetString(config.Keys.FederationMode) {
+	case config.FederationModeNone:
+		return true, nil
+	case config.FederationModeAllowlist:
+		allowed, err := d.IsDomainAllowed(ctx, domain)
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			return true, nil
+		}
+	}
+
 	q := d.conn.
 		NewSelect().
 		Model(&gtsmodel.DomainBlock{}).
@@ -74,3 +92,33 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, d
 
 	return d.AreDomainsBlocked(ctx, domains)
 }
+
+func (d *domainDB) IsDomainAllowed(ctx context.Context, domain string) (bool, db.Error) {
+	if domain == "" {
+		return false, nil
+	}
+
+	q := d.conn.
+		NewSelect().
+		Model(&gtsmodel.DomainAllow{}).
+		Where("LOWER(domain) = LOWER(?)", domain).
+		Limit(1)
+
+	return d.conn.Exists(ctx, q)
+}
+
+// isOwnDomain returns true if the given domain is the host or account domain of this instance, with or without a port.
+func isOwnDomain(domain string) bool {
+	for _, own := range []string{viper.GetString(config.Keys.Host), viper.GetString(config.Keys.AccountDomain)} {
+		if own == "" {
+			continue
+		}
+		if strings.EqualFold(domain, own) {
+			return true
+		}
+		if hostname, _, err := net.SplitHostPort(own); err == nil && strings.EqualFold(domain, hostname) {
+			return true
+		}
+	}
+	return false
+}
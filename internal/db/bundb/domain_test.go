@@ -0,0 +1,104 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DomainTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DomainTestSuite) blocked(domain string) bool {
+	blocked, err := suite.db.IsDomainBlocked(context.Background(), domain)
+	suite.NoError(err)
+	return blocked
+}
+
+func (suite *DomainTestSuite) TestIsDomainBlockedBlocklist() {
+	viper.Set(config.Keys.FederationMode, config.FederationModeBlocklist)
+
+	suite.True(suite.blocked("replyguys.com"))
+	suite.True(suite.blocked("REPLYGUYS.com"))
+	suite.False(suite.blocked("fossbros-anonymous.io"))
+	suite.False(suite.blocked("example.org"))
+	suite.False(suite.blocked("localhost:8080"))
+	suite.False(suite.blocked(""))
+}
+
+func (suite *DomainTestSuite) TestIsDomainBlockedAllowlist() {
+	viper.Set(config.Keys.FederationMode, config.FederationModeAllowlist)
+
+	suite.True(suite.blocked("replyguys.com"))
+	suite.False(suite.blocked("fossbros-anonymous.io"))
+	suite.True(suite.blocked("example.org"))
+	suite.False(suite.blocked("localhost:8080"))
+	suite.False(suite.blocked(""))
+
+	// a domain that's both allowed and blocked is blocked
+	err := suite.db.Put(context.Background(), &gtsmodel.DomainAllow{
+		ID:                 "01G58KDD2XW3BQ4P61YVJ2ZKAW",
+		Domain:             "replyguys.com",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	})
+	suite.NoError(err)
+	allowed, err := suite.db.IsDomainAllowed(context.Background(), "replyguys.com")
+	suite.NoError(err)
+	suite.True(allowed)
+	suite.True(suite.blocked("replyguys.com"))
+}
+
+func (suite *DomainTestSuite) TestIsDomainBlockedNone() {
+	viper.Set(config.Keys.FederationMode, config.FederationModeNone)
+
+	suite.True(suite.blocked("replyguys.com"))
+	suite.True(suite.blocked("fossbros-anonymous.io"))
+	suite.True(suite.blocked("example.org"))
+	suite.False(suite.blocked("localhost:8080"))
+	suite.False(suite.blocked(""))
+}
+
+func (suite *DomainTestSuite) TestAreURIsBlockedAllowlist() {
+	viper.Set(config.Keys.FederationMode, config.FederationModeAllowlist)
+
+	allowedURI, err := url.Parse("https://fossbros-anonymous.io/users/foss_satan")
+	suite.NoError(err)
+	otherURI, err := url.Parse("https://example.org/users/some_user")
+	suite.NoError(err)
+
+	blocked, err := suite.db.AreURIsBlocked(context.Background(), []*url.URL{allowedURI})
+	suite.NoError(err)
+	suite.False(blocked)
+
+	blocked, err = suite.db.AreURIsBlocked(context.Background(), []*url.URL{allowedURI, otherURI})
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func TestDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainTestSuite))
+}
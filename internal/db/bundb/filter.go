@@ -0,0 +1,153 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type filterDB struct {
+	conn *DBConn
+}
+
+func (f *filterDB) newFilterQ(i interface{}) *bun.SelectQuery {
+	return f.conn.
+		NewSelect().
+		Model(i).
+		Relation("Keywords", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("filter_keyword.id ASC")
+		})
+}
+
+func (f *filterDB) GetFilterByID(ctx context.Context, id string) (*gtsmodel.Filter, db.Error) {
+	filter := &gtsmodel.Filter{}
+
+	q := f.newFilterQ(filter).
+		Where("filter.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filter, nil
+}
+
+func (f *filterDB) GetFiltersForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Filter, db.Error) {
+	filters := []*gtsmodel.Filter{}
+
+	q := f.newFilterQ(&filters).
+		Where("filter.account_id = ?", accountID).
+		Order("filter.id ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filters, nil
+}
+
+func (f *filterDB) GetFilterKeywordByID(ctx context.Context, id string) (*gtsmodel.FilterKeyword, db.Error) {
+	keyword := &gtsmodel.FilterKeyword{}
+
+	q := f.conn.
+		NewSelect().
+		Model(keyword).
+		Relation("Filter").
+		Where("filter_keyword.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return keyword, nil
+}
+
+func (f *filterDB) PutFilter(ctx context.Context, filter *gtsmodel.Filter) db.Error {
+	return f.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(filter).Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(filter.Keywords) == 0 {
+			return nil
+		}
+
+		_, err := tx.NewInsert().Model(&filter.Keywords).Exec(ctx)
+		return err
+	})
+}
+
+func (f *filterDB) UpdateFilter(ctx context.Context, filter *gtsmodel.Filter, deleteKeywordIDs []string) db.Error {
+	return f.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.
+			NewUpdate().
+			Model(filter).
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(deleteKeywordIDs) != 0 {
+			if _, err := tx.
+				NewDelete().
+				Model((*gtsmodel.FilterKeyword)(nil)).
+				Where("filter_id = ?", filter.ID).
+				Where("id IN (?)", bun.In(deleteKeywordIDs)).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// upsert the keywords, since some of them may be new
+		for _, k := range filter.Keywords {
+			if _, err := tx.
+				NewInsert().
+				Model(k).
+				On("CONFLICT (id) DO UPDATE").
+				Set("updated_at = EXCLUDED.updated_at").
+				Set("keyword = EXCLUDED.keyword").
+				Set("whole_word = EXCLUDED.whole_word").
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (f *filterDB) DeleteFilterByID(ctx context.Context, id string) db.Error {
+	return f.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Model((*gtsmodel.FilterKeyword)(nil)).
+			Where("filter_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			Model((*gtsmodel.Filter)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
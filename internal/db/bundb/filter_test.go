@@ -0,0 +1,122 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FilterTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *FilterTestSuite) TestGetFiltersForAccountID() {
+	filter := testrig.NewTestFilters()["local_account_1_filter_1"]
+
+	filters, err := suite.db.GetFiltersForAccountID(context.Background(), filter.AccountID)
+	suite.NoError(err)
+	suite.Len(filters, 1)
+	suite.Equal(filter.ID, filters[0].ID)
+	suite.Len(filters[0].Keywords, 1)
+	suite.Equal("fnord", filters[0].Keywords[0].Keyword)
+}
+
+func (suite *FilterTestSuite) TestGetFilterKeywordByID() {
+	keyword := testrig.NewTestFilterKeywords()["local_account_1_filter_1_keyword_1"]
+
+	k, err := suite.db.GetFilterKeywordByID(context.Background(), keyword.ID)
+	suite.NoError(err)
+	suite.NotNil(k.Filter)
+	suite.Equal(keyword.FilterID, k.Filter.ID)
+}
+
+func (suite *FilterTestSuite) TestPutUpdateDeleteFilter() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	filter := &gtsmodel.Filter{
+		ID:                   "01G1XQ3G0WAG6RPSKC9Y4KBWYT",
+		AccountID:            accountID,
+		Title:                "spoilers",
+		Action:               gtsmodel.FilterActionHide,
+		ContextNotifications: true,
+		Keywords: []*gtsmodel.FilterKeyword{
+			{
+				ID:        "01G1XQ40JKF3BDK8W5H8JB2SJT",
+				AccountID: accountID,
+				FilterID:  "01G1XQ3G0WAG6RPSKC9Y4KBWYT",
+				Keyword:   "the butler did it",
+			},
+			{
+				ID:        "01G1XQ4BD4GH1Y1X6TP4HZPM2R",
+				AccountID: accountID,
+				FilterID:  "01G1XQ3G0WAG6RPSKC9Y4KBWYT",
+				Keyword:   "rosebud",
+				WholeWord: true,
+			},
+		},
+	}
+	suite.NoError(suite.db.PutFilter(ctx, filter))
+
+	// change one keyword, add one, and delete the other
+	filter.Title = "film spoilers"
+	filter.Keywords = []*gtsmodel.FilterKeyword{
+		{
+			ID:        "01G1XQ40JKF3BDK8W5H8JB2SJT",
+			AccountID: accountID,
+			FilterID:  filter.ID,
+			Keyword:   "the butler did it!",
+		},
+		{
+			ID:        "01G1XQ5B3N6CGYDZ3ZQ0GZ1RXA",
+			AccountID: accountID,
+			FilterID:  filter.ID,
+			Keyword:   "keyser",
+		},
+	}
+	suite.NoError(suite.db.UpdateFilter(ctx, filter, []string{"01G1XQ4BD4GH1Y1X6TP4HZPM2R"}))
+
+	dbFilter, err := suite.db.GetFilterByID(ctx, filter.ID)
+	suite.NoError(err)
+	suite.Equal("film spoilers", dbFilter.Title)
+	suite.Equal(gtsmodel.FilterActionHide, dbFilter.Action)
+	suite.True(dbFilter.ContextNotifications)
+	suite.False(dbFilter.ContextHome)
+	suite.Len(dbFilter.Keywords, 2)
+	suite.Equal("the butler did it!", dbFilter.Keywords[0].Keyword)
+	suite.Equal("keyser", dbFilter.Keywords[1].Keyword)
+
+	suite.NoError(suite.db.DeleteFilterByID(ctx, filter.ID))
+
+	_, err = suite.db.GetFilterByID(ctx, filter.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetFilterKeywordByID(ctx, "01G1XQ5B3N6CGYDZ3ZQ0GZ1RXA")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
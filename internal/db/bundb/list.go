@@ -0,0 +1,170 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type listDB struct {
+	conn *DBConn
+}
+
+func (l *listDB) newListQ(i interface{}) *bun.SelectQuery {
+	return l.conn.
+		NewSelect().
+		Model(i).
+		Relation("Account")
+}
+
+func (l *listDB) GetListByID(ctx context.Context, id string) (*gtsmodel.List, db.Error) {
+	list := &gtsmodel.List{}
+
+	q := l.newListQ(list).
+		Where("list.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return list, nil
+}
+
+func (l *listDB) GetListsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.List, db.Error) {
+	lists := []*gtsmodel.List{}
+
+	q := l.newListQ(&lists).
+		Where("list.account_id = ?", accountID).
+		Order("list.id ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return lists, nil
+}
+
+func (l *listDB) GetListsContainingAccountID(ctx context.Context, ownerAccountID string, targetAccountID string) ([]*gtsmodel.List, db.Error) {
+	lists := []*gtsmodel.List{}
+
+	q := l.newListQ(&lists).
+		Join("JOIN list_entries AS le ON le.list_id = list.id").
+		Join("JOIN follows AS f ON f.id = le.follow_id").
+		Where("list.account_id = ?", ownerAccountID).
+		Where("f.target_account_id = ?", targetAccountID).
+		Order("list.id ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return lists, nil
+}
+
+func (l *listDB) GetListEntries(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ListEntry, db.Error) {
+	entries := []*gtsmodel.ListEntry{}
+
+	q := l.conn.
+		NewSelect().
+		Model(&entries).
+		Relation("Follow").
+		Relation("Follow.TargetAccount").
+		Where("list_entry.list_id = ?", listID).
+		Order("list_entry.id DESC")
+
+	if maxID != "" {
+		q = q.Where("list_entry.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("list_entry.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("list_entry.id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return entries, nil
+}
+
+func (l *listDB) GetListEntriesForFollowID(ctx context.Context, followID string) ([]*gtsmodel.ListEntry, db.Error) {
+	entries := []*gtsmodel.ListEntry{}
+
+	q := l.conn.
+		NewSelect().
+		Model(&entries).
+		Where("list_entry.follow_id = ?", followID)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return entries, nil
+}
+
+func (l *listDB) PutListEntries(ctx context.Context, entries []*gtsmodel.ListEntry) db.Error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return l.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(&entries).Exec(ctx)
+		return err
+	})
+}
+
+func (l *listDB) DeleteListEntriesForFollowIDs(ctx context.Context, listID string, followIDs []string) db.Error {
+	if len(followIDs) == 0 {
+		return nil
+	}
+
+	_, err := l.conn.
+		NewDelete().
+		Model((*gtsmodel.ListEntry)(nil)).
+		Where("list_id = ?", listID).
+		Where("follow_id IN (?)", bun.In(followIDs)).
+		Exec(ctx)
+	return l.conn.ProcessError(err)
+}
+
+func (l *listDB) DeleteListByID(ctx context.Context, id string) db.Error {
+	return l.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Model((*gtsmodel.ListEntry)(nil)).
+			Where("list_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			Model((*gtsmodel.List)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
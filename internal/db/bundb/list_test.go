@@ -0,0 +1,94 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ListTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ListTestSuite) TestGetListsForAccountID() {
+	list := testrig.NewTestLists()["local_account_1_list_1"]
+
+	lists, err := suite.db.GetListsForAccountID(context.Background(), list.AccountID)
+	suite.NoError(err)
+	suite.Len(lists, 1)
+	suite.Equal(list.ID, lists[0].ID)
+	suite.NotNil(lists[0].Account)
+}
+
+func (suite *ListTestSuite) TestGetListEntries() {
+	list := testrig.NewTestLists()["local_account_1_list_1"]
+
+	entries, err := suite.db.GetListEntries(context.Background(), list.ID, "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.NotNil(entries[0].Follow)
+	suite.NotNil(entries[0].Follow.TargetAccount)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, entries[0].Follow.TargetAccount.ID)
+}
+
+func (suite *ListTestSuite) TestGetListsContainingAccountID() {
+	list := testrig.NewTestLists()["local_account_1_list_1"]
+
+	lists, err := suite.db.GetListsContainingAccountID(context.Background(), list.AccountID, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+	suite.Len(lists, 1)
+
+	lists, err = suite.db.GetListsContainingAccountID(context.Background(), list.AccountID, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.Empty(lists)
+}
+
+func (suite *ListTestSuite) TestGetListTimeline() {
+	list := testrig.NewTestLists()["local_account_1_list_1"]
+
+	statuses, err := suite.db.GetListTimeline(context.Background(), list.ID, "", "", "", 20)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, s := range statuses {
+		suite.Equal(suite.testAccounts["local_account_2"].ID, s.AccountID)
+	}
+}
+
+func (suite *ListTestSuite) TestDeleteListByID() {
+	list := testrig.NewTestLists()["local_account_1_list_1"]
+
+	err := suite.db.DeleteListByID(context.Background(), list.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetListByID(context.Background(), list.ID)
+	suite.Error(err)
+
+	entries := []*gtsmodel.ListEntry{}
+	err = suite.db.GetAll(context.Background(), &entries)
+	suite.Empty(entries)
+}
+
+func TestListTestSuite(t *testing.T) {
+	suite.Run(t, new(ListTestSuite))
+}
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type markerDB struct {
+	conn *DBConn
+}
+
+func (m *markerDB) GetMarkers(ctx context.Context, accountID string, names []gtsmodel.MarkerName) ([]*gtsmodel.Marker, db.Error) {
+	markers := []*gtsmodel.Marker{}
+
+	if len(names) == 0 {
+		return markers, nil
+	}
+
+	q := m.conn.
+		NewSelect().
+		Model(&markers).
+		Where("marker.account_id = ?", accountID).
+		Where("marker.name IN (?)", bun.In(names))
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+	return markers, nil
+}
+
+func (m *markerDB) UpdateMarker(ctx context.Context, marker *gtsmodel.Marker) db.Error {
+	prevVersion := marker.Version
+	marker.Version = prevVersion + 1
+	marker.UpdatedAt = time.Now()
+
+	var (
+		res sql.Result
+		err error
+	)
+
+	if prevVersion == 0 {
+		// this is a new marker, but someone else may have created it in the meantime
+		res, err = m.conn.
+			NewInsert().
+			Model(marker).
+			On("CONFLICT (account_id, name) DO NOTHING").
+			Exec(ctx)
+	} else {
+		// only update the marker if nobody else has updated it since it was read
+		res, err = m.conn.
+			NewUpdate().
+			Model(marker).
+			Column("updated_at", "version", "last_read_id").
+			Where("account_id = ?", marker.AccountID).
+			Where("name = ?", marker.Name).
+			Where("version = ?", prevVersion).
+			Exec(ctx)
+	}
+
+	if err != nil {
+		marker.Version = prevVersion
+		return m.conn.ProcessError(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		marker.Version = prevVersion
+		return m.conn.ProcessError(err)
+	}
+
+	if rows == 0 {
+		marker.Version = prevVersion
+		return db.ErrVersionConflict
+	}
+
+	return nil
+}
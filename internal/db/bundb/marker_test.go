@@ -0,0 +1,103 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MarkerTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *MarkerTestSuite) TestGetMarkers() {
+	marker := testrig.NewTestMarkers()["local_account_1_home"]
+
+	markers, err := suite.db.GetMarkers(context.Background(), marker.AccountID, []gtsmodel.MarkerName{gtsmodel.MarkerNameHome, gtsmodel.MarkerNameNotifications})
+	suite.NoError(err)
+	suite.Len(markers, 1)
+	suite.Equal(gtsmodel.MarkerNameHome, markers[0].Name)
+	suite.Equal(marker.LastReadID, markers[0].LastReadID)
+	suite.Equal(1, markers[0].Version)
+
+	markers, err = suite.db.GetMarkers(context.Background(), marker.AccountID, []gtsmodel.MarkerName{gtsmodel.MarkerNameNotifications})
+	suite.NoError(err)
+	suite.Empty(markers)
+}
+
+func (suite *MarkerTestSuite) TestUpdateMarker() {
+	marker := testrig.NewTestMarkers()["local_account_1_home"]
+	marker.LastReadID = suite.testStatuses["local_account_1_status_2"].ID
+
+	err := suite.db.UpdateMarker(context.Background(), marker)
+	suite.NoError(err)
+	suite.Equal(2, marker.Version)
+
+	markers, err := suite.db.GetMarkers(context.Background(), marker.AccountID, []gtsmodel.MarkerName{gtsmodel.MarkerNameHome})
+	suite.NoError(err)
+	suite.Len(markers, 1)
+	suite.Equal(marker.LastReadID, markers[0].LastReadID)
+	suite.Equal(2, markers[0].Version)
+}
+
+func (suite *MarkerTestSuite) TestUpdateMarkerConflict() {
+	// two requests read the same version of the marker
+	first := testrig.NewTestMarkers()["local_account_1_home"]
+	second := testrig.NewTestMarkers()["local_account_1_home"]
+
+	err := suite.db.UpdateMarker(context.Background(), first)
+	suite.NoError(err)
+
+	// so the second update should be rejected
+	second.LastReadID = suite.testStatuses["local_account_1_status_2"].ID
+	err = suite.db.UpdateMarker(context.Background(), second)
+	suite.ErrorIs(err, db.ErrVersionConflict)
+	suite.Equal(1, second.Version)
+}
+
+func (suite *MarkerTestSuite) TestUpdateNewMarker() {
+	marker := &gtsmodel.Marker{
+		AccountID:  suite.testAccounts["local_account_1"].ID,
+		Name:       gtsmodel.MarkerNameNotifications,
+		LastReadID: "01F8Q0ANPTWW10DAKTX7BRPBJP",
+	}
+
+	err := suite.db.UpdateMarker(context.Background(), marker)
+	suite.NoError(err)
+	suite.Equal(1, marker.Version)
+
+	// creating it again from scratch should conflict
+	marker = &gtsmodel.Marker{
+		AccountID:  suite.testAccounts["local_account_1"].ID,
+		Name:       gtsmodel.MarkerNameNotifications,
+		LastReadID: "01F8Q0ANPTWW10DAKTX7BRPBJP",
+	}
+	err = suite.db.UpdateMarker(context.Background(), marker)
+	suite.ErrorIs(err, db.ErrVersionConflict)
+}
+
+func TestMarkerTestSuite(t *testing.T) {
+	suite.Run(t, new(MarkerTestSuite))
+}
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20220401150722_polls"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// create tables for the new poll structs
+			if _, err := tx.NewCreateTable().Model(&gtsmodel.Poll{}).IfNotExists().Exec(ctx); err != nil {
+				return err
+			}
+
+			if _, err := tx.NewCreateTable().Model(&gtsmodel.PollVote{}).IfNotExists().Exec(ctx); err != nil {
+				return err
+			}
+
+			// polls are selected by their status id, and by expiry time when closing them
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Poll{}).
+				Index("polls_status_id_idx").
+				Column("status_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Poll{}).
+				Index("polls_expires_at_closed_at_idx").
+				Column("expires_at", "closed_at").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// poll votes are selected by poll id, and by poll id + account id
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.PollVote{}).
+				Index("poll_votes_poll_id_idx").
+				Column("poll_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.PollVote{}).
+				Index("poll_votes_poll_id_account_id_idx").
+				Column("poll_id", "account_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// statuses need a new column to point to their poll
+			if _, err := tx.
+				NewAddColumn().
+				Table("statuses").
+				ColumnExpr("poll_id CHAR(26)").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20220410113256_status_edits"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// create table for previous revisions of statuses
+			if _, err := tx.NewCreateTable().Model(&gtsmodel.StatusEdit{}).IfNotExists().Exec(ctx); err != nil {
+				return err
+			}
+
+			// revisions are always selected by the status they belong to
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.StatusEdit{}).
+				Index("status_edits_status_id_idx").
+				Column("status_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// statuses need a new column to record when they were last edited
+			if _, err := tx.
+				NewAddColumn().
+				Table("statuses").
+				ColumnExpr("edited_at TIMESTAMPTZ").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
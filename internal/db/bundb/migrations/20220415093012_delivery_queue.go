@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20220415093012_delivery_queue"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// create table for queued outgoing deliveries
+			if _, err := tx.NewCreateTable().Model(&gtsmodel.Delivery{}).IfNotExists().Exec(ctx); err != nil {
+				return err
+			}
+
+			// the delivery worker selects pending deliveries by when they're next due
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Delivery{}).
+				Index("deliveries_state_next_attempt_at_idx").
+				Column("state", "next_attempt_at").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// deliveries are deferred in bulk when their host is backed off from
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Delivery{}).
+				Index("deliveries_host_idx").
+				Column("host").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
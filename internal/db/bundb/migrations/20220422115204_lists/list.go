@@ -0,0 +1,56 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// List refers to a list of followed accounts, created by one local account
+// so that it can see a timeline of statuses from just those accounts.
+type List struct {
+	ID            string        `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt     time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Title         string        `validate:"required" bun:",nullzero,notnull"`                                    // title of this list, as given by its owner
+	AccountID     string        `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the local account that owns this list
+	RepliesPolicy RepliesPolicy `validate:"oneof=followed list none" bun:",nullzero,notnull,default:'list'"`     // which replies by list members should be shown in the list timeline
+}
+
+// ListEntry refers to one followed account being a member of a list.
+//
+// Entries point to the follow rather than directly to the followed account,
+// so that accounts which are no longer followed drop out of the list automatically.
+type ListEntry struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                  // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item last updated
+	ListID    string    `validate:"required,ulid" bun:"type:CHAR(26),unique:listentrylistfollow,nullzero,notnull"` // id of the list this entry belongs to
+	FollowID  string    `validate:"required,ulid" bun:"type:CHAR(26),unique:listentrylistfollow,nullzero,notnull"` // id of the follow of the list member by the list owner
+}
+
+// RepliesPolicy describes which replies by list members should be shown in a list timeline.
+type RepliesPolicy string
+
+const (
+	// RepliesPolicyFollowed means replies to any account followed by the list owner should be shown.
+	RepliesPolicyFollowed RepliesPolicy = "followed"
+	// RepliesPolicyList means only replies to other members of the list should be shown.
+	RepliesPolicyList RepliesPolicy = "list"
+	// RepliesPolicyNone means no replies should be shown.
+	RepliesPolicyNone RepliesPolicy = "none"
+)
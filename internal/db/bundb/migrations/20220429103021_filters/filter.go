@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Filter refers to a set of keywords that one local account doesn't want to see, or wants
+// to be warned about, in the given contexts.
+type Filter struct {
+	ID                   string       `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt            time.Time    `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt            time.Time    `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ExpiresAt            time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when does this filter stop applying? zero value means never
+	AccountID            string       `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the local account that owns this filter
+	Title                string       `validate:"required" bun:",nullzero,notnull"`                                    // title of this filter, as given by its owner
+	Action               FilterAction `validate:"oneof=warn hide" bun:",nullzero,notnull,default:'warn'"`              // what to do with statuses that match this filter
+	ContextHome          bool         `validate:"-" bun:",notnull,default:false"`                                      // apply this filter to the home timeline and lists
+	ContextNotifications bool         `validate:"-" bun:",notnull,default:false"`                                      // apply this filter to notifications
+	ContextPublic        bool         `validate:"-" bun:",notnull,default:false"`                                      // apply this filter to public timelines
+	ContextThread        bool         `validate:"-" bun:",notnull,default:false"`                                      // apply this filter when viewing a status and its context
+	ContextAccount       bool         `validate:"-" bun:",notnull,default:false"`                                      // apply this filter when viewing an account's statuses
+}
+
+// FilterKeyword refers to one keyword or phrase that a filter matches on.
+type FilterKeyword struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the local account that owns the filter
+	FilterID  string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the filter this keyword belongs to
+	Keyword   string    `validate:"required" bun:",nullzero,notnull"`                                    // the keyword or phrase to match on
+	WholeWord bool      `validate:"-" bun:",notnull,default:false"`                                      // should the keyword only match whole words?
+}
+
+// FilterAction describes what should happen to statuses that match a filter.
+type FilterAction string
+
+const (
+	// FilterActionWarn means matching statuses should be shown behind a warning.
+	FilterActionWarn FilterAction = "warn"
+	// FilterActionHide means matching statuses should not be shown at all.
+	FilterActionHide FilterAction = "hide"
+)
+
+// FilterContext describes a place where statuses are shown, which a filter can apply to.
+type FilterContext string
+
+const (
+	// FilterContextHome means the home timeline and lists.
+	FilterContextHome FilterContext = "home"
+	// FilterContextNotifications means notifications.
+	FilterContextNotifications FilterContext = "notifications"
+	// FilterContextPublic means public timelines.
+	FilterContextPublic FilterContext = "public"
+	// FilterContextThread means a status and its context.
+	FilterContextThread FilterContext = "thread"
+	// FilterContextAccount means the statuses of an account.
+	FilterContextAccount FilterContext = "account"
+)
@@ -0,0 +1,51 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that a local account has asked to be published at a later time.
+// The parameters it was created with are stored alongside it, and turned into a real status when it's due.
+type ScheduledStatus struct {
+	ID             string     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                     // id of this item in the database
+	CreatedAt      time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item created
+	UpdatedAt      time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item last updated
+	ScheduledAt    time.Time  `validate:"required" bun:"type:timestamptz,nullzero,notnull"`                                 // when should the status be published?
+	AccountID      string     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                               // id of the account that scheduled this status
+	ApplicationID  string     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                               // id of the application that was used to schedule this status
+	Text           string     `validate:"-" bun:""`                                                                         // text of the status, as submitted
+	SpoilerText    string     `validate:"-" bun:""`                                                                         // content warning of the status, as submitted
+	InReplyToID    string     `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                      // id of the status this status will reply to
+	AttachmentIDs  []string   `validate:"dive,ulid" bun:"attachments,array"`                                                // database IDs of media attachments that will be attached to the status
+	Sensitive      bool       `validate:"-" bun:",notnull,default:false"`                                                   // mark the status as sensitive?
+	Visibility     Visibility `validate:"oneof=public unlocked followers_only mutuals_only direct" bun:",nullzero,notnull"` // visibility of the status
+	Federated      bool       `validate:"-" bun:",notnull"`                                                                 // federate the status beyond the local timeline(s)?
+	Boostable      bool       `validate:"-" bun:",notnull"`                                                                 // can the status be boosted/reblogged?
+	Replyable      bool       `validate:"-" bun:",notnull"`                                                                 // can the status be replied to?
+	Likeable       bool       `validate:"-" bun:",notnull"`                                                                 // can the status be liked/faved?
+	Language       string     `validate:"-" bun:",nullzero"`                                                                // language of the status, as submitted
+	Format         string     `validate:"-" bun:",nullzero"`                                                                // format in which the status text should be parsed, as submitted
+	PollOptions    []string   `validate:"-" bun:",array"`                                                                   // options of the poll to attach to the status, if any
+	PollExpiresIn  int        `validate:"min=0" bun:",notnull,default:0"`                                                   // how long should the poll be open for, in seconds, once published?
+	PollMultiple   bool       `validate:"-" bun:",notnull,default:false"`                                                   // can voters choose more than one poll option?
+	PollHideTotals bool       `validate:"-" bun:",notnull,default:false"`                                                   // should poll vote counts be hidden until the poll closes?
+}
+
+// Visibility represents the visibility granularity of a status.
+type Visibility string
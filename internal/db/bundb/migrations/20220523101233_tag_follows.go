@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20220523101233_tag_follows"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewCreateTable().Model(&gtsmodel.TagFollow{}).IfNotExists().Exec(ctx); err != nil {
+				return err
+			}
+
+			// follows of a tag are looked up by tag_id when a status using the tag comes in,
+			// which the unique constraint on account_id, tag_id doesn't cover
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.TagFollow{}).
+				Index("tag_follows_tag_id_idx").
+				Column("tag_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// tag timelines are looked up by tag_id too
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.StatusToTag{}).
+				Index("status_to_tags_tag_id_idx").
+				Column("tag_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20220525093012_conversations"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			models := []interface{}{
+				&gtsmodel.Conversation{},
+				&gtsmodel.ConversationToStatus{},
+			}
+
+			for _, i := range models {
+				if _, err := tx.NewCreateTable().Model(i).IfNotExists().Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// conversations are listed per account, newest last status first
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Conversation{}).
+				Index("conversations_account_id_last_status_id_idx").
+				Column("account_id", "last_status_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// when a status is deleted, the conversations it belongs to are looked up by status_id
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.ConversationToStatus{}).
+				Index("conversation_to_statuses_status_id_idx").
+				Column("status_id").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Conversation represents one local account's view of a thread of direct messages
+// between a given set of participants.
+type Conversation struct {
+	ID               string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`
+	CreatedAt        time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt        time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+	AccountID        string    `validate:"required,ulid" bun:"type:CHAR(26),unique:conversationaccountthreadothers,notnull,nullzero"`
+	OtherAccountIDs  []string  `validate:"dive,ulid" bun:"other_account_ids,array"`
+	OtherAccountsKey string    `validate:"-" bun:",unique:conversationaccountthreadothers,notnull"`
+	ThreadID         string    `validate:"required,ulid" bun:"type:CHAR(26),unique:conversationaccountthreadothers,notnull,nullzero"`
+	LastStatusID     string    `validate:"required,ulid" bun:"type:CHAR(26),notnull,nullzero"`
+	Read             bool      `validate:"-" bun:",notnull,default:false"`
+}
+
+// ConversationToStatus is an intermediate struct to keep track of which statuses belong to a conversation.
+type ConversationToStatus struct {
+	ConversationID string `validate:"ulid,required" bun:"type:CHAR(26),unique:conversationstatus,nullzero,notnull"`
+	StatusID       string `validate:"ulid,required" bun:"type:CHAR(26),unique:conversationstatus,nullzero,notnull"`
+}
@@ -0,0 +1,50 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// PushSubscription represents a Web Push subscription made by one oauth token of a local account,
+// so that the client holding the token can be sent notifications while it isn't connected.
+type PushSubscription struct {
+	ID                 string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the local account the subscription belongs to
+	TokenID            string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull,unique"`           // id of the oauth token the subscription was created with; each token has at most one subscription
+	Endpoint           string    `validate:"required,url" bun:",nullzero,notnull"`                                // url of the push service endpoint that notifications are delivered to
+	P256dh             string    `validate:"required" bun:",nullzero,notnull"`                                    // base64url encoded public key of the client, used to encrypt notifications
+	Auth               string    `validate:"required" bun:",nullzero,notnull"`                                    // base64url encoded authentication secret of the client, used to encrypt notifications
+	AlertFollow        bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of new follows?
+	AlertFollowRequest bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of new follow requests?
+	AlertFavourite     bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of faves?
+	AlertMention       bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of mentions?
+	AlertReblog        bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of boosts?
+	AlertPoll          bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of ended polls?
+	AlertStatus        bool      `validate:"-" bun:",notnull,default:false"`                                      // push notifications of new statuses from accounts the account enabled notifications for?
+}
+
+// VAPIDKeyPair is the keypair that this instance uses to identify itself to push services
+// when sending Web Push notifications, as described in RFC 8292.
+type VAPIDKeyPair struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	PublicKey  string    `validate:"required" bun:",nullzero,notnull"`                                    // base64url encoded uncompressed P-256 public key
+	PrivateKey string    `validate:"required" bun:",nullzero,notnull"`                                    // base64url encoded P-256 private key
+}
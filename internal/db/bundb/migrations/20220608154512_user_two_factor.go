@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// recovery code hashes are stored as an array; sqlite doesn't
+			// have an array type so bun stores them as json there instead
+			recoveryCodesType := "VARCHAR"
+			if db.Dialect().Name() == dialect.PG {
+				recoveryCodesType = "VARCHAR[]"
+			}
+
+			for _, column := range []string{
+				"two_factor_secret VARCHAR",
+				"two_factor_enabled_at TIMESTAMPTZ",
+				"two_factor_last_step BIGINT NOT NULL DEFAULT 0",
+				"two_factor_recovery_codes " + recoveryCodesType,
+			} {
+				if _, err := tx.
+					NewAddColumn().
+					Table("users").
+					ColumnExpr(column).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
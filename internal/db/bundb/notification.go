@@ -54,7 +54,7 @@ func (n *notificationDB) GetNotification(ctx context.Context, id string) (*gtsmo
 	return notif, nil
 }
 
-func (n *notificationDB) GetNotifications(ctx context.Context, accountID string, limit int, maxID string, sinceID string) ([]*gtsmodel.Notification, db.Error) {
+func (n *notificationDB) GetNotifications(ctx context.Context, accountID string, types []string, excludeTypes []string, originAccountID string, limit int, maxID string, sinceID string, minID string) ([]*gtsmodel.Notification, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -63,9 +63,7 @@ func (n *notificationDB) GetNotifications(ctx context.Context, accountID string,
 	// Make a guess for slice size
 	notifications := make([]*gtsmodel.Notification, 0, limit)
 
-	q := n.conn.
-		NewSelect().
-		Model(&notifications).
+	q := n.newFilteredNotificationsQ(&notifications, accountID, types, excludeTypes, originAccountID).
 		Column("id")
 
 	if maxID != "" {
@@ -76,9 +74,11 @@ func (n *notificationDB) GetNotifications(ctx context.Context, accountID string,
 		q = q.Where("id > ?", sinceID)
 	}
 
-	q = q.
-		Where("target_account_id = ?", accountID).
-		Order("id DESC")
+	if minID != "" {
+		q = q.Where("id > ?", minID)
+	}
+
+	q = q.Order("id DESC")
 
 	if limit != 0 {
 		q = q.Limit(limit)
@@ -109,6 +109,94 @@ func (n *notificationDB) GetNotifications(ctx context.Context, accountID string,
 	return notifications, nil
 }
 
+func (n *notificationDB) CountNotifications(ctx context.Context, accountID string, types []string, excludeTypes []string, originAccountID string, sinceID string, limit int) (int, db.Error) {
+	// only count up to the limit, so that accounts with huge numbers of notifications don't make this slow
+	inner := n.newFilteredNotificationsQ((*gtsmodel.Notification)(nil), accountID, types, excludeTypes, originAccountID).
+		Column("id")
+
+	if sinceID != "" {
+		inner = inner.Where("id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		inner = inner.Limit(limit)
+	}
+
+	count, err := n.conn.
+		NewSelect().
+		TableExpr("(?) AS notifications", inner).
+		Count(ctx)
+	if err != nil {
+		return 0, n.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (n *notificationDB) DeleteNotification(ctx context.Context, id string) db.Error {
+	if _, err := n.conn.
+		NewDelete().
+		Model(&gtsmodel.Notification{}).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return n.conn.ProcessError(err)
+	}
+
+	n.cache.Remove(id)
+	return nil
+}
+
+func (n *notificationDB) ClearNotifications(ctx context.Context, accountID string) db.Error {
+	ids := []string{}
+	if err := n.conn.
+		NewSelect().
+		Model((*gtsmodel.Notification)(nil)).
+		Column("id").
+		Where("target_account_id = ?", accountID).
+		Scan(ctx, &ids); err != nil {
+		return n.conn.ProcessError(err)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := n.conn.
+		NewDelete().
+		Model(&gtsmodel.Notification{}).
+		Where("id IN (?)", bun.In(ids)).
+		Exec(ctx); err != nil {
+		return n.conn.ProcessError(err)
+	}
+
+	for _, id := range ids {
+		n.cache.Remove(id)
+	}
+	return nil
+}
+
+// newFilteredNotificationsQ returns a query for the notifications targeting the given accountID, filtered by type and origin account.
+func (n *notificationDB) newFilteredNotificationsQ(i interface{}, accountID string, types []string, excludeTypes []string, originAccountID string) *bun.SelectQuery {
+	q := n.conn.
+		NewSelect().
+		Model(i).
+		Where("target_account_id = ?", accountID)
+
+	if len(types) != 0 {
+		q = q.Where("notification_type IN (?)", bun.In(types))
+	}
+
+	if len(excludeTypes) != 0 {
+		q = q.Where("notification_type NOT IN (?)", bun.In(excludeTypes))
+	}
+
+	if originAccountID != "" {
+		q = q.Where("origin_account_id = ?", originAccountID)
+	}
+
+	return q
+}
+
 func (n *notificationDB) getNotificationCache(id string) (*gtsmodel.Notification, bool) {
 	v, ok := n.cache.Get(id)
 	if !ok {
@@ -128,7 +216,7 @@ func (n *notificationDB) putNotificationCache(notif *gtsmodel.Notification) {
 }
 
 func (n *notificationDB) getNotificationDB(ctx context.Context, id string, dst *gtsmodel.Notification) error {
-	q := n.newNotificationQ(dst).WherePK()
+	q := n.newNotificationQ(dst).Where("notification.id = ?", id)
 
 	if err := q.Scan(ctx); err != nil {
 		return n.conn.ProcessError(err)
@@ -25,8 +25,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 func (suite *NotificationTestSuite) spamNotifs() {
@@ -90,7 +92,7 @@ func (suite *NotificationTestSuite) TestGetNotificationsWithSpam() {
 	suite.spamNotifs()
 	testAccount := suite.testAccounts["local_account_1"]
 	before := time.Now()
-	notifications, err := suite.db.GetNotifications(context.Background(), testAccount.ID, 20, "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", "00000000000000000000000000")
+	notifications, err := suite.db.GetNotifications(context.Background(), testAccount.ID, nil, nil, "", 20, "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", "00000000000000000000000000", "")
 	suite.NoError(err)
 	timeTaken := time.Since(before)
 	fmt.Printf("\n\n\n withSpam: got %d notifications in %s\n\n\n", len(notifications), timeTaken)
@@ -104,7 +106,7 @@ func (suite *NotificationTestSuite) TestGetNotificationsWithSpam() {
 func (suite *NotificationTestSuite) TestGetNotificationsWithoutSpam() {
 	testAccount := suite.testAccounts["local_account_1"]
 	before := time.Now()
-	notifications, err := suite.db.GetNotifications(context.Background(), testAccount.ID, 20, "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", "00000000000000000000000000")
+	notifications, err := suite.db.GetNotifications(context.Background(), testAccount.ID, nil, nil, "", 20, "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", "00000000000000000000000000", "")
 	suite.NoError(err)
 	timeTaken := time.Since(before)
 	fmt.Printf("\n\n\n withoutSpam: got %d notifications in %s\n\n\n", len(notifications), timeTaken)
@@ -118,6 +120,114 @@ func (suite *NotificationTestSuite) TestGetNotificationsWithoutSpam() {
 	}
 }
 
+// putFollowNotification puts a follow notification of local_account_1 by local_account_2 in the db.
+func (suite *NotificationTestSuite) putFollowNotification() *gtsmodel.Notification {
+	notif := &gtsmodel.Notification{
+		ID:               "01G4FB0MGM1CBTQ4J2PFRXPJ2D",
+		NotificationType: gtsmodel.NotificationFollow,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  suite.testAccounts["local_account_2"].ID,
+	}
+	if err := suite.db.Put(context.Background(), notif); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return notif
+}
+
+func (suite *NotificationTestSuite) TestGetNotificationsFiltered() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	fave := testrig.NewTestNotifications()["local_account_1_like"]
+	follow := suite.putFollowNotification()
+
+	notifications, err := suite.db.GetNotifications(ctx, testAccount.ID, nil, nil, "", 20, "", "", "")
+	suite.NoError(err)
+	suite.Len(notifications, 2)
+	suite.Equal(follow.ID, notifications[0].ID)
+	suite.Equal(fave.ID, notifications[1].ID)
+
+	notifications, err = suite.db.GetNotifications(ctx, testAccount.ID, []string{"follow", "mention"}, nil, "", 20, "", "", "")
+	suite.NoError(err)
+	suite.Len(notifications, 1)
+	suite.Equal(follow.ID, notifications[0].ID)
+
+	notifications, err = suite.db.GetNotifications(ctx, testAccount.ID, nil, []string{"follow"}, "", 20, "", "", "")
+	suite.NoError(err)
+	suite.Len(notifications, 1)
+	suite.Equal(fave.ID, notifications[0].ID)
+
+	notifications, err = suite.db.GetNotifications(ctx, testAccount.ID, nil, nil, fave.OriginAccountID, 20, "", "", "")
+	suite.NoError(err)
+	suite.Len(notifications, 1)
+	suite.Equal(fave.ID, notifications[0].ID)
+
+	notifications, err = suite.db.GetNotifications(ctx, testAccount.ID, nil, nil, "", 20, "", "", fave.ID)
+	suite.NoError(err)
+	suite.Len(notifications, 1)
+	suite.Equal(follow.ID, notifications[0].ID)
+}
+
+func (suite *NotificationTestSuite) TestCountNotifications() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	fave := testrig.NewTestNotifications()["local_account_1_like"]
+	suite.putFollowNotification()
+
+	count, err := suite.db.CountNotifications(ctx, testAccount.ID, nil, nil, "", "", 100)
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	count, err = suite.db.CountNotifications(ctx, testAccount.ID, nil, nil, "", "", 1)
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	count, err = suite.db.CountNotifications(ctx, testAccount.ID, nil, nil, "", fave.ID, 100)
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	count, err = suite.db.CountNotifications(ctx, testAccount.ID, nil, []string{"follow", "favourite"}, "", "", 100)
+	suite.NoError(err)
+	suite.Equal(0, count)
+}
+
+func (suite *NotificationTestSuite) TestDeleteNotification() {
+	ctx := context.Background()
+	fave := testrig.NewTestNotifications()["local_account_1_like"]
+
+	// get it first so it's cached
+	_, err := suite.db.GetNotification(ctx, fave.ID)
+	suite.NoError(err)
+
+	err = suite.db.DeleteNotification(ctx, fave.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetNotification(ctx, fave.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *NotificationTestSuite) TestClearNotifications() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	follow := suite.putFollowNotification()
+
+	_, err := suite.db.GetNotification(ctx, follow.ID)
+	suite.NoError(err)
+
+	err = suite.db.ClearNotifications(ctx, testAccount.ID)
+	suite.NoError(err)
+
+	notifications, err := suite.db.GetNotifications(ctx, testAccount.ID, nil, nil, "", 20, "", "", "")
+	suite.NoError(err)
+	suite.Empty(notifications)
+
+	_, err = suite.db.GetNotification(ctx, follow.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// clearing nothing is fine too
+	err = suite.db.ClearNotifications(ctx, testAccount.ID)
+	suite.NoError(err)
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, new(NotificationTestSuite))
 }
@@ -0,0 +1,195 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type pollDB struct {
+	conn *DBConn
+}
+
+func (p *pollDB) newPollQ(poll *gtsmodel.Poll) *bun.SelectQuery {
+	return p.conn.
+		NewSelect().
+		Model(poll)
+}
+
+func (p *pollDB) newPollVoteQ(i interface{}) *bun.SelectQuery {
+	return p.conn.
+		NewSelect().
+		Model(i).
+		Relation("Account")
+}
+
+func (p *pollDB) GetPollByID(ctx context.Context, id string) (*gtsmodel.Poll, db.Error) {
+	poll := &gtsmodel.Poll{}
+
+	q := p.newPollQ(poll).
+		Where("poll.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return poll, nil
+}
+
+func (p *pollDB) GetPollByStatusID(ctx context.Context, statusID string) (*gtsmodel.Poll, db.Error) {
+	poll := &gtsmodel.Poll{}
+
+	q := p.newPollQ(poll).
+		Where("poll.status_id = ?", statusID)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return poll, nil
+}
+
+func (p *pollDB) GetExpiredPolls(ctx context.Context, expiredBefore time.Time, limit int) ([]*gtsmodel.Poll, db.Error) {
+	polls := []*gtsmodel.Poll{}
+
+	q := p.conn.
+		NewSelect().
+		Model(&polls).
+		Where("poll.expires_at IS NOT NULL").
+		Where("poll.expires_at < ?", expiredBefore).
+		Where("poll.closed_at IS NULL").
+		Order("poll.expires_at ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return polls, nil
+}
+
+func (p *pollDB) GetPollVotes(ctx context.Context, pollID string) ([]*gtsmodel.PollVote, db.Error) {
+	votes := []*gtsmodel.PollVote{}
+
+	q := p.newPollVoteQ(&votes).
+		Where("poll_vote.poll_id = ?", pollID).
+		Order("poll_vote.created_at ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return votes, nil
+}
+
+func (p *pollDB) GetPollVoteBy(ctx context.Context, pollID string, accountID string) (*gtsmodel.PollVote, db.Error) {
+	vote := &gtsmodel.PollVote{}
+
+	q := p.newPollVoteQ(vote).
+		Where("poll_vote.poll_id = ?", pollID).
+		Where("poll_vote.account_id = ?", accountID)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return vote, nil
+}
+
+func (p *pollDB) PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) db.Error {
+	return p.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(vote).Exec(ctx); err != nil {
+			return err
+		}
+
+		return updatePollCounts(ctx, tx, vote.PollID, vote.Choices, 1)
+	})
+}
+
+func (p *pollDB) AddPollVoteChoice(ctx context.Context, vote *gtsmodel.PollVote, choice int) db.Error {
+	return p.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		vote.Choices = append(vote.Choices, choice)
+		vote.UpdatedAt = time.Now()
+		if _, err := tx.NewUpdate().
+			Model(vote).
+			Column("choices", "updated_at").
+			Where("id = ?", vote.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return updatePollCounts(ctx, tx, vote.PollID, []int{choice}, 0)
+	})
+}
+
+func (p *pollDB) DeletePollByID(ctx context.Context, id string) db.Error {
+	return p.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Model(&gtsmodel.PollVote{}).
+			Where("poll_vote.poll_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			Model(&gtsmodel.Poll{}).
+			Where("poll.id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+// updatePollCounts increments the vote count of each of the given choices in the poll with the given ID,
+// and increments the voters count of the poll by newVoters.
+func updatePollCounts(ctx context.Context, tx bun.Tx, pollID string, choices []int, newVoters int) error {
+	poll := &gtsmodel.Poll{}
+	if err := tx.NewSelect().
+		Model(poll).
+		Where("id = ?", pollID).
+		Scan(ctx); err != nil {
+		return err
+	}
+
+	// make sure we have a count for every option, in case the poll came in without them
+	for len(poll.Votes) < len(poll.Options) {
+		poll.Votes = append(poll.Votes, 0)
+	}
+
+	for _, choice := range choices {
+		if choice < 0 || choice >= len(poll.Votes) {
+			return fmt.Errorf("choice %d out of range for poll %s", choice, pollID)
+		}
+		poll.Votes[choice]++
+	}
+	poll.Voters += newVoters
+	poll.UpdatedAt = time.Now()
+
+	_, err := tx.NewUpdate().
+		Model(poll).
+		Column("votes", "voters", "updated_at").
+		Where("id = ?", pollID).
+		Exec(ctx)
+	return err
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -78,6 +79,108 @@ func (r *relationshipDB) GetBlock(ctx context.Context, account1 string, account2
 	return block, nil
 }
 
+func (r *relationshipDB) newMuteQ(mute interface{}) *bun.SelectQuery {
+	return r.conn.
+		NewSelect().
+		Model(mute).
+		Relation("Account").
+		Relation("TargetAccount")
+}
+
+// whereMuteNotExpired is a bun WhereGroup that selects only mutes which haven't expired at the given time.
+func whereMuteNotExpired(now time.Time) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			WhereOr("user_mute.expires_at IS NULL").
+			WhereOr("user_mute.expires_at > ?", now)
+	}
+}
+
+func (r *relationshipDB) IsMuted(ctx context.Context, account1 string, account2 string, notifications bool) (bool, db.Error) {
+	q := r.conn.
+		NewSelect().
+		Model(&gtsmodel.UserMute{}).
+		Where("user_mute.account_id = ?", account1).
+		Where("user_mute.target_account_id = ?", account2).
+		WhereGroup(" AND ", whereMuteNotExpired(time.Now())).
+		Limit(1)
+
+	if notifications {
+		q = q.Where("user_mute.notifications = ?", true)
+	}
+
+	return r.conn.Exists(ctx, q)
+}
+
+func (r *relationshipDB) GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.UserMute, db.Error) {
+	mute := &gtsmodel.UserMute{}
+
+	q := r.newMuteQ(mute).
+		Where("user_mute.account_id = ?", account1).
+		Where("user_mute.target_account_id = ?", account2)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return mute, nil
+}
+
+func (r *relationshipDB) GetAccountMutes(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.UserMute, string, string, db.Error) {
+	mutes := []*gtsmodel.UserMute{}
+
+	q := r.conn.
+		NewSelect().
+		Model(&mutes).
+		Relation("TargetAccount").
+		Where("user_mute.account_id = ?", accountID).
+		WhereGroup(" AND ", whereMuteNotExpired(time.Now())).
+		Order("user_mute.id DESC")
+
+	if maxID != "" {
+		q = q.Where("user_mute.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("user_mute.id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, "", "", r.conn.ProcessError(err)
+	}
+
+	if len(mutes) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	nextMaxID := mutes[len(mutes)-1].ID
+	prevMinID := mutes[0].ID
+	return mutes, nextMaxID, prevMinID, nil
+}
+
+func (r *relationshipDB) GetExpiredMutes(ctx context.Context, expiredBefore time.Time, limit int) ([]*gtsmodel.UserMute, db.Error) {
+	mutes := []*gtsmodel.UserMute{}
+
+	q := r.conn.
+		NewSelect().
+		Model(&mutes).
+		Where("user_mute.expires_at IS NOT NULL").
+		Where("user_mute.expires_at < ?", expiredBefore).
+		Order("user_mute.expires_at ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return mutes, nil
+}
+
 func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, db.Error) {
 	rel := &gtsmodel.Relationship{
 		ID: targetAccount,
@@ -158,6 +261,24 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	}
 	rel.Requested = count > 0
 
+	// check if the requesting account mutes the target account
+	mute := &gtsmodel.UserMute{}
+	if err := r.conn.
+		NewSelect().
+		Model(mute).
+		Where("user_mute.account_id = ?", requestingAccount).
+		Where("user_mute.target_account_id = ?", targetAccount).
+		WhereGroup(" AND ", whereMuteNotExpired(time.Now())).
+		Limit(1).
+		Scan(ctx); err != nil {
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("getrelationship: error checking muting existence: %s", err)
+		}
+	} else {
+		rel.Muting = true
+		rel.MutingNotifications = mute.Notifications
+	}
+
 	return rel, nil
 }
 
@@ -227,7 +348,9 @@ func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, originAccountI
 		ID:              fr.ID,
 		AccountID:       originAccountID,
 		TargetAccountID: targetAccountID,
+		ShowReblogs:     fr.ShowReblogs,
 		URI:             fr.URI,
+		Notify:          fr.Notify,
 	}
 
 	// if the follow already exists, just update the URI -- we don't need to do anything else
@@ -22,9 +22,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type RelationshipTestSuite struct {
@@ -39,6 +41,103 @@ func (suite *RelationshipTestSuite) TestGetBlock() {
 	suite.Suite.T().Skip("TODO: implement")
 }
 
+func (suite *RelationshipTestSuite) TestIsMuted() {
+	account := suite.testAccounts["local_account_2"]
+	mutedAccount := suite.testAccounts["remote_account_2"]
+
+	muted, err := suite.db.IsMuted(context.Background(), account.ID, mutedAccount.ID, false)
+	suite.NoError(err)
+	suite.True(muted)
+
+	muted, err = suite.db.IsMuted(context.Background(), account.ID, mutedAccount.ID, true)
+	suite.NoError(err)
+	suite.True(muted)
+
+	// mutes only go one way
+	muted, err = suite.db.IsMuted(context.Background(), mutedAccount.ID, account.ID, false)
+	suite.NoError(err)
+	suite.False(muted)
+}
+
+func (suite *RelationshipTestSuite) TestIsMutedStatusesOnly() {
+	mute := &gtsmodel.UserMute{}
+	*mute = *suite.testUserMutes["local_account_2_mute_remote_account_2"]
+	mute.Notifications = false
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), mute))
+
+	muted, err := suite.db.IsMuted(context.Background(), mute.AccountID, mute.TargetAccountID, false)
+	suite.NoError(err)
+	suite.True(muted)
+
+	muted, err = suite.db.IsMuted(context.Background(), mute.AccountID, mute.TargetAccountID, true)
+	suite.NoError(err)
+	suite.False(muted)
+}
+
+func (suite *RelationshipTestSuite) TestIsMutedExpired() {
+	mute := &gtsmodel.UserMute{}
+	*mute = *suite.testUserMutes["local_account_2_mute_remote_account_2"]
+	mute.ExpiresAt = time.Now().Add(-1 * time.Minute)
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), mute))
+
+	muted, err := suite.db.IsMuted(context.Background(), mute.AccountID, mute.TargetAccountID, false)
+	suite.NoError(err)
+	suite.False(muted)
+
+	// the mute still exists until it's lifted
+	dbMute, err := suite.db.GetMute(context.Background(), mute.AccountID, mute.TargetAccountID)
+	suite.NoError(err)
+	suite.Equal(mute.ID, dbMute.ID)
+	suite.True(dbMute.Expired(time.Now()))
+
+	expired, err := suite.db.GetExpiredMutes(context.Background(), time.Now(), 10)
+	suite.NoError(err)
+	suite.Len(expired, 1)
+	suite.Equal(mute.ID, expired[0].ID)
+
+	// expired mutes aren't listed
+	_, _, _, err = suite.db.GetAccountMutes(context.Background(), mute.AccountID, "", "", 10)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RelationshipTestSuite) TestGetAccountMutes() {
+	mute := suite.testUserMutes["local_account_2_mute_remote_account_2"]
+
+	mutes, nextMaxID, prevMinID, err := suite.db.GetAccountMutes(context.Background(), mute.AccountID, "", "", 10)
+	suite.NoError(err)
+	suite.Len(mutes, 1)
+	suite.Equal(mute.ID, mutes[0].ID)
+	suite.Equal(mute.TargetAccountID, mutes[0].TargetAccount.ID)
+	suite.Equal(mute.ID, nextMaxID)
+	suite.Equal(mute.ID, prevMinID)
+
+	expired, err := suite.db.GetExpiredMutes(context.Background(), time.Now(), 10)
+	suite.NoError(err)
+	suite.Empty(expired)
+}
+
+func (suite *RelationshipTestSuite) TestGetRelationshipMuting() {
+	mute := suite.testUserMutes["local_account_2_mute_remote_account_2"]
+
+	relationship, err := suite.db.GetRelationship(context.Background(), mute.AccountID, mute.TargetAccountID)
+	suite.NoError(err)
+	suite.True(relationship.Muting)
+	suite.True(relationship.MutingNotifications)
+
+	relationship, err = suite.db.GetRelationship(context.Background(), mute.TargetAccountID, mute.AccountID)
+	suite.NoError(err)
+	suite.False(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+
+	// lifting the mute should be reflected in the relationship
+	suite.NoError(suite.db.DeleteByID(context.Background(), mute.ID, &gtsmodel.UserMute{}))
+
+	relationship, err = suite.db.GetRelationship(context.Background(), mute.AccountID, mute.TargetAccountID)
+	suite.NoError(err)
+	suite.False(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+}
+
 func (suite *RelationshipTestSuite) TestGetRelationship() {
 	suite.Suite.T().Skip("TODO: implement")
 }
@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type reportDB struct {
+	conn *DBConn
+}
+
+func (r *reportDB) newReportQ(i interface{}) *bun.SelectQuery {
+	return r.conn.
+		NewSelect().
+		Model(i).
+		Relation("Account").
+		Relation("TargetAccount").
+		Relation("AssignedAccount").
+		Relation("ActionTakenByAccount")
+}
+
+func (r *reportDB) GetReportByID(ctx context.Context, id string) (*gtsmodel.Report, db.Error) {
+	report := &gtsmodel.Report{}
+
+	q := r.newReportQ(report).
+		Where("report.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return report, nil
+}
+
+func (r *reportDB) GetReportByURI(ctx context.Context, uri string) (*gtsmodel.Report, db.Error) {
+	report := &gtsmodel.Report{}
+
+	q := r.newReportQ(report).
+		Where("report.uri = ?", uri)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return report, nil
+}
+
+func (r *reportDB) GetReports(ctx context.Context, resolved *bool, accountID string, targetAccountID string, maxID string, limit int) ([]*gtsmodel.Report, db.Error) {
+	reports := []*gtsmodel.Report{}
+
+	q := r.newReportQ(&reports).
+		Order("report.id DESC")
+
+	if resolved != nil {
+		if *resolved {
+			q = q.Where("report.action_taken_at IS NOT NULL")
+		} else {
+			q = q.Where("report.action_taken_at IS NULL")
+		}
+	}
+
+	if accountID != "" {
+		q = q.Where("report.account_id = ?", accountID)
+	}
+
+	if targetAccountID != "" {
+		q = q.Where("report.target_account_id = ?", targetAccountID)
+	}
+
+	if maxID != "" {
+		q = q.Where("report.id < ?", maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return reports, nil
+}
+
+func (r *reportDB) PutReport(ctx context.Context, report *gtsmodel.Report) db.Error {
+	if _, err := r.conn.
+		NewInsert().
+		Model(report).
+		Exec(ctx); err != nil {
+		return r.conn.ProcessError(err)
+	}
+	return nil
+}
+
+func (r *reportDB) UpdateReport(ctx context.Context, report *gtsmodel.Report) db.Error {
+	report.UpdatedAt = time.Now()
+
+	if _, err := r.conn.
+		NewUpdate().
+		Model(report).
+		WherePK().
+		Exec(ctx); err != nil {
+		return r.conn.ProcessError(err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ReportTestSuite) TestGetReportByID() {
+	testReport := testrig.NewTestReports()["local_account_2_report_remote_account_1"]
+
+	report, err := suite.db.GetReportByID(context.Background(), testReport.ID)
+	suite.NoError(err)
+	suite.Equal(testReport.URI, report.URI)
+	suite.Equal(testReport.StatusIDs, report.StatusIDs)
+	suite.NotNil(report.Account)
+	suite.Equal(testReport.AccountID, report.Account.ID)
+	suite.NotNil(report.TargetAccount)
+	suite.Equal(testReport.TargetAccountID, report.TargetAccount.ID)
+	suite.Nil(report.AssignedAccount)
+	suite.False(report.Resolved())
+}
+
+func (suite *ReportTestSuite) TestGetReportsFiltered() {
+	ctx := context.Background()
+	testReport := testrig.NewTestReports()["local_account_2_report_remote_account_1"]
+	resolved := true
+	unresolved := false
+
+	reports, err := suite.db.GetReports(ctx, &unresolved, "", testReport.TargetAccountID, "", 0)
+	suite.NoError(err)
+	suite.Len(reports, 1)
+
+	reports, err = suite.db.GetReports(ctx, &resolved, "", "", "", 0)
+	suite.NoError(err)
+	suite.Empty(reports)
+
+	reports, err = suite.db.GetReports(ctx, nil, suite.testAccounts["local_account_1"].ID, "", "", 0)
+	suite.NoError(err)
+	suite.Empty(reports)
+}
+
+func (suite *ReportTestSuite) TestPutResolveReport() {
+	ctx := context.Background()
+	reporter := suite.testAccounts["local_account_1"]
+	target := suite.testAccounts["remote_account_1"]
+	admin := suite.testAccounts["admin_account"]
+
+	report := &gtsmodel.Report{
+		ID:              "01G2HSGM3RP5WX3S4SCV1X7QPE",
+		URI:             "http://localhost:8080/reports/01G2HSGM3RP5WX3S4SCV1X7QPE",
+		AccountID:       reporter.ID,
+		TargetAccountID: target.ID,
+		Comment:         "spam spam spam",
+		Category:        gtsmodel.ReportCategorySpam,
+	}
+	suite.NoError(suite.db.PutReport(ctx, report))
+
+	_, err := suite.db.GetReportByURI(ctx, report.URI)
+	suite.NoError(err)
+
+	report.ActionTakenAt = time.Now()
+	report.ActionTakenByAccountID = admin.ID
+	suite.NoError(suite.db.UpdateReport(ctx, report))
+
+	dbReport, err := suite.db.GetReportByID(ctx, report.ID)
+	suite.NoError(err)
+	suite.True(dbReport.Resolved())
+	suite.NotNil(dbReport.ActionTakenByAccount)
+	suite.Equal(admin.ID, dbReport.ActionTakenByAccount.ID)
+	suite.Empty(dbReport.StatusIDs)
+
+	_, err = suite.db.GetReportByID(ctx, "01G2HSNQ4HNR4P1R9NGPJ3XW6V")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
@@ -0,0 +1,182 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	conn *DBConn
+}
+
+func (s *scheduledStatusDB) newScheduledStatusQ(i interface{}) *bun.SelectQuery {
+	return s.conn.
+		NewSelect().
+		Model(i).
+		Relation("Account").
+		Relation("Attachments")
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, db.Error) {
+	scheduledStatus := &gtsmodel.ScheduledStatus{}
+
+	q := s.newScheduledStatusQ(scheduledStatus).
+		Where("scheduled_status.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return scheduledStatus, nil
+}
+
+func (s *scheduledStatusDB) GetAccountScheduledStatuses(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ScheduledStatus, db.Error) {
+	scheduledStatuses := []*gtsmodel.ScheduledStatus{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&scheduledStatuses).
+		Relation("Attachments").
+		Where("scheduled_status.account_id = ?", accountID).
+		Order("scheduled_status.id DESC")
+
+	if maxID != "" {
+		q = q.Where("scheduled_status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("scheduled_status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("scheduled_status.id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return scheduledStatuses, nil
+}
+
+func (s *scheduledStatusDB) CountAccountScheduledStatuses(ctx context.Context, accountID string, after time.Time, before time.Time) (int, db.Error) {
+	q := s.conn.
+		NewSelect().
+		Model(&gtsmodel.ScheduledStatus{}).
+		Where("scheduled_status.account_id = ?", accountID)
+
+	if !after.IsZero() {
+		q = q.Where("scheduled_status.scheduled_at >= ?", after)
+	}
+
+	if !before.IsZero() {
+		q = q.Where("scheduled_status.scheduled_at < ?", before)
+	}
+
+	count, err := q.Count(ctx)
+	if err != nil {
+		return 0, s.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (s *scheduledStatusDB) GetDueScheduledStatuses(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.ScheduledStatus, db.Error) {
+	scheduledStatuses := []*gtsmodel.ScheduledStatus{}
+
+	q := s.newScheduledStatusQ(&scheduledStatuses).
+		Where("scheduled_status.scheduled_at <= ?", now).
+		Order("scheduled_status.scheduled_at ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return scheduledStatuses, nil
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) db.Error {
+	return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// mark the media attachments as belonging to this scheduled status,
+		// so that they can't be used anywhere else in the meantime
+		for _, a := range scheduledStatus.Attachments {
+			a.ScheduledStatusID = scheduledStatus.ID
+			a.UpdatedAt = time.Now()
+			if _, err := tx.
+				NewUpdate().
+				Model(a).
+				Column("scheduled_status_id", "updated_at").
+				Where("media_attachment.id = ?", a.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.
+			NewInsert().
+			Model(scheduledStatus).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) db.Error {
+	scheduledStatus.UpdatedAt = time.Now()
+
+	if _, err := s.conn.
+		NewUpdate().
+		Model(scheduledStatus).
+		WherePK().
+		Exec(ctx); err != nil {
+		return s.conn.ProcessError(err)
+	}
+	return nil
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) db.Error {
+	return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// release any media attachments so they're not left orphaned
+		if _, err := tx.
+			NewUpdate().
+			Model(&gtsmodel.MediaAttachment{}).
+			Set("scheduled_status_id = NULL").
+			Set("updated_at = ?", time.Now()).
+			Where("media_attachment.scheduled_status_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			Model(&gtsmodel.ScheduledStatus{}).
+			Where("scheduled_status.id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetScheduledStatusByID() {
+	testScheduledStatus := testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"]
+
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(context.Background(), testScheduledStatus.ID)
+	suite.NoError(err)
+	suite.Equal(testScheduledStatus.Text, scheduledStatus.Text)
+	suite.NotNil(scheduledStatus.Account)
+	suite.Equal(testScheduledStatus.AccountID, scheduledStatus.Account.ID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetAccountScheduledStatuses() {
+	scheduledStatuses, err := suite.db.GetAccountScheduledStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(scheduledStatuses, 1)
+
+	scheduledStatuses, err = suite.db.GetAccountScheduledStatuses(context.Background(), suite.testAccounts["local_account_2"].ID, "", "", "", 20)
+	suite.NoError(err)
+	suite.Empty(scheduledStatuses)
+}
+
+func (suite *ScheduledStatusTestSuite) TestCountAccountScheduledStatuses() {
+	testScheduledStatus := testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"]
+
+	total, err := suite.db.CountAccountScheduledStatuses(context.Background(), testScheduledStatus.AccountID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(1, total)
+
+	day := testScheduledStatus.ScheduledAt.Truncate(24 * time.Hour)
+	daily, err := suite.db.CountAccountScheduledStatuses(context.Background(), testScheduledStatus.AccountID, day, day.Add(24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, daily)
+
+	daily, err = suite.db.CountAccountScheduledStatuses(context.Background(), testScheduledStatus.AccountID, day.Add(24*time.Hour), day.Add(48*time.Hour))
+	suite.NoError(err)
+	suite.Equal(0, daily)
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetDueScheduledStatuses() {
+	testScheduledStatus := testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"]
+
+	due, err := suite.db.GetDueScheduledStatuses(context.Background(), time.Now(), 20)
+	suite.NoError(err)
+	suite.Empty(due)
+
+	due, err = suite.db.GetDueScheduledStatuses(context.Background(), testScheduledStatus.ScheduledAt.Add(time.Minute), 20)
+	suite.NoError(err)
+	suite.Len(due, 1)
+	suite.NotNil(due[0].Account)
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutAndDeleteScheduledStatusWithMedia() {
+	ctx := context.Background()
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:            "01G37Q8YZ5V6TDKT6R2XQNF6YB",
+		ScheduledAt:   time.Now().Add(time.Hour),
+		AccountID:     attachment.AccountID,
+		ApplicationID: suite.testApplications["application_1"].ID,
+		Text:          "this one has media",
+		AttachmentIDs: []string{attachment.ID},
+		Attachments:   []*gtsmodel.MediaAttachment{attachment},
+		Visibility:    gtsmodel.VisibilityPublic,
+	}
+	suite.NoError(suite.db.PutScheduledStatus(ctx, scheduledStatus))
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(scheduledStatus.ID, dbAttachment.ScheduledStatusID)
+
+	suite.NoError(suite.db.DeleteScheduledStatusByID(ctx, scheduledStatus.ID))
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// the attachment should still exist, but no longer belong to the scheduled status
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbAttachment.ScheduledStatusID)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
@@ -168,12 +168,117 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Er
 			}
 		}
 
+		// insert the poll attached to this status, if there is one
+		if status.Poll != nil {
+			status.Poll.StatusID = status.ID
+			if _, err := tx.NewInsert().Model(status.Poll).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
 		// Finally, insert the status
 		_, err := tx.NewInsert().Model(status).Exec(ctx)
 		return err
 	})
 }
 
+func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, previous *gtsmodel.StatusEdit) db.Error {
+	err := s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// store the previous revision of this status, if we were given one
+		if previous != nil {
+			previous.StatusID = status.ID
+			if _, err := tx.NewInsert().Model(previous).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// recreate links between this status and any emojis it uses
+		if _, err := tx.NewDelete().
+			Model(&gtsmodel.StatusToEmoji{}).
+			Where("status_id = ?", status.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, i := range status.EmojiIDs {
+			if _, err := tx.NewInsert().Model(&gtsmodel.StatusToEmoji{
+				StatusID: status.ID,
+				EmojiID:  i,
+			}).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// recreate links between this status and any tags it uses
+		if _, err := tx.NewDelete().
+			Model(&gtsmodel.StatusToTag{}).
+			Where("status_id = ?", status.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, i := range status.TagIDs {
+			if _, err := tx.NewInsert().Model(&gtsmodel.StatusToTag{
+				StatusID: status.ID,
+				TagID:    i,
+			}).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// detach any media attachments that were removed in this edit; we keep the
+		// attachments themselves around, since previous revisions may still refer to them
+		detachQ := tx.NewUpdate().
+			Model(&gtsmodel.MediaAttachment{}).
+			Set("status_id = NULL").
+			Where("status_id = ?", status.ID)
+		if len(status.AttachmentIDs) != 0 {
+			detachQ = detachQ.Where("id NOT IN (?)", bun.In(status.AttachmentIDs))
+		}
+		if _, err := detachQ.Exec(ctx); err != nil {
+			return err
+		}
+
+		// change the status ID of any newly added media attachments to this status
+		for _, a := range status.Attachments {
+			a.StatusID = status.ID
+			a.UpdatedAt = time.Now()
+			if _, err := tx.NewUpdate().Model(a).
+				Where("id = ?", a.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Finally, update the status itself
+		status.UpdatedAt = time.Now()
+		_, err := tx.NewUpdate().Model(status).WherePK().Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// make sure we don't serve the old version from the cache
+	s.cache.Put(status)
+	return nil
+}
+
+func (s *statusDB) GetStatusEdits(ctx context.Context, statusID string) ([]*gtsmodel.StatusEdit, db.Error) {
+	edits := []*gtsmodel.StatusEdit{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&edits).
+		Where("status_edit.status_id = ?", statusID).
+		Order("status_edit.created_at ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return edits, nil
+}
+
 func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status, onlyDirect bool) ([]*gtsmodel.Status, db.Error) {
 	parents := []*gtsmodel.Status{}
 	s.statusParent(ctx, status, &parents, onlyDirect)
@@ -287,11 +392,38 @@ func (s *statusDB) IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Sta
 	return s.conn.Exists(ctx, q)
 }
 
+func (s *statusDB) GetStatusThreadRoot(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Status, db.Error) {
+	root := status
+
+	// keep track of where we've been, so that a malformed thread can't send us round in circles
+	seen := map[string]bool{root.ID: true}
+	for root.InReplyToID != "" && !seen[root.InReplyToID] {
+		parent, err := s.GetStatusByID(ctx, root.InReplyToID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// we don't have the rest of the thread, so this is as far up as we can go
+				break
+			}
+			return nil, err
+		}
+		seen[parent.ID] = true
+		root = parent
+	}
+
+	return root, nil
+}
+
 func (s *statusDB) IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
+	// mutes are stored against the root of the thread they apply to
+	root, err := s.GetStatusThreadRoot(ctx, status)
+	if err != nil {
+		return false, err
+	}
+
 	q := s.conn.
 		NewSelect().
 		Model(&gtsmodel.StatusMute{}).
-		Where("status_id = ?", status.ID).
+		Where("status_id = ?", root.ID).
 		Where("account_id = ?", accountID)
 
 	return s.conn.Exists(ctx, q)
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type StatusTestSuite struct {
@@ -132,6 +133,108 @@ func (suite *StatusTestSuite) TestGetStatusChildren() {
 	}
 }
 
+func (suite *StatusTestSuite) TestGetStatusThreadRoot() {
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+
+	root, err := suite.db.GetStatusThreadRoot(context.Background(), suite.testStatuses["local_account_2_status_5"])
+	suite.NoError(err)
+	suite.Equal(rootStatus.ID, root.ID)
+
+	// a status that isn't a reply is its own root
+	root, err = suite.db.GetStatusThreadRoot(context.Background(), rootStatus)
+	suite.NoError(err)
+	suite.Equal(rootStatus.ID, root.ID)
+}
+
+func (suite *StatusTestSuite) TestIsStatusMutedByThread() {
+	ctx := context.Background()
+
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+	reply := suite.testStatuses["local_account_2_status_5"]
+	mutingAccount := suite.testAccounts["local_account_1"]
+
+	muted, err := suite.db.IsStatusMutedBy(ctx, reply, mutingAccount.ID)
+	suite.NoError(err)
+	suite.False(muted)
+
+	err = suite.db.Put(ctx, &gtsmodel.StatusMute{
+		ID:              "01G3KB3F2JQ4Z8CS2SRSRZTEYA",
+		AccountID:       mutingAccount.ID,
+		TargetAccountID: rootStatus.AccountID,
+		StatusID:        rootStatus.ID,
+	})
+	suite.NoError(err)
+
+	// a mute on the root covers every reply in the thread
+	muted, err = suite.db.IsStatusMutedBy(ctx, reply, mutingAccount.ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	muted, err = suite.db.IsStatusMutedBy(ctx, suite.testStatuses["admin_account_status_3"], mutingAccount.ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	// but not for anyone else
+	muted, err = suite.db.IsStatusMutedBy(ctx, reply, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.False(muted)
+}
+
+func (suite *StatusTestSuite) TestUpdateStatus() {
+	ctx := context.Background()
+
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["admin_account_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	previous := &gtsmodel.StatusEdit{
+		ID:            "01G0CFG7BKXN8VNSJBXFTDA5TX",
+		CreatedAt:     status.CreatedAt,
+		Content:       status.Content,
+		Text:          status.Text,
+		Language:      status.Language,
+		AttachmentIDs: status.AttachmentIDs,
+	}
+
+	status.Content = "hello world! this post has been edited"
+	status.AttachmentIDs = []string{}
+	status.Attachments = []*gtsmodel.MediaAttachment{}
+	status.TagIDs = []string{}
+	status.EmojiIDs = []string{}
+	status.EditedAt = time.Now()
+
+	if err := suite.db.UpdateStatus(ctx, status, previous); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// the cached status should be the new version
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("hello world! this post has been edited", dbStatus.Content)
+	suite.Empty(dbStatus.AttachmentIDs)
+	suite.False(dbStatus.EditedAt.IsZero())
+
+	// the removed attachment should no longer point to the status
+	attachment, err := suite.db.GetAttachmentByID(ctx, "01F8MH6NEM8D7527KZAECTCR76")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(attachment.StatusID)
+
+	// the previous version should be stored as a revision
+	edits, err := suite.db.GetStatusEdits(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(edits, 1)
+	suite.Equal(status.ID, edits[0].StatusID)
+	suite.Equal("hello world! #welcome ! first post on the instance :rainbow: !", edits[0].Content)
+	suite.Equal([]string{"01F8MH6NEM8D7527KZAECTCR76"}, edits[0].AttachmentIDs)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
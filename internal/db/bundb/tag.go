@@ -0,0 +1,88 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type tagDB struct {
+	conn *DBConn
+}
+
+func (t *tagDB) GetTagByID(ctx context.Context, id string) (*gtsmodel.Tag, db.Error) {
+	tag := &gtsmodel.Tag{}
+
+	q := t.conn.
+		NewSelect().
+		Model(tag).
+		Where("tag.id = ?", id)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tag, nil
+}
+
+func (t *tagDB) GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, db.Error) {
+	tag := &gtsmodel.Tag{}
+
+	q := t.conn.
+		NewSelect().
+		Model(tag).
+		Where("LOWER(?) = LOWER(?)", bun.Ident("tag.name"), name)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tag, nil
+}
+
+func (t *tagDB) IsFollowingTag(ctx context.Context, accountID string, tagID string) (bool, db.Error) {
+	q := t.conn.
+		NewSelect().
+		Model(&gtsmodel.TagFollow{}).
+		Where("tag_follow.account_id = ?", accountID).
+		Where("tag_follow.tag_id = ?", tagID).
+		Limit(1)
+
+	return t.conn.Exists(ctx, q)
+}
+
+func (t *tagDB) GetTagFollows(ctx context.Context, tagIDs []string) ([]*gtsmodel.TagFollow, db.Error) {
+	tagFollows := []*gtsmodel.TagFollow{}
+
+	if len(tagIDs) == 0 {
+		return tagFollows, nil
+	}
+
+	q := t.conn.
+		NewSelect().
+		Model(&tagFollows).
+		Where("tag_follow.tag_id IN (?)", bun.In(tagIDs))
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tagFollows, nil
+}
@@ -46,11 +46,24 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		Model(&statuses)
 
 	q = q.ColumnExpr("status.*").
-		// Find out who accountID follows.
-		Join("LEFT JOIN follows AS f ON f.target_account_id = status.account_id").
 		// Sort by highest ID (newest) to lowest ID (oldest)
 		Order("status.id DESC")
 
+	// Find out who accountID follows.
+	followedAccountIDs := t.conn.
+		NewSelect().
+		Model((*gtsmodel.Follow)(nil)).
+		Column("follow.target_account_id").
+		Where("follow.account_id = ?", accountID)
+
+	// Find out which statuses use a hashtag that accountID follows.
+	followedTagStatusIDs := t.conn.
+		NewSelect().
+		Model((*gtsmodel.StatusToTag)(nil)).
+		Column("status_to_tag.status_id").
+		Join("JOIN tag_follows AS tag_follow ON tag_follow.tag_id = status_to_tag.tag_id").
+		Where("tag_follow.account_id = ?", accountID)
+
 	if maxID != "" {
 		// return only statuses LOWER (ie., older) than maxID
 		q = q.Where("status.id < ?", maxID)
@@ -77,14 +90,20 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	}
 
 	// Use a WhereGroup here to specify that we want EITHER statuses posted by accounts that accountID follows,
-	// OR statuses posted by accountID itself (since a user should be able to see their own statuses).
+	// OR statuses posted by accountID itself (since a user should be able to see their own statuses),
+	// OR public statuses that use a hashtag accountID follows (the same ones that are streamed to their home timeline).
 	//
-	// This is equivalent to something like WHERE ... AND (... OR ...)
+	// This is equivalent to something like WHERE ... AND (... OR ... OR (... AND ...))
 	// See: https://bun.uptrace.dev/guide/queries.html#select
 	whereGroup := func(*bun.SelectQuery) *bun.SelectQuery {
 		return q.
-			WhereOr("f.account_id = ?", accountID).
-			WhereOr("status.account_id = ?", accountID)
+			WhereOr("status.account_id IN (?)", followedAccountIDs).
+			WhereOr("status.account_id = ?", accountID).
+			WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("status.visibility = ?", gtsmodel.VisibilityPublic).
+					Where("status.id IN (?)", followedTagStatusIDs)
+			})
 	}
 
 	q = q.WhereGroup(" AND ", whereGroup)
@@ -139,6 +158,71 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, ma
 	return statuses, nil
 }
 
+func (t *timelineDB) GetTagTimeline(ctx context.Context, accountID string, anyTagIDs []string, allTagIDs []string, noneTagIDs []string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	// there's nothing to match against
+	if len(anyTagIDs) == 0 {
+		return statuses, nil
+	}
+
+	// statusIDsWithTags selects the IDs of statuses that use any of the given tags
+	statusIDsWithTags := func(tagIDs []string) *bun.SelectQuery {
+		return t.conn.
+			NewSelect().
+			Model((*gtsmodel.StatusToTag)(nil)).
+			Column("status_to_tag.status_id").
+			Where("status_to_tag.tag_id IN (?)", bun.In(tagIDs))
+	}
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("visibility = ?", gtsmodel.VisibilityPublic).
+		WhereGroup(" AND ", whereEmptyOrNull("boost_of_id")).
+		Where("status.id IN (?)", statusIDsWithTags(anyTagIDs)).
+		Order("status.id DESC")
+
+	for _, tagID := range allTagIDs {
+		q = q.Where("status.id IN (?)", statusIDsWithTags([]string{tagID}))
+	}
+
+	if len(noneTagIDs) != 0 {
+		q = q.Where("status.id NOT IN (?)", statusIDsWithTags(noneTagIDs))
+	}
+
+	if maxID != "" {
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if local {
+		q = q.Where("status.local = ?", local)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
+
 // TODO optimize this query and the logic here, because it's slow as balls -- it takes like a literal second to return with a limit of 20!
 // It might be worth serving it through a timeline instead of raw DB queries, like we do for Home feeds.
 func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, db.Error) {
@@ -214,3 +298,116 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 	prevMinID := faves[0].ID
 	return statuses, nextMaxID, prevMinID, nil
 }
+
+func (t *timelineDB) GetBookmarkedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, limit)
+
+	bq := t.conn.
+		NewSelect().
+		Model(&bookmarks).
+		Where("account_id = ?", accountID).
+		Order("id DESC")
+
+	if maxID != "" {
+		bq = bq.Where("id < ?", maxID)
+	}
+
+	if minID != "" {
+		bq = bq.Where("id > ?", minID)
+	}
+
+	if limit > 0 {
+		bq = bq.Limit(limit)
+	}
+
+	if err := bq.Scan(ctx); err != nil {
+		return nil, "", "", t.conn.ProcessError(err)
+	}
+
+	if len(bookmarks) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	// map[statusID]bookmarkID -- we need this to sort statuses by bookmark ID rather than status ID
+	statusesBookmarksMap := make(map[string]string, len(bookmarks))
+	statusIDs := make([]string, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		statusesBookmarksMap[b.StatusID] = b.ID
+		statusIDs = append(statusIDs, b.StatusID)
+	}
+
+	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
+
+	if err := t.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("id IN (?)", bun.In(statusIDs)).
+		Scan(ctx); err != nil {
+		return nil, "", "", t.conn.ProcessError(err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	// arrange statuses by bookmark ID, most recently bookmarked first
+	sort.Slice(statuses, func(i int, j int) bool {
+		return statusesBookmarksMap[statuses[i].ID] > statusesBookmarksMap[statuses[j].ID]
+	})
+
+	nextMaxID := bookmarks[len(bookmarks)-1].ID
+	prevMinID := bookmarks[0].ID
+	return statuses, nextMaxID, prevMinID, nil
+}
+
+func (t *timelineDB) GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		ColumnExpr("status.*").
+		// Find out which accounts are in the list, by way of the follows that the list entries point to.
+		Join("JOIN follows AS f ON f.target_account_id = status.account_id").
+		Join("JOIN list_entries AS le ON le.follow_id = f.id").
+		Where("le.list_id = ?", listID).
+		// Sort by highest ID (newest) to lowest ID (oldest)
+		Order("status.id DESC")
+
+	if maxID != "" {
+		// return only statuses LOWER (ie., older) than maxID
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		// return only statuses HIGHER (ie., newer) than sinceID
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		// return only statuses HIGHER (ie., newer) than minID
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if limit > 0 {
+		// limit amount of statuses returned
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
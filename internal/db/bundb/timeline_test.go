@@ -23,12 +23,29 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type TimelineTestSuite struct {
 	BunDBStandardTestSuite
 }
 
+func (suite *TimelineTestSuite) TestGetHomeTimelineFollowedTag() {
+	// 1happyturtle doesn't follow admin, but does follow #welcome, which admin_account_status_1 uses
+	viewingAccount := suite.testAccounts["local_account_2"]
+
+	s, err := suite.db.GetHomeTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false)
+	suite.NoError(err)
+
+	ids := map[string]bool{}
+	for _, status := range s {
+		suite.False(ids[status.ID], "status %s appears more than once", status.ID)
+		ids[status.ID] = true
+		suite.True(status.AccountID == viewingAccount.ID || status.ID == suite.testStatuses["admin_account_status_1"].ID)
+	}
+	suite.True(ids[suite.testStatuses["admin_account_status_1"].ID])
+}
+
 func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	viewingAccount := suite.testAccounts["local_account_1"]
 
@@ -38,6 +55,45 @@ func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	suite.Len(s, 6)
 }
 
+func (suite *TimelineTestSuite) TestGetTagTimeline() {
+	viewingAccount := suite.testAccounts["local_account_1"]
+	welcome := suite.testTags["welcome"]
+	hashtag := suite.testTags["Hashtag"]
+
+	// only admin_account_status_1 uses #welcome
+	s, err := suite.db.GetTagTimeline(context.Background(), viewingAccount.ID, []string{welcome.ID}, nil, nil, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Len(s, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, s[0].ID)
+
+	// no statuses use both #welcome and #Hashtag
+	s, err = suite.db.GetTagTimeline(context.Background(), viewingAccount.ID, []string{welcome.ID}, []string{hashtag.ID}, nil, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Empty(s)
+
+	// the status is left out if it uses a tag it shouldn't
+	s, err = suite.db.GetTagTimeline(context.Background(), viewingAccount.ID, []string{welcome.ID, hashtag.ID}, nil, []string{welcome.ID}, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Empty(s)
+}
+
+func (suite *TimelineTestSuite) TestGetTagTimelineReplies() {
+	viewingAccount := suite.testAccounts["local_account_1"]
+	welcome := suite.testTags["welcome"]
+	reply := suite.testStatuses["admin_account_status_3"]
+
+	// tag a public reply with #welcome as well
+	err := suite.db.Put(context.Background(), &gtsmodel.StatusToTag{StatusID: reply.ID, TagID: welcome.ID})
+	suite.NoError(err)
+
+	// replies are included in hashtag timelines
+	s, err := suite.db.GetTagTimeline(context.Background(), viewingAccount.ID, []string{welcome.ID}, nil, nil, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Len(s, 2)
+	suite.Equal(reply.ID, s[0].ID)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, s[1].ID)
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
@@ -0,0 +1,90 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type webPushDB struct {
+	conn *DBConn
+}
+
+func (w *webPushDB) GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, db.Error) {
+	keyPair := &gtsmodel.VAPIDKeyPair{}
+
+	q := w.conn.
+		NewSelect().
+		Model(keyPair).
+		Order("vapid_key_pair.id ASC").
+		Limit(1)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+	return keyPair, nil
+}
+
+func (w *webPushDB) GetPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.PushSubscription, db.Error) {
+	subscription := &gtsmodel.PushSubscription{}
+
+	q := w.conn.
+		NewSelect().
+		Model(subscription).
+		Where("push_subscription.token_id = ?", tokenID)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+	return subscription, nil
+}
+
+func (w *webPushDB) GetPushSubscriptionsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.PushSubscription, db.Error) {
+	subscriptions := []*gtsmodel.PushSubscription{}
+
+	q := w.conn.
+		NewSelect().
+		Model(&subscriptions).
+		Where("push_subscription.account_id = ?", accountID)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+	return subscriptions, nil
+}
+
+func (w *webPushDB) PutPushSubscription(ctx context.Context, subscription *gtsmodel.PushSubscription) db.Error {
+	return w.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// a token only ever has one subscription, so creating a new one replaces the old
+		if _, err := tx.
+			NewDelete().
+			Model(&gtsmodel.PushSubscription{}).
+			Where("token_id = ?", subscription.TokenID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().Model(subscription).Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,91 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type WebPushTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebPushTestSuite) newSubscription(id string, tokenID string) *gtsmodel.PushSubscription {
+	return &gtsmodel.PushSubscription{
+		ID:           id,
+		AccountID:    suite.testAccounts["local_account_1"].ID,
+		TokenID:      tokenID,
+		Endpoint:     "https://push.example.org/send/" + id,
+		P256dh:       "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:         "BTBZMqHH6r4Tts7J_aSIgg",
+		AlertMention: true,
+	}
+}
+
+func (suite *WebPushTestSuite) TestPutPushSubscription() {
+	tokenID := suite.testTokens["local_account_1"].ID
+
+	err := suite.db.PutPushSubscription(context.Background(), suite.newSubscription("01G4A7GNP8ZE4R4QMCDQ9SKVF8", tokenID))
+	suite.NoError(err)
+
+	subscription, err := suite.db.GetPushSubscriptionByTokenID(context.Background(), tokenID)
+	suite.NoError(err)
+	suite.Equal("01G4A7GNP8ZE4R4QMCDQ9SKVF8", subscription.ID)
+	suite.True(subscription.AlertMention)
+	suite.False(subscription.AlertFollow)
+
+	// a second subscription for the same token replaces the first
+	err = suite.db.PutPushSubscription(context.Background(), suite.newSubscription("01G4A7KS5WKRN7YMJVD7Z6X9BD", tokenID))
+	suite.NoError(err)
+
+	subscriptions, err := suite.db.GetPushSubscriptionsForAccountID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Len(subscriptions, 1)
+	suite.Equal("01G4A7KS5WKRN7YMJVD7Z6X9BD", subscriptions[0].ID)
+
+	_, err = suite.db.GetPushSubscriptionByTokenID(context.Background(), suite.testTokens["local_account_2"].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *WebPushTestSuite) TestGetVAPIDKeyPair() {
+	_, err := suite.db.GetVAPIDKeyPair(context.Background())
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	for _, keyPair := range []*gtsmodel.VAPIDKeyPair{
+		{ID: "01G4A8BS3T8CPNNX5G8TDHRGPD", PublicKey: "newer-public", PrivateKey: "newer-private"},
+		{ID: "01G4A8AY7Z1QAC4E2TSXQ6V2R8", PublicKey: "older-public", PrivateKey: "older-private"},
+	} {
+		suite.NoError(suite.db.Put(context.Background(), keyPair))
+	}
+
+	// the oldest keypair wins
+	keyPair, err := suite.db.GetVAPIDKeyPair(context.Background())
+	suite.NoError(err)
+	suite.Equal("older-public", keyPair.PublicKey)
+	suite.Equal("older-private", keyPair.PrivateKey)
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPushTestSuite))
+}
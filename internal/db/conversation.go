@@ -0,0 +1,45 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Conversation contains functions for getting and storing direct message conversations.
+type Conversation interface {
+	// GetConversationByID gets a single conversation by its ID.
+	GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, Error)
+	// GetConversationByThreadAndOtherAccounts gets the conversation of the given account in the given thread,
+	// with the participants given by otherAccountsKey.
+	GetConversationByThreadAndOtherAccounts(ctx context.Context, accountID string, threadID string, otherAccountsKey string) (*gtsmodel.Conversation, Error)
+	// GetAccountConversations gets limit n conversations of the given account.
+	// Conversations are returned in descending order of their last status (newest first), and paged by last status ID.
+	GetAccountConversations(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Conversation, Error)
+	// PutConversationStatus stores the given new or updated conversation, and adds the status with the given ID to it.
+	PutConversationStatus(ctx context.Context, conversation *gtsmodel.Conversation, statusID string) Error
+	// DeleteConversationByID deletes the conversation with the given ID.
+	DeleteConversationByID(ctx context.Context, id string) Error
+	// DeleteStatusFromConversations removes the status with the given ID from any conversations it belongs to.
+	// Conversations that it was the last status of get the status before it as their last status instead,
+	// and conversations with no statuses left are deleted.
+	DeleteStatusFromConversations(ctx context.Context, statusID string) Error
+}
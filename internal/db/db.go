@@ -34,15 +34,25 @@ type DB interface {
 	Account
 	Admin
 	Basic
+	Conversation
+	Delivery
 	Domain
+	Filter
 	Instance
+	List
+	Marker
 	Media
 	Mention
 	Notification
+	Poll
 	Relationship
+	Report
+	ScheduledStatus
 	Session
 	Status
+	Tag
 	Timeline
+	WebPush
 
 	/*
 		USEFUL CONVERSION FUNCTIONS
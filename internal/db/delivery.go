@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delivery contains functions for queueing outgoing federation deliveries in the database.
+type Delivery interface {
+	// PutDeliveries stores the given deliveries in the database in one transaction.
+	PutDeliveries(ctx context.Context, deliveries []*gtsmodel.Delivery) Error
+	// GetDueDeliveries gets limit n pending deliveries that are due to be attempted at or before the given time.
+	// These will be returned in order of delivery.next_attempt_at ascending (oldest to newest in other words), and then by ID,
+	// so that deliveries with the same next attempt time stay in the order they were queued.
+	GetDueDeliveries(ctx context.Context, dueBefore time.Time, limit int) ([]*gtsmodel.Delivery, Error)
+	// GetDeliveries gets limit n deliveries with the given state, newest first, optionally paging down from maxID.
+	// If state is empty, deliveries of all states will be returned.
+	GetDeliveries(ctx context.Context, state gtsmodel.DeliveryState, maxID string, limit int) ([]*gtsmodel.Delivery, Error)
+	// DeferDeliveries pushes back the next attempt of all pending deliveries to the given host to at least the given time.
+	DeferDeliveries(ctx context.Context, host string, until time.Time) Error
+}
@@ -23,17 +23,24 @@ import (
 	"net/url"
 )
 
-// Domain contains DB functions related to domains and domain blocks.
+// Domain contains DB functions related to domains, domain blocks and domain allows.
+//
+// Whether a domain counts as blocked depends on the federation mode: in allowlist mode, domains
+// without a domain allow are blocked too, and in none mode, every domain except our own is blocked.
 type Domain interface {
-	// IsDomainBlocked checks if an instance-level domain block exists for the given domain string (eg., `example.org`).
+	// IsDomainBlocked checks if this instance refuses to federate with the given domain string (eg., `example.org`),
+	// either because an instance-level domain block exists for it, or because the federation mode doesn't allow it.
 	IsDomainBlocked(ctx context.Context, domain string) (bool, Error)
 
-	// AreDomainsBlocked checks if an instance-level domain block exists for any of the given domains strings, and returns true if even one is found.
+	// AreDomainsBlocked checks if this instance refuses to federate with any of the given domains strings, and returns true if even one is found.
 	AreDomainsBlocked(ctx context.Context, domains []string) (bool, Error)
 
-	// IsURIBlocked checks if an instance-level domain block exists for the `host` in the given URI (eg., `https://example.org/users/whatever`).
+	// IsURIBlocked checks if this instance refuses to federate with the `host` in the given URI (eg., `https://example.org/users/whatever`).
 	IsURIBlocked(ctx context.Context, uri *url.URL) (bool, Error)
 
-	// AreURIsBlocked checks if an instance-level domain block exists for any `host` in the given URI slice, and returns true if even one is found.
+	// AreURIsBlocked checks if this instance refuses to federate with any `host` in the given URI slice, and returns true if even one is found.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, Error)
+
+	// IsDomainAllowed checks if an instance-level domain allow exists for the given domain string (eg., `example.org`).
+	IsDomainAllowed(ctx context.Context, domain string) (bool, Error)
 }
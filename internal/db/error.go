@@ -30,6 +30,8 @@ var (
 	ErrMultipleEntries Error = fmt.Errorf("multiple entries")
 	// ErrUnknown denotes an unknown database error.
 	ErrUnknown Error = fmt.Errorf("unknown error")
+	// ErrVersionConflict is returned when a caller tries to update an entry that has been changed by someone else since the caller read it.
+	ErrVersionConflict Error = fmt.Errorf("version conflict")
 )
 
 // ErrAlreadyExists is returned when a caller tries to insert a database entry that already exists in the db.
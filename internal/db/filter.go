@@ -0,0 +1,42 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Filter contains functions for getting filters and filter keywords from the database.
+type Filter interface {
+	// GetFilterByID gets a single filter by its ID, with its keywords populated.
+	GetFilterByID(ctx context.Context, id string) (*gtsmodel.Filter, Error)
+	// GetFiltersForAccountID gets all filters owned by the given account, oldest first, with their keywords populated.
+	GetFiltersForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Filter, Error)
+	// GetFilterKeywordByID gets a single filter keyword by its ID, with the filter it belongs to populated.
+	GetFilterKeywordByID(ctx context.Context, id string) (*gtsmodel.FilterKeyword, Error)
+	// PutFilter stores the given filter and its keywords in the database in one transaction.
+	PutFilter(ctx context.Context, filter *gtsmodel.Filter) Error
+	// UpdateFilter updates the given filter, inserts or updates its keywords, and deletes the
+	// keywords with the given IDs, all in one transaction.
+	UpdateFilter(ctx context.Context, filter *gtsmodel.Filter, deleteKeywordIDs []string) Error
+	// DeleteFilterByID deletes the filter with the given ID, and all of its keywords.
+	DeleteFilterByID(ctx context.Context, id string) Error
+}
@@ -0,0 +1,46 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// List contains functions for getting lists and list entries from the database.
+type List interface {
+	// GetListByID gets a single list by its ID.
+	GetListByID(ctx context.Context, id string) (*gtsmodel.List, Error)
+	// GetListsForAccountID gets all lists owned by the given account, oldest first.
+	GetListsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.List, Error)
+	// GetListsContainingAccountID gets all lists owned by ownerAccountID that targetAccountID is a member of.
+	GetListsContainingAccountID(ctx context.Context, ownerAccountID string, targetAccountID string) ([]*gtsmodel.List, Error)
+	// GetListEntries gets limit n entries of the given list, with the follow and followed account of each entry populated.
+	// Entries are returned in descending order of when they were added to the list (newest first).
+	GetListEntries(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ListEntry, Error)
+	// GetListEntriesForFollowID gets all list entries that point to the given follow.
+	GetListEntriesForFollowID(ctx context.Context, followID string) ([]*gtsmodel.ListEntry, Error)
+	// PutListEntries stores the given list entries in the database in one transaction.
+	PutListEntries(ctx context.Context, entries []*gtsmodel.ListEntry) Error
+	// DeleteListEntriesForFollowIDs removes any entries pointing to the given follows from the given list.
+	DeleteListEntriesForFollowIDs(ctx context.Context, listID string, followIDs []string) Error
+	// DeleteListByID deletes the list with the given ID, and all of its entries.
+	DeleteListByID(ctx context.Context, id string) Error
+}
@@ -0,0 +1,38 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Marker contains functions for getting and setting timeline markers in the database.
+type Marker interface {
+	// GetMarkers gets the markers of the given account for the timelines with the given names.
+	// Timelines the account hasn't set a marker for yet are left out.
+	GetMarkers(ctx context.Context, accountID string, names []gtsmodel.MarkerName) ([]*gtsmodel.Marker, Error)
+	// UpdateMarker stores the given marker, incrementing its version.
+	//
+	// The version of the given marker should be the version it had when it was read from the database,
+	// or 0 for a new marker. If the marker has been changed by someone else in the meantime,
+	// ErrVersionConflict will be returned and the marker will not be stored.
+	UpdateMarker(ctx context.Context, marker *gtsmodel.Marker) Error
+}
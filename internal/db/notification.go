@@ -24,12 +24,22 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-// Notification contains functions for creating and getting notifications.
+// Notification contains functions for creating, getting and removing notifications.
 type Notification interface {
 	// GetNotifications returns a slice of notifications that pertain to the given accountID.
 	//
+	// If types is not empty, only notifications of those types are returned, and notifications of any of the
+	// excludeTypes are never returned. If originAccountID is set, only notifications caused by that account are returned.
+	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
-	GetNotifications(ctx context.Context, accountID string, limit int, maxID string, sinceID string) ([]*gtsmodel.Notification, Error)
+	GetNotifications(ctx context.Context, accountID string, types []string, excludeTypes []string, originAccountID string, limit int, maxID string, sinceID string, minID string) ([]*gtsmodel.Notification, Error)
+	// CountNotifications returns how many notifications that pertain to the given accountID are newer than sinceID,
+	// up to a maximum of limit. The types, excludeTypes and originAccountID filter notifications as in GetNotifications.
+	CountNotifications(ctx context.Context, accountID string, types []string, excludeTypes []string, originAccountID string, sinceID string, limit int) (int, Error)
 	// GetNotification returns one notification according to its id.
 	GetNotification(ctx context.Context, id string) (*gtsmodel.Notification, Error)
+	// DeleteNotification removes the notification with the given id.
+	DeleteNotification(ctx context.Context, id string) Error
+	// ClearNotifications removes all notifications that pertain to the given accountID.
+	ClearNotifications(ctx context.Context, accountID string) Error
 }
@@ -0,0 +1,49 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Poll contains functions for getting polls and poll votes from the database.
+type Poll interface {
+	// GetPollByID gets a single poll by its ID.
+	GetPollByID(ctx context.Context, id string) (*gtsmodel.Poll, Error)
+	// GetPollByStatusID gets the poll attached to the given status.
+	GetPollByStatusID(ctx context.Context, statusID string) (*gtsmodel.Poll, Error)
+	// GetExpiredPolls gets limit n polls that expired before the given time, but which haven't been closed yet.
+	// These will be returned in order of poll.expires_at ascending (oldest to newest in other words).
+	GetExpiredPolls(ctx context.Context, expiredBefore time.Time, limit int) ([]*gtsmodel.Poll, Error)
+	// GetPollVotes gets all the votes that have been cast in the given poll.
+	// Only votes that this instance knows about will be returned: for remote polls, this will usually just be votes by local accounts.
+	GetPollVotes(ctx context.Context, pollID string) ([]*gtsmodel.PollVote, Error)
+	// GetPollVoteBy gets the vote cast by the given account in the given poll, if it exists.
+	GetPollVoteBy(ctx context.Context, pollID string, accountID string) (*gtsmodel.PollVote, Error)
+	// PutPollVote stores the given vote, and adds its choices to the vote counts of the poll it was cast in.
+	PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) Error
+	// AddPollVoteChoice adds the given choice to an existing vote, and increments the vote count of that option in the poll.
+	// This is used for multiple-choice polls, where remote instances may deliver each choice of one vote separately.
+	AddPollVoteChoice(ctx context.Context, vote *gtsmodel.PollVote, choice int) Error
+	// DeletePollByID deletes the poll with the given ID, along with all the votes cast in it.
+	DeletePollByID(ctx context.Context, id string) Error
+}
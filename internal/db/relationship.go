@@ -20,6 +20,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -36,6 +37,22 @@ type Relationship interface {
 	// not if you're just checking for the existence of a block.
 	GetBlock(ctx context.Context, account1 string, account2 string) (*gtsmodel.Block, Error)
 
+	// IsMuted checks whether account1 has a mute in place against account2 which hasn't expired yet.
+	// If notifications is true, then only mutes which also hide notifications from account2 are taken into account.
+	IsMuted(ctx context.Context, account1 string, account2 string, notifications bool) (bool, Error)
+
+	// GetMute returns the mute from account1 targeting account2, if it exists, or an error if it doesn't.
+	//
+	// The mute will be returned even if it has expired but hasn't been lifted yet.
+	GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.UserMute, Error)
+
+	// GetAccountMutes returns the mutes created by the given accountID which haven't expired yet, newest first,
+	// with their target accounts populated, along with the next max ID and previous min ID for paging.
+	GetAccountMutes(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.UserMute, string, string, Error)
+
+	// GetExpiredMutes returns up to limit mutes which expired before the given time.
+	GetExpiredMutes(ctx context.Context, expiredBefore time.Time, limit int) ([]*gtsmodel.UserMute, Error)
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, Error)
 
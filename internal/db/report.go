@@ -0,0 +1,42 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Report contains functions for getting and storing reports of accounts in the database.
+type Report interface {
+	// GetReportByID gets a single report by its ID, with its accounts populated.
+	GetReportByID(ctx context.Context, id string) (*gtsmodel.Report, Error)
+	// GetReportByURI gets a single report by the URI of its Flag activity, with its accounts populated.
+	GetReportByURI(ctx context.Context, uri string) (*gtsmodel.Report, Error)
+	// GetReports gets reports newest first, with their accounts populated.
+	//
+	// If resolved is not nil, only resolved or only unresolved reports will be returned.
+	// If accountID or targetAccountID are set, only reports made by or about those accounts will be returned.
+	GetReports(ctx context.Context, resolved *bool, accountID string, targetAccountID string, maxID string, limit int) ([]*gtsmodel.Report, Error)
+	// PutReport stores the given report in the database.
+	PutReport(ctx context.Context, report *gtsmodel.Report) Error
+	// UpdateReport updates the given report, bumping its updated_at time.
+	UpdateReport(ctx context.Context, report *gtsmodel.Report) Error
+}
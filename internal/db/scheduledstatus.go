@@ -0,0 +1,48 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ScheduledStatus contains functions for getting and storing statuses that are scheduled to be published later.
+type ScheduledStatus interface {
+	// GetScheduledStatusByID gets a single scheduled status by its ID, with its account and media attachments populated.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, Error)
+	// GetAccountScheduledStatuses gets the scheduled statuses of the given account, newest first, with their media attachments populated.
+	GetAccountScheduledStatuses(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ScheduledStatus, Error)
+	// CountAccountScheduledStatuses counts the scheduled statuses of the given account.
+	//
+	// If after and before are not zero, only statuses scheduled in between those times will be counted.
+	CountAccountScheduledStatuses(ctx context.Context, accountID string, after time.Time, before time.Time) (int, Error)
+	// GetDueScheduledStatuses gets limit n scheduled statuses that are due to be published at the given time (or all of them if limit is 0),
+	// oldest first, with their account and media attachments populated.
+	GetDueScheduledStatuses(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.ScheduledStatus, Error)
+	// PutScheduledStatus stores the given scheduled status, and marks its media attachments as belonging to it.
+	PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) Error
+	// UpdateScheduledStatus updates the given scheduled status, bumping its updated_at time.
+	UpdateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) Error
+	// DeleteScheduledStatusByID deletes the scheduled status with the given ID, and releases its
+	// media attachments so that they can be used in another status.
+	DeleteScheduledStatusByID(ctx context.Context, id string) Error
+}
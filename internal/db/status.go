@@ -35,9 +35,16 @@ type Status interface {
 	// GetStatusByURL returns one status from the database, with no rel fields populated, only their linking ID / URIs
 	GetStatusByURL(ctx context.Context, uri string) (*gtsmodel.Status, Error)
 
-	// PutStatus stores one status in the database.
+	// PutStatus stores one status in the database, along with its poll if it has one.
 	PutStatus(ctx context.Context, status *gtsmodel.Status) Error
 
+	// UpdateStatus updates one edited status in the database, along with its emoji, tag and attachment links.
+	// If previous is not nil, it will be stored as a revision of the status in the same transaction.
+	UpdateStatus(ctx context.Context, status *gtsmodel.Status, previous *gtsmodel.StatusEdit) Error
+
+	// GetStatusEdits returns all stored previous revisions of the given status, oldest first.
+	GetStatusEdits(ctx context.Context, statusID string) ([]*gtsmodel.StatusEdit, Error)
+
 	// CountStatusReplies returns the amount of replies recorded for a status, or an error if something goes wrong
 	CountStatusReplies(ctx context.Context, status *gtsmodel.Status) (int, Error)
 
@@ -63,7 +70,11 @@ type Status interface {
 	// IsStatusRebloggedBy checks if a given status has been reblogged/boosted by a given account ID
 	IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
-	// IsStatusMutedBy checks if a given status has been muted by a given account ID
+	// GetStatusThreadRoot returns the status at the top of the thread that the given status belongs to,
+	// as far up the thread as we have statuses in the database. If the status isn't a reply, it's returned as-is.
+	GetStatusThreadRoot(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Status, Error)
+
+	// IsStatusMutedBy checks if the thread that a given status belongs to has been muted by a given account ID
 	IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
@@ -0,0 +1,37 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Tag contains functions for getting hashtags and tag follows from the database.
+type Tag interface {
+	// GetTagByID gets a single tag by its ID.
+	GetTagByID(ctx context.Context, id string) (*gtsmodel.Tag, Error)
+	// GetTagByName gets a single tag by its name, ignoring case.
+	GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, Error)
+	// IsFollowingTag returns true if the given account follows the given tag.
+	IsFollowingTag(ctx context.Context, accountID string, tagID string) (bool, Error)
+	// GetTagFollows gets all follows of any of the given tags.
+	GetTagFollows(ctx context.Context, tagIDs []string) ([]*gtsmodel.TagFollow, Error)
+}
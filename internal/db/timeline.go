@@ -45,4 +45,22 @@ type Timeline interface {
 	//
 	// Also note the extra return values, which correspond to the nextMaxID and prevMinID for building Link headers.
 	GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, Error)
+
+	// GetBookmarkedTimeline fetches the statuses that the requesting account has bookmarked.
+	// It will use the given filters and try to return as many statuses as possible up to the limit.
+	//
+	// Like GetFavedTimeline, statuses are arranged by their BOOKMARK id rather than the STATUS id, and
+	// the extra return values correspond to the nextMaxID and prevMinID for building Link headers.
+	GetBookmarkedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, Error)
+
+	// GetListTimeline returns a slice of statuses from followed accounts that are members of the given list.
+	//
+	// Statuses should be returned in descending order of when they were created (newest first).
+	GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, Error)
+
+	// GetTagTimeline fetches PUBLIC statuses that use at least one of anyTagIDs, all of allTagIDs, and none of noneTagIDs.
+	// Unlike GetPublicTimeline, replies are included, but boosts are still left out.
+	//
+	// Statuses should be returned in descending order of when they were created (newest first).
+	GetTagTimeline(ctx context.Context, accountID string, anyTagIDs []string, allTagIDs []string, noneTagIDs []string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, Error)
 }
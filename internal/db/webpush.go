@@ -0,0 +1,38 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebPush contains functions for getting Web Push subscriptions and the instance VAPID keypair from the database.
+type WebPush interface {
+	// GetVAPIDKeyPair gets the VAPID keypair of this instance.
+	// If more than one keypair has somehow been stored, the oldest one is returned.
+	GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, Error)
+	// GetPushSubscriptionByTokenID gets the push subscription created with the oauth token with the given ID.
+	GetPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.PushSubscription, Error)
+	// GetPushSubscriptionsForAccountID gets all push subscriptions of the given account.
+	GetPushSubscriptionsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.PushSubscription, Error)
+	// PutPushSubscription stores the given push subscription, replacing any other subscription of the same oauth token.
+	PutPushSubscription(ctx context.Context, subscription *gtsmodel.PushSubscription) Error
+}
@@ -90,3 +90,39 @@ func (s *noopSender) SendResetEmail(toAddress string, data ResetData) error {
 
 	return nil
 }
+
+func (s *noopSender) SendNewSignupEmail(toAddress string, data NewSignupData) error {
+	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddress)
+}
+
+func (s *noopSender) SendSignupApprovedEmail(toAddress string, data SignupData) error {
+	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupData) error {
+	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendScheduledStatusFailedEmail(toAddress string, data ScheduledStatusFailedData) error {
+	return s.sendTemplate(scheduledStatusFailedTemplate, scheduledStatusFailedSubject, data, toAddress)
+}
+
+func (s *noopSender) sendTemplate(template string, subject string, data interface{}, toAddress string) error {
+	buf := &bytes.Buffer{}
+	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+		return err
+	}
+
+	msg, err := assembleMessage(subject, buf.String(), toAddress, "test@example.org")
+	if err != nil {
+		return err
+	}
+
+	logrus.Tracef("NOT SENDING %s email to %s with contents: %s", template, toAddress, msg)
+
+	if s.sendCallback != nil {
+		s.sendCallback(toAddress, string(msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email
+
+const (
+	scheduledStatusFailedTemplate = "email_scheduled_status_failed_text.tmpl"
+	scheduledStatusFailedSubject  = "GoToSocial Scheduled Status Not Published"
+)
+
+func (s *sender) SendScheduledStatusFailedEmail(toAddress string, data ScheduledStatusFailedData) error {
+	return s.sendTemplate(scheduledStatusFailedTemplate, scheduledStatusFailedSubject, data, toAddress)
+}
+
+// ScheduledStatusFailedData represents data passed into the scheduled status failed template.
+type ScheduledStatusFailedData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Time the status was scheduled for, already formatted for display.
+	ScheduledAt string
+	// Text of the status that couldn't be published.
+	Text string
+}
@@ -34,6 +34,19 @@ type Sender interface {
 
 	// SendResetEmail sends a 'reset your password' style email to the given toAddress, with the given data.
 	SendResetEmail(toAddress string, data ResetData) error
+
+	// SendNewSignupEmail sends a 'someone signed up and is waiting for approval' style email to the given toAddress, with the given data.
+	// The toAddress should belong to an admin of the instance.
+	SendNewSignupEmail(toAddress string, data NewSignupData) error
+
+	// SendSignupApprovedEmail sends a 'your sign-up has been approved' style email to the given toAddress, with the given data.
+	SendSignupApprovedEmail(toAddress string, data SignupData) error
+
+	// SendSignupRejectedEmail sends a 'your sign-up has been rejected' style email to the given toAddress, with the given data.
+	SendSignupRejectedEmail(toAddress string, data SignupData) error
+
+	// SendScheduledStatusFailedEmail sends a 'your scheduled status couldn't be published' style email to the given toAddress, with the given data.
+	SendScheduledStatusFailedEmail(toAddress string, data ScheduledStatusFailedData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email
+
+import (
+	"bytes"
+	"net/smtp"
+)
+
+const (
+	newSignupTemplate      = "email_new_signup_text.tmpl"
+	newSignupSubject       = "GoToSocial New Sign-Up"
+	signupApprovedTemplate = "email_signup_approved_text.tmpl"
+	signupApprovedSubject  = "GoToSocial Sign-Up Approved"
+	signupRejectedTemplate = "email_signup_rejected_text.tmpl"
+	signupRejectedSubject  = "GoToSocial Sign-Up Rejected"
+)
+
+func (s *sender) SendNewSignupEmail(toAddress string, data NewSignupData) error {
+	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddress)
+}
+
+func (s *sender) SendSignupApprovedEmail(toAddress string, data SignupData) error {
+	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+}
+
+func (s *sender) SendSignupRejectedEmail(toAddress string, data SignupData) error {
+	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+}
+
+func (s *sender) sendTemplate(template string, subject string, data interface{}, toAddress string) error {
+	buf := &bytes.Buffer{}
+	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+		return err
+	}
+
+	msg, err := assembleMessage(subject, buf.String(), toAddress, s.from)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.hostAddress, s.auth, s.from, []string{toAddress}, msg)
+}
+
+// NewSignupData represents data passed into the new sign-up template, which is sent to instance admins.
+type NewSignupData struct {
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Username of the new sign-up.
+	SignupUsername string
+	// Email address of the new sign-up.
+	SignupEmail string
+	// Reason given by the new sign-up for wanting an account, if any.
+	SignupReason string
+}
+
+// SignupData represents data passed into the sign-up approved and sign-up rejected templates.
+type SignupData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+}
@@ -154,6 +154,17 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		return nil, false, err // couldn't parse the public key ID url
 	}
 
+	// don't authenticate requests from domains we don't federate with,
+	// whether they're blocked or just not allowed by the federation mode
+	blocked, err := f.db.IsURIBlocked(ctx, requestingPublicKeyID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error checking whether domain %s is blocked: %s", requestingPublicKeyID.Host, err)
+	}
+	if blocked {
+		l.Debugf("domain %s is blocked", requestingPublicKeyID.Host)
+		return nil, false, nil
+	}
+
 	requestingRemoteAccount := &gtsmodel.Account{}
 	requestingLocalAccount := &gtsmodel.Account{}
 	requestingHost := requestingPublicKeyID.Host
@@ -108,6 +108,13 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 			return nil, fmt.Errorf("GetRemoteAccount: error putting new account: %s", err)
 		}
 
+		// the featured collection is only fetched when we've been asked to get
+		// the latest version of the account, rather than whenever we stumble
+		// across it for the first time (eg., as the author of a status)
+		if refresh {
+			d.updateRemoteAccountFeatured(username, newAccount)
+		}
+
 		return newAccount, nil
 	}
 
@@ -123,11 +130,20 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 	}
 	refreshedAccount.ID = remoteAccount.ID
 
+	// the account moved to isn't part of the AS representation, so keep
+	// it as long as the account still points at the same place
+	if refreshedAccount.MovedToURI == remoteAccount.MovedToURI {
+		refreshedAccount.MovedToAccountID = remoteAccount.MovedToAccountID
+	}
+
 	changed, err := d.populateAccountFields(ctx, refreshedAccount, username, refresh, blocking)
 	if err != nil {
 		return nil, fmt.Errorf("GetRemoteAccount: error populating further refreshedAccount fields: %s", err)
 	}
 
+	// refresh the statuses the account has pinned too
+	d.updateRemoteAccountFeatured(username, refreshedAccount)
+
 	if changed {
 		updatedAccount, err := d.db.UpdateAccount(ctx, refreshedAccount)
 		if err != nil {
@@ -21,8 +21,10 @@ package dereferencing_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -51,6 +53,33 @@ func (suite *AccountTestSuite) TestDereferenceGroup() {
 	suite.Equal(ap.ActorGroup, dbGroup.ActorType)
 }
 
+func (suite *AccountTestSuite) TestDereferenceAccountFeatured() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	pinnedStatusURI := "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+
+	// brand_new_person has one status pinned
+	featured := streams.NewActivityStreamsOrderedCollection()
+	featuredItems := streams.NewActivityStreamsOrderedItemsProperty()
+	featuredItems.AppendIRI(testrig.URLMustParse(pinnedStatusURI))
+	featured.SetActivityStreamsOrderedItems(featuredItems)
+	suite.testRemoteFeatured["https://unknown-instance.com/users/brand_new_person/collections/featured"] = featured
+
+	personURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person")
+	person, err := suite.dereferencer.GetRemoteAccount(context.Background(), fetchingAccount.Username, personURL, true, true)
+	suite.NoError(err)
+	suite.NotNil(person)
+
+	// the featured collection is fetched async, so wait for the pinned status to turn up
+	suite.Eventually(func() bool {
+		status, err := suite.db.GetStatusByURI(context.Background(), pinnedStatusURI)
+		return err == nil && status.Pinned && status.AccountID == person.ID
+	}, 5*time.Second, 100*time.Millisecond)
+
+	pinned, err := suite.db.CountAccountPinned(context.Background(), person.ID)
+	suite.NoError(err)
+	suite.Equal(1, pinned)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
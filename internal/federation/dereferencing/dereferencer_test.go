@@ -24,7 +24,6 @@ import (
 	"io"
 	"net/http"
 
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
@@ -32,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -39,12 +39,13 @@ import (
 type DereferencerStandardTestSuite struct {
 	suite.Suite
 	db      db.DB
-	storage *kv.KVStore
+	storage *storage.Driver
 
 	testRemoteStatuses    map[string]vocab.ActivityStreamsNote
 	testRemotePeople      map[string]vocab.ActivityStreamsPerson
 	testRemoteGroups      map[string]vocab.ActivityStreamsGroup
 	testRemoteAttachments map[string]testrig.RemoteAttachmentFile
+	testRemoteFeatured    map[string]vocab.ActivityStreamsOrderedCollection
 	testAccounts          map[string]*gtsmodel.Account
 
 	dereferencer dereferencing.Dereferencer
@@ -59,6 +60,7 @@ func (suite *DereferencerStandardTestSuite) SetupTest() {
 	suite.testRemotePeople = testrig.NewTestFediPeople()
 	suite.testRemoteGroups = testrig.NewTestFediGroups()
 	suite.testRemoteAttachments = testrig.NewTestFediAttachments("../../../testrig/media")
+	suite.testRemoteFeatured = make(map[string]vocab.ActivityStreamsOrderedCollection)
 
 	suite.db = testrig.NewTestDB()
 	suite.storage = testrig.NewTestStorage()
@@ -124,6 +126,20 @@ func (suite *DereferencerStandardTestSuite) mockTransportController() transport.
 			responseType = "application/activity+json"
 		}
 
+		if featured, ok := suite.testRemoteFeatured[req.URL.String()]; ok {
+			// the request is for a featured collection that we have stored
+			featuredI, err := streams.Serialize(featured)
+			if err != nil {
+				panic(err)
+			}
+			featuredJson, err := json.Marshal(featuredI)
+			if err != nil {
+				panic(err)
+			}
+			responseBytes = featuredJson
+			responseType = "application/activity+json"
+		}
+
 		if attachment, ok := suite.testRemoteAttachments[req.URL.String()]; ok {
 			responseBytes = attachment.Data
 			responseType = attachment.ContentType
@@ -0,0 +1,204 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// maxFeaturedStatuses is the maximum amount of statuses that will be
+// taken from a remote account's featured collection and marked as pinned.
+const maxFeaturedStatuses = 20
+
+// collectionItem is satisfied by the iterators of both the items and orderedItems properties.
+type collectionItem interface {
+	GetIRI() *url.URL
+	GetType() vocab.Type
+}
+
+// updateRemoteAccountFeatured starts an asynchronous fetch of the featured collection of the given
+// remote account, so that the statuses it has pinned show up on its profile.
+//
+// This is always done async, since dereferencing the featured statuses may in turn
+// require the account itself, and we don't want to hold up the caller for it.
+func (d *deref) updateRemoteAccountFeatured(requestingUsername string, account *gtsmodel.Account) {
+	if instanceAccount(account) || account.FeaturedCollectionURI == "" {
+		return
+	}
+
+	go func() {
+		ctx, done := context.WithDeadline(context.Background(), time.Now().Add(1*time.Minute))
+		defer done()
+		if err := d.fetchRemoteAccountFeatured(ctx, requestingUsername, account); err != nil {
+			logrus.Errorf("updateRemoteAccountFeatured: error fetching featured collection of account %s: %s", account.URI, err)
+		}
+	}()
+}
+
+// fetchRemoteAccountFeatured dereferences the featured collection of the given remote account,
+// on behalf of requestingUsername, and makes sure that the statuses in it are stored and marked
+// as pinned. Any other statuses of the account that were previously pinned will be unpinned.
+func (d *deref) fetchRemoteAccountFeatured(ctx context.Context, requestingUsername string, account *gtsmodel.Account) error {
+	if account.FeaturedCollectionURI == "" {
+		return nil
+	}
+
+	featuredIRI, err := url.Parse(account.FeaturedCollectionURI)
+	if err != nil {
+		return fmt.Errorf("fetchRemoteAccountFeatured: couldn't parse featured collection URI %s: %s", account.FeaturedCollectionURI, err)
+	}
+
+	statusIRIs, err := d.dereferenceFeaturedIRIs(ctx, requestingUsername, featuredIRI)
+	if err != nil {
+		return fmt.Errorf("fetchRemoteAccountFeatured: %s", err)
+	}
+
+	pinnedIDs := make(map[string]bool, len(statusIRIs))
+	for _, statusIRI := range statusIRIs {
+		// pinned statuses can only come from the account itself
+		if statusIRI.Host != featuredIRI.Host {
+			continue
+		}
+
+		status, _, _, err := d.GetRemoteStatus(ctx, requestingUsername, statusIRI, false, false)
+		if err != nil {
+			logrus.Debugf("fetchRemoteAccountFeatured: error dereferencing featured status %s: %s", statusIRI, err)
+			continue
+		}
+
+		if status.AccountID != account.ID {
+			continue
+		}
+		pinnedIDs[status.ID] = true
+
+		if !status.Pinned {
+			status.Pinned = true
+			if err := d.db.UpdateStatus(ctx, status, nil); err != nil {
+				return fmt.Errorf("fetchRemoteAccountFeatured: error pinning status %s: %s", status.ID, err)
+			}
+		}
+	}
+
+	// unpin anything that's no longer featured
+	previouslyPinned, err := d.db.GetAccountStatuses(ctx, account.ID, 0, false, false, "", "", true, false, false)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("fetchRemoteAccountFeatured: error getting pinned statuses: %s", err)
+	}
+
+	for _, status := range previouslyPinned {
+		if pinnedIDs[status.ID] {
+			continue
+		}
+
+		status.Pinned = false
+		if err := d.db.UpdateStatus(ctx, status, nil); err != nil {
+			return fmt.Errorf("fetchRemoteAccountFeatured: error unpinning status %s: %s", status.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// dereferenceFeaturedIRIs calls featuredIRI with a GET request, and returns the IRIs of the
+// items in the collection it finds there. Items can be either bare IRIs or embedded objects.
+func (d *deref) dereferenceFeaturedIRIs(ctx context.Context, username string, featuredIRI *url.URL) ([]*url.URL, error) {
+	if blocked, err := d.db.IsDomainBlocked(ctx, featuredIRI.Host); blocked || err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedIRIs: domain %s is blocked", featuredIRI.Host)
+	}
+
+	transport, err := d.transportController.NewTransportForUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedIRIs: error creating transport: %s", err)
+	}
+
+	b, err := transport.Dereference(ctx, featuredIRI)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedIRIs: error deferencing %s: %s", featuredIRI.String(), err)
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedIRIs: error unmarshalling bytes into json: %s", err)
+	}
+
+	t, err := streams.ToType(ctx, m)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedIRIs: error resolving json into ap vocab type: %s", err)
+	}
+
+	items := []collectionItem{}
+	switch t.GetTypeName() {
+	case ap.ObjectOrderedCollection:
+		collection, ok := t.(vocab.ActivityStreamsOrderedCollection)
+		if !ok {
+			return nil, errors.New("dereferenceFeaturedIRIs: error resolving type as activitystreams ordered collection")
+		}
+		if prop := collection.GetActivityStreamsOrderedItems(); prop != nil {
+			for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+				items = append(items, iter)
+			}
+		}
+	case ap.ObjectCollection:
+		collection, ok := t.(vocab.ActivityStreamsCollection)
+		if !ok {
+			return nil, errors.New("dereferenceFeaturedIRIs: error resolving type as activitystreams collection")
+		}
+		if prop := collection.GetActivityStreamsItems(); prop != nil {
+			for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+				items = append(items, iter)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("dereferenceFeaturedIRIs: type name %s not supported", t.GetTypeName())
+	}
+
+	iris := []*url.URL{}
+	for _, item := range items {
+		if len(iris) >= maxFeaturedStatuses {
+			break
+		}
+
+		if iri := item.GetIRI(); iri != nil {
+			iris = append(iris, iri)
+			continue
+		}
+
+		if t := item.GetType(); t != nil {
+			if iri, err := pub.GetId(t); err == nil {
+				iris = append(iris, iri)
+			}
+		}
+	}
+
+	return iris, nil
+}
@@ -25,11 +25,13 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -116,11 +118,34 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 	} else {
 		gtsStatus.ID = maybeStatus.ID
 
+		// pins aren't part of the AS representation of a status
+		gtsStatus.Pinned = maybeStatus.Pinned
+
 		if err := d.populateStatusFields(ctx, gtsStatus, username, includeParent); err != nil {
 			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error populating status fields: %s", err)
 		}
 
-		if err := d.db.UpdateByPrimaryKey(ctx, gtsStatus); err != nil {
+		if err := d.updateStatusPoll(ctx, gtsStatus); err != nil {
+			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error updating status poll: %s", err)
+		}
+
+		// if the status has been edited since we last saw it, keep the previous version around
+		var previous *gtsmodel.StatusEdit
+		if statusEdited(maybeStatus, gtsStatus) {
+			previous, err = d.typeConverter.StatusToStatusEdit(ctx, maybeStatus)
+			if err != nil {
+				return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error creating status edit: %s", err)
+			}
+
+			// not every implementation sets 'updated' on edited statuses
+			if gtsStatus.EditedAt.IsZero() {
+				gtsStatus.EditedAt = time.Now()
+			}
+		} else if gtsStatus.EditedAt.IsZero() {
+			gtsStatus.EditedAt = maybeStatus.EditedAt
+		}
+
+		if err := d.db.UpdateStatus(ctx, gtsStatus, previous); err != nil {
 			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error updating status: %s", err)
 		}
 	}
@@ -128,6 +153,40 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 	return gtsStatus, statusable, new, nil
 }
 
+// statusEdited returns true if the refreshed version of a status differs in content from the one we already had.
+func statusEdited(existing *gtsmodel.Status, refreshed *gtsmodel.Status) bool {
+	if refreshed.EditedAt.After(existing.EditedAt) {
+		return true
+	}
+
+	return existing.Content != refreshed.Content ||
+		existing.ContentWarning != refreshed.ContentWarning ||
+		existing.Sensitive != refreshed.Sensitive
+}
+
+// updateStatusPoll stores the poll of a refreshed status, updating the
+// existing poll of the status if we already have one.
+func (d *deref) updateStatusPoll(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Poll == nil {
+		return nil
+	}
+	status.Poll.StatusID = status.ID
+
+	existing, err := d.db.GetPollByStatusID(ctx, status.ID)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return err
+		}
+		return d.db.Put(ctx, status.Poll)
+	}
+
+	// keep the existing poll ID, so that it's stable for clients
+	status.Poll.ID = existing.ID
+	status.Poll.CreatedAt = existing.CreatedAt
+	status.PollID = existing.ID
+	return d.db.UpdateByPrimaryKey(ctx, status.Poll)
+}
+
 func (d *deref) dereferenceStatusable(ctx context.Context, username string, remoteStatusID *url.URL) (ap.Statusable, error) {
 	if blocked, err := d.db.IsDomainBlocked(ctx, remoteStatusID.Host); blocked || err != nil {
 		return nil, fmt.Errorf("DereferenceStatusable: domain %s is blocked", remoteStatusID.Host)
@@ -153,7 +212,7 @@ func (d *deref) dereferenceStatusable(ctx context.Context, username string, remo
 		return nil, fmt.Errorf("DereferenceStatusable: error resolving json into ap vocab type: %s", err)
 	}
 
-	// Article, Document, Image, Video, Note, Page, Event, Place, Mention, Profile
+	// Article, Document, Image, Video, Note, Page, Event, Place, Mention, Profile, Question
 	switch t.GetTypeName() {
 	case ap.ObjectArticle:
 		p, ok := t.(vocab.ActivityStreamsArticle)
@@ -209,6 +268,12 @@ func (d *deref) dereferenceStatusable(ctx context.Context, username string, remo
 			return nil, errors.New("DereferenceStatusable: error resolving type as ActivityStreamsProfile")
 		}
 		return p, nil
+	case ap.ActivityQuestion:
+		p, ok := t.(vocab.ActivityStreamsQuestion)
+		if !ok {
+			return nil, errors.New("DereferenceStatusable: error resolving type as ActivityStreamsQuestion")
+		}
+		return p, nil
 	}
 
 	return nil, fmt.Errorf("DereferenceStatusable: type name %s not supported", t.GetTypeName())
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/activity/streams/vocab"
@@ -155,6 +156,11 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 			if err := f.createNote(ctx, objectIter.GetActivityStreamsNote(), receivingAccount, requestingAccount, fromFederatorChan); err != nil {
 				errs = append(errs, err.Error())
 			}
+		case ap.ActivityQuestion:
+			// CREATE A QUESTION
+			if err := f.createStatusable(ctx, objectIter.GetActivityStreamsQuestion(), receivingAccount, requestingAccount, fromFederatorChan); err != nil {
+				errs = append(errs, err.Error())
+			}
 		default:
 			errs = append(errs, fmt.Sprintf("received an object on a Create that we couldn't handle: %s", asObjectType.GetTypeName()))
 		}
@@ -169,8 +175,20 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 
 // createNote handles a Create activity with a Note type.
 func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStreamsNote, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account, fromFederatorChan chan messages.FromFederator) error {
+	// A note with a name that replies to one of our polls is a vote in that poll, not a status.
+	if isVote, err := f.createPollVote(ctx, note, requestingAccount); err != nil {
+		return fmt.Errorf("createNote: error processing poll vote: %s", err)
+	} else if isVote {
+		return nil
+	}
+
+	return f.createStatusable(ctx, note, receivingAccount, requestingAccount, fromFederatorChan)
+}
+
+// createStatusable handles a Create activity with a Note or Question type.
+func (f *federatingDB) createStatusable(ctx context.Context, statusable ap.Statusable, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account, fromFederatorChan chan messages.FromFederator) error {
 	l := logrus.WithFields(logrus.Fields{
-		"func":              "createNote",
+		"func":              "createStatusable",
 		"receivingAccount":  receivingAccount.URI,
 		"requestingAccount": requestingAccount.URI,
 	})
@@ -180,9 +198,9 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 	forward := true
 
 	// note should have an attributedTo
-	noteAttributedTo := note.GetActivityStreamsAttributedTo()
+	noteAttributedTo := statusable.GetActivityStreamsAttributedTo()
 	if noteAttributedTo == nil {
-		return errors.New("createNote: note had no attributedTo")
+		return errors.New("createStatusable: note had no attributedTo")
 	}
 
 	// compare the attributedTo(s) with the actor who posted this to our inbox
@@ -200,14 +218,14 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 	// If we do have a forward, we should ignore the content for now and just dereference based on the URL/ID of the note instead, to get the note straight from the horse's mouth
 	if forward {
 		l.Trace("note is a forward")
-		id := note.GetJSONLDId()
+		id := statusable.GetJSONLDId()
 		if !id.IsIRI() {
 			// if the note id isn't an IRI, there's nothing we can do here
 			return nil
 		}
 		// pass the note iri into the processor and have it do the dereferencing instead of doing it here
 		fromFederatorChan <- messages.FromFederator{
-			APObjectType:     ap.ObjectNote,
+			APObjectType:     statusable.GetTypeName(),
 			APActivityType:   ap.ActivityCreate,
 			APIri:            id.GetIRI(),
 			GTSModel:         nil,
@@ -218,9 +236,9 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 
 	// if we reach this point, we know it's not a forwarded status, so proceed with processing it as normal
 
-	status, err := f.typeConverter.ASStatusToStatus(ctx, note)
+	status, err := f.typeConverter.ASStatusToStatus(ctx, statusable)
 	if err != nil {
-		return fmt.Errorf("createNote: error converting note to status: %s", err)
+		return fmt.Errorf("createStatusable: error converting note to status: %s", err)
 	}
 
 	// id the status based on the time it was created
@@ -238,11 +256,11 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 			return nil
 		}
 		// an actual error has happened
-		return fmt.Errorf("createNote: database error inserting status: %s", err)
+		return fmt.Errorf("createStatusable: database error inserting status: %s", err)
 	}
 
 	fromFederatorChan <- messages.FromFederator{
-		APObjectType:     ap.ObjectNote,
+		APObjectType:     statusable.GetTypeName(),
 		APActivityType:   ap.ActivityCreate,
 		GTSModel:         status,
 		ReceivingAccount: receivingAccount,
@@ -251,6 +269,100 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 	return nil
 }
 
+// createPollVote checks whether the given note is a vote in a poll created by one of our accounts,
+// and stores the vote if so. The returned bool will be true if the note was a vote.
+//
+// Each vote Note contains just one choice, so for multiple-choice polls, several Notes may be
+// received for the same vote; these are combined into one vote for the voting account.
+func (f *federatingDB) createPollVote(ctx context.Context, note vocab.ActivityStreamsNote, requestingAccount *gtsmodel.Account) (bool, error) {
+	// votes have a name, which is the chosen option
+	name, err := ap.ExtractName(note)
+	if err != nil || name == "" {
+		return false, nil
+	}
+
+	// votes are a reply to the poll status
+	inReplyToURI := ap.ExtractInReplyToURI(note)
+	if inReplyToURI == nil {
+		return false, nil
+	}
+
+	// the poll status should be one of ours
+	pollStatus, err := f.db.GetStatusByURI(ctx, inReplyToURI.String())
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return false, nil
+		}
+		return false, err
+	}
+	if !pollStatus.Local || pollStatus.PollID == "" {
+		return false, nil
+	}
+
+	// votes should only come from the voter themself
+	voterURI, err := ap.ExtractAttributedTo(note)
+	if err != nil {
+		return true, err
+	}
+	if voterURI.String() != requestingAccount.URI {
+		return true, fmt.Errorf("vote attributed to %s was delivered by %s", voterURI, requestingAccount.URI)
+	}
+
+	poll, err := f.db.GetPollByID(ctx, pollStatus.PollID)
+	if err != nil {
+		return true, err
+	}
+
+	if !poll.ClosedAt.IsZero() || (!poll.ExpiresAt.IsZero() && poll.ExpiresAt.Before(time.Now())) {
+		// the poll is over, so the vote doesn't count
+		return true, nil
+	}
+
+	choice := -1
+	for i, option := range poll.Options {
+		if option == name {
+			choice = i
+			break
+		}
+	}
+	if choice == -1 {
+		return true, fmt.Errorf("%s is not an option in poll %s", name, poll.ID)
+	}
+
+	vote, err := f.db.GetPollVoteBy(ctx, poll.ID, requestingAccount.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return true, err
+	}
+
+	if err == nil {
+		// we already have a vote from this account, so this is either a
+		// duplicate or an additional choice in a multiple-choice poll
+		if !poll.Multiple {
+			return true, nil
+		}
+		for _, c := range vote.Choices {
+			if c == choice {
+				return true, nil
+			}
+		}
+		return true, f.db.AddPollVoteChoice(ctx, vote, choice)
+	}
+
+	voteID, err := id.NewULID()
+	if err != nil {
+		return true, err
+	}
+
+	return true, f.db.PutPollVote(ctx, &gtsmodel.PollVote{
+		ID:        voteID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Choices:   []int{choice},
+		AccountID: requestingAccount.ID,
+		PollID:    poll.ID,
+	})
+}
+
 /*
 	FOLLOW HANDLERS
 */
@@ -36,6 +36,8 @@ type DB interface {
 	Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error
 	Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error
 	Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error
+	Move(ctx context.Context, move vocab.ActivityStreamsMove) error
+	Flag(ctx context.Context, flag vocab.ActivityStreamsFlag) error
 }
 
 // FederatingDB uses the underlying DB interface to implement the go-fed pub.Database interface.
@@ -0,0 +1,91 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+func (f *federatingDB) Flag(ctx context.Context, flag vocab.ActivityStreamsFlag) error {
+	l := logrus.WithFields(
+		logrus.Fields{
+			"func": "Flag",
+		},
+	)
+
+	if logrus.GetLevel() >= logrus.DebugLevel {
+		i, err := marshalItem(flag)
+		if err != nil {
+			return err
+		}
+		l = l.WithField("flag", i)
+		l.Debug("entering Flag")
+	}
+
+	receivingAccount, requestingAccount, fromFederatorChan := extractFromCtx(ctx)
+	if receivingAccount == nil || requestingAccount == nil || fromFederatorChan == nil {
+		// If the receiving account or federator channel wasn't set on the context, that means this request didn't pass
+		// through the API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+
+	actorIRI, err := ap.ExtractActor(flag)
+	if err != nil {
+		return fmt.Errorf("Flag: error extracting actor: %s", err)
+	}
+
+	// a flag can only be sent on behalf of the account that signed it
+	if actorIRI.String() != requestingAccount.URI {
+		return errors.New("Flag: flag actor wasn't the requesting account")
+	}
+
+	report, err := f.typeConverter.ASFlagToReport(ctx, flag)
+	if err != nil {
+		return fmt.Errorf("Flag: could not convert Flag to gts model report: %s", err)
+	}
+
+	// the same flag might be delivered to more than one of our inboxes, so only store it once
+	if _, err := f.db.GetReportByURI(ctx, report.URI); err == nil {
+		l.Debugf("report %s already exists", report.URI)
+		return nil
+	} else if err != db.ErrNoEntries {
+		return fmt.Errorf("Flag: database error checking for existing report: %s", err)
+	}
+
+	newID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+	report.ID = newID
+
+	if err := f.db.PutReport(ctx, report); err != nil {
+		return fmt.Errorf("Flag: database error inserting report: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FlagTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *FlagTestSuite) newFlag(id string, actor string, comment string, objects ...string) vocab.ActivityStreamsFlag {
+	flag := streams.NewActivityStreamsFlag()
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(testrig.URLMustParse(id))
+	flag.SetJSONLDId(idProp)
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actor))
+	flag.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	for _, o := range objects {
+		objectProp.AppendIRI(testrig.URLMustParse(o))
+	}
+	flag.SetActivityStreamsObject(objectProp)
+
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString(comment)
+	flag.SetActivityStreamsContent(contentProp)
+
+	return flag
+}
+
+func (suite *FlagTestSuite) TestFlag() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus := suite.testStatuses["local_account_1_status_1"]
+	fromFederatorChan := make(chan messages.FromFederator, 10)
+
+	ctx := createTestContext(receivingAccount, requestingAccount, fromFederatorChan)
+
+	flag := suite.newFlag(
+		"http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d",
+		requestingAccount.URI,
+		"this is a bad post",
+		receivingAccount.URI,
+		reportedStatus.URI,
+		"http://fossbros-anonymous.io/users/foss_satan/statuses/not_ours",
+	)
+
+	err := suite.federatingDB.Flag(ctx, flag)
+	suite.NoError(err)
+
+	report, err := suite.db.GetReportByURI(context.Background(), "http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d")
+	suite.NoError(err)
+	suite.Equal(requestingAccount.ID, report.AccountID)
+	suite.Equal(receivingAccount.ID, report.TargetAccountID)
+	suite.Equal([]string{reportedStatus.ID}, report.StatusIDs)
+	suite.Equal("this is a bad post", report.Comment)
+	suite.False(report.Resolved())
+
+	// the same flag delivered again shouldn't create a second report
+	err = suite.federatingDB.Flag(ctx, flag)
+	suite.NoError(err)
+
+	reports, err := suite.db.GetReports(context.Background(), nil, requestingAccount.ID, "", "", 0)
+	suite.NoError(err)
+	suite.Len(reports, 1)
+}
+
+func (suite *FlagTestSuite) TestFlagWrongActor() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_2"]
+	fromFederatorChan := make(chan messages.FromFederator, 10)
+
+	ctx := createTestContext(receivingAccount, requestingAccount, fromFederatorChan)
+
+	// one account shouldn't be able to send flags on behalf of another
+	flag := suite.newFlag(
+		"http://fossbros-anonymous.io/5a1e6a2a-3f9e-4bb1-84d3-1a5f69bbd0c3",
+		suite.testAccounts["remote_account_1"].URI,
+		"",
+		receivingAccount.URI,
+	)
+
+	err := suite.federatingDB.Flag(ctx, flag)
+	suite.Error(err)
+}
+
+func (suite *FlagTestSuite) TestFlagNoLocalAccount() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	fromFederatorChan := make(chan messages.FromFederator, 10)
+
+	ctx := createTestContext(receivingAccount, requestingAccount, fromFederatorChan)
+
+	// a flag that's only about remote accounts is none of our business
+	flag := suite.newFlag(
+		"http://fossbros-anonymous.io/a8e7a0c4-8e8c-4a4f-9d3f-0c3e8c7b6f5e",
+		requestingAccount.URI,
+		"",
+		suite.testAccounts["remote_account_2"].URI,
+	)
+
+	err := suite.federatingDB.Flag(ctx, flag)
+	suite.Error(err)
+}
+
+func TestFlagTestSuite(t *testing.T) {
+	suite.Run(t, &FlagTestSuite{})
+}
@@ -53,6 +53,9 @@ func (f *federatingDB) Get(ctx context.Context, id *url.URL) (value vocab.Type,
 		if err != nil {
 			return nil, err
 		}
+		if status.PollID != "" {
+			return f.typeConverter.StatusToASQuestion(ctx, status)
+		}
 		return f.typeConverter.StatusToAS(ctx, status)
 	}
 
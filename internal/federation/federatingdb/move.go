@@ -0,0 +1,91 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove) error {
+	l := logrus.WithFields(
+		logrus.Fields{
+			"func": "Move",
+		},
+	)
+
+	if logrus.GetLevel() >= logrus.DebugLevel {
+		i, err := marshalItem(move)
+		if err != nil {
+			return err
+		}
+		l = l.WithField("move", i)
+		l.Debug("entering Move")
+	}
+
+	receivingAccount, requestingAccount, fromFederatorChan := extractFromCtx(ctx)
+	if receivingAccount == nil || requestingAccount == nil || fromFederatorChan == nil {
+		// If the receiving account or federator channel wasn't set on the context, that means this request didn't pass
+		// through the API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+
+	actorIRI, err := ap.ExtractActor(move)
+	if err != nil {
+		return fmt.Errorf("Move: error extracting actor: %s", err)
+	}
+
+	objectIRI, err := ap.ExtractObject(move)
+	if err != nil {
+		return fmt.Errorf("Move: error extracting object: %s", err)
+	}
+
+	targetIRI, err := ap.ExtractTarget(move)
+	if err != nil {
+		return fmt.Errorf("Move: error extracting target: %s", err)
+	}
+
+	// an account can only move itself
+	if actorIRI.String() != objectIRI.String() || actorIRI.String() != requestingAccount.URI {
+		return errors.New("Move: move actor and object weren't both the requesting account")
+	}
+
+	if targetIRI.String() == requestingAccount.URI {
+		return errors.New("Move: account can't move to itself")
+	}
+
+	// the rest of the move involves dereferencing the target
+	// to check that it agrees, so pass it to the processor async
+	fromFederatorChan <- messages.FromFederator{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		GTSModel:         requestingAccount,
+		APIri:            targetIRI,
+		ReceivingAccount: receivingAccount,
+	}
+
+	return nil
+}
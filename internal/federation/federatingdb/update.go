@@ -22,12 +22,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
@@ -156,5 +158,132 @@ func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 		}
 	}
 
+	if typeName == ap.ObjectNote {
+		// it's an UPDATE to a status, probably because it's been edited
+		l.Debug("got update for NOTE")
+		note, ok := asType.(vocab.ActivityStreamsNote)
+		if !ok {
+			return errors.New("UPDATE: could not convert type to note")
+		}
+		return f.updateNote(ctx, note, receivingAccount, requestingAcct, fromFederatorChan)
+	}
+
+	if typeName == ap.ActivityQuestion {
+		// it's an UPDATE to a poll, probably because its vote counts have changed
+		l.Debug("got update for QUESTION")
+		question, ok := asType.(vocab.ActivityStreamsQuestion)
+		if !ok {
+			return errors.New("UPDATE: could not convert type to question")
+		}
+		return f.updateQuestion(ctx, question, receivingAccount, requestingAcct, fromFederatorChan)
+	}
+
+	return nil
+}
+
+// updateNote passes an update to an existing remote status to the processor, so that it can be refreshed.
+func (f *federatingDB) updateNote(ctx context.Context, note vocab.ActivityStreamsNote, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account, fromFederatorChan chan messages.FromFederator) error {
+	noteID := note.GetJSONLDId()
+	if noteID == nil || !noteID.IsIRI() {
+		return errors.New("UPDATE: note had no id")
+	}
+
+	status, err := f.db.GetStatusByURI(ctx, noteID.GetIRI().String())
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// we don't know about this status, so there's nothing to update
+			return nil
+		}
+		return fmt.Errorf("UPDATE: error getting status %s: %s", noteID.GetIRI(), err)
+	}
+
+	if status.Local {
+		// we don't take updates to our own statuses
+		return nil
+	}
+
+	if requestingAccount == nil || status.AccountURI != requestingAccount.URI {
+		return fmt.Errorf("UPDATE: update for note %s was not requested by its author, this is not valid", status.URI)
+	}
+
+	fromFederatorChan <- messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityUpdate,
+		GTSModel:         status,
+		ReceivingAccount: receivingAccount,
+	}
+
+	return nil
+}
+
+// updateQuestion updates the poll of an existing remote status from the given question.
+func (f *federatingDB) updateQuestion(ctx context.Context, question vocab.ActivityStreamsQuestion, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account, fromFederatorChan chan messages.FromFederator) error {
+	questionID := question.GetJSONLDId()
+	if questionID == nil || !questionID.IsIRI() {
+		return errors.New("UPDATE: question had no id")
+	}
+
+	status, err := f.db.GetStatusByURI(ctx, questionID.GetIRI().String())
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// we don't know about this status, so there's nothing to update
+			return nil
+		}
+		return fmt.Errorf("UPDATE: error getting status %s: %s", questionID.GetIRI(), err)
+	}
+
+	if status.Local || status.PollID == "" {
+		// we don't take updates to our own polls, and we can't add a poll to an existing status
+		return nil
+	}
+
+	if requestingAccount == nil || status.AccountURI != requestingAccount.URI {
+		return fmt.Errorf("UPDATE: update for question %s was not requested by its author, this is not valid", status.URI)
+	}
+
+	poll, err := f.db.GetPollByID(ctx, status.PollID)
+	if err != nil {
+		return fmt.Errorf("UPDATE: error getting poll %s: %s", status.PollID, err)
+	}
+
+	updatedPoll, err := ap.ExtractPoll(question)
+	if err != nil {
+		return fmt.Errorf("UPDATE: error extracting poll from question: %s", err)
+	}
+
+	wasClosed := !poll.ClosedAt.IsZero()
+
+	// only take the fields that can change over the lifetime of a poll
+	poll.Votes = updatedPoll.Votes
+	poll.Voters = updatedPoll.Voters
+	poll.ExpiresAt = updatedPoll.ExpiresAt
+	poll.ClosedAt = updatedPoll.ClosedAt
+	poll.UpdatedAt = time.Now()
+
+	if err := f.db.UpdateByPrimaryKey(ctx, poll); err != nil {
+		return fmt.Errorf("UPDATE: database error updating poll %s: %s", poll.ID, err)
+	}
+
+	if updated := ap.ExtractUpdated(question); updated.After(status.EditedAt) {
+		// the text of the poll status has been edited too, so have the processor refresh it
+		fromFederatorChan <- messages.FromFederator{
+			APObjectType:     ap.ObjectNote,
+			APActivityType:   ap.ActivityUpdate,
+			GTSModel:         status,
+			ReceivingAccount: receivingAccount,
+		}
+	}
+
+	if !wasClosed && !poll.ClosedAt.IsZero() {
+		// the poll has just been closed, so pass it to the processor to let any local voters know
+		poll.Status = status
+		fromFederatorChan <- messages.FromFederator{
+			APObjectType:     ap.ActivityQuestion,
+			APActivityType:   ap.ActivityUpdate,
+			GTSModel:         poll,
+			ReceivingAccount: receivingAccount,
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,78 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type UpdateTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *UpdateTestSuite) TestUpdateNote() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	fromFederatorChan := make(chan messages.FromFederator, 10)
+
+	ctx := createTestContext(receivingAccount, requestingAccount, fromFederatorChan)
+
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	note, err := suite.tc.StatusToAS(context.Background(), testStatus)
+	suite.NoError(err)
+
+	err = suite.federatingDB.Update(ctx, note)
+	suite.NoError(err)
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg := <-fromFederatorChan
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+
+	// the status being updated should be defined on the message
+	status, ok := msg.GTSModel.(*gtsmodel.Status)
+	suite.True(ok)
+	suite.Equal(testStatus.ID, status.ID)
+}
+
+func (suite *UpdateTestSuite) TestUpdateNoteNotAuthor() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_2"]
+	fromFederatorChan := make(chan messages.FromFederator, 10)
+
+	ctx := createTestContext(receivingAccount, requestingAccount, fromFederatorChan)
+
+	note, err := suite.tc.StatusToAS(context.Background(), suite.testStatuses["remote_account_1_status_1"])
+	suite.NoError(err)
+
+	// someone who didn't write the status shouldn't be able to update it
+	err = suite.federatingDB.Update(ctx, note)
+	suite.Error(err)
+	suite.Empty(fromFederatorChan)
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &UpdateTestSuite{})
+}
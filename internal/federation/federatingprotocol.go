@@ -265,6 +265,12 @@ func (f *federator) FederatingCallbacks(ctx context.Context) (wrapped pub.Federa
 		func(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
 			return f.FederatingDB().Announce(ctx, announce)
 		},
+		func(ctx context.Context, move vocab.ActivityStreamsMove) error {
+			return f.FederatingDB().Move(ctx, move)
+		},
+		func(ctx context.Context, flag vocab.ActivityStreamsFlag) error {
+			return f.FederatingDB().Flag(ctx, flag)
+		},
 	}
 
 	return
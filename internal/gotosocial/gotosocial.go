@@ -67,7 +67,7 @@ func (gts *gotosocial) Start(ctx context.Context) error {
 }
 
 // Stop closes down the gotosocial server, first closing the router,
-// then the media manager, then the database.
+// then the media manager, then the delivery queue, then the database.
 // If something goes wrong while stopping, an error will be returned.
 func (gts *gotosocial) Stop(ctx context.Context) error {
 	if err := gts.apiRouter.Stop(ctx); err != nil {
@@ -76,6 +76,9 @@ func (gts *gotosocial) Stop(ctx context.Context) error {
 	if err := gts.mediaManager.Stop(); err != nil {
 		return err
 	}
+	if err := gts.federator.TransportController().Stop(); err != nil {
+		return err
+	}
 	if err := gts.db.Stop(ctx); err != nil {
 		return err
 	}
@@ -135,3 +135,16 @@ func NewErrorConflict(original error, helpText ...string) WithCode {
 		code:     http.StatusConflict,
 	}
 }
+
+// NewErrorUnprocessableEntity returns an ErrorWithCode 422 with the given original error and optional help text.
+func NewErrorUnprocessableEntity(original error, helpText ...string) WithCode {
+	safe := "unprocessable entity"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusUnprocessableEntity,
+	}
+}
@@ -44,7 +44,8 @@ type Account struct {
 	Fields                  []Field          `validate:"-"`                                                                                                          // a key/value map of fields that this account has added to their profile
 	Note                    string           `validate:"-" bun:""`                                                                                                   // A note that this account has on their profile (ie., the account's bio/description of themselves)
 	Memorial                bool             `validate:"-" bun:",default:false"`                                                                                     // Is this a memorial account, ie., has the user passed away?
-	AlsoKnownAs             string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // This account is associated with x account id (TODO: migrate to be AlsoKnownAsID)
+	AlsoKnownAsURIs         []string         `validate:"omitempty,dive,url" bun:"also_known_as_uris,array"`                                                          // ActivityPub URIs of other accounts that this account is also known as (aliases), used to authorize moves
+	MovedToURI              string           `validate:"omitempty,url" bun:",nullzero"`                                                                              // ActivityPub URI of the account that this account has moved to
 	MovedToAccountID        string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // This account has moved this account id in the database
 	Bot                     bool             `validate:"-" bun:",default:false"`                                                                                     // Does this account identify itself as a bot?
 	Reason                  string           `validate:"-" bun:""`                                                                                                   // What reason was given for signing up when this account was created?
@@ -53,6 +54,7 @@ type Account struct {
 	Privacy                 Visibility       `validate:"required_without=Domain,omitempty,oneof=public unlocked followers_only mutuals_only direct" bun:",nullzero"` // Default post privacy for this account
 	Sensitive               bool             `validate:"-" bun:",default:false"`                                                                                     // Set posts from this account to sensitive by default?
 	Language                string           `validate:"omitempty,bcp47_language_tag" bun:",nullzero,notnull,default:'en'"`                                          // What language does this account post in?
+	ExcludedNotifications   []string         `validate:"omitempty,dive,oneof=follow follow_request mention reblog favourite poll status" bun:",array"`               // Types of notification that shouldn't be streamed or pushed to this account's clients
 	URI                     string           `validate:"required,url" bun:",nullzero,notnull,unique"`                                                                // ActivityPub URI for this account.
 	URL                     string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Web URL for this account's profile
 	LastWebfingeredAt       time.Time        `validate:"required_with=Domain" bun:"type:timestamptz,nullzero"`                                                       // Last time this account was refreshed/located with webfinger.
@@ -0,0 +1,44 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Conversation represents one local account's view of a thread of direct messages
+// between a given set of participants.
+type Conversation struct {
+	ID               string     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                              // id of this item in the database
+	CreatedAt        time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                       // when was item created
+	UpdatedAt        time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                       // when was item last updated
+	AccountID        string     `validate:"required,ulid" bun:"type:CHAR(26),unique:conversationaccountthreadothers,notnull,nullzero"` // id of the local account that owns this view of the conversation
+	Account          *Account   `validate:"-" bun:"rel:belongs-to"`                                                                    // pointer to the account specified by accountID
+	OtherAccountIDs  []string   `validate:"dive,ulid" bun:"other_account_ids,array"`                                                   // ids of the other participants in the conversation
+	OtherAccounts    []*Account `validate:"-" bun:"-"`                                                                                 // other participants corresponding to otherAccountIDs
+	OtherAccountsKey string     `validate:"-" bun:",unique:conversationaccountthreadothers,notnull"`                                   // otherAccountIDs sorted and joined with commas, so that a participant set can be looked up
+	ThreadID         string     `validate:"required,ulid" bun:"type:CHAR(26),unique:conversationaccountthreadothers,notnull,nullzero"` // id of the status at the root of the thread this conversation happens in
+	LastStatusID     string     `validate:"required,ulid" bun:"type:CHAR(26),notnull,nullzero"`                                        // id of the most recent status in the conversation
+	LastStatus       *Status    `validate:"-" bun:"rel:belongs-to"`                                                                    // pointer to the status specified by lastStatusID
+	Read             bool       `validate:"-" bun:",notnull,default:false"`                                                            // has the owning account seen the most recent status in the conversation?
+}
+
+// ConversationToStatus is an intermediate struct to keep track of which statuses belong to a conversation.
+type ConversationToStatus struct {
+	ConversationID string `validate:"ulid,required" bun:"type:CHAR(26),unique:conversationstatus,nullzero,notnull"`
+	StatusID       string `validate:"ulid,required" bun:"type:CHAR(26),unique:conversationstatus,nullzero,notnull"`
+}
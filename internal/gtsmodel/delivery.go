@@ -0,0 +1,49 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Delivery represents an outgoing federation message queued for delivery to one remote inbox.
+// Deliveries are removed from the database once they succeed, so any delivery
+// in the database is either still waiting to be sent, or has been given up on.
+type Delivery struct {
+	ID            string        `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt     time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	PubKeyID      string        `validate:"required,url" bun:",nullzero,notnull"`                                // id of the public key of the local account sending this delivery, used to sign the request
+	InboxURI      string        `validate:"required,url" bun:",nullzero,notnull"`                                // uri of the remote inbox to deliver to
+	Host          string        `validate:"required" bun:",nullzero,notnull"`                                    // host of the remote inbox, used to back off from failing hosts
+	Payload       []byte        `validate:"required" bun:",nullzero,notnull"`                                    // serialized activitystreams representation of the activity being delivered
+	State         DeliveryState `validate:"oneof=pending failed" bun:",nullzero,notnull,default:'pending'"`      // is this delivery still being attempted, or has it been given up on?
+	Attempts      int           `validate:"min=0" bun:",notnull,default:0"`                                      // how many times has delivery been attempted?
+	LastAttemptAt time.Time     `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was delivery last attempted? zero value means never
+	NextAttemptAt time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when should delivery next be attempted?
+	LastError     string        `validate:"-" bun:",nullzero"`                                                   // error returned by the last attempt, if any
+}
+
+// DeliveryState describes where a delivery is in its lifecycle.
+type DeliveryState string
+
+const (
+	// DeliveryStatePending means the delivery has not been sent yet, but will be (re)attempted.
+	DeliveryStatePending DeliveryState = "pending"
+	// DeliveryStateFailed means the delivery has been given up on, and will not be attempted again.
+	DeliveryStateFailed DeliveryState = "failed"
+)
@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Marker refers to the last read position of a local account within one of its timelines,
+// so that the position can be synced between the clients the account uses.
+type Marker struct {
+	AccountID  string     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull"`               // id of the local account that owns the marker
+	Name       MarkerName `validate:"oneof=home notifications" bun:",pk,nullzero,notnull"`                 // name of the timeline the marker is for
+	UpdatedAt  time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was the marker last updated
+	Version    int        `validate:"-" bun:",nullzero,notnull,default:0"`                                 // incremented on every update, used for optimistic locking of the marker
+	LastReadID string     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the most recently read item in the timeline
+}
+
+// MarkerName is the name of one of the timelines that a marker can be set for.
+type MarkerName string
+
+const (
+	// MarkerNameHome is the marker for the home timeline.
+	MarkerNameHome MarkerName = "home"
+	// MarkerNameNotifications is the marker for the notifications timeline.
+	MarkerNameNotifications MarkerName = "notifications"
+)
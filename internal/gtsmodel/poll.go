@@ -0,0 +1,49 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Poll represents a poll attached to a status, either local or remote.
+type Poll struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID   string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull,unique"`           // database id of the status this poll is attached to
+	Status     *Status   `validate:"-" bun:"-"`                                                           // status corresponding to statusID
+	Multiple   bool      `validate:"-" bun:",notnull,default:false"`                                      // can voters choose more than one option?
+	HideCounts bool      `validate:"-" bun:",notnull,default:false"`                                      // should vote counts be hidden until the poll closes?
+	Options    []string  `validate:"min=2,dive,required" bun:",array"`                                    // the options that can be voted for, in order
+	Votes      []int     `validate:"-" bun:",array"`                                                      // number of votes cast for each option, in the same order as options
+	Voters     int       `validate:"min=0" bun:",notnull,default:0"`                                      // number of unique accounts that have voted in this poll
+	ExpiresAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when does this poll stop accepting votes? zero value means never
+	ClosedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was this poll closed and its results announced? zero value means still open
+}
+
+// PollVote represents the choice(s) made by one account in a poll.
+type PollVote struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                  // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item last updated
+	Choices   []int     `validate:"min=1,dive,min=0" bun:",array"`                                                 // indices of the poll options chosen by this account
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:pollvoteaccountpoll,notnull,nullzero"` // id of the account that cast this vote
+	Account   *Account  `validate:"-" bun:"rel:belongs-to"`                                                        // account that cast this vote
+	PollID    string    `validate:"required,ulid" bun:"type:CHAR(26),unique:pollvoteaccountpoll,notnull,nullzero"` // id of the poll this vote was cast in
+	Poll      *Poll     `validate:"-" bun:"rel:belongs-to"`                                                        // poll this vote was cast in
+}
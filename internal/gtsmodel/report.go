@@ -0,0 +1,60 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Report models a report of an account, and optionally some of its statuses, to the admins
+// of this instance. Reports can be made by local accounts, or come in from remote instances
+// as a Flag activity.
+type Report struct {
+	ID                     string         `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt              time.Time      `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt              time.Time      `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URI                    string         `validate:"required,url" bun:",nullzero,notnull,unique"`                         // activitypub URI of the Flag activity for this report
+	AccountID              string         `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that made the report; for remote reports this is the remote instance's actor
+	Account                *Account       `validate:"-" bun:"rel:belongs-to,join:account_id=id"`                           // account that made the report
+	TargetAccountID        string         `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account being reported
+	TargetAccount          *Account       `validate:"-" bun:"rel:belongs-to,join:target_account_id=id"`                    // account being reported
+	StatusIDs              []string       `validate:"dive,ulid" bun:"statuses,array"`                                      // database IDs of statuses by the target account that are attached to the report
+	Statuses               []*Status      `validate:"-" bun:"-"`                                                           // statuses attached to the report
+	Comment                string         `validate:"-" bun:""`                                                            // comment given by the account that made the report
+	Category               ReportCategory `validate:"oneof=spam other" bun:",nullzero,notnull,default:'other'"`            // category of the report
+	Forwarded              bool           `validate:"-" bun:",notnull,default:false"`                                      // was this report forwarded to the target account's instance?
+	AssignedAccountID      string         `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the local admin account that is handling this report, if any
+	AssignedAccount        *Account       `validate:"-" bun:"rel:belongs-to,join:assigned_account_id=id"`                  // local admin account that is handling this report
+	ActionTakenAt          time.Time      `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was this report resolved? zero value means it's still open
+	ActionTakenByAccountID string         `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the local admin account that resolved this report, if it's been resolved
+	ActionTakenByAccount   *Account       `validate:"-" bun:"rel:belongs-to,join:action_taken_by_account_id=id"`           // local admin account that resolved this report
+}
+
+// Resolved returns true if an admin has taken action on this report.
+func (r *Report) Resolved() bool {
+	return !r.ActionTakenAt.IsZero()
+}
+
+// ReportCategory describes why an account was reported.
+type ReportCategory string
+
+const (
+	// ReportCategorySpam means the account or statuses were reported as spam.
+	ReportCategorySpam ReportCategory = "spam"
+	// ReportCategoryOther means the account or statuses were reported for some other reason.
+	ReportCategoryOther ReportCategory = "other"
+)
@@ -38,6 +38,8 @@ type Status struct {
 	Mentions                 []*Mention         `validate:"-" bun:"attached_mentions,rel:has-many"`                                                    // Mentions corresponding to mentionIDs
 	EmojiIDs                 []string           `validate:"dive,ulid" bun:"emojis,array"`                                                              // Database IDs of any emojis used in this status
 	Emojis                   []*Emoji           `validate:"-" bun:"attached_emojis,m2m:status_to_emojis"`                                              // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
+	PollID                   string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the poll attached to this status, if any
+	Poll                     *Poll              `validate:"-" bun:"-"`                                                                                 // poll corresponding to pollID
 	Local                    bool               `validate:"-" bun:",notnull,default:false"`                                                            // is this status from a local account?
 	AccountID                string             `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                                        // which account posted this status?
 	Account                  *Account           `validate:"-" bun:"rel:belongs-to"`                                                                    // account corresponding to accountID
@@ -64,6 +66,7 @@ type Status struct {
 	Boostable                bool               `validate:"-" bun:",notnull"`                                                                          // This status can be boosted/reblogged
 	Replyable                bool               `validate:"-" bun:",notnull"`                                                                          // This status can be replied to
 	Likeable                 bool               `validate:"-" bun:",notnull"`                                                                          // This status can be liked/faved
+	EditedAt                 time.Time          `validate:"-" bun:"type:timestamptz,nullzero"`                                                         // when was this status last edited? zero value means never
 }
 
 /*
@@ -40,4 +40,5 @@ type Token struct {
 	Refresh             string    `validate:"-" bun:",pk,nullzero,notnull,default:''"`                             // Refresh token, if present
 	RefreshCreateAt     time.Time `validate:"required_with=Refresh" bun:"type:timestamptz,nullzero"`               // Refresh created at, if refresh present
 	RefreshExpiresAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // Refresh expires at -- null means the refresh token never expires
+	LastUsedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When was this token last used to authorize a request -- null means it's never been used
 }
@@ -56,4 +56,10 @@ type User struct {
 	Approved               bool         `validate:"-" bun:",notnull,default:false"`                                      // Has this user been approved by a moderator?
 	ResetPasswordToken     string       `validate:"required_with=ResetPasswordSentAt" bun:",nullzero"`                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `validate:"required_with=ResetPasswordToken" bun:"type:timestamptz,nullzero"`    // When did we email the user their reset-password email?
+	TwoFactorSecret        string       `validate:"required_with=TwoFactorEnabledAt" bun:",nullzero"`                    // Base32 TOTP secret shared with the user's authenticator app. Set when they start enrolling, before two-factor authentication is enabled.
+	TwoFactorEnabledAt     time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When did the user enable two-factor authentication? Zero if it's not enabled.
+	TwoFactorLastStep      int64        `validate:"min=0" bun:",notnull,default:0"`                                      // TOTP time step of the last code the user signed in with, so that codes can't be used twice.
+	TwoFactorRecoveryCodes []string     `validate:"-" bun:",array"`                                                      // Bcrypt hashes of the one-time recovery codes the user has left, for when they lose their authenticator.
+	TwoFactorAttempts      int          `validate:"min=0" bun:",notnull,default:0"`                                      // How many wrong two-factor codes has the user given in a row? Reset by a right code, or by being locked out.
+	TwoFactorLockedUntil   time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // Until when are two-factor codes refused for this user, after too many wrong ones? Zero if not locked out.
 }
@@ -26,12 +26,12 @@ import (
 	"time"
 
 	"codeberg.org/gruf/go-runners"
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Manager provides an interface for managing media: parsing, storing, and retrieving media objects like photos, videos, and gifs.
@@ -88,7 +88,7 @@ type Manager interface {
 
 type manager struct {
 	db           db.DB
-	storage      *kv.KVStore
+	storage      *storage.Driver
 	pool         runners.WorkerPool
 	stopCronJobs func() error
 	numWorkers   int
@@ -108,7 +108,7 @@ type manager struct {
 // So for an 8 core machine, the media manager will get 4 workers, and a queue of length 40.
 // For a 4 core machine, this will be 2 workers, and a queue length of 20.
 // For a single or 2-core machine, the media manager will get 1 worker, and a queue of length 10.
-func NewManager(database db.DB, storage *kv.KVStore) (Manager, error) {
+func NewManager(database db.DB, storage *storage.Driver) (Manager, error) {
 
 	// configure the worker pool
 	// make sure we always have at least 1 worker even on single-core machines
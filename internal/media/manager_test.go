@@ -28,11 +28,11 @@ import (
 	"testing"
 	"time"
 
-	"codeberg.org/gruf/go-store/kv"
 	"codeberg.org/gruf/go-store/storage"
 	"github.com/stretchr/testify/suite"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 type ManagerTestSuite struct {
@@ -374,13 +374,18 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingWithDiskStorage() {
 	temp := fmt.Sprintf("%s/gotosocial-test", os.TempDir())
 	defer os.RemoveAll(temp)
 
-	diskStorage, err := kv.OpenFile(temp, &storage.DiskConfig{
+	disk, err := storage.OpenFile(temp, &storage.DiskConfig{
 		LockFile: path.Join(temp, "store.lock"),
 	})
 	if err != nil {
 		panic(err)
 	}
 
+	diskStorage, err := gtsstorage.NewDriver(disk)
+	if err != nil {
+		panic(err)
+	}
+
 	diskManager, err := media.NewManager(suite.db, diskStorage)
 	if err != nil {
 		panic(err)
@@ -28,12 +28,12 @@ import (
 	"sync/atomic"
 	"time"
 
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/sirupsen/logrus"
 	terminator "github.com/superseriousbusiness/exif-terminator"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -61,7 +61,7 @@ type ProcessingMedia struct {
 	*/
 
 	database db.DB
-	storage  *kv.KVStore
+	storage  *storage.Driver
 
 	err error // error created during processing, if any
 
@@ -0,0 +1,204 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scope is an oauth scope that can be requested by an application and granted to a token,
+// following the Mastodon scope hierarchy: https://docs.joinmastodon.org/api/oauth-scopes/
+type Scope string
+
+// Top-level scopes, which grant all of the granular scopes beneath them.
+const (
+	ScopeRead       Scope = "read"
+	ScopeWrite      Scope = "write"
+	ScopeFollow     Scope = "follow" // deprecated by Mastodon, but still requested by lots of clients
+	ScopePush       Scope = "push"
+	ScopeAdmin      Scope = "admin" // not a Mastodon scope: grants both admin:read and admin:write
+	ScopeAdminRead  Scope = "admin:read"
+	ScopeAdminWrite Scope = "admin:write"
+)
+
+// Granular read scopes.
+const (
+	ScopeReadAccounts      Scope = "read:accounts"
+	ScopeReadBlocks        Scope = "read:blocks"
+	ScopeReadBookmarks     Scope = "read:bookmarks"
+	ScopeReadFavourites    Scope = "read:favourites"
+	ScopeReadFilters       Scope = "read:filters"
+	ScopeReadFollows       Scope = "read:follows"
+	ScopeReadLists         Scope = "read:lists"
+	ScopeReadMutes         Scope = "read:mutes"
+	ScopeReadNotifications Scope = "read:notifications"
+	ScopeReadSearch        Scope = "read:search"
+	ScopeReadStatuses      Scope = "read:statuses"
+)
+
+// Granular write scopes.
+const (
+	ScopeWriteAccounts      Scope = "write:accounts"
+	ScopeWriteBlocks        Scope = "write:blocks"
+	ScopeWriteBookmarks     Scope = "write:bookmarks"
+	ScopeWriteConversations Scope = "write:conversations"
+	ScopeWriteFavourites    Scope = "write:favourites"
+	ScopeWriteFilters       Scope = "write:filters"
+	ScopeWriteFollows       Scope = "write:follows"
+	ScopeWriteLists         Scope = "write:lists"
+	ScopeWriteMedia         Scope = "write:media"
+	ScopeWriteMutes         Scope = "write:mutes"
+	ScopeWriteNotifications Scope = "write:notifications"
+	ScopeWriteReports       Scope = "write:reports"
+	ScopeWriteStatuses      Scope = "write:statuses"
+)
+
+// Granular admin scopes.
+const (
+	ScopeAdminReadAccounts      Scope = "admin:read:accounts"
+	ScopeAdminReadReports       Scope = "admin:read:reports"
+	ScopeAdminReadDomainAllows  Scope = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks  Scope = "admin:read:domain_blocks"
+	ScopeAdminWriteAccounts     Scope = "admin:write:accounts"
+	ScopeAdminWriteReports      Scope = "admin:write:reports"
+	ScopeAdminWriteDomainAllows Scope = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks Scope = "admin:write:domain_blocks"
+)
+
+// knownScopes contains every scope that an application can request.
+var knownScopes = map[Scope]bool{
+	ScopeRead:                   true,
+	ScopeWrite:                  true,
+	ScopeFollow:                 true,
+	ScopePush:                   true,
+	ScopeAdmin:                  true,
+	ScopeAdminRead:              true,
+	ScopeAdminWrite:             true,
+	ScopeReadAccounts:           true,
+	ScopeReadBlocks:             true,
+	ScopeReadBookmarks:          true,
+	ScopeReadFavourites:         true,
+	ScopeReadFilters:            true,
+	ScopeReadFollows:            true,
+	ScopeReadLists:              true,
+	ScopeReadMutes:              true,
+	ScopeReadNotifications:      true,
+	ScopeReadSearch:             true,
+	ScopeReadStatuses:           true,
+	ScopeWriteAccounts:          true,
+	ScopeWriteBlocks:            true,
+	ScopeWriteBookmarks:         true,
+	ScopeWriteConversations:     true,
+	ScopeWriteFavourites:        true,
+	ScopeWriteFilters:           true,
+	ScopeWriteFollows:           true,
+	ScopeWriteLists:             true,
+	ScopeWriteMedia:             true,
+	ScopeWriteMutes:             true,
+	ScopeWriteNotifications:     true,
+	ScopeWriteReports:           true,
+	ScopeWriteStatuses:          true,
+	ScopeAdminReadAccounts:      true,
+	ScopeAdminReadReports:       true,
+	ScopeAdminReadDomainAllows:  true,
+	ScopeAdminReadDomainBlocks:  true,
+	ScopeAdminWriteAccounts:     true,
+	ScopeAdminWriteReports:      true,
+	ScopeAdminWriteDomainAllows: true,
+	ScopeAdminWriteDomainBlocks: true,
+}
+
+// followScopes are the granular scopes granted by the deprecated follow scope.
+var followScopes = map[Scope]bool{
+	ScopeReadBlocks:   true,
+	ScopeWriteBlocks:  true,
+	ScopeReadFollows:  true,
+	ScopeWriteFollows: true,
+	ScopeReadMutes:    true,
+	ScopeWriteMutes:   true,
+}
+
+// DefaultScope is the scope used when an application or authorization request doesn't ask for one.
+const DefaultScope = string(ScopeRead)
+
+// ValidateScopes checks that every scope in the given space-separated scope string is known.
+func ValidateScopes(scope string) error {
+	scopes := strings.Fields(scope)
+	if len(scopes) == 0 {
+		return fmt.Errorf("no scopes provided")
+	}
+
+	for _, s := range scopes {
+		if !knownScopes[Scope(s)] {
+			return fmt.Errorf("scope %s was not recognized", s)
+		}
+	}
+
+	return nil
+}
+
+// Grants returns true if the given space-separated scope string grants the given scope,
+// either directly or through one of the scopes above it in the hierarchy, so that eg.,
+// read grants read:statuses, and admin:write grants admin:write:accounts.
+// The deprecated follow scope grants reading and writing follows, blocks and mutes.
+func Grants(scope string, required Scope) bool {
+	for _, s := range strings.Fields(scope) {
+		granted := Scope(s)
+		switch {
+		case granted == required:
+			return true
+		case granted == ScopeFollow && followScopes[required]:
+			return true
+		case strings.HasPrefix(string(required), string(granted)+":"):
+			// read grants read:*, admin grants admin:*, admin:read grants admin:read:*, and so on
+			return true
+		}
+	}
+	return false
+}
+
+// GrantsAll returns true if every scope in the given space-separated requested scope string
+// is granted by the given space-separated scope string, for example because an application
+// is allowed to request them.
+func GrantsAll(scope string, requested string) bool {
+	for _, r := range strings.Fields(requested) {
+		if !Grants(scope, Scope(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Scoped wraps the given handler so that requests made with a token that doesn't grant the required scope
+// are rejected with a 403, naming the missing scope. Requests without a token are passed through to the
+// handler unchanged, so that it can decide for itself whether it needs a token or not.
+func Scoped(required Scope, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authed, err := Authed(c, true, false, false, false)
+		if err == nil && !Grants(authed.Token.GetScope(), required) {
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("forbidden: token is missing required scope %s", required)})
+			return
+		}
+		handler(c)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) TestValidateScopes() {
+	suite.NoError(oauth.ValidateScopes("read write follow push"))
+	suite.NoError(oauth.ValidateScopes("read:statuses write:media admin:read:accounts"))
+	suite.EqualError(oauth.ValidateScopes(""), "no scopes provided")
+	suite.EqualError(oauth.ValidateScopes("read write:everything"), "scope write:everything was not recognized")
+}
+
+func (suite *ScopeTestSuite) TestGrants() {
+	suite.True(oauth.Grants("read", oauth.ScopeRead))
+	suite.True(oauth.Grants("read", oauth.ScopeReadStatuses))
+	suite.True(oauth.Grants("write push", oauth.ScopeWriteMedia))
+	suite.True(oauth.Grants("follow", oauth.ScopeWriteBlocks))
+	suite.True(oauth.Grants("admin", oauth.ScopeAdminReadAccounts))
+	suite.True(oauth.Grants("admin:read", oauth.ScopeAdminReadReports))
+
+	suite.False(oauth.Grants("read", oauth.ScopeWriteStatuses))
+	suite.False(oauth.Grants("read:statuses", oauth.ScopeRead))
+	suite.False(oauth.Grants("follow", oauth.ScopeWriteStatuses))
+	suite.False(oauth.Grants("admin:read", oauth.ScopeAdminWriteAccounts))
+	suite.False(oauth.Grants("read write follow push", oauth.ScopeAdminRead))
+	suite.False(oauth.Grants("", oauth.ScopeRead))
+}
+
+func (suite *ScopeTestSuite) TestGrantsAll() {
+	suite.True(oauth.GrantsAll("read write follow push", "read write"))
+	suite.True(oauth.GrantsAll("read write", "read:statuses write:media"))
+	suite.False(oauth.GrantsAll("read write", "read push"))
+	suite.False(oauth.GrantsAll("read:statuses", "read"))
+}
+
+func (suite *ScopeTestSuite) scoped(required oauth.Scope, scope string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/timelines/home", nil)
+	if scope != "" {
+		ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(&gtsmodel.Token{Scope: scope}))
+	}
+
+	oauth.Scoped(required, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})(ctx)
+
+	return recorder
+}
+
+func (suite *ScopeTestSuite) TestScopedGranted() {
+	recorder := suite.scoped(oauth.ScopeReadStatuses, "read write")
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *ScopeTestSuite) TestScopedMissingScope() {
+	recorder := suite.scoped(oauth.ScopeWriteStatuses, "read follow")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`{"error":"forbidden: token is missing required scope write:statuses"}`, recorder.Body.String())
+}
+
+func (suite *ScopeTestSuite) TestScopedNoToken() {
+	// without a token, the handler decides for itself
+	recorder := suite.scoped(oauth.ScopeReadStatuses, "")
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, new(ScopeTestSuite))
+}
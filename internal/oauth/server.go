@@ -20,11 +20,13 @@ package oauth
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/oauth2/v4"
 	"github.com/superseriousbusiness/oauth2/v4/errors"
 	"github.com/superseriousbusiness/oauth2/v4/manage"
@@ -57,11 +59,13 @@ type Server interface {
 	ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error)
 	GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
+	RevokeToken(ctx context.Context, clientID string, clientSecret string, token string) (revoked *gtsmodel.Token, err error)
 }
 
 // s fulfils the Server interface using the underlying oauth2 server
 type s struct {
-	server *server.Server
+	server     *server.Server
+	tokenStore *tokenStore
 }
 
 // New returns a new oauth server that implements the Server interface
@@ -110,8 +114,37 @@ func New(ctx context.Context, database db.Basic) Server {
 		return userID, nil
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
+
+	// make sure only known scopes can be authorized, defaulting to read if none are requested
+	srv.SetAuthorizeScopeHandler(func(w http.ResponseWriter, r *http.Request) (string, error) {
+		scope := r.FormValue("scope")
+		if scope == "" {
+			scope = DefaultScope
+		}
+		if err := ValidateScopes(scope); err != nil {
+			return "", errors.ErrInvalidScope
+		}
+		return scope, nil
+	})
+
+	// make sure tokens can only be generated with scopes that the application asked for when it was created
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		if tgr.Scope == "" {
+			tgr.Scope = DefaultScope
+		}
+		if err := ValidateScopes(tgr.Scope); err != nil {
+			return false, nil
+		}
+
+		app := &gtsmodel.Application{}
+		if err := database.GetWhere(ctx, []db.Where{{Key: "client_id", Value: tgr.ClientID}}, app); err != nil {
+			return false, err
+		}
+		return GrantsAll(app.Scopes, tgr.Scope), nil
+	})
 	return &s{
-		server: srv,
+		server:     srv,
+		tokenStore: ts,
 	}
 }
 
@@ -174,3 +207,32 @@ func (s *s) GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, cl
 func (s *s) LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error) {
 	return s.server.Manager.LoadAccessToken(ctx, access)
 }
+
+// RevokeToken revokes the access or refresh token given by a client, as described in RFC 7009,
+// and returns the revoked token so that the caller can clean up anything that was using it.
+//
+// The client has to authenticate with its secret, and can only revoke its own tokens. Revoking a
+// token that doesn't exist (anymore) isn't an error, so in that case both return values are nil.
+func (s *s) RevokeToken(ctx context.Context, clientID string, clientSecret string, token string) (*gtsmodel.Token, error) {
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	if err != nil || subtle.ConstantTimeCompare([]byte(client.GetSecret()), []byte(clientSecret)) != 1 {
+		return nil, errors.ErrInvalidClient
+	}
+
+	revoked, err := s.tokenStore.getByAccessOrRefresh(ctx, token)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if revoked.ClientID != clientID {
+		return nil, errors.ErrUnauthorizedClient
+	}
+
+	if err := s.tokenStore.removeByID(ctx, revoked.ID); err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
@@ -32,6 +32,10 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4/models"
 )
 
+// lastUsedInterval is how often the last used time of a token is updated when it's used to authorize
+// requests, so that we're not writing to the database on every single request.
+const lastUsedInterval = 5 * time.Minute
+
 // tokenStore is an implementation of oauth2.TokenStore, which uses our db interface as a storage backend.
 type tokenStore struct {
 	oauth2.TokenStore
@@ -42,7 +46,7 @@ type tokenStore struct {
 //
 // In order to allow tokens to 'expire', it will also set off a goroutine that iterates through
 // the tokens in the DB once per minute and deletes any that have expired.
-func newTokenStore(ctx context.Context, db db.Basic) oauth2.TokenStore {
+func newTokenStore(ctx context.Context, db db.Basic) *tokenStore {
 	ts := &tokenStore{
 		db: db,
 	}
@@ -154,6 +158,9 @@ func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.To
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, dbt); err != nil {
 		return nil, err
 	}
+
+	// access tokens are only loaded to authorize requests, so this counts as using the token
+	ts.markUsed(ctx, dbt)
 	return DBTokenToToken(dbt), nil
 }
 
@@ -171,6 +178,44 @@ func (ts *tokenStore) GetByRefresh(ctx context.Context, refresh string) (oauth2.
 	return DBTokenToToken(dbt), nil
 }
 
+// getByAccessOrRefresh selects a token from the DB whose Access or Refresh field is the given token,
+// since token revocation requests don't have to say which of the two they're revoking.
+func (ts *tokenStore) getByAccessOrRefresh(ctx context.Context, token string) (*gtsmodel.Token, error) {
+	if token == "" {
+		return nil, db.ErrNoEntries
+	}
+
+	dbt := &gtsmodel.Token{}
+	err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: token}}, dbt)
+	if err == db.ErrNoEntries {
+		err = ts.db.GetWhere(ctx, []db.Where{{Key: "refresh", Value: token}}, dbt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbt, nil
+}
+
+// removeByID deletes a token from the DB based on the ID field
+func (ts *tokenStore) removeByID(ctx context.Context, id string) error {
+	return ts.db.DeleteWhere(ctx, []db.Where{{Key: "id", Value: id}}, &gtsmodel.Token{})
+}
+
+// markUsed records that the given token has just been used to authorize a request,
+// unless that was already recorded less than lastUsedInterval ago.
+func (ts *tokenStore) markUsed(ctx context.Context, dbt *gtsmodel.Token) {
+	now := time.Now()
+	if now.Sub(dbt.LastUsedAt) < lastUsedInterval {
+		return
+	}
+
+	if err := ts.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: dbt.ID}}, "last_used_at", now, &gtsmodel.Token{}); err != nil {
+		logrus.Errorf("error marking token %s as used: %s", dbt.ID, err)
+		return
+	}
+	dbt.LastUsedAt = now
+}
+
 /*
 	The following models are basically helpers for the token store implementation, they should only be used internally.
 */
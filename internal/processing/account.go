@@ -77,3 +77,31 @@ func (p *processor) AccountBlockCreate(ctx context.Context, authed *oauth.Auth,
 func (p *processor) AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	return p.accountProcessor.BlockRemove(ctx, authed.Account, targetAccountID)
 }
+
+func (p *processor) AccountMuteCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	relationship, errWithCode := p.accountProcessor.MuteCreate(ctx, authed.Account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+	return relationship, nil
+}
+
+func (p *processor) AccountMuteRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	relationship, errWithCode := p.accountProcessor.MuteRemove(ctx, authed.Account, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+	return relationship, nil
+}
+
+func (p *processor) AccountAlias(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.Alias(ctx, authed.Account, form)
+}
+
+func (p *processor) AccountMove(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.Move(ctx, authed.Account, form)
+}
@@ -52,6 +52,14 @@ type Processor interface {
 	GetLocalByUsername(ctx context.Context, requestingAccount *gtsmodel.Account, username string) (*apimodel.Account, gtserror.WithCode)
 	// Update processes the update of an account with the given form
 	Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
+	// Alias sets the aliases of an account, replacing any that were set before.
+	Alias(ctx context.Context, account *gtsmodel.Account, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode)
+	// Move moves an account to another account that has the first account as an alias,
+	// and moves the account's followers over to the new account.
+	Move(ctx context.Context, account *gtsmodel.Account, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode)
+	// MoveFollow moves a follow of origin by follower over to target, keeping the follow settings.
+	// Does nothing if follower doesn't follow origin.
+	MoveFollow(ctx context.Context, follower *gtsmodel.Account, origin *gtsmodel.Account, target *gtsmodel.Account) gtserror.WithCode
 	// StatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
 	// the account given in authed.
 	StatusesGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinned bool, mediaOnly bool, publicOnly bool) ([]apimodel.Status, gtserror.WithCode)
@@ -69,6 +77,10 @@ type Processor interface {
 	BlockCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// BlockRemove handles the removal of a block from requestingAccount to targetAccountID, either remote or local.
 	BlockRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// MuteCreate handles the creation or updating of a mute from requestingAccount to the target account specified in the form.
+	MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode)
+	// MuteRemove handles the removal of a mute from requestingAccount to targetAccountID.
+	MuteRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 
 	// UpdateHeader does the dirty work of checking the header part of an account update form,
 	// parsing and checking the image, and doing the necessary updates in the database for this to become
@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// maxAliases is the maximum number of aliases that an account can have.
+const maxAliases = 5
+
+func (p *processor) Alias(ctx context.Context, account *gtsmodel.Account, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode) {
+	if len(form.AlsoKnownAsURIs) > maxAliases {
+		err := fmt.Errorf("an account can have at most %d aliases", maxAliases)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	aliases := []string{}
+	for _, rawURI := range form.AlsoKnownAsURIs {
+		uri, err := url.Parse(rawURI)
+		if err != nil || (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "" {
+			err := fmt.Errorf("alias %s was not a valid uri", rawURI)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if uri.String() == account.URI {
+			err := errors.New("an account can't be an alias of itself")
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		// make sure the alias is an account that we can actually find
+		alias, errWithCode := p.resolveAccount(ctx, account, uri, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		// skip duplicates, but keep the order that was given
+		if !containsString(aliases, alias.URI) {
+			aliases = append(aliases, alias.URI)
+		}
+	}
+
+	account.AlsoKnownAsURIs = aliases
+	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Alias: could not update account %s: %s", account.ID, err))
+	}
+
+	// the aliases are part of the profile, so federate them out as an update
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       updatedAccount,
+		OriginAccount:  updatedAccount,
+	}
+
+	acctSensitive, err := p.tc.AccountToAPIAccountSensitive(ctx, updatedAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Alias: could not convert account into apisensitive account: %s", err))
+	}
+	return acctSensitive, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
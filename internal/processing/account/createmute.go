@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+func (p *processor) MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	if form.ID == requestingAccount.ID {
+		err := errors.New("you can't mute yourself")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.Duration < 0 {
+		err := errors.New("duration must not be negative")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// make sure the target account actually exists in our db
+	if _, err := p.db.GetAccountByID(ctx, form.ID); err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("MuteCreate: error getting account %s from the db: %s", form.ID, err))
+	}
+
+	// notifications are muted too unless the caller says otherwise
+	notifications := true
+	if form.Notifications != nil {
+		notifications = *form.Notifications
+	}
+
+	var expiresAt time.Time
+	if form.Duration != 0 {
+		expiresAt = time.Now().Add(time.Duration(form.Duration) * time.Second)
+	}
+
+	// if requestingAccount already mutes target account, just update the existing mute with the new settings
+	mute, err := p.db.GetMute(ctx, requestingAccount.ID, form.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error checking existence of mute: %s", err))
+	}
+
+	if mute != nil {
+		mute.Notifications = notifications
+		mute.ExpiresAt = expiresAt
+		mute.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, mute); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error updating mute in db: %s", err))
+		}
+		return p.RelationshipGet(ctx, requestingAccount, form.ID)
+	}
+
+	newMuteID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mute = &gtsmodel.UserMute{
+		ID:              newMuteID,
+		ExpiresAt:       expiresAt,
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: form.ID,
+		Notifications:   notifications,
+	}
+
+	// mutes are private to the account that created them, so there's nothing to federate
+	if err := p.db.Put(ctx, mute); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error creating mute in db: %s", err))
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, form.ID)
+}
@@ -41,7 +41,7 @@ import (
 // 3. Delete account's emoji
 // 4. Delete account's follow requests
 // 5. Delete account's follows
-// 6. Delete account's statuses
+// 6. Delete account's statuses and scheduled statuses
 // 7. Delete account's media attachments
 // 8. Delete account's mentions
 // 9. Delete account's polls
@@ -51,9 +51,10 @@ import (
 // 13. Delete account's mutes
 // 14. Delete account's streams
 // 15. Delete account's tags
-// 16. Delete account's user
-// 17. Delete account's timeline
-// 18. Delete account itself
+// 16. Delete account's conversations
+// 17. Delete account's user
+// 18. Delete account's timeline
+// 19. Delete account itself
 func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origin string) gtserror.WithCode {
 	fields := logrus.Fields{
 		"func":     "Delete",
@@ -66,7 +67,7 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 
 	l.Debug("beginning account delete process")
 
-	// 1. Delete account's application(s), clients, and oauth tokens
+	// 1. Delete account's application(s), clients, oauth tokens and push subscriptions
 	// we only need to do this step for local account since remote ones won't have any tokens or applications on our server
 	if account.Domain == "" {
 		// see if we can get a user for this account
@@ -92,6 +93,11 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 				}
 			}
 		}
+
+		// push subscriptions were made with the tokens, so they can't be used anymore
+		if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.PushSubscription{}); err != nil {
+			l.Errorf("error deleting push subscriptions: %s", err)
+		}
 	}
 
 	// 2. Delete account's blocks
@@ -220,6 +226,12 @@ selectStatusesLoop:
 	}
 	l.Debug("done deleting statuses")
 
+	// scheduled statuses haven't been published yet, so they can just be dropped
+	l.Debug("deleting account scheduled statuses")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.ScheduledStatus{}); err != nil {
+		l.Errorf("error deleting scheduled statuses created by account: %s", err)
+	}
+
 	// 10. Delete account's notifications
 	l.Debug("deleting account notifications")
 	// first notifications created by account
@@ -238,6 +250,11 @@ selectStatusesLoop:
 		l.Errorf("error deleting bookmarks created by account: %s", err)
 	}
 
+	// now bookmarks of the account's statuses
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
+		l.Errorf("error deleting bookmarks targeting account: %s", err)
+	}
+
 	// 12. Delete account's faves
 	// TODO: federate these if necessary
 	l.Debug("deleting account faves")
@@ -251,22 +268,65 @@ selectStatusesLoop:
 		l.Errorf("error deleting status mutes created by account: %s", err)
 	}
 
+	// now mutes of the account's threads
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.StatusMute{}); err != nil {
+		l.Errorf("error deleting status mutes targeting account: %s", err)
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.UserMute{}); err != nil {
+		l.Errorf("error deleting user mutes created by account: %s", err)
+	}
+
+	// now mutes that target this account
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.UserMute{}); err != nil {
+		l.Errorf("error deleting user mutes targeting account: %s", err)
+	}
+
 	// 14. Delete account's streams
 	// TODO
 
 	// 15. Delete account's tags
-	// TODO
+	// tags themselves are shared between accounts, so we only delete the account's follows of them
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.TagFollow{}); err != nil {
+		l.Errorf("error deleting tag follows created by account: %s", err)
+	}
 
-	// 16. Delete account's user
+	// 16. Delete account's conversations
+	l.Debug("deleting account conversations")
+	var maxConversationID string
+	for {
+		conversations, err := p.db.GetAccountConversations(ctx, account.ID, maxConversationID, "", "", 100)
+		if err != nil && err != db.ErrNoEntries {
+			l.Errorf("error getting conversations of account: %s", err)
+			break
+		}
+		if len(conversations) == 0 {
+			break
+		}
+		for _, c := range conversations {
+			if err := p.db.DeleteConversationByID(ctx, c.ID); err != nil {
+				l.Errorf("error deleting conversation %s: %s", c.ID, err)
+			}
+		}
+		// conversations are paged by their last status
+		maxConversationID = conversations[len(conversations)-1].LastStatusID
+	}
+
+	// 17. Delete account's user
 	l.Debug("deleting account user")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &gtsmodel.User{}); err != nil {
 		return gtserror.NewErrorInternalError(err)
 	}
 
-	// 17. Delete account's timeline
+	// 18. Delete account's timeline
 	// TODO
 
-	// 18. Delete account itself
+	// the account's markers in its timelines can go already
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.Marker{}); err != nil {
+		l.Errorf("error deleting timeline markers of account: %s", err)
+	}
+
+	// 19. Delete account itself
 	// to prevent the account being created again, set all these fields and update it in the db
 	// the account won't actually be *removed* from the database but it will be set to just a stub
 
@@ -53,6 +53,11 @@ func (p *processor) StatusesGet(ctx context.Context, requestingAccount *gtsmodel
 			continue
 		}
 
+		filteredOut, err := p.filter.StatusFilteredOut(ctx, s, requestingAccount, gtsmodel.FilterContextAccount)
+		if err != nil || filteredOut {
+			continue
+		}
+
 		apiStatus, err := p.tc.StatusToAPIStatus(ctx, s, requestingAccount)
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status to api: %s", err))
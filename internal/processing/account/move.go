@@ -0,0 +1,151 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (p *processor) Move(ctx context.Context, account *gtsmodel.Account, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode) {
+	// moving takes all of an account's followers away, so make sure it's really the owner asking
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user.EncryptedPassword == "" {
+		return nil, gtserror.NewErrorForbidden(errors.New("user password was not set"))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(form.Password)); err != nil {
+		return nil, gtserror.NewErrorForbidden(errors.New("invalid password"))
+	}
+
+	targetURI, err := url.Parse(form.MovedToURI)
+	if err != nil || (targetURI.Scheme != "http" && targetURI.Scheme != "https") || targetURI.Host == "" {
+		err := fmt.Errorf("moved_to_uri %s was not a valid uri", form.MovedToURI)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if targetURI.String() == account.URI {
+		err := errors.New("an account can't move to itself")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// always get the freshest version of the target, since
+	// its aliases might have been changed just before the move
+	target, errWithCode := p.resolveAccount(ctx, account, targetURI, true)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// the target has to agree that it's the same person as this account
+	if !containsString(target.AlsoKnownAsURIs, account.URI) {
+		err := fmt.Errorf("account %s doesn't have %s as an alias", target.URI, account.URI)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	account.MovedToURI = target.URI
+	account.MovedToAccountID = target.ID
+	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Move: could not update account %s: %s", account.ID, err))
+	}
+
+	// federating the move and moving local followers over happens asynchronously
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityMove,
+		GTSModel:       updatedAccount,
+		OriginAccount:  updatedAccount,
+		TargetAccount:  target,
+	}
+
+	acctSensitive, err := p.tc.AccountToAPIAccountSensitive(ctx, updatedAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Move: could not convert account into apisensitive account: %s", err))
+	}
+	return acctSensitive, nil
+}
+
+func (p *processor) MoveFollow(ctx context.Context, follower *gtsmodel.Account, origin *gtsmodel.Account, target *gtsmodel.Account) gtserror.WithCode {
+	follow := &gtsmodel.Follow{}
+	if err := p.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: follower.ID},
+		{Key: "target_account_id", Value: origin.ID},
+	}, follow); err != nil {
+		if err == db.ErrNoEntries {
+			// not following the origin (anymore), so there's nothing to move
+			return nil
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("MoveFollow: error getting follow: %s", err))
+	}
+
+	// the follower might be the account that was moved to, which can't follow itself
+	if follower.ID != target.ID {
+		if _, errWithCode := p.FollowCreate(ctx, follower, &apimodel.AccountFollowRequest{
+			ID:      target.ID,
+			Reblogs: &follow.ShowReblogs,
+			Notify:  &follow.Notify,
+		}); errWithCode != nil {
+			// leave the old follow in place so the follower doesn't lose track of both accounts
+			return errWithCode
+		}
+	}
+
+	_, errWithCode := p.FollowRemove(ctx, follower, origin.ID)
+	return errWithCode
+}
+
+// resolveAccount gets the account with the given uri, from the database if it's a local account,
+// or by dereferencing it if it's a remote one. If refresh is true, a remote account will always be
+// dereferenced, even if it's already in the database.
+func (p *processor) resolveAccount(ctx context.Context, requestingAccount *gtsmodel.Account, uri *url.URL, refresh bool) (*gtsmodel.Account, gtserror.WithCode) {
+	if uri.Host == viper.GetString(config.Keys.Host) {
+		account, err := p.db.GetAccountByURI(ctx, uri.String())
+		if err != nil {
+			if err == db.ErrNoEntries {
+				err := fmt.Errorf("account %s not found", uri)
+				return nil, gtserror.NewErrorNotFound(err, err.Error())
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return account, nil
+	}
+
+	account, err := p.federator.GetRemoteAccount(ctx, requestingAccount.Username, uri, true, refresh)
+	if err != nil {
+		err := fmt.Errorf("account %s could not be dereferenced: %s", uri, err)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+	return account, nil
+}
@@ -0,0 +1,146 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountMoveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountMoveTestSuite) TestAlias() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+	aliasAccount := suite.testAccounts["admin_account"]
+
+	apiAccount, errWithCode := suite.accountProcessor.Alias(context.Background(), testAccount, &apimodel.AccountAliasRequest{
+		AlsoKnownAsURIs: []string{aliasAccount.URI, aliasAccount.URI},
+	})
+	suite.NoError(errWithCode)
+	suite.Equal([]string{aliasAccount.URI}, apiAccount.Source.AlsoKnownAsURIs)
+
+	// the new aliases should be federated as a profile update
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal([]string{aliasAccount.URI}, dbAccount.AlsoKnownAsURIs)
+}
+
+func (suite *AccountMoveTestSuite) TestAliasSelf() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+
+	_, errWithCode := suite.accountProcessor.Alias(context.Background(), testAccount, &apimodel.AccountAliasRequest{
+		AlsoKnownAsURIs: []string{testAccount.URI},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AccountMoveTestSuite) TestMove() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+
+	// the account being moved to has to list the moving account as an alias
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["admin_account"]
+	targetAccount.AlsoKnownAsURIs = []string{testAccount.URI}
+	_, err := suite.db.UpdateAccount(context.Background(), targetAccount)
+	suite.NoError(err)
+
+	apiAccount, errWithCode := suite.accountProcessor.Move(context.Background(), testAccount, &apimodel.AccountMoveRequest{
+		Password:   "password",
+		MovedToURI: targetAccount.URI,
+	})
+	suite.NoError(errWithCode)
+	suite.NotNil(apiAccount.Moved)
+	suite.Equal(targetAccount.ID, apiAccount.Moved.ID)
+	suite.Nil(apiAccount.Moved.Moved)
+
+	// the move should be passed on for federating and moving followers
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityMove, msg.APActivityType)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(targetAccount.ID, msg.TargetAccount.ID)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(targetAccount.URI, dbAccount.MovedToURI)
+	suite.Equal(targetAccount.ID, dbAccount.MovedToAccountID)
+}
+
+func (suite *AccountMoveTestSuite) TestMoveNotAliased() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+
+	_, errWithCode := suite.accountProcessor.Move(context.Background(), testAccount, &apimodel.AccountMoveRequest{
+		Password:   "password",
+		MovedToURI: suite.testAccounts["admin_account"].URI,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.MovedToURI)
+}
+
+func (suite *AccountMoveTestSuite) TestMoveWrongPassword() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+
+	_, errWithCode := suite.accountProcessor.Move(context.Background(), testAccount, &apimodel.AccountMoveRequest{
+		Password:   "not the password",
+		MovedToURI: suite.testAccounts["admin_account"].URI,
+	})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *AccountMoveTestSuite) TestMoveFollow() {
+	follower := suite.testAccounts["local_account_1"]
+	origin := suite.testAccounts["local_account_2"]
+	target := suite.testAccounts["remote_account_1"]
+
+	errWithCode := suite.accountProcessor.MoveFollow(context.Background(), follower, origin, target)
+	suite.NoError(errWithCode)
+
+	// the origin shouldn't be followed anymore...
+	following, err := suite.db.IsFollowing(context.Background(), follower, origin)
+	suite.NoError(err)
+	suite.False(following)
+
+	// ...and the target should have been sent a follow request instead
+	requested, err := suite.db.IsFollowRequested(context.Background(), follower, target)
+	suite.NoError(err)
+	suite.True(requested)
+}
+
+func TestAccountMoveTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMoveTestSuite))
+}
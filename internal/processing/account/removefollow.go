@@ -75,6 +75,10 @@ func (p *processor) FollowRemove(ctx context.Context, requestingAccount *gtsmode
 		if err := p.db.DeleteByID(ctx, f.ID, f); err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountFollowRemove: error removing follow from db: %s", err))
 		}
+		// the followed account can no longer be a member of any of our lists
+		if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "follow_id", Value: f.ID}}, &gtsmodel.ListEntry{}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountFollowRemove: error removing list entries from db: %s", err))
+		}
 		fChanged = true
 	}
 
@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) MuteRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	// make sure the target account actually exists in our db
+	if _, err := p.db.GetAccountByID(ctx, targetAccountID); err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("MuteRemove: error getting account %s from the db: %s", targetAccountID, err))
+	}
+
+	// remove the mute if there is one; there's nothing to federate since mutes are private
+	if err := p.db.DeleteWhere(ctx, []db.Where{
+		{Key: "account_id", Value: requestingAccount.ID},
+		{Key: "target_account_id", Value: targetAccountID},
+	}, &gtsmodel.UserMute{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteRemove: error removing mute from db: %s", err))
+	}
+
+	// return whatever relationship results from all this
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
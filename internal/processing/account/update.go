@@ -110,6 +110,15 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 			privacy := p.tc.APIVisToVis(apimodel.Visibility(*form.Source.Privacy))
 			account.Privacy = privacy
 		}
+
+		if form.Source.ExcludedNotifications != nil {
+			for _, t := range *form.Source.ExcludedNotifications {
+				if err := validate.NotificationType(t); err != nil {
+					return nil, err
+				}
+			}
+			account.ExcludedNotifications = *form.Source.ExcludedNotifications
+		}
 	}
 
 	updatedAccount, err := p.db.UpdateAccount(ctx, account)
@@ -113,6 +113,54 @@ go check out @1happyturtle, they have a cool account!
 	suite.Equal(noteExpected, dbAccount.Note)
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateExcludedNotifications() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	excluded := []string{"favourite", "reblog"}
+	form := &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			ExcludedNotifications: &excluded,
+		},
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.NoError(err)
+	suite.NotNil(apiAccount)
+	suite.Equal(excluded, apiAccount.Source.ExcludedNotifications)
+
+	// drain the update from the client api channel
+	<-suite.fromClientAPIChan
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(excluded, dbAccount.ExcludedNotifications)
+
+	// an empty list should clear the excluded types again
+	excluded = []string{}
+	_, err = suite.accountProcessor.Update(context.Background(), dbAccount, form)
+	suite.NoError(err)
+	<-suite.fromClientAPIChan
+
+	dbAccount, err = suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.ExcludedNotifications)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateExcludedNotificationsUnknownType() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	excluded := []string{"favourite", "explosion"}
+	form := &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			ExcludedNotifications: &excluded,
+		},
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.EqualError(err, "notification type explosion was not recognized")
+	suite.Nil(apiAccount)
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
@@ -30,6 +30,38 @@ func (p *processor) AdminAccountAction(ctx context.Context, authed *oauth.Auth,
 	return p.adminProcessor.AccountAction(ctx, authed.Account, form)
 }
 
+func (p *processor) AdminAccountsGet(ctx context.Context, authed *oauth.Auth, origin string, status string, domain string, username string, email string, ip string, maxID string, limit int) ([]*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountsGet(ctx, authed.Account, origin, status, domain, username, email, ip, maxID, limit)
+}
+
+func (p *processor) AdminAccountGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountGet(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminAccountApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountApprove(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminAccountReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountReject(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminAccountEnable(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountEnable(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminAccountUnsilence(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountUnsilence(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminAccountUnsuspend(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountUnsuspend(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminAccountResetTwoFactor(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	return p.adminProcessor.AccountResetTwoFactor(ctx, authed.Account, id)
+}
+
 func (p *processor) AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode) {
 	return p.adminProcessor.EmojiCreate(ctx, authed.Account, authed.User, form)
 }
@@ -53,3 +85,47 @@ func (p *processor) AdminDomainBlockGet(ctx context.Context, authed *oauth.Auth,
 func (p *processor) AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode) {
 	return p.adminProcessor.DomainBlockDelete(ctx, authed.Account, id)
 }
+
+func (p *processor) AdminDomainAllowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainAllowCreateRequest) (*apimodel.DomainAllow, gtserror.WithCode) {
+	return p.adminProcessor.DomainAllowCreate(ctx, authed.Account, form.Domain, form.PublicComment, form.PrivateComment)
+}
+
+func (p *processor) AdminDomainAllowsGet(ctx context.Context, authed *oauth.Auth, export bool) ([]*apimodel.DomainAllow, gtserror.WithCode) {
+	return p.adminProcessor.DomainAllowsGet(ctx, authed.Account, export)
+}
+
+func (p *processor) AdminDomainAllowGet(ctx context.Context, authed *oauth.Auth, id string, export bool) (*apimodel.DomainAllow, gtserror.WithCode) {
+	return p.adminProcessor.DomainAllowGet(ctx, authed.Account, id, export)
+}
+
+func (p *processor) AdminDomainAllowDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	return p.adminProcessor.DomainAllowDelete(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminDeliveriesGet(ctx context.Context, authed *oauth.Auth, state string, maxID string, limit int) ([]*apimodel.Delivery, gtserror.WithCode) {
+	return p.adminProcessor.DeliveriesGet(ctx, authed.Account, state, maxID, limit)
+}
+
+func (p *processor) AdminReportsGet(ctx context.Context, authed *oauth.Auth, resolved *bool, accountID string, targetAccountID string, maxID string, limit int) ([]*apimodel.AdminReportInfo, gtserror.WithCode) {
+	return p.adminProcessor.ReportsGet(ctx, authed.Account, resolved, accountID, targetAccountID, maxID, limit)
+}
+
+func (p *processor) AdminReportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	return p.adminProcessor.ReportGet(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportAssign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	return p.adminProcessor.ReportAssign(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportUnassign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	return p.adminProcessor.ReportUnassign(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportResolve(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	return p.adminProcessor.ReportResolve(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportReopen(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	return p.adminProcessor.ReportReopen(ctx, authed.Account, id)
+}
@@ -0,0 +1,306 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (p *processor) AccountsGet(ctx context.Context, account *gtsmodel.Account, origin string, status string, domain string, username string, email string, ip string, maxID string, limit int) ([]*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	if limit <= 0 {
+		err := errors.New("limit must be greater than 0")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	switch origin {
+	case "", "local", "remote":
+	default:
+		err := fmt.Errorf("origin %s was not recognized", origin)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	switch status {
+	case "", "active", "pending", "disabled", "silenced", "suspended":
+	default:
+		err := fmt.Errorf("status %s was not recognized", status)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if ip != "" && net.ParseIP(ip) == nil {
+		err := fmt.Errorf("ip %s could not be parsed", ip)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	accounts, err := p.db.GetAdminAccounts(ctx, origin, status, domain, username, email, ip, maxID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := []*apimodel.AdminAccountInfo{}
+	for _, a := range accounts {
+		apiAccount, errWithCode := p.apiAccount(ctx, a)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+func (p *processor) AccountGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAccount(ctx, targetAccount)
+}
+
+func (p *processor) AccountApprove(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, user, errWithCode := p.getPendingAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	user.Approved = true
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// let the user know by email that they're in
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       user,
+		OriginAccount:  account,
+		TargetAccount:  targetAccount,
+	}
+
+	return p.apiAccount(ctx, targetAccount)
+}
+
+func (p *processor) AccountReject(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, user, errWithCode := p.getPendingAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// convert the account before it's gone, so the caller can see what was rejected
+	apiAccount, errWithCode := p.apiAccount(ctx, targetAccount)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// a pending account can't have done anything yet, so it can be removed entirely,
+	// which frees up its username and email address to sign up with again
+	if err := p.db.DeleteByID(ctx, user.ID, &gtsmodel.User{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteAccount(ctx, targetAccount.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// let the user know by email that they're not getting in
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       user,
+		OriginAccount:  account,
+		TargetAccount:  targetAccount,
+	}
+
+	return apiAccount, nil
+}
+
+func (p *processor) AccountEnable(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	user, errWithCode := p.getUser(ctx, targetAccount)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if user.Disabled {
+		user.Disabled = false
+		if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiAccount(ctx, targetAccount)
+}
+
+func (p *processor) AccountUnsilence(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !targetAccount.SilencedAt.IsZero() {
+		targetAccount.SilencedAt = time.Time{}
+		if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiAccount(ctx, targetAccount)
+}
+
+func (p *processor) AccountUnsuspend(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if targetAccount.SuspendedAt.IsZero() {
+		return p.apiAccount(ctx, targetAccount)
+	}
+
+	if targetAccount.Domain != "" {
+		// accounts suspended along with their whole domain have to wait for the domain block to be removed
+		blocked, err := p.db.IsDomainBlocked(ctx, targetAccount.Domain)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if blocked {
+			err := fmt.Errorf("domain %s of account %s is blocked", targetAccount.Domain, targetAccount.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	} else {
+		// suspending a local account removes its user, so there's nobody left to sign in as
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: targetAccount.ID}}, &gtsmodel.User{}); err != nil {
+			if err == db.ErrNoEntries {
+				err := fmt.Errorf("local account %s was removed when it was suspended and can't be restored", targetAccount.ID)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// statuses and media removed by the suspension are gone for good, but the account can
+	// interact and federate again, and its profile will be refreshed the next time it's dereferenced
+	targetAccount.SuspendedAt = time.Time{}
+	targetAccount.SuspensionOrigin = ""
+	if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccount(ctx, targetAccount)
+}
+
+func (p *processor) AccountResetTwoFactor(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	user, errWithCode := p.getUser(ctx, targetAccount)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// this also throws away any enrollment that wasn't finished
+	if user.TwoFactorSecret != "" {
+		user.TwoFactorSecret = ""
+		user.TwoFactorEnabledAt = time.Time{}
+		user.TwoFactorLastStep = 0
+		user.TwoFactorRecoveryCodes = nil
+		user.TwoFactorAttempts = 0
+		user.TwoFactorLockedUntil = time.Time{}
+		if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiAccount(ctx, targetAccount)
+}
+
+// getAccount fetches the account with the given id, returning a 404 if it doesn't exist.
+func (p *processor) getAccount(ctx context.Context, id string) (*gtsmodel.Account, gtserror.WithCode) {
+	account, err := p.db.GetAccountByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return account, nil
+}
+
+// getUser fetches the user of the given local account, returning a 400 if the account doesn't have one.
+func (p *processor) getUser(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.User, gtserror.WithCode) {
+	if account.Domain != "" {
+		err := fmt.Errorf("account %s is not a local account", account.ID)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			err := fmt.Errorf("account %s has no user", account.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return user, nil
+}
+
+// getPendingAccount fetches the account with the given id and its user, returning a 400 if the user isn't waiting for approval.
+func (p *processor) getPendingAccount(ctx context.Context, id string) (*gtsmodel.Account, *gtsmodel.User, gtserror.WithCode) {
+	account, errWithCode := p.getAccount(ctx, id)
+	if errWithCode != nil {
+		return nil, nil, errWithCode
+	}
+
+	user, errWithCode := p.getUser(ctx, account)
+	if errWithCode != nil {
+		return nil, nil, errWithCode
+	}
+
+	if user.Approved {
+		err := fmt.Errorf("account %s is not pending approval", account.ID)
+		return nil, nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return account, user, nil
+}
+
+func (p *processor) apiAccount(ctx context.Context, account *gtsmodel.Account) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	apiAccount, err := p.tc.AccountToAdminAPIAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiAccount, nil
+}
@@ -3,6 +3,7 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -13,9 +14,9 @@ import (
 )
 
 func (p *processor) AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode {
-	targetAccount, err := p.db.GetAccountByID(ctx, form.TargetAccountID)
-	if err != nil {
-		return gtserror.NewErrorInternalError(err)
+	targetAccount, errWithCode := p.getAccount(ctx, form.TargetAccountID)
+	if errWithCode != nil {
+		return errWithCode
 	}
 
 	adminActionID, err := id.NewULID()
@@ -30,6 +31,18 @@ func (p *processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 		Text:            form.Text,
 	}
 
+	var report *gtsmodel.Report
+	if form.ReportID != "" {
+		if report, errWithCode = p.getReport(ctx, form.ReportID); errWithCode != nil {
+			return errWithCode
+		}
+		if report.TargetAccountID != targetAccount.ID {
+			err := fmt.Errorf("report %s is not about account %s", report.ID, targetAccount.ID)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		adminAction.ReportID = report.ID
+	}
+
 	switch form.Type {
 	case string(gtsmodel.AdminActionSuspend):
 		adminAction.Type = gtsmodel.AdminActionSuspend
@@ -40,6 +53,24 @@ func (p *processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 			OriginAccount:  account,
 			TargetAccount:  targetAccount,
 		}
+	case string(gtsmodel.AdminActionSilence):
+		adminAction.Type = gtsmodel.AdminActionSilence
+		// statuses of silenced accounts are kept out of public timelines
+		targetAccount.SilencedAt = time.Now()
+		if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	case string(gtsmodel.AdminActionDisable):
+		adminAction.Type = gtsmodel.AdminActionDisable
+		// disabled users can't sign in or use their tokens, but their account is left alone
+		user, errWithCode := p.getUser(ctx, targetAccount)
+		if errWithCode != nil {
+			return errWithCode
+		}
+		user.Disabled = true
+		if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
 	default:
 		return gtserror.NewErrorBadRequest(fmt.Errorf("admin action type %s is not supported for this endpoint", form.Type))
 	}
@@ -48,5 +79,13 @@ func (p *processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	// taking action on an account resolves the report that prompted it
+	if report != nil {
+		resolveReport(report, account)
+		if err := p.db.UpdateReport(ctx, report); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	return nil
 }
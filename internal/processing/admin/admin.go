@@ -38,8 +38,27 @@ type Processor interface {
 	DomainBlocksGet(ctx context.Context, account *gtsmodel.Account, export bool) ([]*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlockGet(ctx context.Context, account *gtsmodel.Account, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainBlock, gtserror.WithCode)
+	DomainAllowCreate(ctx context.Context, account *gtsmodel.Account, domain string, publicComment string, privateComment string) (*apimodel.DomainAllow, gtserror.WithCode)
+	DomainAllowsGet(ctx context.Context, account *gtsmodel.Account, export bool) ([]*apimodel.DomainAllow, gtserror.WithCode)
+	DomainAllowGet(ctx context.Context, account *gtsmodel.Account, id string, export bool) (*apimodel.DomainAllow, gtserror.WithCode)
+	DomainAllowDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainAllow, gtserror.WithCode)
 	AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode
+	AccountsGet(ctx context.Context, account *gtsmodel.Account, origin string, status string, domain string, username string, email string, ip string, maxID string, limit int) ([]*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountApprove(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountReject(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountEnable(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountUnsilence(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountUnsuspend(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	AccountResetTwoFactor(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
 	EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode)
+	DeliveriesGet(ctx context.Context, account *gtsmodel.Account, state string, maxID string, limit int) ([]*apimodel.Delivery, gtserror.WithCode)
+	ReportsGet(ctx context.Context, account *gtsmodel.Account, resolved *bool, accountID string, targetAccountID string, maxID string, limit int) ([]*apimodel.AdminReportInfo, gtserror.WithCode)
+	ReportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	ReportAssign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	ReportResolve(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	ReportReopen(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
 }
 
 type processor struct {
@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) DomainAllowCreate(ctx context.Context, account *gtsmodel.Account, domain string, publicComment string, privateComment string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	// first check if we already have an allow -- if err == nil we already had one so there's nothing to do
+	domainAllow := &gtsmodel.DomainAllow{}
+	err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: domain, CaseInsensitive: true}}, domainAllow)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			// something went wrong in the DB
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: db error checking for existence of domain allow %s: %s", domain, err))
+		}
+
+		// there's no allow for this domain yet so create one
+		allowID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: error creating id for new domain allow %s: %s", domain, err))
+		}
+
+		domainAllow = &gtsmodel.DomainAllow{
+			ID:                 allowID,
+			Domain:             domain,
+			CreatedByAccountID: account.ID,
+			PrivateComment:     text.RemoveHTML(privateComment),
+			PublicComment:      text.RemoveHTML(publicComment),
+		}
+
+		if err := p.db.Put(ctx, domainAllow); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: db error putting new domain allow %s: %s", domain, err))
+		}
+	}
+
+	apiDomainAllow, err := p.tc.DomainAllowToAPIDomainAllow(ctx, domainAllow, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: error converting domain allow to frontend/api representation %s: %s", domain, err))
+	}
+
+	return apiDomainAllow, nil
+}
+
+func (p *processor) DomainAllowsGet(ctx context.Context, account *gtsmodel.Account, export bool) ([]*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllows := []*gtsmodel.DomainAllow{}
+
+	if err := p.db.GetAll(ctx, &domainAllows); err != nil {
+		if err != db.ErrNoEntries {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiDomainAllows := []*apimodel.DomainAllow{}
+	for _, a := range domainAllows {
+		apiDomainAllow, err := p.tc.DomainAllowToAPIDomainAllow(ctx, a, export)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiDomainAllows = append(apiDomainAllows, apiDomainAllow)
+	}
+
+	return apiDomainAllows, nil
+}
+
+func (p *processor) DomainAllowGet(ctx context.Context, account *gtsmodel.Account, id string, export bool) (*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllow := &gtsmodel.DomainAllow{}
+
+	if err := p.db.GetByID(ctx, id, domainAllow); err != nil {
+		if err != db.ErrNoEntries {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// there are no entries for this ID
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	apiDomainAllow, err := p.tc.DomainAllowToAPIDomainAllow(ctx, domainAllow, export)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDomainAllow, nil
+}
+
+// DomainAllowDelete removes the domain allow with the given ID. Unlike creating a domain block, this has no side effects:
+// in allowlist mode, this instance just stops federating with the domain, but accounts and statuses from it are kept.
+func (p *processor) DomainAllowDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllow := &gtsmodel.DomainAllow{}
+
+	if err := p.db.GetByID(ctx, id, domainAllow); err != nil {
+		if err != db.ErrNoEntries {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// there are no entries for this ID
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	// prepare the domain allow to return
+	apiDomainAllow, err := p.tc.DomainAllowToAPIDomainAllow(ctx, domainAllow, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, id, domainAllow); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDomainAllow, nil
+}
@@ -0,0 +1,61 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) DeliveriesGet(ctx context.Context, account *gtsmodel.Account, state string, maxID string, limit int) ([]*apimodel.Delivery, gtserror.WithCode) {
+	deliveryState := gtsmodel.DeliveryState(state)
+	switch deliveryState {
+	case "", gtsmodel.DeliveryStatePending, gtsmodel.DeliveryStateFailed:
+	default:
+		err := fmt.Errorf("state %s not recognized, must be one of %s or %s", state, gtsmodel.DeliveryStatePending, gtsmodel.DeliveryStateFailed)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if limit <= 0 {
+		err := errors.New("limit must be greater than 0")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	deliveries, err := p.db.GetDeliveries(ctx, deliveryState, maxID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDeliveries := []*apimodel.Delivery{}
+	for _, d := range deliveries {
+		apiDelivery, err := p.tc.DeliveryToAPIDelivery(ctx, d)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiDeliveries = append(apiDeliveries, apiDelivery)
+	}
+
+	return apiDeliveries, nil
+}
@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) ReportsGet(ctx context.Context, account *gtsmodel.Account, resolved *bool, accountID string, targetAccountID string, maxID string, limit int) ([]*apimodel.AdminReportInfo, gtserror.WithCode) {
+	if limit <= 0 {
+		err := errors.New("limit must be greater than 0")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	reports, err := p.db.GetReports(ctx, resolved, accountID, targetAccountID, maxID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiReports := []*apimodel.AdminReportInfo{}
+	for _, r := range reports {
+		apiReport, err := p.tc.ReportToAdminAPIReport(ctx, r, account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiReports = append(apiReports, apiReport)
+	}
+
+	return apiReports, nil
+}
+
+func (p *processor) ReportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiReport(ctx, report, account)
+}
+
+func (p *processor) ReportAssign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.AssignedAccountID = account.ID
+	report.AssignedAccount = account
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.AssignedAccountID = ""
+	report.AssignedAccount = nil
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportResolve(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	resolveReport(report, account)
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportReopen(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.ActionTakenAt = time.Time{}
+	report.ActionTakenByAccountID = ""
+	report.ActionTakenByAccount = nil
+
+	return p.updateReport(ctx, report, account)
+}
+
+// getReport fetches the report with the given id, returning a 404 if it doesn't exist.
+func (p *processor) getReport(ctx context.Context, id string) (*gtsmodel.Report, gtserror.WithCode) {
+	report, err := p.db.GetReportByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("report %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return report, nil
+}
+
+// resolveReport marks the given report as resolved by the given admin account, if it isn't already.
+func resolveReport(report *gtsmodel.Report, account *gtsmodel.Account) {
+	if report.Resolved() {
+		return
+	}
+	report.ActionTakenAt = time.Now()
+	report.ActionTakenByAccountID = account.ID
+	report.ActionTakenByAccount = account
+}
+
+func (p *processor) updateReport(ctx context.Context, report *gtsmodel.Report, account *gtsmodel.Account) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	if err := p.db.UpdateReport(ctx, report); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return p.apiReport(ctx, report, account)
+}
+
+func (p *processor) apiReport(ctx context.Context, report *gtsmodel.Report, account *gtsmodel.Account) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	apiReport, err := p.tc.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiReport, nil
+}
@@ -0,0 +1,216 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AdminTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AdminTestSuite) adminAuthed() *oauth.Auth {
+	return &oauth.Auth{
+		Account: suite.testAccounts["admin_account"],
+		User:    suite.testUsers["admin_account"],
+	}
+}
+
+func (suite *AdminTestSuite) TestAdminAccountsGet() {
+	ctx := context.Background()
+
+	accounts, errWithCode := suite.processor.AdminAccountsGet(ctx, suite.adminAuthed(), "local", "pending", "", "", "", "", "", 100)
+	suite.NoError(errWithCode)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["unconfirmed_account"].ID, accounts[0].ID)
+	suite.Equal("weed_lord420@example.org", accounts[0].Email)
+	suite.Equal(suite.testAccounts["unconfirmed_account"].Reason, accounts[0].InviteRequest)
+	suite.False(accounts[0].Approved)
+
+	_, errWithCode = suite.processor.AdminAccountsGet(ctx, suite.adminAuthed(), "", "", "", "", "", "not an ip", "", 100)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminTestSuite) TestAdminAccountGet() {
+	ctx := context.Background()
+
+	account, errWithCode := suite.processor.AdminAccountGet(ctx, suite.adminAuthed(), suite.testAccounts["local_account_1"].ID)
+	suite.NoError(errWithCode)
+	suite.Equal("zork@example.org", account.Email)
+	suite.Equal("user", account.Role)
+	suite.True(account.Approved)
+	suite.True(account.Confirmed)
+	suite.Equal("88.234.118.16", account.IP)
+	suite.Len(account.IPs, 3)
+	suite.Equal("59.99.19.172", account.IPs[2].IP)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, account.Account.ID)
+
+	account, errWithCode = suite.processor.AdminAccountGet(ctx, suite.adminAuthed(), suite.testAccounts["admin_account"].ID)
+	suite.NoError(errWithCode)
+	suite.Equal("admin", account.Role)
+
+	// remote accounts don't have user details
+	account, errWithCode = suite.processor.AdminAccountGet(ctx, suite.adminAuthed(), suite.testAccounts["remote_account_1"].ID)
+	suite.NoError(errWithCode)
+	suite.Equal("fossbros-anonymous.io", account.Domain)
+	suite.Empty(account.Email)
+	suite.Empty(account.IPs)
+
+	_, errWithCode = suite.processor.AdminAccountGet(ctx, suite.adminAuthed(), "01G4M1W2ZFZG4NNPK3S0F0N9XT")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *AdminTestSuite) TestAdminAccountApprove() {
+	ctx := context.Background()
+	pending := suite.testAccounts["unconfirmed_account"]
+
+	account, errWithCode := suite.processor.AdminAccountApprove(ctx, suite.adminAuthed(), pending.ID)
+	suite.NoError(errWithCode)
+	suite.True(account.Approved)
+
+	user := &gtsmodel.User{}
+	err := suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: pending.ID}}, user)
+	suite.NoError(err)
+	suite.True(user.Approved)
+
+	// it's not pending anymore so it can't be approved or rejected again
+	_, errWithCode = suite.processor.AdminAccountApprove(ctx, suite.adminAuthed(), pending.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	_, errWithCode = suite.processor.AdminAccountReject(ctx, suite.adminAuthed(), pending.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminTestSuite) TestAdminAccountReject() {
+	ctx := context.Background()
+	pending := suite.testAccounts["unconfirmed_account"]
+
+	account, errWithCode := suite.processor.AdminAccountReject(ctx, suite.adminAuthed(), pending.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(pending.ID, account.ID)
+
+	_, err := suite.db.GetAccountByID(ctx, pending.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: pending.ID}}, &gtsmodel.User{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// the username can be signed up with again
+	available, err := suite.db.IsUsernameAvailable(ctx, pending.Username)
+	suite.NoError(err)
+	suite.True(available)
+}
+
+func (suite *AdminTestSuite) TestAdminAccountSilenceUnsilence() {
+	ctx := context.Background()
+	target := suite.testAccounts["remote_account_1"]
+
+	errWithCode := suite.processor.AdminAccountAction(ctx, suite.adminAuthed(), &apimodel.AdminAccountActionRequest{
+		Type:            "silence",
+		TargetAccountID: target.ID,
+	})
+	suite.NoError(errWithCode)
+
+	accounts, errWithCode := suite.processor.AdminAccountsGet(ctx, suite.adminAuthed(), "", "silenced", "", "", "", "", "", 100)
+	suite.NoError(errWithCode)
+	suite.Len(accounts, 1)
+	suite.Equal(target.ID, accounts[0].ID)
+	suite.True(accounts[0].Silenced)
+
+	account, errWithCode := suite.processor.AdminAccountUnsilence(ctx, suite.adminAuthed(), target.ID)
+	suite.NoError(errWithCode)
+	suite.False(account.Silenced)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, target.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.SilencedAt.IsZero())
+}
+
+func (suite *AdminTestSuite) TestAdminAccountDisableEnable() {
+	ctx := context.Background()
+	target := suite.testAccounts["local_account_1"]
+
+	errWithCode := suite.processor.AdminAccountAction(ctx, suite.adminAuthed(), &apimodel.AdminAccountActionRequest{
+		Type:            "disable",
+		TargetAccountID: target.ID,
+	})
+	suite.NoError(errWithCode)
+
+	account, errWithCode := suite.processor.AdminAccountGet(ctx, suite.adminAuthed(), target.ID)
+	suite.NoError(errWithCode)
+	suite.True(account.Disabled)
+
+	account, errWithCode = suite.processor.AdminAccountEnable(ctx, suite.adminAuthed(), target.ID)
+	suite.NoError(errWithCode)
+	suite.False(account.Disabled)
+
+	// remote accounts don't have a user to disable
+	errWithCode = suite.processor.AdminAccountAction(ctx, suite.adminAuthed(), &apimodel.AdminAccountActionRequest{
+		Type:            "disable",
+		TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminTestSuite) TestAdminAccountUnsuspend() {
+	ctx := context.Background()
+
+	target := suite.testAccounts["remote_account_2"]
+	target.SuspendedAt = time.Now()
+	target.SuspensionOrigin = suite.testAccounts["admin_account"].ID
+	_, err := suite.db.UpdateAccount(ctx, target)
+	suite.NoError(err)
+
+	account, errWithCode := suite.processor.AdminAccountUnsuspend(ctx, suite.adminAuthed(), target.ID)
+	suite.NoError(errWithCode)
+	suite.False(account.Suspended)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, target.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.SuspendedAt.IsZero())
+	suite.Empty(dbAccount.SuspensionOrigin)
+}
+
+func (suite *AdminTestSuite) TestAdminAccountUnsuspendRemovedLocalAccount() {
+	ctx := context.Background()
+
+	// suspending a local account removes its user
+	target := suite.testAccounts["local_account_2"]
+	target.SuspendedAt = time.Now()
+	_, err := suite.db.UpdateAccount(ctx, target)
+	suite.NoError(err)
+	err = suite.db.DeleteByID(ctx, suite.testUsers["local_account_2"].ID, &gtsmodel.User{})
+	suite.NoError(err)
+
+	_, errWithCode := suite.processor.AdminAccountUnsuspend(ctx, suite.adminAuthed(), target.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAdminTestSuite(t *testing.T) {
+	suite.Run(t, &AdminTestSuite{})
+}
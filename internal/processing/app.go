@@ -20,9 +20,11 @@ package processing
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -32,11 +34,16 @@ func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 	// set default 'read' for scopes if it's not set
 	var scopes string
 	if form.Scopes == "" {
-		scopes = "read"
+		scopes = oauth.DefaultScope
 	} else {
 		scopes = form.Scopes
 	}
 
+	// tokens can only be granted scopes that the app asks for now, so make sure they all exist
+	if err := oauth.ValidateScopes(scopes); err != nil {
+		return nil, err
+	}
+
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
 	if err != nil {
@@ -83,5 +90,25 @@ func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 		return nil, err
 	}
 
+	// clients need the instance vapid key to create push subscriptions
+	apiApp.VapidKey, err = p.webPushSender.VAPIDPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiApp, nil
+}
+
+func (p *processor) AppVerifyCredentials(ctx context.Context, authed *oauth.Auth) (*apimodel.Application, gtserror.WithCode) {
+	apiApp, err := p.tc.AppToAPIAppPublic(ctx, authed.Application)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AppVerifyCredentials: error converting application: %s", err))
+	}
+
+	apiApp.VapidKey, err = p.webPushSender.VAPIDPublicKey(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AppVerifyCredentials: error getting vapid key: %s", err))
+	}
+
 	return apiApp, nil
 }
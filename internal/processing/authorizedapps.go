@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) OAuthRevoke(ctx context.Context, form *apimodel.OAuthRevoke) error {
+	revoked, err := p.oauthServer.RevokeToken(ctx, form.ClientID, form.ClientSecret, form.Token)
+	if err != nil {
+		return err
+	}
+
+	if revoked != nil {
+		p.cleanUpRevokedToken(ctx, revoked)
+	}
+	return nil
+}
+
+func (p *processor) AuthorizedAppsGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.AuthorizedApp, gtserror.WithCode) {
+	tokens := []*gtsmodel.Token{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &tokens); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AuthorizedAppsGet: error getting tokens of user %s: %s", user.ID, err))
+	}
+
+	// most recently authorized first
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
+	})
+
+	apps := []*apimodel.AuthorizedApp{}
+	for _, t := range tokens {
+		if t.Access == "" {
+			// authorization codes that haven't been swapped for an access token yet can't be used for anything
+			continue
+		}
+
+		app, err := p.tc.TokenToAPIAuthorizedApp(ctx, t)
+		if err != nil {
+			logrus.Debugf("AuthorizedAppsGet: skipping token %s: %s", t.ID, err)
+			continue
+		}
+		apps = append(apps, app)
+	}
+
+	return apps, nil
+}
+
+func (p *processor) AuthorizedAppRevoke(ctx context.Context, user *gtsmodel.User, tokenID string) gtserror.WithCode {
+	token := &gtsmodel.Token{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "id", Value: tokenID}, {Key: "user_id", Value: user.ID}}, token); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(errors.New("AuthorizedAppRevoke: token not found"))
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("AuthorizedAppRevoke: error getting token %s: %s", tokenID, err))
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "id", Value: token.ID}}, &gtsmodel.Token{}); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("AuthorizedAppRevoke: error deleting token %s: %s", token.ID, err))
+	}
+
+	p.cleanUpRevokedToken(ctx, token)
+	return nil
+}
+
+// cleanUpRevokedToken stops everything that was still using the given, just revoked token:
+// its open streams are closed, and its push subscription is removed.
+func (p *processor) cleanUpRevokedToken(ctx context.Context, token *gtsmodel.Token) {
+	p.streamingProcessor.CloseStreamsForToken(token.Access)
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: token.ID}}, &[]*gtsmodel.PushSubscription{}); err != nil && err != db.ErrNoEntries {
+		logrus.Errorf("cleanUpRevokedToken: error deleting push subscription of token %s: %s", token.ID, err)
+	}
+}
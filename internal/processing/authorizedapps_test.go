@@ -0,0 +1,149 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type AuthorizedAppsTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AuthorizedAppsTestSuite) TestAuthorizedAppsGet() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	apps, errWithCode := suite.processor.AuthorizedAppsGet(ctx, suite.testUsers["local_account_1"])
+	suite.NoError(errWithCode)
+	suite.Len(apps, 1)
+	suite.Equal(token.ID, apps[0].ID)
+	suite.Equal("really cool gts application", apps[0].Application.Name)
+	suite.Equal("https://reallycool.app", apps[0].Application.Website)
+	suite.Empty(apps[0].Application.ClientSecret)
+	suite.Equal([]string{"read", "write", "follow", "push"}, apps[0].Scopes)
+	suite.Nil(apps[0].LastUsedAt)
+
+	// using the token to authorize a request records when it was last used
+	_, err := suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.NoError(err)
+
+	apps, errWithCode = suite.processor.AuthorizedAppsGet(ctx, suite.testUsers["local_account_1"])
+	suite.NoError(errWithCode)
+	suite.Len(apps, 1)
+	suite.NotNil(apps[0].LastUsedAt)
+}
+
+func (suite *AuthorizedAppsTestSuite) TestAuthorizedAppRevoke() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+	account := suite.testAccounts["local_account_1"]
+
+	tokenStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, account, token.Access, stream.TimelineHome)
+	suite.NoError(errWithCode)
+	otherStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, account, "some other token", stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	errWithCode = suite.processor.AuthorizedAppRevoke(ctx, suite.testUsers["local_account_1"], token.ID)
+	suite.NoError(errWithCode)
+
+	// the token is gone, and so are the streams that were opened with it
+	_, err := suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, open := <-tokenStream.Messages
+	suite.False(open)
+	suite.True(otherStream.Connected)
+
+	apps, errWithCode := suite.processor.AuthorizedAppsGet(ctx, suite.testUsers["local_account_1"])
+	suite.NoError(errWithCode)
+	suite.Empty(apps)
+}
+
+func (suite *AuthorizedAppsTestSuite) TestAuthorizedAppRevokeOtherUsersToken() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_2"]
+
+	errWithCode := suite.processor.AuthorizedAppRevoke(ctx, suite.testUsers["local_account_1"], token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// the token still works
+	_, err := suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.NoError(err)
+}
+
+func (suite *AuthorizedAppsTestSuite) TestOAuthRevoke() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+	client := suite.testClients["local_account_1"]
+
+	// the client has to authenticate
+	err := suite.processor.OAuthRevoke(ctx, &model.OAuthRevoke{
+		ClientID:     client.ID,
+		ClientSecret: "not the secret",
+		Token:        token.Access,
+	})
+	suite.EqualError(err, "invalid_client")
+
+	// and can only revoke its own tokens
+	otherClient := suite.testClients["local_account_2"]
+	err = suite.processor.OAuthRevoke(ctx, &model.OAuthRevoke{
+		ClientID:     otherClient.ID,
+		ClientSecret: otherClient.Secret,
+		Token:        token.Access,
+	})
+	suite.EqualError(err, "unauthorized_client")
+
+	tokenStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, suite.testAccounts["local_account_1"], token.Access, stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	err = suite.processor.OAuthRevoke(ctx, &model.OAuthRevoke{
+		ClientID:     client.ID,
+		ClientSecret: client.Secret,
+		Token:        token.Access,
+	})
+	suite.NoError(err)
+
+	dbToken := &gtsmodel.Token{}
+	err = suite.db.GetByID(ctx, token.ID, dbToken)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, open := <-tokenStream.Messages
+	suite.False(open)
+
+	// revoking a token that doesn't exist anymore is fine
+	err = suite.processor.OAuthRevoke(ctx, &model.OAuthRevoke{
+		ClientID:     client.ID,
+		ClientSecret: client.Secret,
+		Token:        token.Access,
+	})
+	suite.NoError(err)
+}
+
+func TestAuthorizedAppsTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizedAppsTestSuite))
+}
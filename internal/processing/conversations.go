@@ -0,0 +1,131 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) ConversationsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ConversationsResponse, gtserror.WithCode) {
+	conversations, err := p.db.GetAccountConversations(ctx, authed.Account.ID, maxID, sinceID, minID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	resp := &apimodel.ConversationsResponse{
+		Conversations: []*apimodel.Conversation{},
+	}
+
+	if len(conversations) == 0 {
+		return resp, nil
+	}
+
+	for _, c := range conversations {
+		apiConversation, err := p.tc.ConversationToAPIConversation(ctx, c, authed.Account)
+		if err != nil {
+			logrus.Debugf("ConversationsGet: skipping conversation %s because it couldn't be converted: %s", c.ID, err)
+			continue
+		}
+		resp.Conversations = append(resp.Conversations, apiConversation)
+	}
+
+	// prepare the next and previous links; conversations are paged by the ID of their last status
+	protocol := viper.GetString(config.Keys.Protocol)
+	host := viper.GetString(config.Keys.Host)
+
+	nextLink := &url.URL{
+		Scheme:   protocol,
+		Host:     host,
+		Path:     "/api/v1/conversations",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, conversations[len(conversations)-1].LastStatusID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   protocol,
+		Host:     host,
+		Path:     "/api/v1/conversations",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, conversations[0].LastStatusID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) ConversationDelete(ctx context.Context, authed *oauth.Auth, conversationID string) gtserror.WithCode {
+	if _, errWithCode := p.getOwnConversation(ctx, authed, conversationID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteConversationByID(ctx, conversationID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) ConversationRead(ctx context.Context, authed *oauth.Auth, conversationID string) (*apimodel.Conversation, gtserror.WithCode) {
+	conversation, errWithCode := p.getOwnConversation(ctx, authed, conversationID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !conversation.Read {
+		conversation.Read = true
+		if err := p.db.UpdateByPrimaryKey(ctx, conversation); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiConversation, err := p.tc.ConversationToAPIConversation(ctx, conversation, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiConversation, nil
+}
+
+// getOwnConversation gets the conversation with the given ID, making sure that it belongs to the authed account.
+func (p *processor) getOwnConversation(ctx context.Context, authed *oauth.Auth, conversationID string) (*gtsmodel.Conversation, gtserror.WithCode) {
+	conversation, err := p.db.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if conversation.AccountID != authed.Account.ID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("conversation %s does not belong to account %s", conversationID, authed.Account.ID))
+	}
+
+	return conversation, nil
+}
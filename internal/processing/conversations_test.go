@@ -0,0 +1,94 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ConversationTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ConversationTestSuite) TestConversationsGet() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	resp, errWithCode := suite.processor.ConversationsGet(context.Background(), suite.testAutheds["local_account_1"], "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Conversations, 1)
+	suite.Equal(conversation.ID, resp.Conversations[0].ID)
+	suite.True(resp.Conversations[0].Unread)
+	suite.Equal(conversation.LastStatusID, resp.Conversations[0].LastStatus.ID)
+	suite.Len(resp.Conversations[0].Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, resp.Conversations[0].Accounts[0].ID)
+	suite.Equal(`<http://localhost:8080/api/v1/conversations?limit=20&max_id=01FN3VJGFH10KR7S2PB0GFJZYG>; rel="next", <http://localhost:8080/api/v1/conversations?limit=20&min_id=01FN3VJGFH10KR7S2PB0GFJZYG>; rel="prev"`, resp.LinkHeader)
+
+	// the admin isn't part of any conversations
+	resp, errWithCode = suite.processor.ConversationsGet(context.Background(), &oauth.Auth{Account: suite.testAccounts["admin_account"]}, "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Conversations)
+	suite.Empty(resp.LinkHeader)
+}
+
+func (suite *ConversationTestSuite) TestConversationRead() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	apiConversation, errWithCode := suite.processor.ConversationRead(context.Background(), suite.testAutheds["local_account_1"], conversation.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiConversation.Unread)
+
+	dbConversation, err := suite.db.GetConversationByID(context.Background(), conversation.ID)
+	suite.NoError(err)
+	suite.True(dbConversation.Read)
+
+	// someone else's conversation can't be read
+	_, errWithCode = suite.processor.ConversationRead(context.Background(), suite.testAutheds["local_account_2"], conversation.ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ConversationTestSuite) TestConversationDelete() {
+	conversation := testrig.NewTestConversations()["local_account_1_local_account_2_status_6"]
+
+	// someone else's conversation can't be deleted
+	errWithCode := suite.processor.ConversationDelete(context.Background(), suite.testAutheds["local_account_2"], conversation.ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.processor.ConversationDelete(context.Background(), suite.testAutheds["local_account_1"], conversation.ID)
+	suite.NoError(errWithCode)
+
+	_, err := suite.db.GetConversationByID(context.Background(), conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// the status itself is still there
+	_, err = suite.db.GetStatusByID(context.Background(), conversation.LastStatusID)
+	suite.NoError(err)
+}
+
+func TestConversationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTestSuite))
+}
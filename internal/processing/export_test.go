@@ -0,0 +1,27 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import "context"
+
+// PublishDueScheduledStatuses runs one round of publishing scheduled statuses with the given processor,
+// so tests don't have to wait for the ticker.
+func PublishDueScheduledStatuses(ctx context.Context, p Processor) error {
+	return p.(*processor).publishDueScheduledStatuses(ctx)
+}
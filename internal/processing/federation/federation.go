@@ -67,6 +67,10 @@ type Processor interface {
 	// This contains links to PUBLIC posts made by this user.
 	GetOutbox(ctx context.Context, requestedUsername string, page bool, maxID string, minID string, requestURL *url.URL) (interface{}, gtserror.WithCode)
 
+	// GetFeatured returns the activitypub representation of a local user's featured collection.
+	// This contains the PUBLIC and UNLISTED statuses that this user has pinned to their profile.
+	GetFeatured(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
+
 	// PostInbox handles POST requests to a user's inbox for new activitypub messages.
 	//
 	// PostInbox returns true if the request was handled as an ActivityPub POST to an actor's inbox.
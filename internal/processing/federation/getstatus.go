@@ -25,6 +25,7 @@ import (
 	"net/url"
 
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -77,7 +78,12 @@ func (p *processor) GetStatus(ctx context.Context, requestedUsername string, req
 	}
 
 	// requester is authorized to view the status, so convert it to AP representation and serialize it
-	asStatus, err := p.tc.StatusToAS(ctx, s)
+	var asStatus vocab.Type
+	if s.PollID != "" {
+		asStatus, err = p.tc.StatusToASQuestion(ctx, s)
+	} else {
+		asStatus, err = p.tc.StatusToAS(ctx, s)
+	}
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
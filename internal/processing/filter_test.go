@@ -0,0 +1,204 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type FilterTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *FilterTestSuite) TestFiltersV1Get() {
+	filters, errWithCode := suite.processor.FiltersV1Get(context.Background(), suite.testAutheds["local_account_1"])
+	suite.NoError(errWithCode)
+	suite.Len(filters, 1)
+	suite.Equal("01G1XMVDHYQ50PWG7C7N2ZHWTZ", filters[0].ID)
+	suite.Equal("fnord", filters[0].Phrase)
+	suite.Equal([]string{"home", "public"}, filters[0].Context)
+	suite.True(filters[0].WholeWord)
+	suite.False(filters[0].Irreversible)
+	suite.Nil(filters[0].ExpiresAt)
+}
+
+func (suite *FilterTestSuite) TestFilterV2GetNotOwner() {
+	filter, errWithCode := suite.processor.FilterV2Get(context.Background(), suite.testAutheds["local_account_2"], "01G1XMV0ARAWV4MT2SQ1W8GDM8")
+	suite.Nil(filter)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FilterTestSuite) TestFilterV1CreateUpdateDelete() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	created, errWithCode := suite.processor.FilterV1Create(ctx, authed, &apimodel.FilterV1Request{
+		Phrase:    "spiders",
+		Context:   []string{"home", "notifications"},
+		ExpiresIn: 3600,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("spiders", created.Phrase)
+	suite.NotNil(created.ExpiresAt)
+
+	// a v1 filter is a keyword of a v2 filter, titled with the phrase
+	keyword, errWithCode := suite.processor.FilterKeywordGet(ctx, authed, created.ID)
+	suite.NoError(errWithCode)
+	suite.Equal("spiders", keyword.Keyword)
+
+	updated, errWithCode := suite.processor.FilterV1Update(ctx, authed, created.ID, &apimodel.FilterV1Request{
+		Phrase:       "big spiders",
+		Context:      []string{"thread"},
+		Irreversible: true,
+		WholeWord:    true,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal("big spiders", updated.Phrase)
+	suite.Equal([]string{"thread"}, updated.Context)
+	suite.True(updated.Irreversible)
+	suite.True(updated.WholeWord)
+	suite.Nil(updated.ExpiresAt)
+
+	suite.NoError(suite.processor.FilterV1Delete(ctx, authed, created.ID))
+
+	_, errWithCode = suite.processor.FilterV1Get(ctx, authed, created.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// the filter that held the keyword is gone too
+	filters, errWithCode := suite.processor.FiltersV2Get(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Len(filters, 1)
+}
+
+func (suite *FilterTestSuite) TestFilterV1CreateBadContext() {
+	filter, errWithCode := suite.processor.FilterV1Create(context.Background(), suite.testAutheds["local_account_1"], &apimodel.FilterV1Request{
+		Phrase:  "spiders",
+		Context: []string{"everywhere"},
+	})
+	suite.Nil(filter)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *FilterTestSuite) TestFilterV2CreateUpdateDelete() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	created, errWithCode := suite.processor.FilterV2Create(ctx, authed, &apimodel.FilterV2CreateRequest{
+		Title:        "creepy crawlies",
+		Context:      []string{"home"},
+		FilterAction: "hide",
+		KeywordsAttributes: []apimodel.FilterKeywordAttributes{
+			{Keyword: "spiders", WholeWord: true},
+			{Keyword: "beetles"},
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("creepy crawlies", created.Title)
+	suite.Equal("hide", created.FilterAction)
+	suite.Len(created.Keywords, 2)
+	suite.Empty(created.Statuses)
+
+	newTitle := "insects"
+	updated, errWithCode := suite.processor.FilterV2Update(ctx, authed, created.ID, &apimodel.FilterV2UpdateRequest{
+		Title: &newTitle,
+		KeywordsAttributes: []apimodel.FilterKeywordAttributes{
+			{ID: created.Keywords[0].ID, Destroy: true},
+			{ID: created.Keywords[1].ID, Keyword: "ants"},
+			{Keyword: "wasps"},
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("insects", updated.Title)
+	suite.Equal("hide", updated.FilterAction)
+	suite.Equal([]string{"home"}, updated.Context)
+
+	keywords, errWithCode := suite.processor.FilterKeywordsGet(ctx, authed, created.ID)
+	suite.NoError(errWithCode)
+	if suite.Len(keywords, 2) {
+		suite.Equal(created.Keywords[1].ID, keywords[0].ID)
+		suite.Equal("ants", keywords[0].Keyword)
+		suite.Equal("wasps", keywords[1].Keyword)
+	}
+
+	// keywords of someone else's filter can't be changed
+	_, errWithCode = suite.processor.FilterKeywordUpdate(ctx, suite.testAutheds["local_account_2"], keywords[0].ID, &apimodel.FilterKeywordRequest{Keyword: "bees"})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	suite.NoError(suite.processor.FilterV2Delete(ctx, authed, created.ID))
+
+	_, errWithCode = suite.processor.FilterKeywordGet(ctx, authed, keywords[0].ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FilterTestSuite) TestFilterHidesStatusFromHomeTimeline() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	statusID := suite.testStatuses["admin_account_status_1"].ID
+
+	resp, errWithCode := suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.True(timelineContains(resp.Statuses, statusID))
+
+	// a warning filter leaves the status in place, but marks it as filtered
+	warnFilter, errWithCode := suite.processor.FilterV2Create(ctx, authed, &apimodel.FilterV2CreateRequest{
+		Title:              "welcomes",
+		Context:            []string{"home"},
+		KeywordsAttributes: []apimodel.FilterKeywordAttributes{{Keyword: "welcome", WholeWord: true}},
+	})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	for _, s := range resp.Statuses {
+		if s.ID == statusID {
+			if suite.Len(s.Filtered, 1) {
+				suite.Equal(warnFilter.ID, s.Filtered[0].Filter.ID)
+				suite.Equal([]string{"welcome"}, s.Filtered[0].KeywordMatches)
+			}
+		}
+	}
+
+	// a hiding filter removes it altogether
+	hide := "hide"
+	_, errWithCode = suite.processor.FilterV2Update(ctx, authed, warnFilter.ID, &apimodel.FilterV2UpdateRequest{FilterAction: &hide})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.False(timelineContains(resp.Statuses, statusID))
+}
+
+func timelineContains(statuses []*apimodel.Status, statusID string) bool {
+	for _, s := range statuses {
+		if s.ID == statusID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, &FilterTestSuite{})
+}
@@ -0,0 +1,187 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// In v1 of the filters API, each filter is a single keyword, so v1 filters are served
+// using the IDs of the keywords that make them up.
+
+func (p *processor) FiltersV1Get(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FilterV1, gtserror.WithCode) {
+	filters, err := p.db.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilters := []*apimodel.FilterV1{}
+	for _, f := range filters {
+		for _, k := range f.Keywords {
+			apiFilter, errWithCode := p.apiFilterV1(ctx, k, f)
+			if errWithCode != nil {
+				return nil, errWithCode
+			}
+			apiFilters = append(apiFilters, apiFilter)
+		}
+	}
+
+	return apiFilters, nil
+}
+
+func (p *processor) FilterV1Get(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.FilterV1, gtserror.WithCode) {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiFilterV1(ctx, keyword, keyword.Filter)
+}
+
+func (p *processor) FilterV1Create(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterV1Request) (*apimodel.FilterV1, gtserror.WithCode) {
+	phrase := strings.TrimSpace(form.Phrase)
+	if phrase == "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("filter phrase was empty"), "phrase must be provided")
+	}
+
+	filterID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:        filterID,
+		AccountID: authed.Account.ID,
+		Title:     phrase,
+	}
+
+	if errWithCode := setFilterV1(filter, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	keyword, errWithCode := newFilterKeyword(filter, phrase, form.WholeWord)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	filter.Keywords = []*gtsmodel.FilterKeyword{keyword}
+
+	if err := p.db.PutFilter(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return p.apiFilterV1(ctx, keyword, filter)
+}
+
+func (p *processor) FilterV1Update(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterV1Request) (*apimodel.FilterV1, gtserror.WithCode) {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// get the whole filter, since any other keywords it has must be kept as they are
+	filter, errWithCode := p.getOwnFilter(ctx, authed, keyword.FilterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	i := filterKeywordIndex(filter, keyword.ID)
+	if i == -1 {
+		return nil, gtserror.NewErrorInternalError(errors.New("filter keyword missing from its own filter"))
+	}
+	keyword = filter.Keywords[i]
+
+	if errWithCode := setFilterKeyword(keyword, form.Phrase, form.WholeWord); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := setFilterV1(filter, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filter.UpdatedAt = time.Now()
+	if err := p.db.UpdateFilter(ctx, filter, nil); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return p.apiFilterV1(ctx, keyword, filter)
+}
+
+func (p *processor) FilterV1Delete(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed, filterID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	filter, errWithCode := p.getOwnFilter(ctx, authed, keyword.FilterID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// don't leave a filter behind with nothing to match on
+	var err error
+	if len(filter.Keywords) <= 1 {
+		err = p.db.DeleteFilterByID(ctx, filter.ID)
+	} else {
+		err = p.db.DeleteByID(ctx, keyword.ID, &gtsmodel.FilterKeyword{})
+	}
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return nil
+}
+
+func (p *processor) apiFilterV1(ctx context.Context, keyword *gtsmodel.FilterKeyword, filter *gtsmodel.Filter) (*apimodel.FilterV1, gtserror.WithCode) {
+	apiFilter, err := p.tc.FilterKeywordToAPIFilterV1(ctx, keyword, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiFilter, nil
+}
+
+// setFilterV1 sets the contexts, action, and expiry of the given filter from the given v1 form.
+// Irreversible v1 filters hide matching statuses, while other v1 filters warn about them.
+func setFilterV1(filter *gtsmodel.Filter, form *apimodel.FilterV1Request) gtserror.WithCode {
+	if errWithCode := setFilterContexts(filter, form.Context); errWithCode != nil {
+		return errWithCode
+	}
+
+	filter.Action = gtsmodel.FilterActionWarn
+	if form.Irreversible {
+		filter.Action = gtsmodel.FilterActionHide
+	}
+
+	return setFilterExpiry(filter, form.ExpiresIn)
+}
@@ -0,0 +1,449 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) FiltersV2Get(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FilterV2, gtserror.WithCode) {
+	filters, err := p.db.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilters := []*apimodel.FilterV2{}
+	for _, f := range filters {
+		apiFilter, errWithCode := p.apiFilterV2(ctx, f)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiFilters = append(apiFilters, apiFilter)
+	}
+
+	return apiFilters, nil
+}
+
+func (p *processor) FilterV2Get(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.FilterV2, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnFilter(ctx, authed, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiFilterV2(ctx, filter)
+}
+
+func (p *processor) FilterV2Create(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterV2CreateRequest) (*apimodel.FilterV2, gtserror.WithCode) {
+	title := strings.TrimSpace(form.Title)
+	if title == "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("filter title was empty"), "filter title must be provided")
+	}
+
+	filterID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:        filterID,
+		AccountID: authed.Account.ID,
+		Title:     title,
+		Action:    gtsmodel.FilterActionWarn,
+		Keywords:  []*gtsmodel.FilterKeyword{},
+	}
+
+	if errWithCode := setFilterContexts(filter, form.Context); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.FilterAction != "" {
+		var errWithCode gtserror.WithCode
+		if filter.Action, errWithCode = parseFilterAction(form.FilterAction); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if errWithCode := setFilterExpiry(filter, form.ExpiresIn); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	for _, attributes := range form.KeywordsAttributes {
+		if attributes.Destroy {
+			continue
+		}
+
+		keyword, errWithCode := newFilterKeyword(filter, attributes.Keyword, attributes.WholeWord)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		filter.Keywords = append(filter.Keywords, keyword)
+	}
+
+	if err := p.db.PutFilter(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return p.apiFilterV2(ctx, filter)
+}
+
+func (p *processor) FilterV2Update(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterV2UpdateRequest) (*apimodel.FilterV2, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnFilter(ctx, authed, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Title != nil {
+		title := strings.TrimSpace(*form.Title)
+		if title == "" {
+			return nil, gtserror.NewErrorBadRequest(errors.New("filter title was empty"), "filter title must not be empty")
+		}
+		filter.Title = title
+	}
+
+	if form.Context != nil {
+		if errWithCode := setFilterContexts(filter, form.Context); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if form.FilterAction != nil {
+		if filter.Action, errWithCode = parseFilterAction(*form.FilterAction); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if form.ExpiresIn != nil {
+		if errWithCode := setFilterExpiry(filter, *form.ExpiresIn); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	deleteKeywordIDs := []string{}
+	for _, attributes := range form.KeywordsAttributes {
+		if attributes.ID == "" {
+			if attributes.Destroy {
+				continue
+			}
+
+			keyword, errWithCode := newFilterKeyword(filter, attributes.Keyword, attributes.WholeWord)
+			if errWithCode != nil {
+				return nil, errWithCode
+			}
+			filter.Keywords = append(filter.Keywords, keyword)
+			continue
+		}
+
+		i := filterKeywordIndex(filter, attributes.ID)
+		if i == -1 {
+			err := fmt.Errorf("keyword %s does not belong to filter %s", attributes.ID, filter.ID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		if attributes.Destroy {
+			deleteKeywordIDs = append(deleteKeywordIDs, attributes.ID)
+			filter.Keywords = append(filter.Keywords[:i], filter.Keywords[i+1:]...)
+			continue
+		}
+
+		if errWithCode := setFilterKeyword(filter.Keywords[i], attributes.Keyword, attributes.WholeWord); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	filter.UpdatedAt = time.Now()
+	if err := p.db.UpdateFilter(ctx, filter, deleteKeywordIDs); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return p.apiFilterV2(ctx, filter)
+}
+
+func (p *processor) FilterV2Delete(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode {
+	if _, errWithCode := p.getOwnFilter(ctx, authed, filterID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteFilterByID(ctx, filterID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return nil
+}
+
+func (p *processor) FilterKeywordsGet(ctx context.Context, authed *oauth.Auth, filterID string) ([]*apimodel.FilterKeyword, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnFilter(ctx, authed, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiKeywords := []*apimodel.FilterKeyword{}
+	for _, k := range filter.Keywords {
+		apiKeyword, errWithCode := p.apiFilterKeyword(ctx, k)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiKeywords = append(apiKeywords, apiKeyword)
+	}
+
+	return apiKeywords, nil
+}
+
+func (p *processor) FilterKeywordGet(ctx context.Context, authed *oauth.Auth, keywordID string) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed, keywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiFilterKeyword(ctx, keyword)
+}
+
+func (p *processor) FilterKeywordCreate(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterKeywordRequest) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnFilter(ctx, authed, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	keyword, errWithCode := newFilterKeyword(filter, form.Keyword, form.WholeWord)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.Put(ctx, keyword); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return p.apiFilterKeyword(ctx, keyword)
+}
+
+func (p *processor) FilterKeywordUpdate(ctx context.Context, authed *oauth.Auth, keywordID string, form *apimodel.FilterKeywordRequest) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed, keywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := setFilterKeyword(keyword, form.Keyword, form.WholeWord); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.UpdateByPrimaryKey(ctx, keyword); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return p.apiFilterKeyword(ctx, keyword)
+}
+
+func (p *processor) FilterKeywordDelete(ctx context.Context, authed *oauth.Auth, keywordID string) gtserror.WithCode {
+	if _, errWithCode := p.getOwnFilterKeyword(ctx, authed, keywordID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteByID(ctx, keywordID, &gtsmodel.FilterKeyword{}); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.unloadFilteredTimelines(ctx, authed.Account.ID)
+
+	return nil
+}
+
+// getOwnFilter gets the filter with the given ID, with its keywords, making sure it's owned by the requesting account.
+// Filters owned by other accounts are treated as not found, so that their existence isn't leaked.
+func (p *processor) getOwnFilter(ctx context.Context, authed *oauth.Auth, filterID string) (*gtsmodel.Filter, gtserror.WithCode) {
+	filter, err := p.db.GetFilterByID(ctx, filterID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if filter.AccountID != authed.Account.ID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("filter %s does not belong to account %s", filterID, authed.Account.ID))
+	}
+
+	return filter, nil
+}
+
+// getOwnFilterKeyword gets the filter keyword with the given ID, with its filter, making sure it's owned by the requesting account.
+// Keywords owned by other accounts are treated as not found, so that their existence isn't leaked.
+func (p *processor) getOwnFilterKeyword(ctx context.Context, authed *oauth.Auth, keywordID string) (*gtsmodel.FilterKeyword, gtserror.WithCode) {
+	keyword, err := p.db.GetFilterKeywordByID(ctx, keywordID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if keyword.AccountID != authed.Account.ID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("filter keyword %s does not belong to account %s", keywordID, authed.Account.ID))
+	}
+
+	return keyword, nil
+}
+
+// unloadFilteredTimelines unloads the home and list timelines of the given account from memory,
+// so that they're rebuilt with the account's current filters and mutes next time they're requested.
+func (p *processor) unloadFilteredTimelines(ctx context.Context, accountID string) {
+	p.statusTimelines.UnloadTimeline(ctx, accountID)
+
+	lists, err := p.db.GetListsForAccountID(ctx, accountID)
+	if err != nil && err != db.ErrNoEntries {
+		return
+	}
+
+	for _, l := range lists {
+		p.listTimelines.UnloadTimeline(ctx, l.ID)
+	}
+}
+
+func (p *processor) apiFilterV2(ctx context.Context, filter *gtsmodel.Filter) (*apimodel.FilterV2, gtserror.WithCode) {
+	apiFilter, err := p.tc.FilterToAPIFilterV2(ctx, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiFilter, nil
+}
+
+func (p *processor) apiFilterKeyword(ctx context.Context, keyword *gtsmodel.FilterKeyword) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	apiKeyword, err := p.tc.FilterKeywordToAPIFilterKeyword(ctx, keyword)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiKeyword, nil
+}
+
+// newFilterKeyword returns a new keyword for the given filter. It isn't added to the filter's keywords.
+func newFilterKeyword(filter *gtsmodel.Filter, keyword string, wholeWord bool) (*gtsmodel.FilterKeyword, gtserror.WithCode) {
+	keywordID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	k := &gtsmodel.FilterKeyword{
+		ID:        keywordID,
+		AccountID: filter.AccountID,
+		FilterID:  filter.ID,
+	}
+
+	if errWithCode := setFilterKeyword(k, keyword, wholeWord); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return k, nil
+}
+
+func setFilterKeyword(k *gtsmodel.FilterKeyword, keyword string, wholeWord bool) gtserror.WithCode {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return gtserror.NewErrorBadRequest(errors.New("filter keyword was empty"), "keyword must be provided")
+	}
+
+	k.Keyword = keyword
+	k.WholeWord = wholeWord
+	k.UpdatedAt = time.Now()
+	return nil
+}
+
+func setFilterContexts(filter *gtsmodel.Filter, contexts []string) gtserror.WithCode {
+	if len(contexts) == 0 {
+		return gtserror.NewErrorBadRequest(errors.New("no filter contexts given"), "context must be provided")
+	}
+
+	filter.ContextHome = false
+	filter.ContextNotifications = false
+	filter.ContextPublic = false
+	filter.ContextThread = false
+	filter.ContextAccount = false
+
+	for _, c := range contexts {
+		switch gtsmodel.FilterContext(c) {
+		case gtsmodel.FilterContextHome:
+			filter.ContextHome = true
+		case gtsmodel.FilterContextNotifications:
+			filter.ContextNotifications = true
+		case gtsmodel.FilterContextPublic:
+			filter.ContextPublic = true
+		case gtsmodel.FilterContextThread:
+			filter.ContextThread = true
+		case gtsmodel.FilterContextAccount:
+			filter.ContextAccount = true
+		default:
+			err := fmt.Errorf("filter context %s not recognized", c)
+			return gtserror.NewErrorBadRequest(err, "context must be one or more of home, notifications, public, thread, account")
+		}
+	}
+
+	return nil
+}
+
+// setFilterExpiry sets the filter to expire the given number of seconds from now, or to never expire if expiresIn is 0.
+func setFilterExpiry(filter *gtsmodel.Filter, expiresIn int) gtserror.WithCode {
+	if expiresIn < 0 {
+		return gtserror.NewErrorBadRequest(errors.New("negative filter expiry"), "expires_in must not be negative")
+	}
+
+	if expiresIn == 0 {
+		filter.ExpiresAt = time.Time{}
+		return nil
+	}
+
+	filter.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	return nil
+}
+
+func parseFilterAction(s string) (gtsmodel.FilterAction, gtserror.WithCode) {
+	switch a := gtsmodel.FilterAction(s); a {
+	case gtsmodel.FilterActionWarn, gtsmodel.FilterActionHide:
+		return a, nil
+	default:
+		err := fmt.Errorf("filter action %s not recognized", s)
+		return "", gtserror.NewErrorBadRequest(err, "filter_action must be one of warn, hide")
+	}
+}
+
+func filterKeywordIndex(filter *gtsmodel.Filter, keywordID string) int {
+	for i, k := range filter.Keywords {
+		if k.ID == keywordID {
+			return i
+		}
+	}
+	return -1
+}
@@ -20,12 +20,14 @@ package processing
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -55,25 +57,45 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		case ap.ActivityBlock:
 			// CREATE BLOCK
 			return p.processCreateBlockFromClientAPI(ctx, clientMsg)
+		case ap.ActivityFlag:
+			// CREATE FLAG/REPORT
+			return p.processCreateReportFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityUpdate:
 		// UPDATE
 		switch clientMsg.APObjectType {
+		case ap.ObjectNote:
+			// UPDATE NOTE
+			return p.processUpdateStatusFromClientAPI(ctx, clientMsg)
 		case ap.ObjectProfile, ap.ActorPerson:
 			// UPDATE ACCOUNT/PROFILE
 			return p.processUpdateAccountFromClientAPI(ctx, clientMsg)
 		}
+	case ap.ActivityMove:
+		// MOVE
+		if clientMsg.APObjectType == ap.ObjectProfile {
+			// MOVE ACCOUNT/PROFILE
+			return p.processMoveAccountFromClientAPI(ctx, clientMsg)
+		}
 	case ap.ActivityAccept:
 		// ACCEPT
-		if clientMsg.APObjectType == ap.ActivityFollow {
+		switch clientMsg.APObjectType {
+		case ap.ActivityFollow:
 			// ACCEPT FOLLOW
 			return p.processAcceptFollowFromClientAPI(ctx, clientMsg)
+		case ap.ObjectProfile:
+			// ACCEPT ACCOUNT (sign-up)
+			return p.processAcceptAccountFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityReject:
 		// REJECT
-		if clientMsg.APObjectType == ap.ActivityFollow {
+		switch clientMsg.APObjectType {
+		case ap.ActivityFollow:
 			// REJECT FOLLOW (request)
 			return p.processRejectFollowFromClientAPI(ctx, clientMsg)
+		case ap.ObjectProfile:
+			// REJECT ACCOUNT (sign-up)
+			return p.processRejectAccountFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityUndo:
 		// UNDO
@@ -122,11 +144,52 @@ func (p *processor) processCreateAccountFromClientAPI(ctx context.Context, clien
 		return err
 	}
 
-	// email a confirmation to this user
-	return p.userProcessor.SendConfirmEmail(ctx, user, account.Username)
+	// email a confirmation to this user; if that doesn't work, we still
+	// carry on, so that the admins hear about the sign-up regardless
+	confirmErr := p.userProcessor.SendConfirmEmail(ctx, user, account.Username)
+	if confirmErr != nil {
+		confirmErr = fmt.Errorf("error sending confirmation email to user %s: %s", user.ID, confirmErr)
+	}
+
+	// let the admins know if there's a new sign-up for them to review
+	if !user.Approved {
+		if err := p.userProcessor.SendNewSignupEmail(ctx, user, account); err != nil {
+			err = fmt.Errorf("error sending new sign-up email to admins: %s", err)
+			if confirmErr != nil {
+				return fmt.Errorf("%s; %s", confirmErr, err)
+			}
+			return err
+		}
+	}
+
+	return confirmErr
+}
+
+func (p *processor) processAcceptAccountFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	user, ok := clientMsg.GTSModel.(*gtsmodel.User)
+	if !ok {
+		return errors.New("accept was not parseable as *gtsmodel.User")
+	}
+
+	return p.userProcessor.SendSignupApprovedEmail(ctx, user, clientMsg.TargetAccount.Username)
+}
+
+func (p *processor) processRejectAccountFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	// the user has already been removed from the database, so we only have this copy of it
+	user, ok := clientMsg.GTSModel.(*gtsmodel.User)
+	if !ok {
+		return errors.New("reject was not parseable as *gtsmodel.User")
+	}
+
+	return p.userProcessor.SendSignupRejectedEmail(ctx, user, clientMsg.TargetAccount.Username)
 }
 
 func (p *processor) processCreateStatusFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	if vote, ok := clientMsg.GTSModel.(*gtsmodel.PollVote); ok {
+		// a vote in a poll is represented as a Note, but it isn't a status
+		return p.federatePollVote(ctx, vote)
+	}
+
 	status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
 		return errors.New("note was not parseable as *gtsmodel.Status")
@@ -136,6 +199,10 @@ func (p *processor) processCreateStatusFromClientAPI(ctx context.Context, client
 		return err
 	}
 
+	if err := p.updateConversations(ctx, status); err != nil {
+		return err
+	}
+
 	if err := p.notifyStatus(ctx, status); err != nil {
 		return err
 	}
@@ -206,6 +273,20 @@ func (p *processor) processCreateBlockFromClientAPI(ctx context.Context, clientM
 	return p.federateBlock(ctx, block)
 }
 
+func (p *processor) processCreateReportFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	report, ok := clientMsg.GTSModel.(*gtsmodel.Report)
+	if !ok {
+		return errors.New("report was not parseable as *gtsmodel.Report")
+	}
+
+	// only reports that the reporting account asked to forward are sent out
+	if !report.Forwarded {
+		return nil
+	}
+
+	return p.federateReport(ctx, report)
+}
+
 func (p *processor) processUpdateAccountFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	account, ok := clientMsg.GTSModel.(*gtsmodel.Account)
 	if !ok {
@@ -215,6 +296,34 @@ func (p *processor) processUpdateAccountFromClientAPI(ctx context.Context, clien
 	return p.federateAccountUpdate(ctx, account, clientMsg.OriginAccount)
 }
 
+func (p *processor) processMoveAccountFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	account, ok := clientMsg.GTSModel.(*gtsmodel.Account)
+	if !ok {
+		return errors.New("account was not parseable as *gtsmodel.Account")
+	}
+
+	if err := p.federateAccountMove(ctx, account); err != nil {
+		return err
+	}
+
+	// remote followers will move themselves when they get the move,
+	// but local followers have to be moved over here
+	return p.moveLocalFollowers(ctx, account, clientMsg.TargetAccount)
+}
+
+func (p *processor) processUpdateStatusFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return errors.New("note was not parseable as *gtsmodel.Status")
+	}
+
+	if err := p.refreshStatusInTimelines(ctx, status); err != nil {
+		return err
+	}
+
+	return p.federateStatusUpdate(ctx, status)
+}
+
 func (p *processor) processAcceptFollowFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	follow, ok := clientMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
@@ -303,6 +412,33 @@ func (p *processor) processDeleteStatusFromClientAPI(ctx context.Context, client
 		return err
 	}
 
+	// delete the poll attached to this status, along with its votes
+	if statusToDelete.PollID != "" {
+		if err := p.db.DeletePollByID(ctx, statusToDelete.PollID); err != nil {
+			return err
+		}
+	}
+
+	// delete all previous revisions of this status
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusEdit{}); err != nil {
+		return err
+	}
+
+	// delete all bookmarks of this status
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
+		return err
+	}
+
+	// delete all mutes of the thread this status is the root of
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusMute{}); err != nil {
+		return err
+	}
+
+	// remove this status from any direct message conversations it's part of
+	if err := p.db.DeleteStatusFromConversations(ctx, statusToDelete.ID); err != nil {
+		return err
+	}
+
 	// delete this status from any and all timelines
 	if err := p.deleteStatusFromTimelines(ctx, statusToDelete); err != nil {
 		return err
@@ -403,14 +539,27 @@ func (p *processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 		return nil
 	}
 
-	asStatus, err := p.tc.StatusToAS(ctx, status)
-	if err != nil {
-		return fmt.Errorf("federateStatus: error converting status to as format: %s", err)
-	}
+	var create vocab.ActivityStreamsCreate
+	if status.PollID != "" {
+		asQuestion, err := p.tc.StatusToASQuestion(ctx, status)
+		if err != nil {
+			return fmt.Errorf("federateStatus: error converting status to as format: %s", err)
+		}
 
-	create, err := p.tc.WrapNoteInCreate(asStatus, false)
-	if err != nil {
-		return fmt.Errorf("federateStatus: error wrapping status in create: %s", err)
+		create, err = p.tc.WrapQuestionInCreate(asQuestion, false)
+		if err != nil {
+			return fmt.Errorf("federateStatus: error wrapping status in create: %s", err)
+		}
+	} else {
+		asStatus, err := p.tc.StatusToAS(ctx, status)
+		if err != nil {
+			return fmt.Errorf("federateStatus: error converting status to as format: %s", err)
+		}
+
+		create, err = p.tc.WrapNoteInCreate(asStatus, false)
+		if err != nil {
+			return fmt.Errorf("federateStatus: error wrapping status in create: %s", err)
+		}
 	}
 
 	outboxIRI, err := url.Parse(status.Account.OutboxURI)
@@ -776,6 +925,136 @@ func (p *processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave,
 	return err
 }
 
+func (p *processor) federatePollVote(ctx context.Context, vote *gtsmodel.PollVote) error {
+	if vote.Poll == nil {
+		poll, err := p.db.GetPollByID(ctx, vote.PollID)
+		if err != nil {
+			return fmt.Errorf("federatePollVote: error fetching poll: %s", err)
+		}
+		vote.Poll = poll
+	}
+
+	if vote.Poll.Status == nil {
+		status, err := p.db.GetStatusByID(ctx, vote.Poll.StatusID)
+		if err != nil {
+			return fmt.Errorf("federatePollVote: error fetching poll status: %s", err)
+		}
+		vote.Poll.Status = status
+	}
+
+	// votes in local polls are counted directly, so there's nothing to federate
+	if vote.Poll.Status.Local {
+		return nil
+	}
+
+	if vote.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, vote.AccountID)
+		if err != nil {
+			return fmt.Errorf("federatePollVote: error fetching voting account: %s", err)
+		}
+		vote.Account = a
+	}
+
+	creates, err := p.tc.PollVoteToASCreates(ctx, vote)
+	if err != nil {
+		return fmt.Errorf("federatePollVote: error converting vote to as format: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(vote.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federatePollVote: error parsing outboxURI %s: %s", vote.Account.OutboxURI, err)
+	}
+
+	for _, create := range creates {
+		if _, err := p.federator.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
+			return fmt.Errorf("federatePollVote: error sending vote: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *processor) federateStatusUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	// statuses with polls are federated as questions
+	if status.PollID != "" {
+		return p.federatePollUpdate(ctx, status)
+	}
+
+	// do nothing if the status shouldn't be federated
+	if !status.Federated {
+		return nil
+	}
+
+	if status.Account == nil {
+		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateStatusUpdate: error fetching status author account: %s", err)
+		}
+		status.Account = statusAccount
+	}
+
+	// do nothing if this isn't our status
+	if status.Account.Domain != "" {
+		return nil
+	}
+
+	note, err := p.tc.StatusToAS(ctx, status)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error converting status to as format: %s", err)
+	}
+
+	update, err := p.tc.WrapNoteInUpdate(note, status.Account)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error wrapping note in update: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(status.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
+	return err
+}
+
+func (p *processor) federatePollUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	// do nothing if the status shouldn't be federated
+	if !status.Federated {
+		return nil
+	}
+
+	if status.Account == nil {
+		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("federatePollUpdate: error fetching status author account: %s", err)
+		}
+		status.Account = statusAccount
+	}
+
+	// do nothing if this isn't our status
+	if status.Account.Domain != "" {
+		return nil
+	}
+
+	question, err := p.tc.StatusToASQuestion(ctx, status)
+	if err != nil {
+		return fmt.Errorf("federatePollUpdate: error converting status to as format: %s", err)
+	}
+
+	update, err := p.tc.WrapQuestionInUpdate(question, status.Account)
+	if err != nil {
+		return fmt.Errorf("federatePollUpdate: error wrapping question in update: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(status.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federatePollUpdate: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
+	return err
+}
+
 func (p *processor) federateAnnounce(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) error {
 	announce, err := p.tc.BoostToAS(ctx, boostWrapperStatus, boostingAccount, boostedAccount)
 	if err != nil {
@@ -811,6 +1090,65 @@ func (p *processor) federateAccountUpdate(ctx context.Context, updatedAccount *g
 	return err
 }
 
+func (p *processor) federateAccountMove(ctx context.Context, account *gtsmodel.Account) error {
+	move, err := p.tc.AccountToASMove(ctx, account)
+	if err != nil {
+		return fmt.Errorf("federateAccountMove: error converting account to move: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateAccountMove: error parsing outboxURI %s: %s", account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, move)
+	return err
+}
+
+func (p *processor) federateReport(ctx context.Context, report *gtsmodel.Report) error {
+	if report.TargetAccount == nil {
+		reportTargetAccount, err := p.db.GetAccountByID(ctx, report.TargetAccountID)
+		if err != nil {
+			return fmt.Errorf("federateReport: error getting report target account from database: %s", err)
+		}
+		report.TargetAccount = reportTargetAccount
+	}
+
+	// if the target account is local there's nothing to do here
+	if report.TargetAccount.Domain == "" {
+		return nil
+	}
+
+	flag, err := p.tc.ReportToASFlag(ctx, report)
+	if err != nil {
+		return fmt.Errorf("federateReport: error converting report to AS format: %s", err)
+	}
+
+	flagI, err := streams.Serialize(flag)
+	if err != nil {
+		return fmt.Errorf("federateReport: error serializing flag: %s", err)
+	}
+
+	flagBytes, err := json.Marshal(flagI)
+	if err != nil {
+		return fmt.Errorf("federateReport: error marshalling flag: %s", err)
+	}
+
+	inboxIRI, err := url.Parse(report.TargetAccount.InboxURI)
+	if err != nil {
+		return fmt.Errorf("federateReport: error parsing inboxURI %s: %s", report.TargetAccount.InboxURI, err)
+	}
+
+	// the flag is sent directly by the instance account rather than through an outbox,
+	// so that the reporting account stays anonymous
+	t, err := p.federator.TransportController().NewTransportForUsername(ctx, "")
+	if err != nil {
+		return fmt.Errorf("federateReport: error creating transport for instance account: %s", err)
+	}
+
+	return t.BatchDeliver(ctx, flagBytes, []*url.URL{inboxIRI})
+}
+
 func (p *processor) federateBlock(ctx context.Context, block *gtsmodel.Block) error {
 	if block.Account == nil {
 		blockAccount, err := p.db.GetAccountByID(ctx, block.AccountID)
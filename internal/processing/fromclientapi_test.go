@@ -22,10 +22,12 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
@@ -45,12 +47,12 @@ func (suite *FromClientAPITestSuite) TestProcessStreamNewStatus() {
 	receivingAccount := suite.testAccounts["local_account_1"]
 
 	// open a home timeline stream for zork
-	wssStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, stream.TimelineHome)
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, "", stream.TimelineHome)
 	suite.NoError(errWithCode)
 
 	// open another stream for zork, but for a different timeline;
 	// this shouldn't get stuff streamed into it, since it's for the public timeline
-	irrelevantStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, stream.TimelinePublic)
+	irrelevantStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, "", stream.TimelinePublic)
 	suite.NoError(errWithCode)
 
 	// make a new status from admin account
@@ -113,6 +115,386 @@ func (suite *FromClientAPITestSuite) TestProcessStreamNewStatus() {
 	suite.Empty(irrelevantStream.Messages)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessStreamNewStatusToList() {
+	ctx := context.Background()
+
+	// local_account_2 is in zork's "cool people" list, so a new status from
+	// them should be streamed into a stream opened for that list
+	postingAccount := suite.testAccounts["local_account_2"]
+	receivingAccount := suite.testAccounts["local_account_1"]
+	listID := "01G1CNBTNXGQNRHCM3WVSQHP8T"
+
+	listStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, "", stream.ListTimeline(listID))
+	suite.NoError(errWithCode)
+
+	// local_account_2 can't open a stream for a list it doesn't own
+	_, errWithCode = suite.processor.OpenStreamForAccount(ctx, postingAccount, "", stream.ListTimeline(listID))
+	suite.Error(errWithCode)
+
+	newStatus := &gtsmodel.Status{
+		ID:                       "01G1CX0Y3PXYP6QJ9EKWJ0XTZ9",
+		URI:                      "http://localhost:8080/users/1happyturtle/statuses/01G1CX0Y3PXYP6QJ9EKWJ0XTZ9",
+		URL:                      "http://localhost:8080/@1happyturtle/statuses/01G1CX0Y3PXYP6QJ9EKWJ0XTZ9",
+		Content:                  "this status should stream to the list :)",
+		AttachmentIDs:            []string{},
+		TagIDs:                   []string{},
+		MentionIDs:               []string{},
+		EmojiIDs:                 []string{},
+		CreatedAt:                testrig.TimeMustParse("2022-04-22T12:00:00Z"),
+		UpdatedAt:                testrig.TimeMustParse("2022-04-22T12:00:00Z"),
+		Local:                    true,
+		AccountURI:               "http://localhost:8080/users/1happyturtle",
+		AccountID:                postingAccount.ID,
+		Visibility:               gtsmodel.VisibilityPublic,
+		Language:                 "en",
+		CreatedWithApplicationID: "01F8MGYG9E893WRHW0TAEXR8GJ",
+		Federated:                false,
+		Boostable:                true,
+		Replyable:                true,
+		Likeable:                 true,
+		ActivityStreamsType:      ap.ObjectNote,
+	}
+
+	err := suite.db.PutStatus(ctx, newStatus)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       newStatus,
+		OriginAccount:  postingAccount,
+	})
+	suite.NoError(err)
+
+	msg := <-listStream.Messages
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.EqualValues([]string{stream.TimelineList, listID}, msg.Stream)
+	statusStreamed := &model.Status{}
+	err = json.Unmarshal([]byte(msg.Payload), statusStreamed)
+	suite.NoError(err)
+	suite.Equal(newStatus.ID, statusStreamed.ID)
+	suite.Empty(listStream.Messages)
+
+	// the status should be at the top of the list timeline too
+	resp, errWithCode := suite.processor.ListTimelineGet(ctx, suite.testAutheds["local_account_1"], listID, "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Statuses)
+	suite.Equal(newStatus.ID, resp.Statuses[0].ID)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessStreamNewStatusWithHashtag() {
+	ctx := context.Background()
+
+	// local_account_2 doesn't follow admin, but does follow #welcome,
+	// so a new public status from admin using #welcome should go in their home timeline
+	postingAccount := suite.testAccounts["admin_account"]
+	tagFollowingAccount := suite.testAccounts["local_account_2"]
+	streamingAccount := suite.testAccounts["local_account_1"]
+	welcomeTag := suite.testTags["welcome"]
+
+	homeStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, tagFollowingAccount, "", stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	// zork streams the hashtag, both federated and local
+	hashtagStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, streamingAccount, "", stream.HashtagTimeline("Welcome"))
+	suite.NoError(errWithCode)
+	localHashtagStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, streamingAccount, "", stream.LocalHashtagTimeline("welcome"))
+	suite.NoError(errWithCode)
+
+	// and another hashtag that shouldn't get anything
+	irrelevantStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, streamingAccount, "", stream.HashtagTimeline("Hashtag"))
+	suite.NoError(errWithCode)
+
+	newStatus := &gtsmodel.Status{
+		ID:                       "01G3PNQ5D1M7N3XJ3BX6Y4J0GK",
+		URI:                      "http://localhost:8080/users/admin/statuses/01G3PNQ5D1M7N3XJ3BX6Y4J0GK",
+		URL:                      "http://localhost:8080/@admin/statuses/01G3PNQ5D1M7N3XJ3BX6Y4J0GK",
+		Content:                  "#welcome to all the new people!",
+		AttachmentIDs:            []string{},
+		TagIDs:                   []string{welcomeTag.ID},
+		MentionIDs:               []string{},
+		EmojiIDs:                 []string{},
+		CreatedAt:                testrig.TimeMustParse("2022-05-23T10:00:00Z"),
+		UpdatedAt:                testrig.TimeMustParse("2022-05-23T10:00:00Z"),
+		Local:                    true,
+		AccountURI:               "http://localhost:8080/users/admin",
+		AccountID:                postingAccount.ID,
+		Visibility:               gtsmodel.VisibilityPublic,
+		Language:                 "en",
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                false,
+		Boostable:                true,
+		Replyable:                true,
+		Likeable:                 true,
+		ActivityStreamsType:      ap.ObjectNote,
+	}
+
+	err := suite.db.PutStatus(ctx, newStatus)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       newStatus,
+		OriginAccount:  postingAccount,
+	})
+	suite.NoError(err)
+
+	msg := <-homeStream.Messages
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.EqualValues([]string{stream.TimelineHome}, msg.Stream)
+	statusStreamed := &model.Status{}
+	err = json.Unmarshal([]byte(msg.Payload), statusStreamed)
+	suite.NoError(err)
+	suite.Equal(newStatus.ID, statusStreamed.ID)
+
+	msg = <-hashtagStream.Messages
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.EqualValues([]string{stream.TimelineHashtag, "welcome"}, msg.Stream)
+
+	msg = <-localHashtagStream.Messages
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.EqualValues([]string{stream.TimelineHashtagLocal, "welcome"}, msg.Stream)
+
+	suite.Empty(homeStream.Messages)
+	suite.Empty(hashtagStream.Messages)
+	suite.Empty(localHashtagStream.Messages)
+	suite.Empty(irrelevantStream.Messages)
+
+	// the status should be at the top of local_account_2's home timeline too
+	resp, errWithCode := suite.processor.HomeTimelineGet(ctx, suite.testAutheds["local_account_2"], "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Statuses)
+	suite.Equal(newStatus.ID, resp.Statuses[0].ID)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessStreamNewDirectMessage() {
+	ctx := context.Background()
+
+	// admin sends a direct message to zork: both of them should get a conversation for it
+	postingAccount := suite.testAccounts["admin_account"]
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	postingStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, postingAccount, "", stream.TimelineDirect)
+	suite.NoError(errWithCode)
+	receivingStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, "", stream.TimelineDirect)
+	suite.NoError(errWithCode)
+
+	mention := &gtsmodel.Mention{
+		ID:               "01G3XK9Q2DS3M6P9JB7E8Z2T1N",
+		StatusID:         "01G3XK8W4G4V0KJZ5T0N0ZQZPA",
+		OriginAccountID:  postingAccount.ID,
+		OriginAccountURI: postingAccount.URI,
+		TargetAccountID:  receivingAccount.ID,
+		NameString:       "@the_mighty_zork",
+	}
+	err := suite.db.Put(ctx, mention)
+	suite.NoError(err)
+
+	newStatus := &gtsmodel.Status{
+		ID:                       "01G3XK8W4G4V0KJZ5T0N0ZQZPA",
+		URI:                      "http://localhost:8080/users/admin/statuses/01G3XK8W4G4V0KJZ5T0N0ZQZPA",
+		URL:                      "http://localhost:8080/@admin/statuses/01G3XK8W4G4V0KJZ5T0N0ZQZPA",
+		Content:                  "@the_mighty_zork psst, this is just between us",
+		AttachmentIDs:            []string{},
+		TagIDs:                   []string{},
+		MentionIDs:               []string{mention.ID},
+		EmojiIDs:                 []string{},
+		CreatedAt:                testrig.TimeMustParse("2022-05-25T10:00:00Z"),
+		UpdatedAt:                testrig.TimeMustParse("2022-05-25T10:00:00Z"),
+		Local:                    true,
+		AccountURI:               "http://localhost:8080/users/admin",
+		AccountID:                postingAccount.ID,
+		Visibility:               gtsmodel.VisibilityDirect,
+		Language:                 "en",
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                true,
+		Boostable:                false,
+		Replyable:                true,
+		Likeable:                 true,
+		ActivityStreamsType:      ap.ObjectNote,
+	}
+
+	err = suite.db.PutStatus(ctx, newStatus)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       newStatus,
+		OriginAccount:  postingAccount,
+	})
+	suite.NoError(err)
+
+	msg := <-receivingStream.Messages
+	suite.Equal(stream.EventTypeConversation, msg.Event)
+	suite.EqualValues([]string{stream.TimelineDirect}, msg.Stream)
+	conversation := &model.Conversation{}
+	err = json.Unmarshal([]byte(msg.Payload), conversation)
+	suite.NoError(err)
+	suite.True(conversation.Unread)
+	suite.Equal(newStatus.ID, conversation.LastStatus.ID)
+	suite.Len(conversation.Accounts, 1)
+	suite.Equal(postingAccount.ID, conversation.Accounts[0].ID)
+
+	msg = <-postingStream.Messages
+	suite.Equal(stream.EventTypeConversation, msg.Event)
+	conversation = &model.Conversation{}
+	err = json.Unmarshal([]byte(msg.Payload), conversation)
+	suite.NoError(err)
+	suite.False(conversation.Unread)
+	suite.Len(conversation.Accounts, 1)
+	suite.Equal(receivingAccount.ID, conversation.Accounts[0].ID)
+
+	// zork should now be able to see the conversation, above the one they already had
+	resp, errWithCode := suite.processor.ConversationsGet(ctx, suite.testAutheds["local_account_1"], "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Conversations, 2)
+	suite.Equal(newStatus.ID, resp.Conversations[0].LastStatus.ID)
+
+	// deleting the status removes the conversations again
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       newStatus,
+		OriginAccount:  postingAccount,
+	})
+	suite.NoError(err)
+
+	resp, errWithCode = suite.processor.ConversationsGet(ctx, suite.testAutheds["local_account_1"], "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Conversations, 1)
+	suite.NotEqual(newStatus.ID, resp.Conversations[0].LastStatus.ID)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessStatusDeleteDropsBookmarks() {
+	ctx := context.Background()
+
+	deletingAccount := suite.testAccounts["admin_account"]
+	statusToDelete := suite.testStatuses["admin_account_status_1"]
+	bookmarkingAuth := suite.testAutheds["local_account_1"]
+
+	// zork has this status bookmarked
+	resp, errWithCode := suite.processor.BookmarkedTimelineGet(ctx, bookmarkingAuth, "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Statuses, 1)
+	suite.Equal(statusToDelete.ID, resp.Statuses[0].ID)
+	suite.Contains(resp.LinkHeader, "api/v1/bookmarks?limit=20&max_id=01G3AQTRYT0CXT7SF9X4KBCNYQ")
+
+	err := suite.db.DeleteByID(ctx, statusToDelete.ID, &gtsmodel.Status{})
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       statusToDelete,
+		OriginAccount:  deletingAccount,
+		TargetAccount:  deletingAccount,
+	})
+	suite.NoError(err)
+
+	// the bookmark should be gone
+	resp, errWithCode = suite.processor.BookmarkedTimelineGet(ctx, bookmarkingAuth, "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Statuses)
+
+	bookmarks := []*gtsmodel.StatusBookmark{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &bookmarks)
+	suite.NoError(err)
+	suite.Empty(bookmarks)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessStatusDeleteWithPoll() {
+	ctx := context.Background()
+
+	deletingAccount := suite.testAccounts["local_account_1"]
+	votingAccount := suite.testAccounts["local_account_2"]
+
+	// copy an existing status, but give it a poll
+	statusToDelete := &gtsmodel.Status{}
+	*statusToDelete = *suite.testStatuses["local_account_1_status_1"]
+	statusToDelete.ID = "01G5CJ6Y8V5Q5QKFQ0H4PAX5ZN"
+	statusToDelete.URI = "http://localhost:8080/users/the_mighty_zork/statuses/01G5CJ6Y8V5Q5QKFQ0H4PAX5ZN"
+	statusToDelete.URL = "http://localhost:8080/@the_mighty_zork/statuses/01G5CJ6Y8V5Q5QKFQ0H4PAX5ZN"
+	statusToDelete.PollID = "01G5CJ7J1WXQ6ZM0K7F2V9AP3D"
+	statusToDelete.Poll = &gtsmodel.Poll{
+		ID:        statusToDelete.PollID,
+		Options:   []string{"yes", "no"},
+		Votes:     []int{0, 0},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	suite.NoError(suite.db.PutStatus(ctx, statusToDelete))
+
+	suite.NoError(suite.db.PutPollVote(ctx, &gtsmodel.PollVote{
+		ID:        "01G5CJ81Z9V0BKS1K3QW2Y6RNE",
+		Choices:   []int{0},
+		AccountID: votingAccount.ID,
+		PollID:    statusToDelete.PollID,
+	}))
+
+	err := suite.db.DeleteByID(ctx, statusToDelete.ID, &gtsmodel.Status{})
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       statusToDelete,
+		OriginAccount:  deletingAccount,
+		TargetAccount:  deletingAccount,
+	})
+	suite.NoError(err)
+
+	// the poll and its votes should be gone
+	_, err = suite.db.GetPollByID(ctx, statusToDelete.PollID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	votes, err := suite.db.GetPollVotes(ctx, statusToDelete.PollID)
+	if err != nil {
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+	suite.Empty(votes)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessFaveInMutedThread() {
+	ctx := context.Background()
+
+	favingAccount := suite.testAccounts["local_account_2"]
+	receivingAccount := suite.testAccounts["local_account_1"]
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// zork mutes the thread by way of a reply further down it
+	apiStatus, errWithCode := suite.processor.StatusMute(ctx, suite.testAutheds["local_account_1"], suite.testStatuses["admin_account_status_3"].ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+
+	fave := &gtsmodel.StatusFave{
+		ID:              "01G3KB6SVXQ6D6ZFHB1WJ93X6S",
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: receivingAccount.ID,
+		TargetAccount:   receivingAccount,
+		StatusID:        rootStatus.ID,
+		URI:             "http://localhost:8080/users/1happyturtle/liked/01G3KB6SVXQ6D6ZFHB1WJ93X6S",
+	}
+	err := suite.db.Put(ctx, fave)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityLike,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       fave,
+		OriginAccount:  favingAccount,
+		TargetAccount:  receivingAccount,
+	})
+	suite.NoError(err)
+
+	// no notification should have been created for the fave
+	notifications := []*gtsmodel.Notification{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "origin_account_id", Value: favingAccount.ID}, {Key: "status_id", Value: rootStatus.ID}}, &notifications)
+	suite.NoError(err)
+	suite.Empty(notifications)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
@@ -21,9 +21,11 @@ package processing
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -61,6 +63,15 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 			continue
 		}
 
+		// don't notify accounts that have muted the thread this status belongs to
+		muted, err := p.db.IsStatusMutedBy(ctx, status, m.TargetAccountID)
+		if err != nil {
+			return fmt.Errorf("notifyStatus: error checking thread mute for account %s: %s", m.TargetAccountID, err)
+		}
+		if muted {
+			continue
+		}
+
 		// make sure a notif doesn't already exist for this mention
 		if err := p.db.GetWhere(ctx, []db.Where{
 			{Key: "notification_type", Value: gtsmodel.NotificationMention},
@@ -102,7 +113,7 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 			return fmt.Errorf("notifyStatus: error converting notification to api representation: %s", err)
 		}
 
-		if err := p.streamingProcessor.StreamNotificationToAccount(apiNotif, m.TargetAccount); err != nil {
+		if err := p.streamingProcessor.StreamNotificationToAccount(ctx, apiNotif, m.TargetAccount); err != nil {
 			return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 		}
 	}
@@ -149,7 +160,7 @@ func (p *processor) notifyFollowRequest(ctx context.Context, followRequest *gtsm
 		return fmt.Errorf("notifyStatus: error converting notification to api representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(apiNotif, targetAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, apiNotif, targetAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
@@ -195,7 +206,7 @@ func (p *processor) notifyFollow(ctx context.Context, follow *gtsmodel.Follow, t
 		return fmt.Errorf("notifyStatus: error converting notification to api representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(apiNotif, targetAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, apiNotif, targetAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
@@ -217,6 +228,23 @@ func (p *processor) notifyFave(ctx context.Context, fave *gtsmodel.StatusFave) e
 		return nil
 	}
 
+	if fave.Status == nil {
+		s, err := p.db.GetStatusByID(ctx, fave.StatusID)
+		if err != nil {
+			return fmt.Errorf("notifyFave: error getting status with id %s: %s", fave.StatusID, err)
+		}
+		fave.Status = s
+	}
+
+	// just return if the target account has muted the thread the faved status belongs to
+	muted, err := p.db.IsStatusMutedBy(ctx, fave.Status, targetAccount.ID)
+	if err != nil {
+		return fmt.Errorf("notifyFave: error checking thread mute for account %s: %s", targetAccount.ID, err)
+	}
+	if muted {
+		return nil
+	}
+
 	notifID, err := id.NewULID()
 	if err != nil {
 		return err
@@ -243,7 +271,7 @@ func (p *processor) notifyFave(ctx context.Context, fave *gtsmodel.StatusFave) e
 		return fmt.Errorf("notifyStatus: error converting notification to api representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(apiNotif, targetAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, apiNotif, targetAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
@@ -283,6 +311,13 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return nil
 	}
 
+	// the boosted account has muted the thread the boosted status belongs to, nothing to do
+	if muted, err := p.db.IsStatusMutedBy(ctx, status.BoostOf, status.BoostOfAccountID); err != nil {
+		return fmt.Errorf("notifyAnnounce: error checking thread mute for account %s: %s", status.BoostOfAccountID, err)
+	} else if muted {
+		return nil
+	}
+
 	// make sure a notif doesn't already exist for this announce
 	err := p.db.GetWhere(ctx, []db.Where{
 		{Key: "notification_type", Value: gtsmodel.NotificationReblog},
@@ -322,15 +357,88 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("notifyStatus: error converting notification to api representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(apiNotif, status.BoostOfAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, apiNotif, status.BoostOfAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
 	return nil
 }
 
+// notifyPollClosed notifies local voters in the given poll, and its author
+// if they're local, that the poll has ended.
+func (p *processor) notifyPollClosed(ctx context.Context, poll *gtsmodel.Poll) error {
+	if poll.Status == nil {
+		s, err := p.db.GetStatusByID(ctx, poll.StatusID)
+		if err != nil {
+			return fmt.Errorf("notifyPollClosed: error getting status with id %s: %s", poll.StatusID, err)
+		}
+		poll.Status = s
+	}
+
+	if poll.Status.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, poll.Status.AccountID)
+		if err != nil {
+			return fmt.Errorf("notifyPollClosed: error getting account with id %s: %s", poll.Status.AccountID, err)
+		}
+		poll.Status.Account = a
+	}
+
+	votes, err := p.db.GetPollVotes(ctx, poll.ID)
+	if err != nil {
+		return fmt.Errorf("notifyPollClosed: error getting votes for poll %s: %s", poll.ID, err)
+	}
+
+	// notify all local voters, plus the author if they're local too
+	targetAccounts := []*gtsmodel.Account{}
+	if poll.Status.Account.Domain == "" {
+		targetAccounts = append(targetAccounts, poll.Status.Account)
+	}
+	for _, vote := range votes {
+		if vote.Account == nil || vote.Account.Domain != "" {
+			continue
+		}
+		targetAccounts = append(targetAccounts, vote.Account)
+	}
+
+	for _, targetAccount := range targetAccounts {
+		notifID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		notif := &gtsmodel.Notification{
+			ID:               notifID,
+			NotificationType: gtsmodel.NotificationPoll,
+			TargetAccountID:  targetAccount.ID,
+			TargetAccount:    targetAccount,
+			OriginAccountID:  poll.Status.AccountID,
+			OriginAccount:    poll.Status.Account,
+			StatusID:         poll.StatusID,
+			Status:           poll.Status,
+		}
+
+		if err := p.db.Put(ctx, notif); err != nil {
+			return fmt.Errorf("notifyPollClosed: error putting notification in database: %s", err)
+		}
+
+		// now stream the notification to the user
+		apiNotif, err := p.tc.NotificationToAPINotification(ctx, notif)
+		if err != nil {
+			return fmt.Errorf("notifyPollClosed: error converting notification to api representation: %s", err)
+		}
+
+		if err := p.streamingProcessor.StreamNotificationToAccount(ctx, apiNotif, targetAccount); err != nil {
+			return fmt.Errorf("notifyPollClosed: error streaming notification to account: %s", err)
+		}
+	}
+
+	return nil
+}
+
 // timelineStatus processes the given new status and inserts it into
-// the HOME timelines of accounts that follow the status author.
+// the HOME timelines of accounts that follow the status author, or
+// that follow one of the hashtags it uses. It's then also streamed
+// to any open streams of those hashtags.
 func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status) error {
 	// make sure the author account is pinned onto the status
 	if status.Account == nil {
@@ -355,12 +463,23 @@ func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status)
 		})
 	}
 
+	// get local accounts that follow a hashtag used in the status, but don't follow the author
+	tagFollowerIDs, err := p.statusTagFollowerIDs(ctx, status, follows)
+	if err != nil {
+		return fmt.Errorf("timelineStatus: error getting tag followers for status %s: %s", status.ID, err)
+	}
+
 	wg := sync.WaitGroup{}
-	wg.Add(len(follows))
-	errors := make(chan error, len(follows))
+	wg.Add(len(follows)*2 + len(tagFollowerIDs))
+	errors := make(chan error, len(follows)*2+len(tagFollowerIDs))
 
 	for _, f := range follows {
 		go p.timelineStatusForAccount(ctx, status, f.AccountID, errors, &wg)
+		go p.timelineStatusForLists(ctx, status, f, errors, &wg)
+	}
+
+	for _, accountID := range tagFollowerIDs {
+		go p.timelineStatusForAccount(ctx, status, accountID, errors, &wg)
 	}
 
 	// read any errors that come in from the async functions
@@ -382,6 +501,105 @@ func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("timelineStatus: one or more errors timelining statuses: %s", strings.Join(errs, ";"))
 	}
 
+	return p.streamStatusToHashtags(ctx, status)
+}
+
+// statusTagFollowerIDs returns the IDs of local accounts that follow one of the hashtags
+// used in the given status, leaving out accounts that are already in the given follows.
+//
+// Only public statuses are put in the timelines of hashtag followers.
+func (p *processor) statusTagFollowerIDs(ctx context.Context, status *gtsmodel.Status, follows []*gtsmodel.Follow) ([]string, error) {
+	if status.Visibility != gtsmodel.VisibilityPublic || len(status.TagIDs) == 0 {
+		return nil, nil
+	}
+
+	tagFollows, err := p.db.GetTagFollows(ctx, status.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(follows))
+	for _, f := range follows {
+		skip[f.AccountID] = true
+	}
+
+	accountIDs := []string{}
+	for _, tf := range tagFollows {
+		if skip[tf.AccountID] {
+			continue
+		}
+		skip[tf.AccountID] = true
+		accountIDs = append(accountIDs, tf.AccountID)
+	}
+
+	return accountIDs, nil
+}
+
+// streamStatusToHashtags streams the given new status to the open hashtag streams
+// of any hashtags it uses, for each account that's allowed to see it there.
+func (p *processor) streamStatusToHashtags(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityPublic {
+		return nil
+	}
+
+	for _, tagID := range status.TagIDs {
+		tag, err := p.db.GetTagByID(ctx, tagID)
+		if err != nil {
+			return fmt.Errorf("streamStatusToHashtags: error getting tag with id %s: %s", tagID, err)
+		}
+
+		timelines := []string{stream.HashtagTimeline(tag.Name)}
+		if status.Local {
+			timelines = append(timelines, stream.LocalHashtagTimeline(tag.Name))
+		}
+
+		for _, timeline := range timelines {
+			for _, accountID := range p.streamingProcessor.StreamingAccountIDs(timeline) {
+				if err := p.streamStatusToHashtagForAccount(ctx, status, timeline, accountID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// streamStatusToHashtagForAccount streams the given status to the given hashtag timeline
+// of the account with the given ID, if it's tagtimelineable for that account.
+func (p *processor) streamStatusToHashtagForAccount(ctx context.Context, status *gtsmodel.Status, timeline string, accountID string) error {
+	streamAccount, err := p.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("streamStatusToHashtagForAccount: error getting account with id %s: %s", accountID, err)
+	}
+
+	timelineable, err := p.filter.StatusTagtimelineable(ctx, status, streamAccount)
+	if err != nil {
+		return fmt.Errorf("streamStatusToHashtagForAccount: error getting timelineability of status %s for account %s: %s", status.ID, accountID, err)
+	}
+
+	if !timelineable {
+		return nil
+	}
+
+	filteredOut, err := p.filter.StatusFilteredOut(ctx, status, streamAccount, gtsmodel.FilterContextPublic)
+	if err != nil {
+		return fmt.Errorf("streamStatusToHashtagForAccount: error checking filters on status %s for account %s: %s", status.ID, accountID, err)
+	}
+
+	if filteredOut {
+		return nil
+	}
+
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, streamAccount)
+	if err != nil {
+		return fmt.Errorf("streamStatusToHashtagForAccount: error converting status %s to frontend representation: %s", status.ID, err)
+	}
+
+	if err := p.streamingProcessor.StreamUpdateToAccount(apiStatus, streamAccount, timeline); err != nil {
+		return fmt.Errorf("streamStatusToHashtagForAccount: error streaming status %s: %s", status.ID, err)
+	}
+
 	return nil
 }
 
@@ -411,6 +629,17 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 		return
 	}
 
+	// make sure the timeline owner hasn't filtered the status out
+	filteredOut, err := p.filter.StatusFilteredOut(ctx, status, timelineAccount, gtsmodel.FilterContextHome)
+	if err != nil {
+		errors <- fmt.Errorf("timelineStatusForAccount: error checking filters on status for timeline with id %s: %s", accountID, err)
+		return
+	}
+
+	if filteredOut {
+		return
+	}
+
 	// stick the status in the timeline for the account and then immediately prepare it so they can see it right away
 	inserted, err := p.statusTimelines.IngestAndPrepare(ctx, status, timelineAccount.ID)
 	if err != nil {
@@ -432,6 +661,184 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 	}
 }
 
+// timelineStatusForLists puts the given status in the timeline of each
+// list that contains the given follow, if it's listtimelineable.
+//
+// If the status was inserted into a list timeline, it will also be
+// streamed via websockets to the owner of the list.
+func (p *processor) timelineStatusForLists(ctx context.Context, status *gtsmodel.Status, follow *gtsmodel.Follow, errors chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// the author's own entry in the followers list isn't a real follow, so it can't be in any lists
+	if follow.ID == "" {
+		return
+	}
+
+	entries, err := p.db.GetListEntriesForFollowID(ctx, follow.ID)
+	if err != nil && err != db.ErrNoEntries {
+		errors <- fmt.Errorf("timelineStatusForLists: error getting list entries for follow %s: %s", follow.ID, err)
+		return
+	}
+
+	for _, e := range entries {
+		list, err := p.db.GetListByID(ctx, e.ListID)
+		if err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error getting list with id %s: %s", e.ListID, err)
+			continue
+		}
+
+		timelineable, err := listTimelineable(ctx, p.db, p.filter, status, list)
+		if err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error getting timelineability for status for list with id %s: %s", list.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			continue
+		}
+
+		inserted, err := p.listTimelines.IngestAndPrepare(ctx, status, list.ID)
+		if err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error ingesting status %s: %s", status.ID, err)
+			continue
+		}
+
+		if !inserted {
+			continue
+		}
+
+		apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, list.Account)
+		if err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error converting status %s to frontend representation: %s", status.ID, err)
+			continue
+		}
+
+		if err := p.streamingProcessor.StreamUpdateToAccount(apiStatus, list.Account, stream.ListTimeline(list.ID)); err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error streaming status %s: %s", status.ID, err)
+		}
+	}
+}
+
+// updateConversations puts the given direct message into the conversation of every local
+// participant who can see it, creating conversations as necessary, and streams the updated
+// conversations to the direct streams of those participants.
+//
+// Conversations are keyed by the thread the status belongs to and the set of other participants,
+// so that each local participant ends up with one conversation per thread and audience.
+func (p *processor) updateConversations(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect {
+		return nil
+	}
+
+	// make sure the author account is pinned onto the status
+	if status.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("updateConversations: error getting author account with id %s: %s", status.AccountID, err)
+		}
+		status.Account = a
+	}
+
+	if status.Mentions == nil {
+		menchies, err := p.db.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return fmt.Errorf("updateConversations: error getting mentions for status %s from the db: %s", status.ID, err)
+		}
+		status.Mentions = menchies
+	}
+
+	// participants are the author of the status and everyone it mentions
+	participants := []*gtsmodel.Account{status.Account}
+	participantIDs := map[string]bool{status.AccountID: true}
+	for _, m := range status.Mentions {
+		if participantIDs[m.TargetAccountID] {
+			continue
+		}
+		if m.TargetAccount == nil {
+			a, err := p.db.GetAccountByID(ctx, m.TargetAccountID)
+			if err != nil {
+				return fmt.Errorf("updateConversations: error getting account with id %s from the db: %s", m.TargetAccountID, err)
+			}
+			m.TargetAccount = a
+		}
+		participants = append(participants, m.TargetAccount)
+		participantIDs[m.TargetAccountID] = true
+	}
+
+	threadRoot, err := p.db.GetStatusThreadRoot(ctx, status)
+	if err != nil {
+		return fmt.Errorf("updateConversations: error getting thread root of status %s: %s", status.ID, err)
+	}
+
+	for _, participant := range participants {
+		if participant.Domain != "" {
+			// not a local account so we don't keep a conversation for it
+			continue
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, status, participant)
+		if err != nil {
+			return fmt.Errorf("updateConversations: error checking visibility of status %s for account %s: %s", status.ID, participant.ID, err)
+		}
+		if !visible {
+			continue
+		}
+
+		otherAccountIDs := make([]string, 0, len(participants)-1)
+		for _, other := range participants {
+			if other.ID != participant.ID {
+				otherAccountIDs = append(otherAccountIDs, other.ID)
+			}
+		}
+		sort.Strings(otherAccountIDs)
+		otherAccountsKey := strings.Join(otherAccountIDs, ",")
+
+		conversation, err := p.db.GetConversationByThreadAndOtherAccounts(ctx, participant.ID, threadRoot.ID, otherAccountsKey)
+		if err != nil {
+			if err != db.ErrNoEntries {
+				return fmt.Errorf("updateConversations: error getting conversation for account %s: %s", participant.ID, err)
+			}
+
+			conversationID, err := id.NewULID()
+			if err != nil {
+				return err
+			}
+
+			conversation = &gtsmodel.Conversation{
+				ID:               conversationID,
+				AccountID:        participant.ID,
+				Account:          participant,
+				OtherAccountIDs:  otherAccountIDs,
+				OtherAccountsKey: otherAccountsKey,
+				ThreadID:         threadRoot.ID,
+			}
+		}
+
+		// statuses can arrive out of order over federation, so only move the last status forward
+		if status.ID > conversation.LastStatusID {
+			conversation.LastStatusID = status.ID
+			conversation.LastStatus = status
+			// the participant has obviously read their own status
+			conversation.Read = participant.ID == status.AccountID
+		}
+
+		if err := p.db.PutConversationStatus(ctx, conversation, status.ID); err != nil {
+			return fmt.Errorf("updateConversations: error putting conversation %s in the db: %s", conversation.ID, err)
+		}
+
+		apiConversation, err := p.tc.ConversationToAPIConversation(ctx, conversation, participant)
+		if err != nil {
+			return fmt.Errorf("updateConversations: error converting conversation %s to api representation: %s", conversation.ID, err)
+		}
+
+		if err := p.streamingProcessor.StreamConversationToAccount(apiConversation, participant); err != nil {
+			return fmt.Errorf("updateConversations: error streaming conversation to account: %s", err)
+		}
+	}
+
+	return nil
+}
+
 // deleteStatusFromTimelines completely removes the given status from all timelines.
 // It will also stream deletion of the status to all open streams.
 func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmodel.Status) error {
@@ -439,5 +846,93 @@ func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmo
 		return err
 	}
 
+	if err := p.listTimelines.WipeItemFromAllTimelines(ctx, status.ID); err != nil {
+		return err
+	}
+
 	return p.streamingProcessor.StreamDelete(status.ID)
 }
+
+// refreshStatusInTimelines re-prepares the given edited status in all timelines,
+// and streams the new version of it to any local followers of the author who can see it.
+func (p *processor) refreshStatusInTimelines(ctx context.Context, status *gtsmodel.Status) error {
+	if err := p.statusTimelines.RefreshItemInAllTimelines(ctx, status.ID); err != nil {
+		return err
+	}
+
+	if err := p.listTimelines.RefreshItemInAllTimelines(ctx, status.ID); err != nil {
+		return err
+	}
+
+	// make sure the author account is pinned onto the status
+	if status.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error getting author account with id %s: %s", status.AccountID, err)
+		}
+		status.Account = a
+	}
+
+	// get local followers of the account that posted the status
+	follows, err := p.db.GetAccountFollowedBy(ctx, status.AccountID, true)
+	if err != nil {
+		return fmt.Errorf("refreshStatusInTimelines: error getting followers for account id %s: %s", status.AccountID, err)
+	}
+
+	// if the poster is local, they should see their own edit too
+	if status.Account.Domain == "" {
+		follows = append(follows, &gtsmodel.Follow{
+			AccountID: status.AccountID,
+			Account:   status.Account,
+		})
+	}
+
+	for _, f := range follows {
+		streamAccount, err := p.db.GetAccountByID(ctx, f.AccountID)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error getting account with id %s: %s", f.AccountID, err)
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, status, streamAccount)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error checking visibility of status %s: %s", status.ID, err)
+		}
+
+		if !visible {
+			continue
+		}
+
+		apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, streamAccount)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error converting status %s to frontend representation: %s", status.ID, err)
+		}
+
+		if err := p.streamingProcessor.StreamStatusUpdateToAccount(apiStatus, streamAccount); err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error streaming status %s: %s", status.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// moveLocalFollowers moves the follows of all local followers of origin over to target.
+func (p *processor) moveLocalFollowers(ctx context.Context, origin *gtsmodel.Account, target *gtsmodel.Account) error {
+	follows, err := p.db.GetAccountFollowedBy(ctx, origin.ID, true)
+	if err != nil {
+		return fmt.Errorf("moveLocalFollowers: error getting followers of account %s: %s", origin.ID, err)
+	}
+
+	for _, follow := range follows {
+		follower, err := p.db.GetAccountByID(ctx, follow.AccountID)
+		if err != nil {
+			return fmt.Errorf("moveLocalFollowers: error getting follower account %s: %s", follow.AccountID, err)
+		}
+
+		// one follower failing to move (eg., because of a block) shouldn't stop the rest from moving
+		if errWithCode := p.accountProcessor.MoveFollow(ctx, follower, origin, target); errWithCode != nil {
+			logrus.Errorf("moveLocalFollowers: error moving follower %s from %s to %s: %s", follower.ID, origin.ID, target.ID, errWithCode)
+		}
+	}
+
+	return nil
+}
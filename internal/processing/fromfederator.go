@@ -25,7 +25,9 @@ import (
 	"net/url"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -47,7 +49,7 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 	case ap.ActivityCreate:
 		// CREATE SOMETHING
 		switch federatorMsg.APObjectType {
-		case ap.ObjectNote:
+		case ap.ObjectNote, ap.ActivityQuestion:
 			// CREATE A STATUS
 			return p.processCreateStatusFromFederator(ctx, federatorMsg)
 		case ap.ActivityLike:
@@ -65,9 +67,22 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 		}
 	case ap.ActivityUpdate:
 		// UPDATE SOMETHING
-		if federatorMsg.APObjectType == ap.ObjectProfile {
+		switch federatorMsg.APObjectType {
+		case ap.ObjectProfile:
 			// UPDATE AN ACCOUNT
 			return p.processUpdateAccountFromFederator(ctx, federatorMsg)
+		case ap.ObjectNote:
+			// UPDATE A STATUS
+			return p.processUpdateStatusFromFederator(ctx, federatorMsg)
+		case ap.ActivityQuestion:
+			// UPDATE A POLL
+			return p.processUpdatePollFromFederator(ctx, federatorMsg)
+		}
+	case ap.ActivityMove:
+		// MOVE SOMETHING
+		if federatorMsg.APObjectType == ap.ObjectProfile {
+			// MOVE AN ACCOUNT
+			return p.processMoveAccountFromFederator(ctx, federatorMsg)
 		}
 	case ap.ActivityDelete:
 		// DELETE SOMETHING
@@ -143,6 +158,10 @@ func (p *processor) processCreateStatusFromFederator(ctx context.Context, federa
 		return err
 	}
 
+	if err := p.updateConversations(ctx, status); err != nil {
+		return err
+	}
+
 	if err := p.notifyStatus(ctx, status); err != nil {
 		return err
 	}
@@ -340,6 +359,94 @@ func (p *processor) processUpdateAccountFromFederator(ctx context.Context, feder
 	return nil
 }
 
+// processMoveAccountFromFederator handles Activity Move and Object Profile
+func (p *processor) processMoveAccountFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	origin, ok := federatorMsg.GTSModel.(*gtsmodel.Account)
+	if !ok {
+		return errors.New("profile was not parseable as *gtsmodel.Account")
+	}
+
+	if federatorMsg.APIri == nil {
+		return errors.New("move target was not set")
+	}
+
+	// always get the freshest version of the target, since
+	// it has to have been given the origin as an alias
+	var target *gtsmodel.Account
+	var err error
+	if federatorMsg.APIri.Host == viper.GetString(config.Keys.Host) {
+		target, err = p.db.GetAccountByURI(ctx, federatorMsg.APIri.String())
+	} else {
+		target, err = p.federator.GetRemoteAccount(ctx, federatorMsg.ReceivingAccount.Username, federatorMsg.APIri, true, true)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting move target %s: %s", federatorMsg.APIri, err)
+	}
+
+	// without the alias, anyone could claim to have moved to any account
+	aliased := false
+	for _, alias := range target.AlsoKnownAsURIs {
+		if alias == origin.URI {
+			aliased = true
+			break
+		}
+	}
+	if !aliased {
+		return fmt.Errorf("move target %s doesn't have %s as an alias", target.URI, origin.URI)
+	}
+
+	if origin.MovedToAccountID != target.ID {
+		origin.MovedToURI = target.URI
+		origin.MovedToAccountID = target.ID
+		if _, err := p.db.UpdateAccount(ctx, origin); err != nil {
+			return fmt.Errorf("error updating moved account %s: %s", origin.ID, err)
+		}
+	}
+
+	// the move is delivered to each follower's inbox, so only the receiving account's follow is moved here
+	if errWithCode := p.accountProcessor.MoveFollow(ctx, federatorMsg.ReceivingAccount, origin, target); errWithCode != nil {
+		return fmt.Errorf("error moving follow of %s from %s to %s: %s", federatorMsg.ReceivingAccount.ID, origin.ID, target.ID, errWithCode)
+	}
+
+	return nil
+}
+
+// processUpdateStatusFromFederator handles Activity Update and Object Note
+func (p *processor) processUpdateStatusFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	status, ok := federatorMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return errors.New("note was not parseable as *gtsmodel.Status")
+	}
+
+	statusURI, err := url.Parse(status.URI)
+	if err != nil {
+		return err
+	}
+
+	// dereference the latest version of the status, which will also store the previous version as an edit
+	updatedStatus, _, _, err := p.federator.GetRemoteStatus(ctx, federatorMsg.ReceivingAccount.Username, statusURI, true, false)
+	if err != nil {
+		return fmt.Errorf("error refreshing updated status from federator: %s", err)
+	}
+
+	return p.refreshStatusInTimelines(ctx, updatedStatus)
+}
+
+// processUpdatePollFromFederator handles Activity Update and Object Question
+func (p *processor) processUpdatePollFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	poll, ok := federatorMsg.GTSModel.(*gtsmodel.Poll)
+	if !ok {
+		return errors.New("question was not parseable as *gtsmodel.Poll")
+	}
+
+	// the only side effect of an updated poll is letting voters know when it's been closed
+	if poll.ClosedAt.IsZero() {
+		return nil
+	}
+
+	return p.notifyPollClosed(ctx, poll)
+}
+
 // processDeleteStatusFromFederator handles Activity Delete and Object Note
 func (p *processor) processDeleteStatusFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	// TODO: handle side effects of status deletion here:
@@ -370,6 +477,33 @@ func (p *processor) processDeleteStatusFromFederator(ctx context.Context, federa
 		return err
 	}
 
+	// delete the poll attached to this status, along with its votes
+	if statusToDelete.PollID != "" {
+		if err := p.db.DeletePollByID(ctx, statusToDelete.PollID); err != nil {
+			return err
+		}
+	}
+
+	// delete all previous revisions of this status
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusEdit{}); err != nil {
+		return err
+	}
+
+	// delete all bookmarks of this status
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
+		return err
+	}
+
+	// delete all mutes of the thread this status is the root of
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusMute{}); err != nil {
+		return err
+	}
+
+	// remove this status from any direct message conversations it's part of
+	if err := p.db.DeleteStatusFromConversations(ctx, statusToDelete.ID); err != nil {
+		return err
+	}
+
 	// remove this status from any and all timelines
 	return p.deleteStatusFromTimelines(ctx, statusToDelete)
 }
@@ -116,7 +116,7 @@ func (suite *FromFederatorTestSuite) TestProcessReplyMention() {
 		Likeable:            true,
 	}
 
-	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), repliedAccount, stream.TimelineHome)
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), repliedAccount, "", stream.TimelineHome)
 	suite.NoError(errWithCode)
 
 	// id the status based on the time it was created
@@ -175,7 +175,7 @@ func (suite *FromFederatorTestSuite) TestProcessFave() {
 	favedStatus := suite.testStatuses["local_account_1_status_1"]
 	favingAccount := suite.testAccounts["remote_account_1"]
 
-	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), favedAccount, stream.TimelineNotifications)
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), favedAccount, "", stream.TimelineNotifications)
 	suite.NoError(errWithCode)
 
 	fave := &gtsmodel.StatusFave{
@@ -242,7 +242,7 @@ func (suite *FromFederatorTestSuite) TestProcessFaveWithDifferentReceivingAccoun
 	favedStatus := suite.testStatuses["local_account_1_status_1"]
 	favingAccount := suite.testAccounts["remote_account_1"]
 
-	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), receivingAccount, stream.TimelineHome)
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), receivingAccount, "", stream.TimelineHome)
 	suite.NoError(errWithCode)
 
 	fave := &gtsmodel.StatusFave{
@@ -375,6 +375,85 @@ func (suite *FromFederatorTestSuite) TestProcessAccountDelete() {
 	suite.Equal(dbAccount.ID, dbAccount.SuspensionOrigin)
 }
 
+func (suite *FromFederatorTestSuite) TestProcessAccountMove() {
+	ctx := context.Background()
+
+	movedAccount := &gtsmodel.Account{}
+	*movedAccount = *suite.testAccounts["remote_account_2"]
+	receivingAccount := suite.testAccounts["local_account_2"]
+
+	// the account being moved to has to list the moved account as an alias
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["admin_account"]
+	targetAccount.AlsoKnownAsURIs = []string{movedAccount.URI}
+	_, err := suite.db.UpdateAccount(ctx, targetAccount)
+	suite.NoError(err)
+
+	// the receiving account follows the moved account, with notifications on
+	follow := &gtsmodel.Follow{
+		ID:              "01G2BAX4H7CVFQ6CA2W4KJ8R6Y",
+		CreatedAt:       time.Now().Add(-1 * time.Hour),
+		UpdatedAt:       time.Now().Add(-1 * time.Hour),
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: movedAccount.ID,
+		ShowReblogs:     true,
+		URI:             fmt.Sprintf("%s/follow/01G2BAX4H7CVFQ6CA2W4KJ8R6Y", receivingAccount.URI),
+		Notify:          true,
+	}
+	err = suite.db.Put(ctx, follow)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		GTSModel:         movedAccount,
+		APIri:            testrig.URLMustParse(targetAccount.URI),
+		ReceivingAccount: receivingAccount,
+	})
+	suite.NoError(err)
+
+	// the moved account should point to the target now
+	dbAccount, err := suite.db.GetAccountByID(ctx, movedAccount.ID)
+	suite.NoError(err)
+	suite.Equal(targetAccount.URI, dbAccount.MovedToURI)
+	suite.Equal(targetAccount.ID, dbAccount.MovedToAccountID)
+
+	// the follow should have moved over to the target, keeping its settings
+	followsMoved, err := suite.db.IsFollowing(ctx, receivingAccount, movedAccount)
+	suite.NoError(err)
+	suite.False(followsMoved)
+
+	newFollow := &gtsmodel.Follow{}
+	err = suite.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: receivingAccount.ID},
+		{Key: "target_account_id", Value: targetAccount.ID},
+	}, newFollow)
+	suite.NoError(err)
+	suite.True(newFollow.Notify)
+}
+
+func (suite *FromFederatorTestSuite) TestProcessAccountMoveNotAliased() {
+	ctx := context.Background()
+
+	movedAccount := suite.testAccounts["remote_account_2"]
+	receivingAccount := suite.testAccounts["local_account_2"]
+	targetAccount := suite.testAccounts["admin_account"]
+
+	err := suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		GTSModel:         movedAccount,
+		APIri:            testrig.URLMustParse(targetAccount.URI),
+		ReceivingAccount: receivingAccount,
+	})
+	suite.Error(err)
+
+	// nothing should have changed
+	dbAccount, err := suite.db.GetAccountByID(ctx, movedAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.MovedToURI)
+}
+
 func (suite *FromFederatorTestSuite) TestProcessFollowRequestLocked() {
 	ctx := context.Background()
 
@@ -383,7 +462,7 @@ func (suite *FromFederatorTestSuite) TestProcessFollowRequestLocked() {
 	// target is a locked account
 	targetAccount := suite.testAccounts["local_account_2"]
 
-	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), targetAccount, stream.TimelineHome)
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), targetAccount, "", stream.TimelineHome)
 	suite.NoError(errWithCode)
 
 	// put the follow request in the database as though it had passed through the federating db already
@@ -434,7 +513,7 @@ func (suite *FromFederatorTestSuite) TestProcessFollowRequestUnlocked() {
 	// target is an unlocked account
 	targetAccount := suite.testAccounts["local_account_1"]
 
-	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), targetAccount, stream.TimelineHome)
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), targetAccount, "", stream.TimelineHome)
 	suite.NoError(errWithCode)
 
 	// put the follow request in the database as though it had passed through the federating db already
@@ -473,8 +552,10 @@ func (suite *FromFederatorTestSuite) TestProcessFollowRequestUnlocked() {
 	suite.Equal("follow", notif.Type)
 	suite.Equal(originAccount.ID, notif.Account.ID)
 
-	// an accept message should be sent to satan's inbox
-	suite.Len(suite.sentHTTPRequests, 1)
+	// an accept message should be sent to satan's inbox, once the delivery queue gets to it
+	suite.Eventually(func() bool {
+		return len(suite.sentHTTPRequests) == 1
+	}, 5*time.Second, 10*time.Millisecond)
 	acceptBytes := suite.sentHTTPRequests[originAccount.InboxURI]
 	accept := &struct {
 		Actor  string `json:"actor"`
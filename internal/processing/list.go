@@ -0,0 +1,320 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) ListsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.List, gtserror.WithCode) {
+	lists, err := p.db.GetListsForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLists := []*apimodel.List{}
+	for _, l := range lists {
+		apiList, err := p.tc.ListToAPIList(ctx, l)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiLists = append(apiLists, apiList)
+	}
+
+	return apiLists, nil
+}
+
+func (p *processor) ListGet(ctx context.Context, authed *oauth.Auth, listID string) (*apimodel.List, gtserror.WithCode) {
+	list, errWithCode := p.getOwnList(ctx, authed, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiList(ctx, list)
+}
+
+func (p *processor) ListCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode) {
+	title := strings.TrimSpace(form.Title)
+	if title == "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("list title was empty"), "list title must be provided")
+	}
+
+	repliesPolicy := gtsmodel.RepliesPolicyList
+	if form.RepliesPolicy != "" {
+		var errWithCode gtserror.WithCode
+		if repliesPolicy, errWithCode = parseRepliesPolicy(form.RepliesPolicy); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	listID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	list := &gtsmodel.List{
+		ID:            listID,
+		Title:         title,
+		AccountID:     authed.Account.ID,
+		Account:       authed.Account,
+		RepliesPolicy: repliesPolicy,
+	}
+
+	if err := p.db.Put(ctx, list); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiList(ctx, list)
+}
+
+func (p *processor) ListUpdate(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListUpdateRequest) (*apimodel.List, gtserror.WithCode) {
+	list, errWithCode := p.getOwnList(ctx, authed, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Title != nil {
+		title := strings.TrimSpace(*form.Title)
+		if title == "" {
+			return nil, gtserror.NewErrorBadRequest(errors.New("list title was empty"), "list title must not be empty")
+		}
+		list.Title = title
+	}
+
+	if form.RepliesPolicy != nil {
+		if list.RepliesPolicy, errWithCode = parseRepliesPolicy(*form.RepliesPolicy); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if err := p.db.UpdateByPrimaryKey(ctx, list); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiList(ctx, list)
+}
+
+func (p *processor) ListDelete(ctx context.Context, authed *oauth.Auth, listID string) gtserror.WithCode {
+	if _, errWithCode := p.getOwnList(ctx, authed, listID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteListByID(ctx, listID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) ListAccountsGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.ListAccountsResponse, gtserror.WithCode) {
+	if _, errWithCode := p.getOwnList(ctx, authed, listID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	entries, err := p.db.GetListEntries(ctx, listID, maxID, sinceID, minID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	resp := &apimodel.ListAccountsResponse{
+		Accounts: []*apimodel.Account{},
+	}
+
+	if len(entries) == 0 {
+		return resp, nil
+	}
+
+	for _, e := range entries {
+		if e.Follow == nil || e.Follow.TargetAccount == nil {
+			logrus.Debugf("ListAccountsGet: skipping list entry %s because its follow or followed account could not be found", e.ID)
+			continue
+		}
+
+		apiAccount, err := p.tc.AccountToAPIAccountPublic(ctx, e.Follow.TargetAccount)
+		if err != nil {
+			logrus.Debugf("ListAccountsGet: skipping list entry %s because its account couldn't be converted: %s", e.ID, err)
+			continue
+		}
+		resp.Accounts = append(resp.Accounts, apiAccount)
+	}
+
+	// with no limit the whole list has been returned, so there's nothing to page through
+	if limit <= 0 {
+		return resp, nil
+	}
+
+	// the link header pages by list entry ID rather than by account ID
+	blocksResp, errWithCode := p.packageBlocksResponse(resp.Accounts, "api/v1/lists/"+listID+"/accounts", entries[len(entries)-1].ID, entries[0].ID, limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	resp.LinkHeader = blocksResp.LinkHeader
+
+	return resp, nil
+}
+
+func (p *processor) ListAccountsAdd(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode {
+	if _, errWithCode := p.getOwnList(ctx, authed, listID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(form.AccountIDs) == 0 {
+		return gtserror.NewErrorBadRequest(errors.New("no account ids given"), "account_ids must be provided")
+	}
+
+	entries := []*gtsmodel.ListEntry{}
+	for _, accountID := range form.AccountIDs {
+		follow, errWithCode := p.getOwnFollow(ctx, authed, accountID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		existing, err := p.db.GetListsContainingAccountID(ctx, authed.Account.ID, accountID)
+		if err != nil && err != db.ErrNoEntries {
+			return gtserror.NewErrorInternalError(err)
+		}
+		if listContains(existing, listID) {
+			// already a member, nothing to do
+			continue
+		}
+
+		entryID, err := id.NewULID()
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		entries = append(entries, &gtsmodel.ListEntry{
+			ID:       entryID,
+			ListID:   listID,
+			FollowID: follow.ID,
+		})
+	}
+
+	if err := p.db.PutListEntries(ctx, entries); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) ListAccountsRemove(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode {
+	if _, errWithCode := p.getOwnList(ctx, authed, listID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(form.AccountIDs) == 0 {
+		return gtserror.NewErrorBadRequest(errors.New("no account ids given"), "account_ids must be provided")
+	}
+
+	followIDs := []string{}
+	for _, accountID := range form.AccountIDs {
+		follow := &gtsmodel.Follow{}
+		if err := p.db.GetWhere(ctx, []db.Where{
+			{Key: "account_id", Value: authed.Account.ID},
+			{Key: "target_account_id", Value: accountID},
+		}, follow); err != nil {
+			if err == db.ErrNoEntries {
+				// not followed so can't be in the list either
+				continue
+			}
+			return gtserror.NewErrorInternalError(err)
+		}
+		followIDs = append(followIDs, follow.ID)
+	}
+
+	if err := p.db.DeleteListEntriesForFollowIDs(ctx, listID, followIDs); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getOwnList gets the list with the given ID, making sure it's owned by the requesting account.
+// Lists owned by other accounts are treated as not found, so that their existence isn't leaked.
+func (p *processor) getOwnList(ctx context.Context, authed *oauth.Auth, listID string) (*gtsmodel.List, gtserror.WithCode) {
+	list, err := p.db.GetListByID(ctx, listID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if list.AccountID != authed.Account.ID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("list %s does not belong to account %s", listID, authed.Account.ID))
+	}
+
+	return list, nil
+}
+
+// getOwnFollow gets the follow from the requesting account to the given account, if it exists.
+func (p *processor) getOwnFollow(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*gtsmodel.Follow, gtserror.WithCode) {
+	follow := &gtsmodel.Follow{}
+	if err := p.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: authed.Account.ID},
+		{Key: "target_account_id", Value: targetAccountID},
+	}, follow); err != nil {
+		if err == db.ErrNoEntries {
+			err = fmt.Errorf("account %s is not followed by account %s", targetAccountID, authed.Account.ID)
+			return nil, gtserror.NewErrorNotFound(err, "you must follow an account to add it to a list")
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return follow, nil
+}
+
+func (p *processor) apiList(ctx context.Context, list *gtsmodel.List) (*apimodel.List, gtserror.WithCode) {
+	apiList, err := p.tc.ListToAPIList(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiList, nil
+}
+
+func parseRepliesPolicy(s string) (gtsmodel.RepliesPolicy, gtserror.WithCode) {
+	switch rp := gtsmodel.RepliesPolicy(s); rp {
+	case gtsmodel.RepliesPolicyFollowed, gtsmodel.RepliesPolicyList, gtsmodel.RepliesPolicyNone:
+		return rp, nil
+	default:
+		err := fmt.Errorf("replies policy %s not recognized", s)
+		return "", gtserror.NewErrorBadRequest(err, "replies_policy must be one of followed, list, none")
+	}
+}
+
+func listContains(lists []*gtsmodel.List, listID string) bool {
+	for _, l := range lists {
+		if l.ID == listID {
+			return true
+		}
+	}
+	return false
+}
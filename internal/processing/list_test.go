@@ -0,0 +1,132 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ListTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ListTestSuite) TestListsGet() {
+	lists, errWithCode := suite.processor.ListsGet(context.Background(), suite.testAutheds["local_account_1"])
+	suite.NoError(errWithCode)
+	suite.Len(lists, 1)
+	suite.Equal("01G1CNBTNXGQNRHCM3WVSQHP8T", lists[0].ID)
+	suite.Equal("cool people", lists[0].Title)
+	suite.Equal("followed", lists[0].RepliesPolicy)
+}
+
+func (suite *ListTestSuite) TestListGetNotOwner() {
+	list, errWithCode := suite.processor.ListGet(context.Background(), suite.testAutheds["local_account_2"], "01G1CNBTNXGQNRHCM3WVSQHP8T")
+	suite.Nil(list)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ListTestSuite) TestListCreateUpdateDelete() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	created, errWithCode := suite.processor.ListCreate(ctx, authed, &apimodel.ListCreateRequest{Title: "  cats  "})
+	suite.NoError(errWithCode)
+	suite.Equal("cats", created.Title)
+	suite.Equal("list", created.RepliesPolicy)
+
+	newTitle := "more cats"
+	newPolicy := "none"
+	updated, errWithCode := suite.processor.ListUpdate(ctx, authed, created.ID, &apimodel.ListUpdateRequest{Title: &newTitle, RepliesPolicy: &newPolicy})
+	suite.NoError(errWithCode)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal("more cats", updated.Title)
+	suite.Equal("none", updated.RepliesPolicy)
+
+	badPolicy := "everything"
+	_, errWithCode = suite.processor.ListUpdate(ctx, authed, created.ID, &apimodel.ListUpdateRequest{RepliesPolicy: &badPolicy})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	suite.NoError(suite.processor.ListDelete(ctx, authed, created.ID))
+
+	_, errWithCode = suite.processor.ListGet(ctx, authed, created.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ListTestSuite) TestListCreateNoTitle() {
+	list, errWithCode := suite.processor.ListCreate(context.Background(), suite.testAutheds["local_account_1"], &apimodel.ListCreateRequest{})
+	suite.Nil(list)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *ListTestSuite) TestListAccountsAddRemove() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	listID := "01G1CNBTNXGQNRHCM3WVSQHP8T"
+	adminAccount := suite.testAccounts["admin_account"]
+
+	// zork follows the admin account, so it can be added; adding it twice is fine
+	suite.NoError(suite.processor.ListAccountsAdd(ctx, authed, listID, &apimodel.ListAccountsChangeRequest{AccountIDs: []string{adminAccount.ID}}))
+	suite.NoError(suite.processor.ListAccountsAdd(ctx, authed, listID, &apimodel.ListAccountsChangeRequest{AccountIDs: []string{adminAccount.ID}}))
+
+	resp, errWithCode := suite.processor.ListAccountsGet(ctx, authed, listID, "", "", "", 40)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Accounts, 2)
+	suite.Equal(adminAccount.ID, resp.Accounts[0].ID)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, resp.Accounts[1].ID)
+	suite.NotEmpty(resp.LinkHeader)
+
+	// zork doesn't follow remote_account_1, so it can't be added
+	errWithCode = suite.processor.ListAccountsAdd(ctx, authed, listID, &apimodel.ListAccountsChangeRequest{AccountIDs: []string{suite.testAccounts["remote_account_1"].ID}})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	suite.NoError(suite.processor.ListAccountsRemove(ctx, authed, listID, &apimodel.ListAccountsChangeRequest{AccountIDs: []string{adminAccount.ID}}))
+
+	resp, errWithCode = suite.processor.ListAccountsGet(ctx, authed, listID, "", "", "", 0)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, resp.Accounts[0].ID)
+	suite.Empty(resp.LinkHeader)
+}
+
+func (suite *ListTestSuite) TestListTimelineGet() {
+	resp, errWithCode := suite.processor.ListTimelineGet(context.Background(), suite.testAutheds["local_account_1"], "01G1CNBTNXGQNRHCM3WVSQHP8T", "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Statuses)
+	suite.NotEmpty(resp.LinkHeader)
+
+	// only statuses by members of the list should be shown
+	for _, s := range resp.Statuses {
+		suite.Equal(suite.testAccounts["local_account_2"].ID, s.Account.ID)
+	}
+}
+
+func (suite *ListTestSuite) TestListTimelineGetNotOwner() {
+	resp, errWithCode := suite.processor.ListTimelineGet(context.Background(), suite.testAutheds["local_account_2"], "01G1CNBTNXGQNRHCM3WVSQHP8T", "", "", "", 20)
+	suite.Nil(resp)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestListTestSuite(t *testing.T) {
+	suite.Run(t, &ListTestSuite{})
+}
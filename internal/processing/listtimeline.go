@@ -0,0 +1,163 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+// ListGrabFunction returns a function that satisfies the GrabFunction interface in internal/timeline.
+//
+// List timelines are keyed by list ID rather than by account ID.
+func ListGrabFunction(database db.DB) timeline.GrabFunction {
+	return func(ctx context.Context, timelineListID string, maxID string, sinceID string, minID string, limit int) ([]timeline.Timelineable, bool, error) {
+		statuses, err := database.GetListTimeline(ctx, timelineListID, maxID, sinceID, minID, limit)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, true, nil // we just don't have enough statuses left in the db so return stop = true
+			}
+			return nil, false, fmt.Errorf("listGrabFunction: error getting statuses from db: %s", err)
+		}
+
+		items := []timeline.Timelineable{}
+		for _, s := range statuses {
+			items = append(items, s)
+		}
+
+		return items, false, nil
+	}
+}
+
+// ListFilterFunction returns a function that satisfies the FilterFunction interface in internal/timeline.
+func ListFilterFunction(database db.DB, filter visibility.Filter) timeline.FilterFunction {
+	return func(ctx context.Context, timelineListID string, item timeline.Timelineable) (shouldIndex bool, err error) {
+		status, ok := item.(*gtsmodel.Status)
+		if !ok {
+			return false, errors.New("listFilterFunction: could not convert item to *gtsmodel.Status")
+		}
+
+		list, err := database.GetListByID(ctx, timelineListID)
+		if err != nil {
+			return false, fmt.Errorf("listFilterFunction: error getting list with id %s", timelineListID)
+		}
+
+		timelineable, err := listTimelineable(ctx, database, filter, status, list)
+		if err != nil {
+			logrus.Warnf("error checking listtimelineability of status %s for list %s: %s", status.ID, timelineListID, err)
+		}
+
+		return timelineable, nil // we don't return the error here because we want to just skip this item if something goes wrong
+	}
+}
+
+// ListPrepareFunction returns a function that satisfies the PrepareFunction interface in internal/timeline.
+//
+// Statuses are prepared from the point of view of the account that owns the list.
+func ListPrepareFunction(database db.DB, tc typeutils.TypeConverter) timeline.PrepareFunction {
+	return func(ctx context.Context, timelineListID string, itemID string) (timeline.Preparable, error) {
+		status, err := database.GetStatusByID(ctx, itemID)
+		if err != nil {
+			return nil, fmt.Errorf("listPrepareFunction: error getting status with id %s", itemID)
+		}
+
+		list, err := database.GetListByID(ctx, timelineListID)
+		if err != nil {
+			return nil, fmt.Errorf("listPrepareFunction: error getting list with id %s", timelineListID)
+		}
+
+		return tc.StatusToAPIStatus(ctx, status, list.Account)
+	}
+}
+
+// listTimelineable checks whether the given status should appear in the timeline of the given list:
+// it must be hometimelineable for the list owner, and replies must fit the replies policy of the list.
+func listTimelineable(ctx context.Context, database db.DB, filter visibility.Filter, status *gtsmodel.Status, list *gtsmodel.List) (bool, error) {
+	timelineable, err := filter.StatusHometimelineable(ctx, status, list.Account)
+	if err != nil || !timelineable {
+		return false, err
+	}
+
+	// lists are part of the home context as far as filters are concerned
+	filteredOut, err := filter.StatusFilteredOut(ctx, status, list.Account, gtsmodel.FilterContextHome)
+	if err != nil || filteredOut {
+		return false, err
+	}
+
+	// not a reply, or a reply to self / to the list owner: always fine
+	if status.InReplyToAccountID == "" ||
+		status.InReplyToAccountID == status.AccountID ||
+		status.InReplyToAccountID == list.AccountID {
+		return true, nil
+	}
+
+	switch list.RepliesPolicy {
+	case gtsmodel.RepliesPolicyFollowed:
+		// hometimelineable already made sure the replied-to account is followed
+		return true, nil
+	case gtsmodel.RepliesPolicyList:
+		lists, err := database.GetListsContainingAccountID(ctx, list.AccountID, status.InReplyToAccountID)
+		if err != nil && err != db.ErrNoEntries {
+			return false, err
+		}
+		return listContains(lists, list.ID), nil
+	default:
+		return false, nil
+	}
+}
+
+func (p *processor) ListTimelineGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	if _, errWithCode := p.getOwnList(ctx, authed, listID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	preparedItems, err := p.listTimelines.GetTimeline(ctx, listID, maxID, sinceID, minID, limit, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(preparedItems) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	statuses := []*apimodel.Status{}
+	for _, i := range preparedItems {
+		status, ok := i.(*apimodel.Status)
+		if !ok {
+			return nil, gtserror.NewErrorInternalError(errors.New("error converting prepared timeline entry to api status"))
+		}
+		statuses = append(statuses, status)
+	}
+
+	return p.packageStatusResponse(statuses, "api/v1/timelines/list/"+listID, statuses[len(preparedItems)-1].ID, statuses[0].ID, limit)
+}
@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) MarkersGet(ctx context.Context, authed *oauth.Auth, timelines []string) (*apimodel.Marker, gtserror.WithCode) {
+	names := []gtsmodel.MarkerName{}
+	for _, t := range timelines {
+		name := gtsmodel.MarkerName(t)
+		switch name {
+		case gtsmodel.MarkerNameHome, gtsmodel.MarkerNameNotifications:
+			names = append(names, name)
+		default:
+			// unknown timelines are just ignored
+			continue
+		}
+	}
+
+	markers, err := p.db.GetMarkers(ctx, authed.Account.ID, names)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiMarker, err := p.tc.MarkersToAPIMarker(ctx, markers)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiMarker, nil
+}
+
+func (p *processor) MarkersSet(ctx context.Context, authed *oauth.Auth, form *apimodel.MarkerPostRequest) (*apimodel.Marker, gtserror.WithCode) {
+	names := []gtsmodel.MarkerName{}
+	lastReadIDs := map[gtsmodel.MarkerName]string{}
+	if form.Home != nil {
+		names = append(names, gtsmodel.MarkerNameHome)
+		lastReadIDs[gtsmodel.MarkerNameHome] = form.Home.LastReadID
+	}
+	if form.Notifications != nil {
+		names = append(names, gtsmodel.MarkerNameNotifications)
+		lastReadIDs[gtsmodel.MarkerNameNotifications] = form.Notifications.LastReadID
+	}
+
+	if len(names) == 0 {
+		err := errors.New("no timelines provided")
+		return nil, gtserror.NewErrorBadRequest(err, "at least one of home[last_read_id] or notifications[last_read_id] must be provided")
+	}
+
+	for _, name := range names {
+		if lastReadIDs[name] == "" {
+			err := fmt.Errorf("no last read id provided for timeline %s", name)
+			return nil, gtserror.NewErrorBadRequest(err, fmt.Sprintf("%s[last_read_id] must not be empty", name))
+		}
+	}
+
+	existing, err := p.db.GetMarkers(ctx, authed.Account.ID, names)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	markers := make([]*gtsmodel.Marker, 0, len(names))
+	for _, name := range names {
+		var marker *gtsmodel.Marker
+		for _, m := range existing {
+			if m.Name == name {
+				marker = m
+				break
+			}
+		}
+
+		if marker == nil {
+			// first time a marker has been set for this timeline
+			marker = &gtsmodel.Marker{
+				AccountID: authed.Account.ID,
+				Name:      name,
+			}
+		}
+		marker.LastReadID = lastReadIDs[name]
+
+		if err := p.db.UpdateMarker(ctx, marker); err != nil {
+			if err == db.ErrVersionConflict {
+				return nil, gtserror.NewErrorConflict(err, fmt.Sprintf("the %s marker was updated by another request, please try again", name))
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		markers = append(markers, marker)
+	}
+
+	apiMarker, err := p.tc.MarkersToAPIMarker(ctx, markers)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// let any other sessions of the account know about the new position
+	if err := p.streamingProcessor.StreamMarkerToAccount(apiMarker, authed.Account); err != nil {
+		logrus.Errorf("MarkersSet: error streaming markers to account %s: %s", authed.Account.ID, err)
+	}
+
+	return apiMarker, nil
+}
@@ -0,0 +1,101 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type MarkerTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *MarkerTestSuite) TestMarkersGet() {
+	marker, errWithCode := suite.processor.MarkersGet(context.Background(), suite.testAutheds["local_account_1"], []string{"home", "notifications", "nonsense"})
+	suite.NoError(errWithCode)
+	suite.NotNil(marker.Home)
+	suite.Equal("01F8MHAMCHF6Y650WCRSCP4WMY", marker.Home.LastReadID)
+	suite.Equal(1, marker.Home.Version)
+	// no notifications marker has been set yet
+	suite.Nil(marker.Notifications)
+
+	b, err := json.Marshal(marker)
+	suite.NoError(err)
+	suite.NotContains(string(b), "notifications")
+
+	// nothing asked for, nothing returned
+	marker, errWithCode = suite.processor.MarkersGet(context.Background(), suite.testAutheds["local_account_1"], nil)
+	suite.NoError(errWithCode)
+	suite.Nil(marker.Home)
+	suite.Nil(marker.Notifications)
+}
+
+func (suite *MarkerTestSuite) TestMarkersSet() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	// another session of the account is streaming
+	userStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, authed.Account, "", stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	marker, errWithCode := suite.processor.MarkersSet(ctx, authed, &model.MarkerPostRequest{
+		Home:          &model.MarkerTimelineRequest{LastReadID: "01F8MHAYFKS4KMXF8K5Y1C0KRN"},
+		Notifications: &model.MarkerTimelineRequest{LastReadID: "01F8Q0ANPTWW10DAKTX7BRPBJP"},
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("01F8MHAYFKS4KMXF8K5Y1C0KRN", marker.Home.LastReadID)
+	suite.Equal(2, marker.Home.Version)
+	suite.Equal("01F8Q0ANPTWW10DAKTX7BRPBJP", marker.Notifications.LastReadID)
+	suite.Equal(1, marker.Notifications.Version)
+
+	msg := <-userStream.Messages
+	suite.Equal(stream.EventTypeMarker, msg.Event)
+	streamed := &model.Marker{}
+	err := json.Unmarshal([]byte(msg.Payload), streamed)
+	suite.NoError(err)
+	suite.Equal(marker, streamed)
+
+	marker, errWithCode = suite.processor.MarkersGet(ctx, authed, []string{"home", "notifications"})
+	suite.NoError(errWithCode)
+	suite.Equal("01F8MHAYFKS4KMXF8K5Y1C0KRN", marker.Home.LastReadID)
+	suite.Equal("01F8Q0ANPTWW10DAKTX7BRPBJP", marker.Notifications.LastReadID)
+}
+
+func (suite *MarkerTestSuite) TestMarkersSetNothing() {
+	_, errWithCode := suite.processor.MarkersSet(context.Background(), suite.testAutheds["local_account_1"], &model.MarkerPostRequest{})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.MarkersSet(context.Background(), suite.testAutheds["local_account_1"], &model.MarkerPostRequest{
+		Home: &model.MarkerTimelineRequest{},
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestMarkerTestSuite(t *testing.T) {
+	suite.Run(t, new(MarkerTestSuite))
+}
@@ -113,7 +113,7 @@ func (p *processor) getAttachmentContent(ctx context.Context, requestingAccount
 
 	// if we have the media cached on our server already, we can now simply return it from storage
 	if a.Cached {
-		return p.streamFromStorage(storagePath, attachmentContent)
+		return p.streamFromStorage(ctx, storagePath, attachmentContent)
 	}
 
 	// if we don't have it cached, then we can assume two things:
@@ -221,7 +221,7 @@ func (p *processor) getAttachmentContent(ctx context.Context, requestingAccount
 			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error loading recached attachment: %s", err))
 		}
 		// ... so now we can safely return it
-		return p.streamFromStorage(storagePath, attachmentContent)
+		return p.streamFromStorage(ctx, storagePath, attachmentContent)
 	}
 
 	return attachmentContent, nil
@@ -253,10 +253,16 @@ func (p *processor) getEmojiContent(ctx context.Context, wantedEmojiID string, e
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for emoji", emojiSize))
 	}
 
-	return p.streamFromStorage(storagePath, emojiContent)
+	return p.streamFromStorage(ctx, storagePath, emojiContent)
 }
 
-func (p *processor) streamFromStorage(storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
+func (p *processor) streamFromStorage(ctx context.Context, storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
+	// if the storage backend can serve the file directly, just point the caller there
+	if u := p.storage.URL(ctx, storagePath); u != nil {
+		content.URL = u
+		return content, nil
+	}
+
 	reader, err := p.storage.GetStream(storagePath)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error retrieving from storage: %s", err))
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// muteExpiryInterval is how often the processor checks for mutes that have expired.
+const muteExpiryInterval = 1 * time.Minute
+
+func (p *processor) MutesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.MutesResponse, gtserror.WithCode) {
+	mutes, nextMaxID, prevMinID, err := p.db.GetAccountMutes(ctx, authed.Account.ID, maxID, sinceID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.MutesResponse{
+				Accounts: []*apimodel.Account{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := []*apimodel.Account{}
+	for _, m := range mutes {
+		apiAccount, err := p.tc.AccountToAPIAccountPublic(ctx, m.TargetAccount)
+		if err != nil {
+			continue
+		}
+		if !m.ExpiresAt.IsZero() {
+			apiAccount.MuteExpiresAt = m.ExpiresAt.Format(time.RFC3339)
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	resp := &apimodel.MutesResponse{
+		Accounts: apiAccounts,
+	}
+
+	// prepare the next and previous links
+	if len(apiAccounts) != 0 {
+		protocol := viper.GetString(config.Keys.Protocol)
+		host := viper.GetString(config.Keys.Host)
+
+		nextLink := &url.URL{
+			Scheme:   protocol,
+			Host:     host,
+			Path:     "/api/v1/mutes",
+			RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, nextMaxID),
+		}
+		next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+		prevLink := &url.URL{
+			Scheme:   protocol,
+			Host:     host,
+			Path:     "/api/v1/mutes",
+			RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, prevMinID),
+		}
+		prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+		resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+	}
+
+	return resp, nil
+}
+
+// liftExpiredMutes removes all mutes that have passed their expiry time, and unloads the
+// timelines of the accounts that created them so that muted statuses show up again.
+func (p *processor) liftExpiredMutes(ctx context.Context) error {
+	for {
+		mutes, err := p.db.GetExpiredMutes(ctx, time.Now(), 50)
+		if err != nil {
+			return fmt.Errorf("liftExpiredMutes: error getting expired mutes: %s", err)
+		}
+
+		if len(mutes) == 0 {
+			return nil
+		}
+
+		for _, mute := range mutes {
+			if err := p.db.DeleteByID(ctx, mute.ID, mute); err != nil {
+				return fmt.Errorf("liftExpiredMutes: error deleting mute %s: %s", mute.ID, err)
+			}
+
+			logrus.Debugf("liftExpiredMutes: lifted mute %s of account %s by account %s", mute.ID, mute.TargetAccountID, mute.AccountID)
+			p.unloadFilteredTimelines(ctx, mute.AccountID)
+		}
+	}
+}
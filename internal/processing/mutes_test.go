@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MuteTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *MuteTestSuite) TestMutesGet() {
+	authed := suite.testAutheds["local_account_2"]
+
+	resp, errWithCode := suite.processor.MutesGet(context.Background(), authed, "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Accounts, 1)
+	suite.Equal(suite.testAccounts["remote_account_2"].ID, resp.Accounts[0].ID)
+	suite.Empty(resp.Accounts[0].MuteExpiresAt)
+	suite.Contains(resp.LinkHeader, "/api/v1/mutes?limit=20&max_id=")
+}
+
+func (suite *MuteTestSuite) TestMuteCreateUpdateRemove() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	targetAccount := suite.testAccounts["admin_account"]
+
+	// notifications are muted by default
+	relationship, errWithCode := suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Muting)
+	suite.True(relationship.MutingNotifications)
+
+	// muting again updates the existing mute
+	notifications := false
+	relationship, errWithCode = suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{
+		ID:            targetAccount.ID,
+		Notifications: &notifications,
+		Duration:      3600,
+	})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+
+	resp, errWithCode := suite.processor.MutesGet(ctx, authed, "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Accounts, 1)
+	suite.Equal(targetAccount.ID, resp.Accounts[0].ID)
+	suite.NotEmpty(resp.Accounts[0].MuteExpiresAt)
+
+	relationship, errWithCode = suite.processor.AccountMuteRemove(ctx, authed, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+
+	resp, errWithCode = suite.processor.MutesGet(ctx, authed, "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Accounts)
+}
+
+func (suite *MuteTestSuite) TestMuteCreateInvalid() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	_, errWithCode := suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{ID: authed.Account.ID})
+	suite.EqualError(errWithCode, "you can't mute yourself")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{
+		ID:       suite.testAccounts["admin_account"].ID,
+		Duration: -1,
+	})
+	suite.EqualError(errWithCode, "duration must not be negative")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{ID: "01G3GMB0N4YQJ5K5ES2CJWV7TZ"})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *MuteTestSuite) TestMuteHidesStatusesFromHomeTimeline() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	statusID := suite.testStatuses["admin_account_status_1"].ID
+
+	resp, errWithCode := suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.True(timelineContains(resp.Statuses, statusID))
+
+	_, errWithCode = suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{ID: suite.testAccounts["admin_account"].ID})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.False(timelineContains(resp.Statuses, statusID))
+
+	_, errWithCode = suite.processor.AccountMuteRemove(ctx, authed, suite.testAccounts["admin_account"].ID)
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.True(timelineContains(resp.Statuses, statusID))
+}
+
+func (suite *MuteTestSuite) TestMuteHidesNotifications() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	notificationID := testrig.NewTestNotifications()["local_account_1_like"].ID
+
+	// muting only statuses leaves notifications alone
+	notifications := false
+	_, errWithCode := suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{
+		ID:            suite.testAccounts["admin_account"].ID,
+		Notifications: &notifications,
+	})
+	suite.NoError(errWithCode)
+
+	notifs, errWithCode := suite.processor.NotificationsGet(ctx, authed, nil, nil, "", 20, "", "", "")
+	suite.NoError(errWithCode)
+	if suite.Len(notifs, 1) {
+		suite.Equal(notificationID, notifs[0].ID)
+	}
+
+	notifications = true
+	_, errWithCode = suite.processor.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{
+		ID:            suite.testAccounts["admin_account"].ID,
+		Notifications: &notifications,
+	})
+	suite.NoError(errWithCode)
+
+	notifs, errWithCode = suite.processor.NotificationsGet(ctx, authed, nil, nil, "", 20, "", "", "")
+	suite.NoError(errWithCode)
+	suite.Empty(notifs)
+}
+
+func TestMuteTestSuite(t *testing.T) {
+	suite.Run(t, &MuteTestSuite{})
+}
@@ -20,24 +20,71 @@ package processing
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
-func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode) {
+const (
+	// defaultUnreadCountLimit is how many unread notifications are counted at most if no limit is given.
+	defaultUnreadCountLimit = 100
+	// maxUnreadCountLimit is the highest limit that unread notifications can be counted up to.
+	maxUnreadCountLimit = 1000
+)
+
+func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, types []string, excludeTypes []string, accountID string, limit int, maxID string, sinceID string, minID string) ([]*apimodel.Notification, gtserror.WithCode) {
 	l := logrus.WithField("func", "NotificationsGet")
 
-	notifs, err := p.db.GetNotifications(ctx, authed.Account.ID, limit, maxID, sinceID)
+	apiNotifs := []*apimodel.Notification{}
+
+	knownTypes := knownNotificationTypes(types)
+	if len(types) != 0 && len(knownTypes) == 0 {
+		// only types we don't have were asked for
+		return apiNotifs, nil
+	}
+
+	notifs, err := p.db.GetNotifications(ctx, authed.Account.ID, knownTypes, knownNotificationTypes(excludeTypes), accountID, limit, maxID, sinceID, minID)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	apiNotifs := []*apimodel.Notification{}
 	for _, n := range notifs {
+		muted, err := p.filter.NotificationMuted(ctx, n)
+		if err != nil {
+			l.Debugf("got an error checking mutes on a notification, will skip it: %s", err)
+			continue
+		}
+		if muted {
+			continue
+		}
+
+		if n.StatusID != "" {
+			if n.Status == nil {
+				status, err := p.db.GetStatusByID(ctx, n.StatusID)
+				if err != nil {
+					l.Debugf("got an error getting the status of a notification, will skip it: %s", err)
+					continue
+				}
+				n.Status = status
+			}
+
+			filteredOut, err := p.filter.StatusFilteredOut(ctx, n.Status, authed.Account, gtsmodel.FilterContextNotifications)
+			if err != nil {
+				l.Debugf("got an error checking filters on a notification, will skip it: %s", err)
+				continue
+			}
+			if filteredOut {
+				continue
+			}
+		}
+
 		apiNotif, err := p.tc.NotificationToAPINotification(ctx, n)
 		if err != nil {
 			l.Debugf("got an error converting a notification to api, will skip it: %s", err)
@@ -48,3 +95,77 @@ func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, li
 
 	return apiNotifs, nil
 }
+
+func (p *processor) NotificationsUnreadCount(ctx context.Context, authed *oauth.Auth, types []string, excludeTypes []string, accountID string, limit int) (*apimodel.NotificationsUnreadCount, gtserror.WithCode) {
+	if limit <= 0 {
+		limit = defaultUnreadCountLimit
+	}
+	if limit > maxUnreadCountLimit {
+		limit = maxUnreadCountLimit
+	}
+
+	knownTypes := knownNotificationTypes(types)
+	if len(types) != 0 && len(knownTypes) == 0 {
+		// only types we don't have were asked for
+		return &apimodel.NotificationsUnreadCount{Count: 0}, nil
+	}
+
+	// notifications are unread if they're newer than the account's notifications marker;
+	// if the account hasn't set the marker yet then everything is unread
+	var lastReadID string
+	markers, err := p.db.GetMarkers(ctx, authed.Account.ID, []gtsmodel.MarkerName{gtsmodel.MarkerNameNotifications})
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if len(markers) != 0 {
+		lastReadID = markers[0].LastReadID
+	}
+
+	count, err := p.db.CountNotifications(ctx, authed.Account.ID, knownTypes, knownNotificationTypes(excludeTypes), accountID, lastReadID, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.NotificationsUnreadCount{Count: count}, nil
+}
+
+func (p *processor) NotificationDismiss(ctx context.Context, authed *oauth.Auth, notificationID string) gtserror.WithCode {
+	notif, err := p.db.GetNotification(ctx, notificationID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if notif.TargetAccountID != authed.Account.ID {
+		err := fmt.Errorf("notification %s does not belong to account %s", notificationID, authed.Account.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.db.DeleteNotification(ctx, notificationID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) NotificationsClear(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
+	if err := p.db.ClearNotifications(ctx, authed.Account.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// knownNotificationTypes returns the given notification types without any that aren't recognized,
+// since clients may ask for types of notification that this server doesn't have.
+func knownNotificationTypes(types []string) []string {
+	known := []string{}
+	for _, t := range types {
+		if err := validate.NotificationType(t); err == nil {
+			known = append(known, t)
+		}
+	}
+	return known
+}
@@ -20,9 +20,12 @@ package processing_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type NotificationTestSuite struct {
@@ -32,7 +35,7 @@ type NotificationTestSuite struct {
 // get a notification where someone has liked our status
 func (suite *NotificationTestSuite) TestGetNotifications() {
 	receivingAccount := suite.testAccounts["local_account_1"]
-	notifs, err := suite.processor.NotificationsGet(context.Background(), suite.testAutheds["local_account_1"], 10, "", "")
+	notifs, err := suite.processor.NotificationsGet(context.Background(), suite.testAutheds["local_account_1"], nil, nil, "", 10, "", "", "")
 	suite.NoError(err)
 	suite.Len(notifs, 1)
 	notif := notifs[0]
@@ -42,6 +45,80 @@ func (suite *NotificationTestSuite) TestGetNotifications() {
 	suite.Equal(receivingAccount.ID, notif.Status.Account.ID)
 }
 
+func (suite *NotificationTestSuite) TestGetNotificationsFiltered() {
+	authed := suite.testAutheds["local_account_1"]
+
+	// the only notification is a fave from the admin account
+	notifs, err := suite.processor.NotificationsGet(context.Background(), authed, []string{"favourite"}, nil, suite.testAccounts["admin_account"].ID, 10, "", "", "")
+	suite.NoError(err)
+	suite.Len(notifs, 1)
+
+	notifs, err = suite.processor.NotificationsGet(context.Background(), authed, nil, []string{"favourite"}, "", 10, "", "", "")
+	suite.NoError(err)
+	suite.Empty(notifs)
+
+	notifs, err = suite.processor.NotificationsGet(context.Background(), authed, nil, nil, suite.testAccounts["local_account_2"].ID, 10, "", "", "")
+	suite.NoError(err)
+	suite.Empty(notifs)
+
+	// asking only for types we don't know about shouldn't return everything
+	notifs, err = suite.processor.NotificationsGet(context.Background(), authed, []string{"admin.sign_up"}, nil, "", 10, "", "", "")
+	suite.NoError(err)
+	suite.Empty(notifs)
+}
+
+func (suite *NotificationTestSuite) TestNotificationsUnreadCount() {
+	authed := suite.testAutheds["local_account_1"]
+
+	// no notifications marker has been set yet, so everything is unread
+	unread, err := suite.processor.NotificationsUnreadCount(context.Background(), authed, nil, nil, "", 0)
+	suite.NoError(err)
+	suite.Equal(1, unread.Count)
+
+	fave := testrig.NewTestNotifications()["local_account_1_like"]
+	if err := suite.db.UpdateMarker(context.Background(), &gtsmodel.Marker{
+		AccountID:  authed.Account.ID,
+		Name:       gtsmodel.MarkerNameNotifications,
+		LastReadID: fave.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	unread, err = suite.processor.NotificationsUnreadCount(context.Background(), authed, nil, nil, "", 0)
+	suite.NoError(err)
+	suite.Equal(0, unread.Count)
+}
+
+func (suite *NotificationTestSuite) TestNotificationDismiss() {
+	fave := testrig.NewTestNotifications()["local_account_1_like"]
+
+	// the notification doesn't belong to local_account_2 so they can't dismiss it
+	errWithCode := suite.processor.NotificationDismiss(context.Background(), suite.testAutheds["local_account_2"], fave.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.processor.NotificationDismiss(context.Background(), suite.testAutheds["local_account_1"], fave.ID)
+	suite.Nil(errWithCode)
+
+	notifs, errWithCode := suite.processor.NotificationsGet(context.Background(), suite.testAutheds["local_account_1"], nil, nil, "", 10, "", "", "")
+	suite.Nil(errWithCode)
+	suite.Empty(notifs)
+
+	// it's gone now so dismissing it again is a 404
+	errWithCode = suite.processor.NotificationDismiss(context.Background(), suite.testAutheds["local_account_1"], fave.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *NotificationTestSuite) TestNotificationsClear() {
+	errWithCode := suite.processor.NotificationsClear(context.Background(), suite.testAutheds["local_account_1"])
+	suite.Nil(errWithCode)
+
+	notifs, errWithCode := suite.processor.NotificationsGet(context.Background(), suite.testAutheds["local_account_1"], nil, nil, "", 10, "", "", "")
+	suite.Nil(errWithCode)
+	suite.Empty(notifs)
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, &NotificationTestSuite{})
 }
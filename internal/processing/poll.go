@@ -0,0 +1,93 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) PollGet(ctx context.Context, authed *oauth.Auth, pollID string) (*apimodel.Poll, gtserror.WithCode) {
+	return p.statusProcessor.PollGet(ctx, authed.Account, pollID)
+}
+
+func (p *processor) PollVote(ctx context.Context, authed *oauth.Auth, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
+	return p.statusProcessor.PollVote(ctx, authed.Account, pollID, choices)
+}
+
+// pollExpiryInterval is how often the processor checks for polls that have expired.
+const pollExpiryInterval = 1 * time.Minute
+
+// closeExpiredPolls closes all polls that have passed their expiry time, notifies
+// local voters that the polls have ended, and federates the final results of local polls.
+func (p *processor) closeExpiredPolls(ctx context.Context) error {
+	for {
+		now := time.Now()
+
+		polls, err := p.db.GetExpiredPolls(ctx, now, 50)
+		if err != nil {
+			return fmt.Errorf("closeExpiredPolls: error getting expired polls: %s", err)
+		}
+
+		if len(polls) == 0 {
+			return nil
+		}
+
+		for _, poll := range polls {
+			if err := p.closePoll(ctx, poll, now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// closePoll marks the given poll as closed at the given time and handles the side effects.
+func (p *processor) closePoll(ctx context.Context, poll *gtsmodel.Poll, closedAt time.Time) error {
+	poll.ClosedAt = closedAt
+	poll.UpdatedAt = closedAt
+	if err := p.db.UpdateByPrimaryKey(ctx, poll); err != nil {
+		return fmt.Errorf("closePoll: error updating poll %s: %s", poll.ID, err)
+	}
+
+	status, err := p.db.GetStatusByID(ctx, poll.StatusID)
+	if err != nil {
+		return fmt.Errorf("closePoll: error getting status for poll %s: %s", poll.ID, err)
+	}
+	poll.Status = status
+
+	if err := p.notifyPollClosed(ctx, poll); err != nil {
+		logrus.Errorf("closePoll: error notifying voters of closed poll %s: %s", poll.ID, err)
+	}
+
+	if status.Local {
+		// let remote instances know the final results
+		if err := p.federatePollUpdate(ctx, status); err != nil {
+			logrus.Errorf("closePoll: error federating closed poll %s: %s", poll.ID, err)
+		}
+	}
+
+	return nil
+}
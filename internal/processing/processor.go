@@ -22,8 +22,8 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
 
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/sirupsen/logrus"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -41,10 +41,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor should be passed to api modules (see internal/apimodule/...). It is used for
@@ -98,9 +100,35 @@ type Processor interface {
 	AccountBlockCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// AccountBlockRemove handles the removal of a block from authed account to target account, either remote or local.
 	AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountMuteCreate handles the creation or updating of a mute from authed account to the target account specified in the form.
+	AccountMuteCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountMuteRemove handles the removal of a mute from authed account to target account.
+	AccountMuteRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountAlias sets the aliases of the authed account, and returns the updated account.
+	AccountAlias(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode)
+	// AccountMove moves the authed account to another account, and returns the updated account.
+	AccountMove(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode)
 
 	// AdminAccountAction handles the creation/execution of an action on an account.
 	AdminAccountAction(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminAccountActionRequest) gtserror.WithCode
+	// AdminAccountsGet returns a page of accounts, newest first, optionally filtered by origin, status and user details.
+	AdminAccountsGet(ctx context.Context, authed *oauth.Auth, origin string, status string, domain string, username string, email string, ip string, maxID string, limit int) ([]*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountGet returns the admin view of one account, specified by ID.
+	AdminAccountGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountApprove approves the sign-up of the pending account with the given ID, so that it can sign in.
+	AdminAccountApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountReject rejects the sign-up of the pending account with the given ID, removing the account and its user.
+	AdminAccountReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountEnable re-enables the user of the disabled account with the given ID.
+	AdminAccountEnable(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountUnsilence lifts the silence on the account with the given ID.
+	AdminAccountUnsilence(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountUnsuspend lifts the suspension of the account with the given ID.
+	// Anything removed when the account was suspended isn't restored.
+	AdminAccountUnsuspend(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountResetTwoFactor turns off two-factor authentication for the user of the local account with the given ID,
+	// for when they've lost both their authenticator and their recovery codes.
+	AdminAccountResetTwoFactor(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
 	// AdminEmojiCreate handles the creation of a new instance emoji by an admin, using the given form.
 	AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode)
 	// AdminDomainBlockCreate handles the creation of a new domain block by an admin, using the given form.
@@ -113,16 +141,84 @@ type Processor interface {
 	AdminDomainBlockGet(ctx context.Context, authed *oauth.Auth, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
 	// AdminDomainBlockDelete deletes one domain block, specified by ID, returning the deleted domain block.
 	AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode)
+	// AdminDomainAllowCreate handles the creation of a new domain allow by an admin, using the given form.
+	AdminDomainAllowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainAllowCreateRequest) (*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainAllowsGet returns a list of currently allowed domains.
+	AdminDomainAllowsGet(ctx context.Context, authed *oauth.Auth, export bool) ([]*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainAllowGet returns one domain allow, specified by ID.
+	AdminDomainAllowGet(ctx context.Context, authed *oauth.Auth, id string, export bool) (*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainAllowDelete deletes one domain allow, specified by ID, returning the deleted domain allow.
+	AdminDomainAllowDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDeliveriesGet returns a list of queued outgoing deliveries, optionally filtered by state.
+	AdminDeliveriesGet(ctx context.Context, authed *oauth.Auth, state string, maxID string, limit int) ([]*apimodel.Delivery, gtserror.WithCode)
+	// AdminReportsGet returns a page of reports, newest first, optionally filtered by resolved state and the accounts involved.
+	AdminReportsGet(ctx context.Context, authed *oauth.Auth, resolved *bool, accountID string, targetAccountID string, maxID string, limit int) ([]*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportGet returns one report, specified by ID.
+	AdminReportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportAssign assigns the report with the given ID to the requesting admin.
+	AdminReportAssign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportUnassign removes any assigned admin from the report with the given ID.
+	AdminReportUnassign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportResolve marks the report with the given ID as resolved by the requesting admin.
+	AdminReportResolve(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportReopen marks the report with the given ID as unresolved again.
+	AdminReportReopen(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
 
 	// AppCreate processes the creation of a new API application
 	AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, error)
+	// AppVerifyCredentials returns the application of the token used for the request, to show that the application's credentials work.
+	AppVerifyCredentials(ctx context.Context, authed *oauth.Auth) (*apimodel.Application, gtserror.WithCode)
+
+	// AuthorizedAppsGet returns the applications that hold tokens for the given user, one entry per token.
+	AuthorizedAppsGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.AuthorizedApp, gtserror.WithCode)
+	// AuthorizedAppRevoke revokes the token of the given user with the given ID, closing any streams that were opened with it.
+	AuthorizedAppRevoke(ctx context.Context, user *gtsmodel.User, tokenID string) gtserror.WithCode
 
 	// BlocksGet returns a list of accounts blocked by the requesting account.
 	BlocksGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.BlocksResponse, gtserror.WithCode)
 
+	// ConversationsGet returns a page of the direct message conversations of the requesting account, most recently active first.
+	ConversationsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ConversationsResponse, gtserror.WithCode)
+	// ConversationDelete removes the conversation with the given ID from the requesting account's conversations.
+	// The statuses in the conversation are not deleted.
+	ConversationDelete(ctx context.Context, authed *oauth.Auth, conversationID string) gtserror.WithCode
+	// ConversationRead marks the conversation with the given ID as read, and returns it.
+	ConversationRead(ctx context.Context, authed *oauth.Auth, conversationID string) (*apimodel.Conversation, gtserror.WithCode)
+
 	// FileGet handles the fetching of a media attachment file via the fileserver.
 	FileGet(ctx context.Context, authed *oauth.Auth, form *apimodel.GetContentRequestForm) (*apimodel.Content, gtserror.WithCode)
 
+	// FiltersV1Get returns all v1 filters of the requesting account, one for each keyword of its filters.
+	FiltersV1Get(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FilterV1, gtserror.WithCode)
+	// FilterV1Get returns the v1 filter with the given ID, if it's owned by the requesting account.
+	FilterV1Get(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.FilterV1, gtserror.WithCode)
+	// FilterV1Create creates a new filter with a single keyword for the requesting account, using the given form.
+	FilterV1Create(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterV1Request) (*apimodel.FilterV1, gtserror.WithCode)
+	// FilterV1Update replaces the v1 filter with the given ID, using the given form.
+	FilterV1Update(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterV1Request) (*apimodel.FilterV1, gtserror.WithCode)
+	// FilterV1Delete deletes the v1 filter with the given ID.
+	FilterV1Delete(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode
+	// FiltersV2Get returns all filters of the requesting account.
+	FiltersV2Get(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FilterV2, gtserror.WithCode)
+	// FilterV2Get returns the filter with the given ID, if it's owned by the requesting account.
+	FilterV2Get(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.FilterV2, gtserror.WithCode)
+	// FilterV2Create creates a new filter for the requesting account, using the given form.
+	FilterV2Create(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterV2CreateRequest) (*apimodel.FilterV2, gtserror.WithCode)
+	// FilterV2Update updates the filter with the given ID, and its keywords, using the given form.
+	FilterV2Update(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterV2UpdateRequest) (*apimodel.FilterV2, gtserror.WithCode)
+	// FilterV2Delete deletes the filter with the given ID, along with all of its keywords.
+	FilterV2Delete(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode
+	// FilterKeywordsGet returns the keywords of the filter with the given ID.
+	FilterKeywordsGet(ctx context.Context, authed *oauth.Auth, filterID string) ([]*apimodel.FilterKeyword, gtserror.WithCode)
+	// FilterKeywordGet returns the filter keyword with the given ID, if it's owned by the requesting account.
+	FilterKeywordGet(ctx context.Context, authed *oauth.Auth, keywordID string) (*apimodel.FilterKeyword, gtserror.WithCode)
+	// FilterKeywordCreate adds a keyword to the filter with the given ID, using the given form.
+	FilterKeywordCreate(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterKeywordRequest) (*apimodel.FilterKeyword, gtserror.WithCode)
+	// FilterKeywordUpdate updates the filter keyword with the given ID, using the given form.
+	FilterKeywordUpdate(ctx context.Context, authed *oauth.Auth, keywordID string, form *apimodel.FilterKeywordRequest) (*apimodel.FilterKeyword, gtserror.WithCode)
+	// FilterKeywordDelete deletes the filter keyword with the given ID.
+	FilterKeywordDelete(ctx context.Context, authed *oauth.Auth, keywordID string) gtserror.WithCode
+
 	// FollowRequestsGet handles the getting of the authed account's incoming follow requests
 	FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]apimodel.Account, gtserror.WithCode)
 	// FollowRequestAccept handles the acceptance of a follow request from the given account ID.
@@ -137,6 +233,29 @@ type Processor interface {
 	// It should already be ascertained that the requesting account is authenticated and an admin.
 	InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.Instance, gtserror.WithCode)
 
+	// ListsGet returns all lists owned by the requesting account.
+	ListsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.List, gtserror.WithCode)
+	// ListGet returns the list with the given ID, if it's owned by the requesting account.
+	ListGet(ctx context.Context, authed *oauth.Auth, listID string) (*apimodel.List, gtserror.WithCode)
+	// ListCreate creates a new list for the requesting account, using the given form.
+	ListCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode)
+	// ListUpdate updates the title and/or replies policy of the list with the given ID, using the given form.
+	ListUpdate(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListUpdateRequest) (*apimodel.List, gtserror.WithCode)
+	// ListDelete deletes the list with the given ID, along with all of its entries.
+	ListDelete(ctx context.Context, authed *oauth.Auth, listID string) gtserror.WithCode
+	// ListAccountsGet returns a page of the accounts that are members of the list with the given ID.
+	ListAccountsGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.ListAccountsResponse, gtserror.WithCode)
+	// ListAccountsAdd adds the given accounts to the list with the given ID. The requesting account must follow each of them.
+	ListAccountsAdd(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode
+	// ListAccountsRemove removes the given accounts from the list with the given ID.
+	ListAccountsRemove(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode
+
+	// MarkersGet returns the markers of the requesting account for the given timelines.
+	MarkersGet(ctx context.Context, authed *oauth.Auth, timelines []string) (*apimodel.Marker, gtserror.WithCode)
+	// MarkersSet updates the markers of the requesting account for the timelines given in the form,
+	// and streams the updated markers to any other open streams of the account.
+	MarkersSet(ctx context.Context, authed *oauth.Auth, form *apimodel.MarkerPostRequest) (*apimodel.Marker, gtserror.WithCode)
+
 	// MediaCreate handles the creation of a media attachment, using the given form.
 	MediaCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AttachmentRequest) (*apimodel.Attachment, error)
 	// MediaGet handles the GET of a media attachment with the given ID
@@ -144,8 +263,52 @@ type Processor interface {
 	// MediaUpdate handles the PUT of a media attachment with the given ID and form
 	MediaUpdate(ctx context.Context, authed *oauth.Auth, attachmentID string, form *apimodel.AttachmentUpdateRequest) (*apimodel.Attachment, gtserror.WithCode)
 
-	// NotificationsGet
-	NotificationsGet(ctx context.Context, authed *oauth.Auth, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode)
+	// MutesGet returns a list of accounts muted by the requesting account.
+	MutesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.MutesResponse, gtserror.WithCode)
+
+	// NotificationsGet returns notifications of the requesting account, optionally only those of the given types, or not of the given
+	// excludeTypes, or caused by the account with the given accountID.
+	NotificationsGet(ctx context.Context, authed *oauth.Auth, types []string, excludeTypes []string, accountID string, limit int, maxID string, sinceID string, minID string) ([]*apimodel.Notification, gtserror.WithCode)
+	// NotificationsUnreadCount counts the notifications of the requesting account that are newer than its notifications marker, up to limit.
+	NotificationsUnreadCount(ctx context.Context, authed *oauth.Auth, types []string, excludeTypes []string, accountID string, limit int) (*apimodel.NotificationsUnreadCount, gtserror.WithCode)
+	// NotificationDismiss removes the notification with the given ID from the requesting account's notifications.
+	NotificationDismiss(ctx context.Context, authed *oauth.Auth, notificationID string) gtserror.WithCode
+	// NotificationsClear removes all of the requesting account's notifications.
+	NotificationsClear(ctx context.Context, authed *oauth.Auth) gtserror.WithCode
+
+	// OAuthRevoke revokes the token given in the form, as requested by the client that the token belongs to (RFC 7009).
+	// Any streams opened with the token are closed, and its push subscription is removed.
+	// If the client can't revoke the token, one of the oauth2 library's errors is returned, for passing back to the client.
+	OAuthRevoke(ctx context.Context, form *apimodel.OAuthRevoke) error
+
+	// PollGet gets the poll with the given ID, taking account of privacy settings of the status it's attached to.
+	PollGet(ctx context.Context, authed *oauth.Auth, pollID string) (*apimodel.Poll, gtserror.WithCode)
+	// PollVote casts a vote with the given choices in the poll with the given ID, returning the updated poll if the vote goes through.
+	PollVote(ctx context.Context, authed *oauth.Auth, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode)
+
+	// PushSubscriptionGet returns the push subscription of the token used for the request.
+	PushSubscriptionGet(ctx context.Context, authed *oauth.Auth) (*apimodel.PushSubscription, gtserror.WithCode)
+	// PushSubscriptionCreate subscribes the token used for the request to push notifications, replacing any subscription it already had.
+	PushSubscriptionCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionCreateRequest) (*apimodel.PushSubscription, gtserror.WithCode)
+	// PushSubscriptionUpdate changes which notifications are pushed to the subscription of the token used for the request.
+	PushSubscriptionUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionUpdateRequest) (*apimodel.PushSubscription, gtserror.WithCode)
+	// PushSubscriptionDelete removes the push subscription of the token used for the request, if it has one.
+	PushSubscriptionDelete(ctx context.Context, authed *oauth.Auth) gtserror.WithCode
+
+	// ReportCreate files a report of an account, and optionally some of its statuses, on behalf of the requesting account.
+	// If the reported account is remote and forwarding is requested, the report is also sent to its instance.
+	ReportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ReportCreateRequest) (*apimodel.Report, gtserror.WithCode)
+
+	// ScheduledStatusCreate schedules a status to be created at the time given in the form, rather than creating it straight away.
+	ScheduledStatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.ScheduledStatus, gtserror.WithCode)
+	// ScheduledStatusesGet returns a page of the scheduled statuses of the requesting account.
+	ScheduledStatusesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ScheduledStatusesResponse, gtserror.WithCode)
+	// ScheduledStatusGet returns the scheduled status with the given ID, if it's owned by the requesting account.
+	ScheduledStatusGet(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) (*apimodel.ScheduledStatus, gtserror.WithCode)
+	// ScheduledStatusUpdate moves the scheduled status with the given ID to the time given in the form.
+	ScheduledStatusUpdate(ctx context.Context, authed *oauth.Auth, scheduledStatusID string, form *apimodel.ScheduledStatusUpdateRequest) (*apimodel.ScheduledStatus, gtserror.WithCode)
+	// ScheduledStatusDelete cancels the scheduled status with the given ID, so that it's never published.
+	ScheduledStatusDelete(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) gtserror.WithCode
 
 	// SearchGet performs a search with the given params, resolving/dereferencing remotely as desired
 	SearchGet(ctx context.Context, authed *oauth.Auth, searchQuery *apimodel.SearchQuery) (*apimodel.SearchResult, gtserror.WithCode)
@@ -154,6 +317,12 @@ type Processor interface {
 	StatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, error)
 	// StatusDelete processes the delete of a given status, returning the deleted status if the delete goes through.
 	StatusDelete(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
+	// StatusEdit processes the given form to edit an existing status, returning the api model representation of the edited status if it's OK.
+	StatusEdit(ctx context.Context, authed *oauth.Auth, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode)
+	// StatusHistoryGet returns all versions of the given status, oldest first, taking account of privacy settings and blocks etc.
+	StatusHistoryGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode)
+	// StatusSourceGet returns the plain-text source of the given status, taking account of privacy settings and blocks etc.
+	StatusSourceGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
 	// StatusFave processes the faving of a given status, returning the updated status if the fave goes through.
 	StatusFave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusBoost processes the boost/reblog of a given status, returning the newly-created boost if all is well.
@@ -168,6 +337,18 @@ type Processor interface {
 	StatusGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusUnfave processes the unfaving of a given status, returning the updated status if the fave goes through.
 	StatusUnfave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
+	// StatusBookmark processes the bookmarking of a given status, returning the updated status if the bookmark goes through.
+	StatusBookmark(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnbookmark processes the removal of a bookmark from a given status, returning the updated status if all is well.
+	StatusUnbookmark(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusPin processes the pinning of a given status to the authed account's profile, returning the updated status if the pin goes through.
+	StatusPin(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnpin processes the removal of a given status from the authed account's profile, returning the updated status if all is well.
+	StatusUnpin(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusMute processes the muting of the thread that a given status belongs to, so that the authed account no longer gets notifications from it.
+	StatusMute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnmute processes the removal of a mute from the thread that a given status belongs to.
+	StatusUnmute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// StatusGetContext returns the context (previous and following posts) from the given status ID
 	StatusGetContext(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
 
@@ -175,19 +356,44 @@ type Processor interface {
 	HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// PublicTimelineGet returns statuses from the public/local timeline, with the given filters/parameters.
 	PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// ListTimelineGet returns statuses from the timeline of the given list, with the given filters/parameters.
+	ListTimelineGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// TagTimelineGet returns public statuses that use the given hashtag, or any of anyTags, as well as all of allTags and none of noneTags.
+	TagTimelineGet(ctx context.Context, authed *oauth.Auth, tagName string, anyTags []string, allTags []string, noneTags []string, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// FavedTimelineGet returns faved statuses, with the given filters/parameters.
 	FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// BookmarkedTimelineGet returns statuses bookmarked by the requesting account, with the given filters/parameters.
+	BookmarkedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+
+	// TagGet returns the hashtag with the given name, including whether the requesting account follows it.
+	TagGet(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// TagFollow makes the requesting account follow the hashtag with the given name, so that public statuses using it are put in their home timeline.
+	TagFollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// TagUnfollow removes the requesting account's follow of the hashtag with the given name, if it exists.
+	TagUnfollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
 
 	// AuthorizeStreamingRequest returns a gotosocial account in exchange for an access token, or an error if the given token is not valid.
 	AuthorizeStreamingRequest(ctx context.Context, accessToken string) (*gtsmodel.Account, error)
 	// OpenStreamForAccount opens a new stream for the given account, with the given stream type.
-	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode)
+	// The stream is closed again if the given access token, which the stream was opened with, is revoked.
+	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, accessToken string, streamType string) (*stream.Stream, gtserror.WithCode)
 
 	// UserChangePassword changes the password for the given user, with the given form.
 	UserChangePassword(ctx context.Context, authed *oauth.Auth, form *apimodel.PasswordChangeRequest) gtserror.WithCode
 	// UserConfirmEmail confirms an email address using the given token.
 	// The user belonging to the confirmed email is also returned.
 	UserConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode)
+	// UserTwoFactorGet returns whether the authenticated user has two-factor authentication enabled.
+	UserTwoFactorGet(ctx context.Context, authed *oauth.Auth) (*apimodel.TwoFactor, gtserror.WithCode)
+	// UserTwoFactorEnroll starts enrolling the authenticated user in two-factor authentication,
+	// returning the new TOTP secret and a QR code for adding it to an authenticator app.
+	UserTwoFactorEnroll(ctx context.Context, authed *oauth.Auth, form *apimodel.TwoFactorEnrollRequest) (*apimodel.TwoFactorEnrollment, gtserror.WithCode)
+	// UserTwoFactorConfirm finishes enrolling the authenticated user in two-factor authentication, returning their recovery codes.
+	UserTwoFactorConfirm(ctx context.Context, authed *oauth.Auth, form *apimodel.TwoFactorConfirmRequest) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode)
+	// UserTwoFactorDisable turns off two-factor authentication for the authenticated user.
+	UserTwoFactorDisable(ctx context.Context, authed *oauth.Auth, form *apimodel.TwoFactorDisableRequest) gtserror.WithCode
+	// UserTwoFactorVerify checks the code given by the user in the second step of signing in.
+	UserTwoFactorVerify(ctx context.Context, user *gtsmodel.User, code string) (bool, error)
 
 	/*
 		FEDERATION API-FACING PROCESSING FUNCTIONS
@@ -214,6 +420,8 @@ type Processor interface {
 	GetFediStatusReplies(ctx context.Context, requestedUsername string, requestedStatusID string, page bool, onlyOtherAccounts bool, minID string, requestURL *url.URL) (interface{}, gtserror.WithCode)
 	// GetFediOutbox returns the public outbox of the requested user, with the given parameters.
 	GetFediOutbox(ctx context.Context, requestedUsername string, page bool, maxID string, minID string, requestURL *url.URL) (interface{}, gtserror.WithCode)
+	// GetFediFeatured returns the featured collection of the requested user, containing the statuses they've pinned.
+	GetFediFeatured(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
 	// GetWebfingerAccount handles the GET for a webfinger resource. Most commonly, it will be used for returning account lookups.
 	GetWebfingerAccount(ctx context.Context, requestedUsername string) (*apimodel.WellKnownResponse, gtserror.WithCode)
 	// GetNodeInfoRel returns a well known response giving the path to node info.
@@ -242,10 +450,12 @@ type processor struct {
 	tc              typeutils.TypeConverter
 	oauthServer     oauth.Server
 	mediaManager    media.Manager
-	storage         *kv.KVStore
+	storage         *storage.Driver
 	statusTimelines timeline.Manager
+	listTimelines   timeline.Manager
 	db              db.DB
 	filter          visibility.Filter
+	webPushSender   webpush.Sender
 
 	/*
 		SUB-PROCESSORS
@@ -266,16 +476,17 @@ func NewProcessor(
 	federator federation.Federator,
 	oauthServer oauth.Server,
 	mediaManager media.Manager,
-	storage *kv.KVStore,
+	storage *storage.Driver,
 	db db.DB,
-	emailSender email.Sender) Processor {
+	emailSender email.Sender,
+	webPushSender webpush.Sender) Processor {
 
 	fromClientAPI := make(chan messages.FromClientAPI, 1000)
 	fromFederator := make(chan messages.FromFederator, 1000)
 	parseMentionFunc := GetParseMentionFunc(db, federator)
 
 	statusProcessor := status.New(db, tc, fromClientAPI, parseMentionFunc)
-	streamingProcessor := streaming.New(db, oauthServer)
+	streamingProcessor := streaming.New(db, oauthServer, webPushSender)
 	accountProcessor := account.New(db, tc, mediaManager, oauthServer, fromClientAPI, federator, parseMentionFunc)
 	adminProcessor := admin.New(db, tc, mediaManager, fromClientAPI)
 	mediaProcessor := mediaProcessor.New(db, tc, mediaManager, federator.TransportController(), storage)
@@ -293,8 +504,10 @@ func NewProcessor(
 		mediaManager:    mediaManager,
 		storage:         storage,
 		statusTimelines: timeline.NewManager(StatusGrabFunction(db), StatusFilterFunction(db, filter), StatusPrepareFunction(db, tc), StatusSkipInsertFunction()),
+		listTimelines:   timeline.NewManager(ListGrabFunction(db), ListFilterFunction(db, filter), ListPrepareFunction(db, tc), StatusSkipInsertFunction()),
 		db:              db,
 		filter:          visibility.NewFilter(db),
+		webPushSender:   webPushSender,
 
 		accountProcessor:    accountProcessor,
 		adminProcessor:      adminProcessor,
@@ -331,6 +544,58 @@ func (p *processor) Start(ctx context.Context) error {
 			}
 		}
 	}()
+
+	// close expired polls on a schedule, in a separate goroutine so that runs never overlap
+	go func() {
+		ticker := time.NewTicker(pollExpiryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.closeExpiredPolls(ctx); err != nil {
+					logrus.Error(err)
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+
+	// publish scheduled statuses when they're due, likewise in their own goroutine
+	go func() {
+		ticker := time.NewTicker(scheduledStatusInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.publishDueScheduledStatuses(ctx); err != nil {
+					logrus.Error(err)
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+
+	// lift mutes that have expired, so that timelines are rebuilt without them
+	go func() {
+		ticker := time.NewTicker(muteExpiryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.liftExpiredMutes(ctx); err != nil {
+					logrus.Error(err)
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+
 	return nil
 }
 
@@ -26,7 +26,6 @@ import (
 	"io/ioutil"
 	"net/http"
 
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -36,9 +35,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -46,7 +47,7 @@ type ProcessingStandardTestSuite struct {
 	// standard suite interfaces
 	suite.Suite
 	db                  db.DB
-	storage             *kv.KVStore
+	storage             *storage.Driver
 	mediaManager        media.Manager
 	typeconverter       typeutils.TypeConverter
 	transportController transport.Controller
@@ -54,6 +55,9 @@ type ProcessingStandardTestSuite struct {
 	oauthServer         oauth.Server
 	timelineManager     timeline.Manager
 	emailSender         email.Sender
+	webPushSender       webpush.Sender
+
+	sentEmails map[string]string
 
 	// standard suite models
 	testTokens       map[string]*gtsmodel.Token
@@ -70,6 +74,7 @@ type ProcessingStandardTestSuite struct {
 	testActivities   map[string]testrig.ActivityWithSignature
 
 	sentHTTPRequests map[string][]byte
+	sentPushRequests chan *http.Request
 
 	processor processing.Processor
 }
@@ -86,10 +91,15 @@ func (suite *ProcessingStandardTestSuite) SetupSuite() {
 	suite.testMentions = testrig.NewTestMentions()
 	suite.testAutheds = map[string]*oauth.Auth{
 		"local_account_1": {
-			Application: suite.testApplications["local_account_1"],
+			Application: suite.testApplications["application_1"],
 			User:        suite.testUsers["local_account_1"],
 			Account:     suite.testAccounts["local_account_1"],
 		},
+		"local_account_2": {
+			Application: suite.testApplications["application_2"],
+			User:        suite.testUsers["local_account_2"],
+			Account:     suite.testAccounts["local_account_2"],
+		},
 	}
 	suite.testBlocks = testrig.NewTestBlocks()
 }
@@ -219,18 +229,35 @@ func (suite *ProcessingStandardTestSuite) SetupTest() {
 	suite.mediaManager = testrig.NewTestMediaManager(suite.db, suite.storage)
 	suite.federator = testrig.NewTestFederator(suite.db, suite.transportController, suite.storage, suite.mediaManager)
 	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
-	suite.emailSender = testrig.NewEmailSender("../../web/template/", nil)
+	suite.sentEmails = make(map[string]string)
+	suite.emailSender = testrig.NewEmailSender("../../web/template/", suite.sentEmails)
+
+	// make a separate client for web push deliveries, which passes them on to the test
+	suite.sentPushRequests = make(chan *http.Request, 100)
+	suite.webPushSender = testrig.NewWebPushSender(suite.db, testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		suite.sentPushRequests <- req
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	}))
 
-	suite.processor = processing.NewProcessor(suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, suite.storage, suite.db, suite.emailSender)
+	suite.processor = processing.NewProcessor(suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, suite.storage, suite.db, suite.emailSender, suite.webPushSender)
 
 	testrig.StandardDBSetup(suite.db, suite.testAccounts)
 	testrig.StandardStorageSetup(suite.storage, "../../testrig/media")
+	if err := suite.transportController.Start(context.Background()); err != nil {
+		panic(err)
+	}
 	if err := suite.processor.Start(context.Background()); err != nil {
 		panic(err)
 	}
 }
 
 func (suite *ProcessingStandardTestSuite) TearDownTest() {
+	if err := suite.transportController.Stop(); err != nil {
+		panic(err)
+	}
 	testrig.StandardDBTeardown(suite.db)
 	testrig.StandardStorageTeardown(suite.storage)
 	if err := suite.processor.Stop(); err != nil {
@@ -0,0 +1,203 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+func (p *processor) PushSubscriptionGet(ctx context.Context, authed *oauth.Auth) (*apimodel.PushSubscription, gtserror.WithCode) {
+	subscription, errWithCode := p.getOwnPushSubscription(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiSubscription, err := p.tc.PushSubscriptionToAPIPushSubscription(ctx, subscription)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+func (p *processor) PushSubscriptionCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionCreateRequest) (*apimodel.PushSubscription, gtserror.WithCode) {
+	if form.Subscription == nil || form.Subscription.Keys == nil {
+		err := errors.New("no subscription provided")
+		return nil, gtserror.NewErrorBadRequest(err, "subscription[endpoint], subscription[keys][p256dh] and subscription[keys][auth] must be provided")
+	}
+
+	endpoint, err := url.Parse(form.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		err := fmt.Errorf("endpoint %s is not a valid https url", form.Subscription.Endpoint)
+		return nil, gtserror.NewErrorBadRequest(err, "subscription[endpoint] must be a valid https url")
+	}
+
+	if err := webpush.ValidateKeys(form.Subscription.Keys.P256dh, form.Subscription.Keys.Auth); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tokenID, errWithCode := p.getAuthedTokenID(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// make sure the instance has a vapid keypair before it's served with the subscription
+	if _, err := p.webPushSender.VAPIDPublicKey(ctx); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	subscriptionID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	subscription := &gtsmodel.PushSubscription{
+		ID:        subscriptionID,
+		AccountID: authed.Account.ID,
+		TokenID:   tokenID,
+		Endpoint:  form.Subscription.Endpoint,
+		P256dh:    form.Subscription.Keys.P256dh,
+		Auth:      form.Subscription.Keys.Auth,
+	}
+	if form.Data != nil {
+		setPushSubscriptionAlerts(subscription, form.Data.Alerts)
+	}
+
+	// this replaces any subscription the token already had
+	if err := p.db.PutPushSubscription(ctx, subscription); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubscription, err := p.tc.PushSubscriptionToAPIPushSubscription(ctx, subscription)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+func (p *processor) PushSubscriptionUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionUpdateRequest) (*apimodel.PushSubscription, gtserror.WithCode) {
+	subscription, errWithCode := p.getOwnPushSubscription(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Data != nil {
+		setPushSubscriptionAlerts(subscription, form.Data.Alerts)
+	}
+
+	if err := p.db.UpdateByPrimaryKey(ctx, subscription); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubscription, err := p.tc.PushSubscriptionToAPIPushSubscription(ctx, subscription)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+func (p *processor) PushSubscriptionDelete(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
+	tokenID, errWithCode := p.getAuthedTokenID(ctx, authed)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// deleting a subscription that doesn't exist is fine
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: tokenID}}, &[]*gtsmodel.PushSubscription{}); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getOwnPushSubscription returns the push subscription of the token used for the request, or a 404 if it doesn't have one.
+func (p *processor) getOwnPushSubscription(ctx context.Context, authed *oauth.Auth) (*gtsmodel.PushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getAuthedTokenID(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription, err := p.db.GetPushSubscriptionByTokenID(ctx, tokenID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			err := fmt.Errorf("token %s has no push subscription", tokenID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return subscription, nil
+}
+
+// getAuthedTokenID returns the database ID of the oauth token used for the request.
+func (p *processor) getAuthedTokenID(ctx context.Context, authed *oauth.Auth) (string, gtserror.WithCode) {
+	if authed.Token == nil {
+		err := errors.New("request was not made with a token")
+		return "", gtserror.NewErrorNotAuthorized(err, "push subscriptions need an access token")
+	}
+
+	token := &gtsmodel.Token{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "access", Value: authed.Token.GetAccess()}}, token); err != nil {
+		if err == db.ErrNoEntries {
+			err := errors.New("access token not found")
+			return "", gtserror.NewErrorNotAuthorized(err, "access token not found")
+		}
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return token.ID, nil
+}
+
+// setPushSubscriptionAlerts sets the alerts of the given subscription to the ones given in the request.
+// Alerts that weren't given are left alone.
+func setPushSubscriptionAlerts(subscription *gtsmodel.PushSubscription, alerts *apimodel.PushSubscriptionRequestAlerts) {
+	if alerts == nil {
+		return
+	}
+
+	for _, a := range []struct {
+		value *bool
+		field *bool
+	}{
+		{alerts.Follow, &subscription.AlertFollow},
+		{alerts.FollowRequest, &subscription.AlertFollowRequest},
+		{alerts.Favourite, &subscription.AlertFavourite},
+		{alerts.Mention, &subscription.AlertMention},
+		{alerts.Reblog, &subscription.AlertReblog},
+		{alerts.Poll, &subscription.AlertPoll},
+		{alerts.Status, &subscription.AlertStatus},
+	} {
+		if a.value != nil {
+			*a.field = *a.value
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type PushTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+// keys of the user agent from the example in RFC 8291 appendix A
+const (
+	testPushP256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	testPushAuth   = "BTBZMqHH6r4Tts7J_aSIgg"
+)
+
+func (suite *PushTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Account: suite.testAccounts["local_account_1"],
+		Token:   oauth.DBTokenToToken(suite.testTokens["local_account_1"]),
+	}
+}
+
+func (suite *PushTestSuite) subscribe(alerts *model.PushSubscriptionRequestAlerts) *model.PushSubscription {
+	subscription, errWithCode := suite.processor.PushSubscriptionCreate(context.Background(), suite.authed(), &model.PushSubscriptionCreateRequest{
+		Subscription: &model.PushSubscriptionRequestSubscription{
+			Endpoint: "https://push.example.org/send/some-token",
+			Keys: &model.PushSubscriptionRequestKeys{
+				P256dh: testPushP256dh,
+				Auth:   testPushAuth,
+			},
+		},
+		Data: &model.PushSubscriptionRequestData{Alerts: alerts},
+	})
+	suite.NoError(errWithCode)
+	return subscription
+}
+
+func (suite *PushTestSuite) TestPushSubscriptionCreate() {
+	yes := true
+	subscription := suite.subscribe(&model.PushSubscriptionRequestAlerts{Favourite: &yes, Mention: &yes})
+	suite.NotEmpty(subscription.ID)
+	suite.Equal("https://push.example.org/send/some-token", subscription.Endpoint)
+	suite.NotEmpty(subscription.ServerKey)
+	suite.True(subscription.Alerts.Favourite)
+	suite.True(subscription.Alerts.Mention)
+	suite.False(subscription.Alerts.Follow)
+
+	// the server key is the same one apps are given
+	app, err := suite.processor.AppCreate(context.Background(), suite.authed(), &model.ApplicationCreateRequest{
+		ClientName:   "some push app",
+		RedirectURIs: "urn:ietf:wg:oauth:2.0:oob",
+	})
+	suite.NoError(err)
+	suite.Equal(subscription.ServerKey, app.VapidKey)
+
+	// subscribing again replaces the old subscription
+	replaced := suite.subscribe(nil)
+	suite.NotEqual(subscription.ID, replaced.ID)
+	suite.False(replaced.Alerts.Favourite)
+
+	fetched, errWithCode := suite.processor.PushSubscriptionGet(context.Background(), suite.authed())
+	suite.NoError(errWithCode)
+	suite.Equal(replaced, fetched)
+}
+
+func (suite *PushTestSuite) TestPushSubscriptionCreateInvalid() {
+	_, errWithCode := suite.processor.PushSubscriptionCreate(context.Background(), suite.authed(), &model.PushSubscriptionCreateRequest{
+		Subscription: &model.PushSubscriptionRequestSubscription{
+			Endpoint: "http://push.example.org/send/some-token",
+			Keys: &model.PushSubscriptionRequestKeys{
+				P256dh: testPushP256dh,
+				Auth:   testPushAuth,
+			},
+		},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.PushSubscriptionCreate(context.Background(), suite.authed(), &model.PushSubscriptionCreateRequest{
+		Subscription: &model.PushSubscriptionRequestSubscription{
+			Endpoint: "https://push.example.org/send/some-token",
+			Keys: &model.PushSubscriptionRequestKeys{
+				P256dh: testPushAuth,
+				Auth:   testPushAuth,
+			},
+		},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("bad request: p256dh is not an uncompressed P-256 public key", errWithCode.Safe())
+}
+
+func (suite *PushTestSuite) TestPushSubscriptionUpdateAndDelete() {
+	yes := true
+	no := false
+	suite.subscribe(&model.PushSubscriptionRequestAlerts{Favourite: &yes, Mention: &yes})
+
+	updated, errWithCode := suite.processor.PushSubscriptionUpdate(context.Background(), suite.authed(), &model.PushSubscriptionUpdateRequest{
+		Data: &model.PushSubscriptionRequestData{
+			Alerts: &model.PushSubscriptionRequestAlerts{Favourite: &no, Follow: &yes},
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.False(updated.Alerts.Favourite)
+	suite.True(updated.Alerts.Follow)
+	// left alone
+	suite.True(updated.Alerts.Mention)
+
+	errWithCode = suite.processor.PushSubscriptionDelete(context.Background(), suite.authed())
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.processor.PushSubscriptionGet(context.Background(), suite.authed())
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// deleting again is fine
+	errWithCode = suite.processor.PushSubscriptionDelete(context.Background(), suite.authed())
+	suite.NoError(errWithCode)
+}
+
+func (suite *PushTestSuite) TestPushNotification() {
+	yes := true
+	subscription := suite.subscribe(&model.PushSubscriptionRequestAlerts{Favourite: &yes})
+
+	// local_account_2 faves a status of local_account_1, which should get pushed
+	_, err := suite.processor.StatusFave(context.Background(), suite.testAutheds["local_account_2"], suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(err)
+
+	var req *http.Request
+	select {
+	case req = <-suite.sentPushRequests:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for push message")
+	}
+
+	suite.Equal(http.MethodPost, req.Method)
+	suite.Equal("https://push.example.org/send/some-token", req.URL.String())
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.Equal("application/octet-stream", req.Header.Get("Content-Type"))
+	suite.Equal("172800", req.Header.Get("TTL"))
+	suite.True(strings.HasPrefix(req.Header.Get("Authorization"), "vapid t="))
+	suite.True(strings.HasSuffix(req.Header.Get("Authorization"), ", k="+subscription.ServerKey))
+}
+
+func TestPushTestSuite(t *testing.T) {
+	suite.Run(t, &PushTestSuite{})
+}
@@ -0,0 +1,152 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// maxReportCommentChars is the maximum length of the comment on a report, in characters.
+const maxReportCommentChars = 1000
+
+func (p *processor) ReportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ReportCreateRequest) (*apimodel.Report, gtserror.WithCode) {
+	if form.AccountID == "" {
+		err := errors.New("account_id must be provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.AccountID == authed.Account.ID {
+		err := errors.New("you cannot report yourself")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetAccount, err := p.db.GetAccountByID(ctx, form.AccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", form.AccountID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if utf8.RuneCountInString(form.Comment) > maxReportCommentChars {
+		err := fmt.Errorf("comment must be no more than %d characters", maxReportCommentChars)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	category := gtsmodel.ReportCategoryOther
+	switch gtsmodel.ReportCategory(form.Category) {
+	case "", gtsmodel.ReportCategoryOther:
+	case gtsmodel.ReportCategorySpam:
+		category = gtsmodel.ReportCategorySpam
+	default:
+		err := fmt.Errorf("category %s not recognized, must be one of %s or %s", form.Category, gtsmodel.ReportCategorySpam, gtsmodel.ReportCategoryOther)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	statusIDs := []string{}
+	statuses := []*gtsmodel.Status{}
+	seenStatusIDs := make(map[string]bool, len(form.StatusIDs))
+	for _, statusID := range form.StatusIDs {
+		if seenStatusIDs[statusID] {
+			continue
+		}
+		seenStatusIDs[statusID] = true
+
+		status, err := p.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				err := fmt.Errorf("status %s not found", statusID)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if status.AccountID != targetAccount.ID {
+			err := fmt.Errorf("status %s was not posted by account %s", statusID, targetAccount.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		// the reporter can only attach statuses they can actually see, otherwise they could
+		// use the report to get hold of the contents of followers-only or direct statuses
+		visible, err := p.filter.StatusVisible(ctx, status, authed.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if !visible {
+			err := fmt.Errorf("status %s not found", statusID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		statusIDs = append(statusIDs, status.ID)
+		statuses = append(statuses, status)
+	}
+
+	reportID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             uris.GenerateURIForReport(reportID),
+		AccountID:       authed.Account.ID,
+		Account:         authed.Account,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusIDs:       statusIDs,
+		Statuses:        statuses,
+		Comment:         form.Comment,
+		Category:        category,
+		// there's only somewhere to forward the report to if the target account is remote
+		Forwarded: form.Forward && targetAccount.Domain != "",
+	}
+
+	if err := p.db.PutReport(ctx, report); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// process the new report in the background, forwarding it if necessary
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ActivityFlag,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       report,
+		OriginAccount:  authed.Account,
+		TargetAccount:  targetAccount,
+	}
+
+	apiReport, err := p.tc.ReportToAPIReport(ctx, report)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReport, nil
+}
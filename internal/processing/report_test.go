@@ -0,0 +1,197 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ReportTestSuite) TestReportCreateForwarded() {
+	ctx := context.Background()
+	reportingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+	targetStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	report, errWithCode := suite.processor.ReportCreate(ctx, suite.testAutheds["local_account_1"], &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		StatusIDs: []string{targetStatus.ID, targetStatus.ID},
+		Comment:   "this is spam",
+		Forward:   true,
+		Category:  "spam",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("spam", report.Category)
+	suite.True(report.Forwarded)
+	suite.False(report.ActionTaken)
+	suite.Nil(report.ActionTakenAt)
+	suite.Equal([]string{targetStatus.ID}, report.StatusIDs)
+	suite.Equal(targetAccount.ID, report.TargetAccount.ID)
+	time.Sleep(1 * time.Second) // wait a sec for the flag to be sent
+
+	// the flag should be sent to the reported account's inbox by the instance account
+	instanceAccount, err := suite.db.GetInstanceAccount(ctx, "")
+	suite.NoError(err)
+
+	sent, ok := suite.sentHTTPRequests[targetAccount.InboxURI]
+	suite.True(ok)
+	flag := &struct {
+		Actor   string   `json:"actor"`
+		Content string   `json:"content"`
+		Object  []string `json:"object"`
+		Type    string   `json:"type"`
+	}{}
+	suite.NoError(json.Unmarshal(sent, flag))
+	suite.Equal("Flag", flag.Type)
+	suite.Equal(instanceAccount.URI, flag.Actor)
+	suite.Equal("this is spam", flag.Content)
+	suite.Equal([]string{targetAccount.URI, targetStatus.URI}, flag.Object)
+	suite.NotContains(string(sent), reportingAccount.URI)
+}
+
+func (suite *ReportTestSuite) TestReportCreateNotForwardedLocal() {
+	ctx := context.Background()
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	report, errWithCode := suite.processor.ReportCreate(ctx, suite.testAutheds["local_account_1"], &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Forward:   true,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("other", report.Category)
+	suite.False(report.Forwarded)
+	suite.Empty(report.StatusIDs)
+}
+
+func (suite *ReportTestSuite) TestReportCreateBadRequests() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	for _, form := range []*apimodel.ReportCreateRequest{
+		// reporting yourself
+		{AccountID: authed.Account.ID},
+		// status by another account
+		{AccountID: suite.testAccounts["remote_account_1"].ID, StatusIDs: []string{suite.testStatuses["local_account_2_status_1"].ID}},
+		// unknown category
+		{AccountID: suite.testAccounts["remote_account_1"].ID, Category: "violation"},
+	} {
+		report, errWithCode := suite.processor.ReportCreate(ctx, authed, form)
+		suite.Nil(report)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+}
+
+func (suite *ReportTestSuite) TestReportCreateInvisibleStatus() {
+	ctx := context.Background()
+
+	// 1happyturtle doesn't follow zork, so can't see or report zork's followers-only status
+	report, errWithCode := suite.processor.ReportCreate(ctx, suite.testAutheds["local_account_2"], &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		StatusIDs: []string{suite.testStatuses["local_account_1_status_5"].ID},
+	})
+	suite.Nil(report)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *ReportTestSuite) TestAdminReportAssignResolveReopen() {
+	ctx := context.Background()
+	authed := &oauth.Auth{
+		Account: suite.testAccounts["admin_account"],
+		User:    suite.testUsers["admin_account"],
+	}
+	testReport := testrig.NewTestReports()["local_account_2_report_remote_account_1"]
+
+	report, errWithCode := suite.processor.AdminReportGet(ctx, authed, testReport.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(testReport.AccountID, report.Account.ID)
+	suite.Len(report.Statuses, 1)
+	suite.Nil(report.AssignedAccount)
+
+	report, errWithCode = suite.processor.AdminReportAssign(ctx, authed, testReport.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(authed.Account.ID, report.AssignedAccount.ID)
+
+	report, errWithCode = suite.processor.AdminReportResolve(ctx, authed, testReport.ID)
+	suite.NoError(errWithCode)
+	suite.True(report.ActionTaken)
+	suite.NotNil(report.ActionTakenAt)
+	suite.Equal(authed.Account.ID, report.ActionTakenByAccount.ID)
+
+	resolved := true
+	reports, errWithCode := suite.processor.AdminReportsGet(ctx, authed, &resolved, "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(reports, 1)
+
+	report, errWithCode = suite.processor.AdminReportReopen(ctx, authed, testReport.ID)
+	suite.NoError(errWithCode)
+	suite.False(report.ActionTaken)
+	suite.Nil(report.ActionTakenByAccount)
+
+	report, errWithCode = suite.processor.AdminReportUnassign(ctx, authed, testReport.ID)
+	suite.NoError(errWithCode)
+	suite.Nil(report.AssignedAccount)
+
+	reports, errWithCode = suite.processor.AdminReportsGet(ctx, authed, &resolved, "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Empty(reports)
+}
+
+func (suite *ReportTestSuite) TestAdminAccountActionResolvesReport() {
+	ctx := context.Background()
+	authed := &oauth.Auth{
+		Account: suite.testAccounts["admin_account"],
+		User:    suite.testUsers["admin_account"],
+	}
+	testReport := testrig.NewTestReports()["local_account_2_report_remote_account_1"]
+
+	errWithCode := suite.processor.AdminAccountAction(ctx, authed, &apimodel.AdminAccountActionRequest{
+		Type:            "suspend",
+		ReportID:        testReport.ID,
+		TargetAccountID: testReport.TargetAccountID,
+	})
+	suite.NoError(errWithCode)
+
+	report, err := suite.db.GetReportByID(ctx, testReport.ID)
+	suite.NoError(err)
+	suite.True(report.Resolved())
+	suite.Equal(authed.Account.ID, report.ActionTakenByAccountID)
+
+	// the suspension itself happens asynchronously, so wait for it to finish
+	// before returning, otherwise it might run on top of the next test's data
+	suite.Eventually(func() bool {
+		account, err := suite.db.GetAccountByID(ctx, testReport.TargetAccountID)
+		return err == nil && !account.SuspendedAt.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, &ReportTestSuite{})
+}
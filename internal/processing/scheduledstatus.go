@@ -0,0 +1,402 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// scheduledStatusInterval is how often the processor checks for scheduled statuses that are due to be published.
+	scheduledStatusInterval = 1 * time.Minute
+	// scheduledStatusMinOffset is how far in the future a status must be scheduled.
+	scheduledStatusMinOffset = 5 * time.Minute
+	// scheduledStatusesMaxTotal is how many scheduled statuses one account may have at once.
+	scheduledStatusesMaxTotal = 300
+	// scheduledStatusesMaxDaily is how many statuses one account may schedule for the same day.
+	scheduledStatusesMaxDaily = 25
+	// scheduledStatusMaxAttempts is how many times publishing a scheduled status may fail before it's given up on.
+	scheduledStatusMaxAttempts = 5
+)
+
+func (p *processor) ScheduledStatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledAt, errWithCode := p.checkScheduledAt(ctx, authed.Account.ID, form.ScheduledAt, nil)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check the parts of the form that refer to other things now, so that the caller
+	// finds out about any problems straight away rather than when the status is due.
+	// The visibility flags are resolved now too, so that the status is published with
+	// the settings it was scheduled with, even if the account defaults change.
+	status := &gtsmodel.Status{}
+
+	if err := p.statusProcessor.ProcessReplyToID(ctx, form, authed.Account.ID, status); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.statusProcessor.ProcessMediaIDs(ctx, form, authed.Account.ID, status); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.statusProcessor.ProcessVisibility(ctx, form, authed.Account.Privacy, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	scheduledStatusID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:            scheduledStatusID,
+		ScheduledAt:   scheduledAt,
+		AccountID:     authed.Account.ID,
+		Account:       authed.Account,
+		ApplicationID: authed.Application.ID,
+		Text:          form.Status,
+		SpoilerText:   form.SpoilerText,
+		InReplyToID:   form.InReplyToID,
+		AttachmentIDs: status.AttachmentIDs,
+		Attachments:   status.Attachments,
+		Sensitive:     form.Sensitive,
+		Visibility:    status.Visibility,
+		Federated:     status.Federated,
+		Boostable:     status.Boostable,
+		Replyable:     status.Replyable,
+		Likeable:      status.Likeable,
+		Language:      form.Language,
+		Format:        string(form.Format),
+	}
+
+	if form.Poll != nil {
+		scheduledStatus.PollOptions = form.Poll.Options
+		scheduledStatus.PollExpiresIn = form.Poll.ExpiresIn
+		scheduledStatus.PollMultiple = form.Poll.Multiple
+		scheduledStatus.PollHideTotals = form.Poll.HideTotals
+	}
+
+	if err := p.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+func (p *processor) ScheduledStatusesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ScheduledStatusesResponse, gtserror.WithCode) {
+	scheduledStatuses, err := p.db.GetAccountScheduledStatuses(ctx, authed.Account.ID, maxID, sinceID, minID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	resp := &apimodel.ScheduledStatusesResponse{
+		ScheduledStatuses: []*apimodel.ScheduledStatus{},
+	}
+
+	if len(scheduledStatuses) == 0 {
+		return resp, nil
+	}
+
+	for _, s := range scheduledStatuses {
+		apiScheduledStatus, errWithCode := p.apiScheduledStatus(ctx, s)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		resp.ScheduledStatuses = append(resp.ScheduledStatuses, apiScheduledStatus)
+	}
+
+	// prepare the next and previous links
+	protocol := viper.GetString(config.Keys.Protocol)
+	host := viper.GetString(config.Keys.Host)
+
+	nextLink := &url.URL{
+		Scheme:   protocol,
+		Host:     host,
+		Path:     "api/v1/scheduled_statuses",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, scheduledStatuses[len(scheduledStatuses)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   protocol,
+		Host:     host,
+		Path:     "api/v1/scheduled_statuses",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, scheduledStatuses[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) ScheduledStatusGet(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, authed, scheduledStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+func (p *processor) ScheduledStatusUpdate(ctx context.Context, authed *oauth.Auth, scheduledStatusID string, form *apimodel.ScheduledStatusUpdateRequest) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, authed, scheduledStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := p.checkScheduledAt(ctx, authed.Account.ID, form.ScheduledAt, scheduledStatus)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledStatus.ScheduledAt = scheduledAt
+	if err := p.db.UpdateScheduledStatus(ctx, scheduledStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+func (p *processor) ScheduledStatusDelete(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) gtserror.WithCode {
+	if _, errWithCode := p.getOwnScheduledStatus(ctx, authed, scheduledStatusID); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteScheduledStatusByID(ctx, scheduledStatusID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// checkScheduledAt parses the given scheduled_at value, and checks that it's far enough in the future,
+// and that the account hasn't scheduled too many statuses already. If an existing scheduled status is
+// being rescheduled, it should be passed in so that it isn't counted against itself.
+func (p *processor) checkScheduledAt(ctx context.Context, accountID string, scheduledAtString string, existing *gtsmodel.ScheduledStatus) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtString)
+	if err != nil {
+		return time.Time{}, gtserror.NewErrorBadRequest(err, "scheduled_at must be an ISO 8601 datetime")
+	}
+
+	if scheduledAt.Before(time.Now().Add(scheduledStatusMinOffset)) {
+		err := fmt.Errorf("scheduled_at %s is too soon", scheduledAtString)
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(err, "scheduled_at must be at least 5 minutes in the future")
+	}
+
+	if existing == nil {
+		total, err := p.db.CountAccountScheduledStatuses(ctx, accountID, time.Time{}, time.Time{})
+		if err != nil {
+			return time.Time{}, gtserror.NewErrorInternalError(err)
+		}
+
+		if total >= scheduledStatusesMaxTotal {
+			err := fmt.Errorf("account %s already has %d scheduled statuses", accountID, total)
+			return time.Time{}, gtserror.NewErrorUnprocessableEntity(err, "too many scheduled statuses")
+		}
+	}
+
+	dayStart := scheduledAt.UTC().Truncate(24 * time.Hour)
+	dayEnd := dayStart.Add(24 * time.Hour)
+
+	daily, err := p.db.CountAccountScheduledStatuses(ctx, accountID, dayStart, dayEnd)
+	if err != nil {
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil && !existing.ScheduledAt.Before(dayStart) && existing.ScheduledAt.Before(dayEnd) {
+		// the status being rescheduled is already counted for this day
+		daily--
+	}
+
+	if daily >= scheduledStatusesMaxDaily {
+		err := fmt.Errorf("account %s already has %d statuses scheduled for %s", accountID, daily, dayStart.Format("2006-01-02"))
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(err, "too many scheduled statuses for the same day")
+	}
+
+	return scheduledAt, nil
+}
+
+func (p *processor) getOwnScheduledStatus(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, err := p.db.GetScheduledStatusByID(ctx, scheduledStatusID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduledStatus.AccountID != authed.Account.ID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("scheduled status %s does not belong to account %s", scheduledStatusID, authed.Account.ID))
+	}
+
+	return scheduledStatus, nil
+}
+
+func (p *processor) apiScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduledStatus, err := p.tc.ScheduledStatusToAPIScheduledStatus(ctx, scheduledStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting scheduled status %s to api representation: %s", scheduledStatus.ID, err))
+	}
+	return apiScheduledStatus, nil
+}
+
+// publishDueScheduledStatuses publishes all scheduled statuses whose time has come.
+//
+// A scheduled status is only deleted once it's been published successfully. If publishing fails,
+// it's left in place with its media attachments still reserved, so that the account can still see
+// and delete it, and publishing will be tried again the next time this function runs. Once it has
+// failed scheduledStatusMaxAttempts times, or as soon as it fails in a way that retrying won't fix,
+// it's deleted instead, and the account that scheduled it is let know by email.
+func (p *processor) publishDueScheduledStatuses(ctx context.Context) error {
+	scheduledStatuses, err := p.db.GetDueScheduledStatuses(ctx, time.Now(), 0)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("publishDueScheduledStatuses: error getting due scheduled statuses: %s", err)
+	}
+
+	for _, scheduledStatus := range scheduledStatuses {
+		if errWithCode := p.publishScheduledStatus(ctx, scheduledStatus); errWithCode != nil {
+			logrus.Errorf("publishDueScheduledStatuses: error publishing scheduled status %s: %s", scheduledStatus.ID, errWithCode)
+
+			scheduledStatus.Attempts++
+			if errWithCode.Code() >= http.StatusInternalServerError && scheduledStatus.Attempts < scheduledStatusMaxAttempts {
+				if err := p.db.UpdateScheduledStatus(ctx, scheduledStatus); err != nil {
+					return fmt.Errorf("publishDueScheduledStatuses: error updating scheduled status %s: %s", scheduledStatus.ID, err)
+				}
+				continue
+			}
+
+			if err := p.failScheduledStatus(ctx, scheduledStatus); err != nil {
+				return fmt.Errorf("publishDueScheduledStatuses: %s", err)
+			}
+			continue
+		}
+
+		// the status now owns the media attachments, so this only removes the scheduled status itself
+		if err := p.db.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+			return fmt.Errorf("publishDueScheduledStatuses: error deleting scheduled status %s: %s", scheduledStatus.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// failScheduledStatus gives up on publishing the given scheduled status: it's deleted, which releases
+// its media attachments, and the account that scheduled it is sent an email about it if possible.
+func (p *processor) failScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	logrus.Infof("failScheduledStatus: giving up on publishing scheduled status %s after %d attempt(s)", scheduledStatus.ID, scheduledStatus.Attempts)
+
+	if err := p.db.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		return fmt.Errorf("error deleting scheduled status %s: %s", scheduledStatus.ID, err)
+	}
+
+	if scheduledStatus.Account == nil {
+		return nil
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: scheduledStatus.AccountID}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("error getting user for account %s: %s", scheduledStatus.AccountID, err)
+	}
+
+	// the scheduled status is gone either way, so a problem with the email shouldn't stop the rest being published
+	if err := p.userProcessor.SendScheduledStatusFailedEmail(ctx, user, scheduledStatus.Account.Username, scheduledStatus); err != nil {
+		logrus.Errorf("failScheduledStatus: %s", err)
+	}
+
+	return nil
+}
+
+// publishScheduledStatus creates a status from the given scheduled status, exactly as though
+// the account had just posted it, so that it goes through the normal client API processing.
+func (p *processor) publishScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) gtserror.WithCode {
+	account := scheduledStatus.Account
+	if account == nil {
+		return gtserror.NewErrorNotFound(errors.New("scheduled status has no account"))
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		logrus.Debugf("publishScheduledStatus: not publishing scheduled status %s because account %s is suspended", scheduledStatus.ID, account.ID)
+		return nil
+	}
+
+	application := &gtsmodel.Application{}
+	if err := p.db.GetByID(ctx, scheduledStatus.ApplicationID, application); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(fmt.Errorf("application %s no longer exists", scheduledStatus.ApplicationID))
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("error getting application %s: %s", scheduledStatus.ApplicationID, err))
+	}
+
+	federated := scheduledStatus.Federated
+	boostable := scheduledStatus.Boostable
+	replyable := scheduledStatus.Replyable
+	likeable := scheduledStatus.Likeable
+
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      scheduledStatus.Text,
+			MediaIDs:    scheduledStatus.AttachmentIDs,
+			InReplyToID: scheduledStatus.InReplyToID,
+			Sensitive:   scheduledStatus.Sensitive,
+			SpoilerText: scheduledStatus.SpoilerText,
+			Visibility:  p.tc.VisToAPIVis(ctx, scheduledStatus.Visibility),
+			Language:    scheduledStatus.Language,
+			Format:      apimodel.StatusFormat(scheduledStatus.Format),
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: &federated,
+			Boostable: &boostable,
+			Replyable: &replyable,
+			Likeable:  &likeable,
+		},
+	}
+
+	if len(scheduledStatus.PollOptions) != 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduledStatus.PollOptions,
+			ExpiresIn:  scheduledStatus.PollExpiresIn,
+			Multiple:   scheduledStatus.PollMultiple,
+			HideTotals: scheduledStatus.PollHideTotals,
+		}
+	}
+
+	if _, errWithCode := p.statusProcessor.CreateFromScheduled(ctx, account, application, form, scheduledStatus.ID); errWithCode != nil {
+		return errWithCode
+	}
+
+	return nil
+}
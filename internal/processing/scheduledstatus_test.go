@@ -0,0 +1,191 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+type ScheduledStatusTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) newForm(text string, scheduledAt time.Time) *apimodel.AdvancedStatusCreateForm {
+	return &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      text,
+			Visibility:  apimodel.VisibilityUnlisted,
+			ScheduledAt: scheduledAt.Format(time.RFC3339),
+			Format:      apimodel.StatusFormatPlain,
+		},
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusesGet() {
+	resp, errWithCode := suite.processor.ScheduledStatusesGet(context.Background(), suite.testAutheds["local_account_1"], "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.Len(resp.ScheduledStatuses, 1)
+	suite.Equal("01G36SF3V6Y6V5BF9P4R7PQG7G", resp.ScheduledStatuses[0].ID)
+	suite.Equal("this is a post from the future!", resp.ScheduledStatuses[0].Params.Text)
+	suite.Contains(resp.LinkHeader, "api/v1/scheduled_statuses?limit=20&max_id=01G36SF3V6Y6V5BF9P4R7PQG7G")
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusGetNotOwner() {
+	scheduledStatus, errWithCode := suite.processor.ScheduledStatusGet(context.Background(), suite.testAutheds["local_account_2"], "01G36SF3V6Y6V5BF9P4R7PQG7G")
+	suite.Nil(scheduledStatus)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateUpdateDelete() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	form := suite.newForm("see you tomorrow", time.Now().Add(24*time.Hour))
+	form.MediaIDs = []string{attachment.ID}
+
+	created, errWithCode := suite.processor.ScheduledStatusCreate(ctx, authed, form)
+	suite.NoError(errWithCode)
+	suite.Equal("see you tomorrow", created.Params.Text)
+	suite.Equal(apimodel.VisibilityUnlisted, created.Params.Visibility)
+	suite.Equal([]string{attachment.ID}, created.Params.MediaIDs)
+	suite.Len(created.MediaAttachments, 1)
+
+	// the media now belongs to the scheduled status, so it can't be used elsewhere
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(created.ID, dbAttachment.ScheduledStatusID)
+
+	_, errWithCode = suite.processor.ScheduledStatusCreate(ctx, authed, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	newScheduledAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	updated, errWithCode := suite.processor.ScheduledStatusUpdate(ctx, authed, created.ID, &apimodel.ScheduledStatusUpdateRequest{ScheduledAt: newScheduledAt.Format(time.RFC3339)})
+	suite.NoError(errWithCode)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal(newScheduledAt.Format(time.RFC3339), updated.ScheduledAt)
+
+	suite.NoError(suite.processor.ScheduledStatusDelete(ctx, authed, created.ID))
+
+	_, errWithCode = suite.processor.ScheduledStatusGet(ctx, authed, created.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// deleting the scheduled status releases the media again
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbAttachment.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateBadTime() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	form := suite.newForm("too soon", time.Now().Add(time.Minute))
+	_, errWithCode := suite.processor.ScheduledStatusCreate(ctx, authed, form)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	form.ScheduledAt = "next tuesday"
+	_, errWithCode = suite.processor.ScheduledStatusCreate(ctx, authed, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateDailyLimit() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	day := time.Now().Add(72 * time.Hour).UTC().Truncate(24 * time.Hour)
+
+	for i := 0; i < 25; i++ {
+		_, errWithCode := suite.processor.ScheduledStatusCreate(ctx, authed, suite.newForm("busy day", day.Add(time.Duration(i)*time.Minute)))
+		suite.NoError(errWithCode)
+	}
+
+	_, errWithCode := suite.processor.ScheduledStatusCreate(ctx, authed, suite.newForm("one too many", day.Add(time.Hour)))
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// the next day is fine though
+	_, errWithCode = suite.processor.ScheduledStatusCreate(ctx, authed, suite.newForm("quieter day", day.Add(25*time.Hour)))
+	suite.NoError(errWithCode)
+
+	// and so is moving a status around within a full day
+	resp, errWithCode := suite.processor.ScheduledStatusesGet(ctx, authed, "", "", "", 0)
+	suite.NoError(errWithCode)
+	for _, s := range resp.ScheduledStatuses {
+		if s.Params.Text == "busy day" {
+			_, errWithCode = suite.processor.ScheduledStatusUpdate(ctx, authed, s.ID, &apimodel.ScheduledStatusUpdateRequest{ScheduledAt: day.Add(2 * time.Hour).Format(time.RFC3339)})
+			suite.NoError(errWithCode)
+			break
+		}
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestPublishScheduledStatusGivesUp() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	// replying to a status that doesn't exist will never work, however many times it's tried
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:            "01G5FV6KQDW2M6Y0X4JQ3HN7CA",
+		ScheduledAt:   time.Now().Add(-1 * time.Minute),
+		AccountID:     account.ID,
+		ApplicationID: suite.testApplications["application_1"].ID,
+		Text:          "replying to nothing at all",
+		InReplyToID:   "01G5FV9S0YQ8S1YVV8KZ3ZB0RT",
+		Visibility:    gtsmodel.VisibilityPublic,
+		Federated:     true,
+		Boostable:     true,
+		Replyable:     true,
+		Likeable:      true,
+	}
+	suite.NoError(suite.db.PutScheduledStatus(ctx, scheduledStatus))
+
+	for i := 1; i < 5; i++ {
+		suite.NoError(processing.PublishDueScheduledStatuses(ctx, suite.processor))
+
+		// still there to be tried again
+		dbScheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+		suite.NoError(err)
+		suite.Equal(i, dbScheduledStatus.Attempts)
+		suite.Empty(suite.sentEmails)
+	}
+
+	suite.NoError(processing.PublishDueScheduledStatuses(ctx, suite.processor))
+
+	// now it's been given up on, and the owner knows about it
+	_, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	suite.Len(suite.sentEmails, 1)
+	email, ok := suite.sentEmails["zork@example.org"]
+	suite.True(ok)
+	suite.Contains(email, "couldn't be published")
+	suite.Contains(email, "replying to nothing at all")
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
@@ -57,7 +57,7 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 	}
 
 	for _, status := range parents {
-		if v, err := p.filter.StatusVisible(ctx, status, requestingAccount); err == nil && v {
+		if v, err := p.threadVisible(ctx, status, requestingAccount); err == nil && v {
 			apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, requestingAccount)
 			if err == nil {
 				context.Ancestors = append(context.Ancestors, *apiStatus)
@@ -75,7 +75,7 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 	}
 
 	for _, status := range children {
-		if v, err := p.filter.StatusVisible(ctx, status, requestingAccount); err == nil && v {
+		if v, err := p.threadVisible(ctx, status, requestingAccount); err == nil && v {
 			apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, requestingAccount)
 			if err == nil {
 				context.Descendants = append(context.Descendants, *apiStatus)
@@ -85,3 +85,18 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 
 	return context, nil
 }
+
+// threadVisible returns true if the given status is visible to the requesting account,
+// and hasn't been filtered out of threads by the requesting account.
+func (p *processor) threadVisible(ctx context.Context, status *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error) {
+	visible, err := p.filter.StatusVisible(ctx, status, requestingAccount)
+	if err != nil || !visible {
+		return false, err
+	}
+
+	filteredOut, err := p.filter.StatusFilteredOut(ctx, status, requestingAccount, gtsmodel.FilterContextThread)
+	if err != nil {
+		return false, err
+	}
+	return !filteredOut, nil
+}
@@ -34,6 +34,14 @@ import (
 )
 
 func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode) {
+	return p.create(ctx, account, application, form, "")
+}
+
+func (p *processor) CreateFromScheduled(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm, scheduledStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	return p.create(ctx, account, application, form, scheduledStatusID)
+}
+
+func (p *processor) create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm, scheduledStatusID string) (*apimodel.Status, gtserror.WithCode) {
 	accountURIs := uris.GenerateURIsForAccount(account.Username)
 	thisStatusID, err := id.NewULID()
 	if err != nil {
@@ -63,7 +71,11 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := p.ProcessMediaIDs(ctx, form, account.ID, newStatus); err != nil {
+	if err := p.processMediaIDs(ctx, form, account.ID, scheduledStatusID, newStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessPoll(ctx, form, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -98,6 +98,50 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithHTMLEscapedQuot
 	suite.Equal("\"test\"", apiStatus.SpoilerText)
 }
 
+func (suite *StatusCreateTestSuite) TestCreateFromScheduled() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	scheduledStatusID := "01G36SF3V6Y6V5BF9P4R7PQG7G"
+
+	// reserve the attachment for a scheduled status, as though it was scheduled with it
+	attachment, err := suite.db.GetAttachmentByID(ctx, suite.testAttachments["local_account_1_unattached_1"].ID)
+	suite.NoError(err)
+	attachment.ScheduledStatusID = scheduledStatusID
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, attachment))
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "this is a post from the future!",
+			MediaIDs:   []string{attachment.ID},
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	// the attachment can't be used by an ordinary status
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Error(errWithCode)
+	suite.Nil(apiStatus)
+
+	// or by a different scheduled status
+	apiStatus, errWithCode = suite.status.CreateFromScheduled(ctx, creatingAccount, creatingApplication, statusCreateForm, "01G5AQ2Y3R0DCSV9J4DXCW4A8N")
+	suite.Error(errWithCode)
+	suite.Nil(apiStatus)
+
+	// but the scheduled status it's reserved for can publish it
+	apiStatus, errWithCode = suite.status.CreateFromScheduled(ctx, creatingAccount, creatingApplication, statusCreateForm, scheduledStatusID)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(apiStatus.ID, dbAttachment.StatusID)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -0,0 +1,221 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) Edit(ctx context.Context, account *gtsmodel.Account, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+
+	if targetStatus.AccountID != account.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"))
+	}
+
+	if targetStatus.BoostOfID != "" {
+		err := errors.New("boosts can't be edited")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// make sure the edit leaves something in the status before we change anything,
+	// so that a rejected edit doesn't leave new mentions or tags lying around
+	if form.Status == "" && len(form.MediaIDs) == 0 && targetStatus.PollID == "" {
+		err := errors.New("no status or media provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// snapshot the current version of the status before we change anything
+	previous, err := p.tc.StatusToStatusEdit(ctx, targetStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	previousMentionIDs := targetStatus.MentionIDs
+
+	// wrap the edit in a create form so we can reuse the create processing utils
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      form.Status,
+			MediaIDs:    form.MediaIDs,
+			Sensitive:   form.Sensitive,
+			SpoilerText: form.SpoilerText,
+			Language:    form.Language,
+			Format:      form.Format,
+		},
+	}
+
+	if err := p.processEditMediaIDs(ctx, createForm, account.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetStatus.Text = form.Status
+	targetStatus.ContentWarning = text.SanitizeCaption(form.SpoilerText)
+	targetStatus.Sensitive = form.Sensitive
+
+	// keep the language of the status if a new one wasn't given
+	if err := p.ProcessLanguage(ctx, createForm, targetStatus.Language, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessMentions(ctx, createForm, account.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessTags(ctx, createForm, account.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessEmojis(ctx, createForm, account.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessContent(ctx, createForm, account.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	targetStatus.EditedAt = time.Now()
+
+	// update the status in the database, storing the previous version alongside it
+	if err := p.db.UpdateStatus(ctx, targetStatus, previous); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating status in the database: %s", err))
+	}
+
+	// mentions were recreated from the new text, so the old ones can go
+	for _, m := range previousMentionIDs {
+		if err := p.db.DeleteByID(ctx, m, &gtsmodel.Mention{}); err != nil {
+			logrus.Errorf("Edit: error deleting old mention %s: %s", m, err)
+		}
+	}
+
+	// send it back to the processor for async processing
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       targetStatus,
+		OriginAccount:  account,
+	}
+
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, targetStatus, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return apiStatus, nil
+}
+
+func (p *processor) History(ctx context.Context, account *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getVisibleStatus(ctx, account, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	edits, err := p.db.GetStatusEdits(ctx, targetStatus.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting edits of status %s: %s", targetStatus.ID, err))
+	}
+
+	// the current version of the status is always the last entry in its history
+	current, err := p.tc.StatusToStatusEdit(ctx, targetStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	edits = append(edits, current)
+
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(edits))
+	for _, e := range edits {
+		apiEdit, err := p.tc.StatusEditToAPIStatusEdit(ctx, e, targetStatus)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting edit of status %s to frontend representation: %s", targetStatus.ID, err))
+		}
+		apiEdits = append(apiEdits, apiEdit)
+	}
+
+	return apiEdits, nil
+}
+
+func (p *processor) Source(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getVisibleStatus(ctx, account, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return &apimodel.StatusSource{
+		ID:          targetStatus.ID,
+		Text:        targetStatus.Text,
+		SpoilerText: targetStatus.ContentWarning,
+	}, nil
+}
+
+// getVisibleStatus gets the status with the given ID, making sure it's visible to the requesting account.
+func (p *processor) getVisibleStatus(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*gtsmodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", targetStatus.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
+	}
+
+	return targetStatus, nil
+}
+
+// processEditMediaIDs works like ProcessMediaIDs, but also allows attachments that are already on the status being edited.
+func (p *processor) processEditMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error {
+	gtsMediaAttachments := []*gtsmodel.MediaAttachment{}
+	attachments := []string{}
+	for _, mediaID := range form.MediaIDs {
+		// check these attachments exist
+		a, err := p.db.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			return fmt.Errorf("invalid media type or media not found for media id %s", mediaID)
+		}
+		// check they belong to the requesting account id
+		if a.AccountID != thisAccountID {
+			return fmt.Errorf("media with id %s does not belong to account %s", mediaID, thisAccountID)
+		}
+		// check they're not already used in another status
+		if (a.StatusID != "" && a.StatusID != status.ID) || a.ScheduledStatusID != "" {
+			return fmt.Errorf("media with id %s is already attached to a status", mediaID)
+		}
+		gtsMediaAttachments = append(gtsMediaAttachments, a)
+		attachments = append(attachments, a.ID)
+	}
+	status.Attachments = gtsMediaAttachments
+	status.AttachmentIDs = attachments
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	form := &model.StatusEditRequest{
+		Status:      "hello everyone! edit: wow i can edit now",
+		SpoilerText: "introduction post",
+		Sensitive:   true,
+		Format:      model.StatusFormatPlain,
+	}
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, form)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+	suite.Equal("<p>hello everyone! edit: wow i can edit now</p>", apiStatus.Content)
+	suite.Equal("en", apiStatus.Language)
+	suite.NotEmpty(apiStatus.EditedAt)
+
+	// an update message should have been sent to the client api channel
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(targetStatus.ID, msg.GTSModel.(*gtsmodel.Status).ID)
+
+	// history should contain the original version followed by the current one
+	history, errWithCode := suite.status.History(ctx, editingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Len(history, 2)
+	suite.Equal("hello everyone!", history[0].Content)
+	suite.Equal("<p>hello everyone! edit: wow i can edit now</p>", history[1].Content)
+
+	source, errWithCode := suite.status.Source(ctx, editingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal("hello everyone! edit: wow i can edit now", source.Text)
+	suite.Equal("introduction post", source.SpoilerText)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwner() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_2"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	form := &model.StatusEditRequest{
+		Status: "this isn't mine to edit",
+	}
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, form)
+	suite.Nil(apiStatus)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNoContent() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, &model.StatusEditRequest{})
+	suite.Nil(apiStatus)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusRejectedLeavesNoMentions() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	mentionsBefore := []*gtsmodel.Mention{}
+	suite.NoError(suite.db.GetAll(ctx, &mentionsBefore))
+
+	// the attachment belongs to someone else, so the edit should be rejected
+	form := &model.StatusEditRequest{
+		Status:   "hello @1happyturtle, look at this",
+		MediaIDs: []string{suite.testAttachments["admin_account_status_1_attachment_1"].ID},
+		Format:   model.StatusFormatPlain,
+	}
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, form)
+	suite.Nil(apiStatus)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	mentionsAfter := []*gtsmodel.Mention{}
+	suite.NoError(suite.db.GetAll(ctx, &mentionsAfter))
+	suite.Len(mentionsAfter, len(mentionsBefore))
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Equal(targetStatus.Text, dbStatus.Text)
+	suite.Equal(targetStatus.MentionIDs, dbStatus.MentionIDs)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
@@ -0,0 +1,90 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+func (p *processor) Mute(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+	if targetStatus.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", targetStatusID))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", targetStatus.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
+	}
+
+	// mutes apply to the whole thread, so they're stored against the root of it
+	rootStatus, err := p.db.GetStatusThreadRoot(ctx, targetStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting thread root of status %s: %s", targetStatus.ID, err))
+	}
+
+	// first check if the thread is already muted, if so we don't need to do anything
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "status_id", Value: rootStatus.ID}, {Key: "account_id", Value: requestingAccount.ID}}, &gtsmodel.StatusMute{}); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking existing mute: %s", err))
+		}
+
+		thisMuteID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// mutes are private to the muting account, so there's nothing to federate
+		gtsMute := &gtsmodel.StatusMute{
+			ID:              thisMuteID,
+			AccountID:       requestingAccount.ID,
+			Account:         requestingAccount,
+			TargetAccountID: rootStatus.AccountID,
+			TargetAccount:   rootStatus.Account,
+			StatusID:        rootStatus.ID,
+			Status:          rootStatus,
+		}
+
+		if err := p.db.Put(ctx, gtsMute); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting mute in database: %s", err))
+		}
+	}
+
+	// return the apidon representation of the target status
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return apiStatus, nil
+}
@@ -0,0 +1,78 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) Pin(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+
+	// only the owner of a status can pin it, and only to their own profile
+	if targetStatus.AccountID != requestingAccount.ID {
+		return nil, gtserror.NewErrorNotFound(errors.New("status does not belong to requesting account"))
+	}
+
+	if targetStatus.BoostOfID != "" {
+		err := errors.New("boosts cannot be pinned")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if targetStatus.Visibility == gtsmodel.VisibilityDirect {
+		err := errors.New("direct statuses cannot be pinned")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if !targetStatus.Pinned {
+		pinned, err := p.db.CountAccountPinned(ctx, requestingAccount.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error counting pinned statuses: %s", err))
+		}
+
+		if maxPinned := viper.GetInt(config.Keys.StatusesMaxPinned); pinned >= maxPinned {
+			err := fmt.Errorf("you already have the maximum of %d statuses pinned", maxPinned)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		targetStatus.Pinned = true
+		if err := p.db.UpdateStatus(ctx, targetStatus, nil); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating status %s: %s", targetStatus.ID, err))
+		}
+	}
+
+	// return the apidon representation of the target status
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return apiStatus, nil
+}
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type StatusPinTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusPinTestSuite) TestPinUnpin() {
+	ctx := context.Background()
+
+	pinningAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, errWithCode := suite.status.Pin(ctx, pinningAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Pinned)
+
+	// pinning again is harmless
+	apiStatus, errWithCode = suite.status.Pin(ctx, pinningAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Pinned)
+
+	pinned, err := suite.db.CountAccountPinned(ctx, pinningAccount.ID)
+	suite.NoError(err)
+	suite.Equal(1, pinned)
+
+	apiStatus, errWithCode = suite.status.Unpin(ctx, pinningAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Pinned)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.False(dbStatus.Pinned)
+}
+
+func (suite *StatusPinTestSuite) TestPinOtherAccountsStatus() {
+	_, errWithCode := suite.status.Pin(context.Background(), suite.testAccounts["local_account_1"], suite.testStatuses["admin_account_status_1"].ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusPinTestSuite) TestPinOverLimit() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesMaxPinned, 1)
+
+	pinningAccount := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.status.Pin(ctx, pinningAccount, suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.status.Pin(ctx, pinningAccount, suite.testStatuses["local_account_1_status_2"].ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("unprocessable entity: you already have the maximum of 1 statuses pinned", errWithCode.Safe())
+}
+
+func TestStatusPinTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPinTestSuite))
+}
@@ -0,0 +1,172 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (p *processor) PollGet(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string) (*apimodel.Poll, gtserror.WithCode) {
+	poll, errWithCode := p.getVisiblePoll(ctx, requestingAccount, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiPoll, err := p.tc.PollToAPIPoll(ctx, poll, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting poll %s to frontend representation: %s", poll.ID, err))
+	}
+
+	return apiPoll, nil
+}
+
+func (p *processor) PollVote(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
+	poll, errWithCode := p.getVisiblePoll(ctx, requestingAccount, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if poll.Status.AccountID == requestingAccount.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("you can't vote in your own poll"))
+	}
+
+	if !poll.ClosedAt.IsZero() || (!poll.ExpiresAt.IsZero() && poll.ExpiresAt.Before(time.Now())) {
+		return nil, gtserror.NewErrorForbidden(errors.New("poll has already ended"))
+	}
+
+	if err := validatePollChoices(poll, choices); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// check if the account has already voted in this poll
+	if _, err := p.db.GetPollVoteBy(ctx, poll.ID, requestingAccount.ID); err == nil {
+		return nil, gtserror.NewErrorConflict(errors.New("you have already voted in this poll"))
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking for existing vote: %s", err))
+	}
+
+	voteID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	vote := &gtsmodel.PollVote{
+		ID:        voteID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Choices:   choices,
+		AccountID: requestingAccount.ID,
+		Account:   requestingAccount,
+		PollID:    poll.ID,
+		Poll:      poll,
+	}
+
+	if err := p.db.PutPollVote(ctx, vote); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting vote in database: %s", err))
+	}
+
+	// send it back to the processor for async processing
+	p.fromClientAPI <- messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       vote,
+		OriginAccount:  requestingAccount,
+		TargetAccount:  poll.Status.Account,
+	}
+
+	// get the poll again so that the vote counts are up to date
+	updatedPoll, err := p.db.GetPollByID(ctx, poll.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error refetching poll %s: %s", poll.ID, err))
+	}
+	updatedPoll.Status = poll.Status
+
+	apiPoll, err := p.tc.PollToAPIPoll(ctx, updatedPoll, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting poll %s to frontend representation: %s", poll.ID, err))
+	}
+
+	return apiPoll, nil
+}
+
+// getVisiblePoll fetches the poll with the given ID along with its status,
+// and checks that the status is visible to the requesting account.
+func (p *processor) getVisiblePoll(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string) (*gtsmodel.Poll, gtserror.WithCode) {
+	poll, err := p.db.GetPollByID(ctx, pollID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("poll %s not found", pollID))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching poll %s: %s", pollID, err))
+	}
+
+	status, err := p.db.GetStatusByID(ctx, poll.StatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status for poll %s: %s", pollID, err))
+	}
+	if status.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", status.ID))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, status, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", status.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("poll is not visible"))
+	}
+
+	poll.Status = status
+	return poll, nil
+}
+
+// validatePollChoices checks that the given choices are a valid vote in the given poll.
+func validatePollChoices(poll *gtsmodel.Poll, choices []int) error {
+	if len(choices) == 0 {
+		return errors.New("no choices provided")
+	}
+
+	if len(choices) > 1 && !poll.Multiple {
+		return errors.New("poll only allows one choice")
+	}
+
+	seen := make(map[int]bool, len(choices))
+	for _, choice := range choices {
+		if choice < 0 || choice >= len(poll.Options) {
+			return fmt.Errorf("choice %d is not a valid option", choice)
+		}
+		if seen[choice] {
+			return fmt.Errorf("choice %d provided more than once", choice)
+		}
+		seen[choice] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type StatusPollTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusPollTestSuite) createPoll(multiple bool) *model.Status {
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status: "which is better?",
+			Poll: &model.PollRequest{
+				Options:   []string{"cats", "dogs", "both"},
+				ExpiresIn: 3600,
+				Multiple:  multiple,
+			},
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(context.Background(), creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.NotNil(apiStatus.Poll)
+
+	return apiStatus
+}
+
+func (suite *StatusPollTestSuite) TestCreatePoll() {
+	apiStatus := suite.createPoll(false)
+
+	suite.False(apiStatus.Poll.Expired)
+	suite.False(apiStatus.Poll.Multiple)
+	suite.Len(apiStatus.Poll.Options, 3)
+	suite.Equal("cats", apiStatus.Poll.Options[0].Title)
+	suite.Equal(0, apiStatus.Poll.VotesCount)
+
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), apiStatus.ID)
+	suite.NoError(err)
+	suite.Equal(apiStatus.Poll.ID, dbStatus.PollID)
+	suite.Equal("Question", dbStatus.ActivityStreamsType)
+}
+
+func (suite *StatusPollTestSuite) TestVoteInPoll() {
+	apiStatus := suite.createPoll(true)
+	votingAccount := suite.testAccounts["local_account_2"]
+
+	apiPoll, errWithCode := suite.status.PollVote(context.Background(), votingAccount, apiStatus.Poll.ID, []int{0, 2})
+	suite.NoError(errWithCode)
+	suite.True(apiPoll.Voted)
+	suite.Equal([]int{0, 2}, apiPoll.OwnVotes)
+	suite.Equal(2, apiPoll.VotesCount)
+	suite.Equal(1, apiPoll.VotersCount)
+	suite.Equal(1, apiPoll.Options[0].VotesCount)
+	suite.Equal(0, apiPoll.Options[1].VotesCount)
+	suite.Equal(1, apiPoll.Options[2].VotesCount)
+
+	// voting again should not be allowed
+	_, errWithCode = suite.status.PollVote(context.Background(), votingAccount, apiStatus.Poll.ID, []int{1})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *StatusPollTestSuite) TestVoteInPollInvalidChoices() {
+	apiStatus := suite.createPoll(false)
+	votingAccount := suite.testAccounts["local_account_2"]
+
+	// only one choice is allowed
+	_, errWithCode := suite.status.PollVote(context.Background(), votingAccount, apiStatus.Poll.ID, []int{0, 1})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// no such option
+	_, errWithCode = suite.status.PollVote(context.Background(), votingAccount, apiStatus.Poll.ID, []int{3})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *StatusPollTestSuite) TestVoteInOwnPoll() {
+	apiStatus := suite.createPoll(false)
+	creatingAccount := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.status.PollVote(context.Background(), creatingAccount, apiStatus.Poll.ID, []int{0})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func TestStatusPollTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPollTestSuite))
+}
@@ -35,6 +35,14 @@ import (
 type Processor interface {
 	// Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
 	Create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode)
+	// CreateFromScheduled works like Create, but also allows the form to use media attachments that are reserved for the given scheduled status.
+	CreateFromScheduled(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm, scheduledStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Edit processes the given form to edit an existing status, returning the api model representation of the edited status if it's OK.
+	Edit(ctx context.Context, account *gtsmodel.Account, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode)
+	// History returns all versions of the given status, oldest first, taking account of privacy settings and blocks etc.
+	History(ctx context.Context, account *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode)
+	// Source returns the plain-text source of the given status, taking account of privacy settings and blocks etc.
+	Source(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
 	// Delete processes the delete of a given status, returning the deleted status if the delete goes through.
 	Delete(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Fave processes the faving of a given status, returning the updated status if the fave goes through.
@@ -51,8 +59,24 @@ type Processor interface {
 	Get(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Unfave processes the unfaving of a given status, returning the updated status if the fave goes through.
 	Unfave(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Bookmark processes the bookmarking of a given status, returning the updated status if the bookmark goes through.
+	Bookmark(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Unbookmark processes the removal of a bookmark from a given status, returning the updated status if all is well.
+	Unbookmark(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Pin processes the pinning of a given status to the requesting account's profile, returning the updated status if the pin goes through.
+	Pin(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Unpin processes the removal of a given status from the requesting account's profile, returning the updated status if all is well.
+	Unpin(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Mute processes the muting of the thread that a given status belongs to, returning the updated status if the mute goes through.
+	Mute(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Unmute processes the removal of a mute from the thread that a given status belongs to, returning the updated status if all is well.
+	Unmute(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Context returns the context (previous and following posts) from the given status ID
 	Context(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
+	// PollGet gets the given poll, taking account of privacy settings of the status it's attached to.
+	PollGet(ctx context.Context, account *gtsmodel.Account, pollID string) (*apimodel.Poll, gtserror.WithCode)
+	// PollVote processes a vote by the given account in the given poll, returning the updated poll if the vote goes through.
+	PollVote(ctx context.Context, account *gtsmodel.Account, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode)
 
 	/*
 		PROCESSING UTILS
@@ -61,6 +85,7 @@ type Processor interface {
 	ProcessVisibility(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultVis gtsmodel.Visibility, status *gtsmodel.Status) error
 	ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error
 	ProcessMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error
+	ProcessPoll(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error
 	ProcessLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error
 	ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
 	ProcessTags(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
@@ -19,7 +19,6 @@
 package status_test
 
 import (
-	"codeberg.org/gruf/go-store/kv"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -28,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -38,7 +38,7 @@ type StatusStandardTestSuite struct {
 	db                db.DB
 	typeConverter     typeutils.TypeConverter
 	tc                transport.Controller
-	storage           *kv.KVStore
+	storage           *storage.Driver
 	mediaManager      media.Manager
 	federator         federation.Federator
 	fromClientAPIChan chan messages.FromClientAPI
@@ -22,11 +22,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -151,6 +154,11 @@ func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.Advance
 }
 
 func (p *processor) ProcessMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error {
+	return p.processMediaIDs(ctx, form, thisAccountID, "", status)
+}
+
+// processMediaIDs works like ProcessMediaIDs, but also allows attachments that are reserved for the given scheduled status, if any.
+func (p *processor) processMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, scheduledStatusID string, status *gtsmodel.Status) error {
 	if form.MediaIDs == nil {
 		return nil
 	}
@@ -168,7 +176,7 @@ func (p *processor) ProcessMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return fmt.Errorf("media with id %s does not belong to account %s", mediaID, thisAccountID)
 		}
 		// check they're not already used in a status
-		if a.StatusID != "" || a.ScheduledStatusID != "" {
+		if a.StatusID != "" || (a.ScheduledStatusID != "" && a.ScheduledStatusID != scheduledStatusID) {
 			return fmt.Errorf("media with id %s is already attached to a status", mediaID)
 		}
 		gtsMediaAttachments = append(gtsMediaAttachments, a)
@@ -179,6 +187,40 @@ func (p *processor) ProcessMediaIDs(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
+func (p *processor) ProcessPoll(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error {
+	if form.Poll == nil {
+		return nil
+	}
+
+	pollID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	options := make([]string, 0, len(form.Poll.Options))
+	for _, option := range form.Poll.Options {
+		options = append(options, text.RemoveHTML(option))
+	}
+
+	now := time.Now()
+	poll := &gtsmodel.Poll{
+		ID:         pollID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		StatusID:   status.ID,
+		Multiple:   form.Poll.Multiple,
+		HideCounts: form.Poll.HideTotals,
+		Options:    options,
+		Votes:      make([]int, len(options)),
+		ExpiresAt:  now.Add(time.Duration(form.Poll.ExpiresIn) * time.Second),
+	}
+
+	status.PollID = poll.ID
+	status.Poll = poll
+	status.ActivityStreamsType = ap.ActivityQuestion
+	return nil
+}
+
 func (p *processor) ProcessLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
 	if form.Language != "" {
 		status.Language = form.Language
@@ -76,9 +76,19 @@ func StatusFilterFunction(database db.DB, filter visibility.Filter) timeline.Fil
 		timelineable, err := filter.StatusHometimelineable(ctx, status, requestingAccount)
 		if err != nil {
 			logrus.Warnf("error checking hometimelineability of status %s for account %s: %s", status.ID, timelineAccountID, err)
+			return false, nil // we don't return the error here because we want to just skip this item if something goes wrong
 		}
 
-		return timelineable, nil // we don't return the error here because we want to just skip this item if something goes wrong
+		if timelineable {
+			filteredOut, err := filter.StatusFilteredOut(ctx, status, requestingAccount, gtsmodel.FilterContextHome)
+			if err != nil {
+				logrus.Warnf("error checking filters on status %s for account %s: %s", status.ID, timelineAccountID, err)
+				return false, nil
+			}
+			timelineable = !filteredOut
+		}
+
+		return timelineable, nil
 	}
 }
 
@@ -209,7 +219,7 @@ func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	s, err := p.filterPublicStatuses(ctx, authed, statuses)
+	s, err := p.filterPublicStatuses(ctx, authed, statuses, p.filter.StatusPublictimelineable)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -223,6 +233,55 @@ func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 	return p.packageStatusResponse(s, "api/v1/timelines/public", s[len(s)-1].ID, s[0].ID, limit)
 }
 
+func (p *processor) TagTimelineGet(ctx context.Context, authed *oauth.Auth, tagName string, anyTags []string, allTags []string, noneTags []string, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	emptyResponse := &apimodel.StatusTimelineResponse{
+		Statuses: []*apimodel.Status{},
+	}
+
+	// the hashtag itself is treated as one of the 'any' tags
+	anyTagIDs, _, err := p.getTagIDs(ctx, append([]string{tagName}, anyTags...))
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	allTagIDs, allFound, err := p.getTagIDs(ctx, allTags)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// if none of the 'any' tags have been used yet, or one of the 'all' tags
+	// hasn't been used yet, then there can't be any statuses that match
+	if len(anyTagIDs) == 0 || !allFound {
+		return emptyResponse, nil
+	}
+
+	noneTagIDs, _, err := p.getTagIDs(ctx, noneTags)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	statuses, err := p.db.GetTagTimeline(ctx, authed.Account.ID, anyTagIDs, allTagIDs, noneTagIDs, maxID, sinceID, minID, limit, local)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries left
+			return emptyResponse, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	s, err := p.filterPublicStatuses(ctx, authed, statuses, p.filter.StatusTagtimelineable)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(s) == 0 {
+		return emptyResponse, nil
+	}
+
+	return p.packageStatusResponse(s, "api/v1/timelines/tag/"+tagName, s[len(s)-1].ID, s[0].ID, limit)
+}
+
 func (p *processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
 	statuses, nextMaxID, prevMinID, err := p.db.GetFavedTimeline(ctx, authed.Account.ID, maxID, minID, limit)
 	if err != nil {
@@ -250,7 +309,37 @@ func (p *processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 	return p.packageStatusResponse(s, "api/v1/favourites", nextMaxID, prevMinID, limit)
 }
 
-func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status) ([]*apimodel.Status, error) {
+func (p *processor) BookmarkedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	statuses, nextMaxID, prevMinID, err := p.db.GetBookmarkedTimeline(ctx, authed.Account.ID, maxID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries left
+			return &apimodel.StatusTimelineResponse{
+				Statuses: []*apimodel.Status{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// bookmarked statuses are filtered the same way as faved ones: the requester just has to be able to see them
+	s, err := p.filterFavedStatuses(ctx, authed, statuses)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(s) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	return p.packageStatusResponse(s, "api/v1/bookmarks", nextMaxID, prevMinID, limit)
+}
+
+// filterPublicStatuses converts the given statuses to their api representation, leaving out any that
+// the given timelineable function says shouldn't be shown, or that the account has filtered out.
+func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status, timelineable func(context.Context, *gtsmodel.Status, *gtsmodel.Account) (bool, error)) ([]*apimodel.Status, error) {
 	l := logrus.WithField("func", "filterPublicStatuses")
 
 	apiStatuses := []*apimodel.Status{}
@@ -264,12 +353,21 @@ func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("filterPublicStatuses: error getting status author: %s", err))
 		}
 
-		timelineable, err := p.filter.StatusPublictimelineable(ctx, s, authed.Account)
+		ok, err := timelineable(ctx, s, authed.Account)
 		if err != nil {
 			l.Debugf("filterPublicStatuses: skipping status %s because of an error checking status visibility: %s", s.ID, err)
 			continue
 		}
-		if !timelineable {
+		if !ok {
+			continue
+		}
+
+		filteredOut, err := p.filter.StatusFilteredOut(ctx, s, authed.Account, gtsmodel.FilterContextPublic)
+		if err != nil {
+			l.Debugf("filterPublicStatuses: skipping status %s because of an error checking filters: %s", s.ID, err)
+			continue
+		}
+		if filteredOut {
 			continue
 		}
 
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 func (p *processor) AuthorizeStreamingRequest(ctx context.Context, accessToken string) (*gtsmodel.Account, error) {
@@ -31,6 +32,11 @@ func (p *processor) AuthorizeStreamingRequest(ctx context.Context, accessToken s
 		return nil, fmt.Errorf("AuthorizeStreamingRequest: error loading access token: %s", err)
 	}
 
+	// streams carry statuses and notifications, so the token has to be allowed to read at least one of them
+	if !oauth.Grants(ti.GetScope(), oauth.ScopeReadStatuses) && !oauth.Grants(ti.GetScope(), oauth.ScopeReadNotifications) {
+		return nil, fmt.Errorf("AuthorizeStreamingRequest: token is missing required scope %s", oauth.ScopeReadStatuses)
+	}
+
 	uid := ti.GetUserID()
 	if uid == "" {
 		return nil, fmt.Errorf("AuthorizeStreamingRequest: no userid in token")
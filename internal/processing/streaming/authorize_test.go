@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type AuthorizeTestSuite struct {
@@ -43,6 +45,27 @@ func (suite *AuthorizeTestSuite) TestAuthorize() {
 	suite.Nil(noAccount)
 }
 
+func (suite *AuthorizeTestSuite) TestAuthorizeMissingScope() {
+	ctx := context.Background()
+
+	// a token that can only write can't be used to read streams
+	token := suite.testTokens["local_account_1"]
+	err := suite.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: token.ID}}, "scope", "write push", &gtsmodel.Token{})
+	suite.NoError(err)
+
+	account, err := suite.streamingProcessor.AuthorizeStreamingRequest(ctx, token.Access)
+	suite.EqualError(err, "AuthorizeStreamingRequest: token is missing required scope read:statuses")
+	suite.Nil(account)
+
+	// reading notifications is enough to open a stream
+	err = suite.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: token.ID}}, "scope", "read:notifications", &gtsmodel.Token{})
+	suite.NoError(err)
+
+	account, err = suite.streamingProcessor.AuthorizeStreamingRequest(ctx, token.Access)
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, account.ID)
+}
+
 func TestAuthorizeTestSuite(t *testing.T) {
 	suite.Run(t, &AuthorizeTestSuite{})
 }
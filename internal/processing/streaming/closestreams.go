@@ -0,0 +1,57 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package streaming
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+func (p *processor) CloseStreamsForToken(accessToken string) {
+	if accessToken == "" {
+		return
+	}
+
+	p.streamMap.Range(func(k interface{}, v interface{}) bool {
+		accountID, ok := k.(string)
+		if !ok {
+			panic("streamMap key was not a string (account id)")
+		}
+
+		streamsForAccount, ok := v.(*stream.StreamsForAccount)
+		if !ok {
+			return true
+		}
+
+		// collect the streams first, since closing them modifies the slice of streams
+		toClose := []*stream.Stream{}
+		streamsForAccount.Lock()
+		for _, s := range streamsForAccount.Streams {
+			if s.AccessToken == accessToken {
+				toClose = append(toClose, s)
+			}
+		}
+		streamsForAccount.Unlock()
+
+		for _, s := range toClose {
+			p.closeStream(accountID, s)
+		}
+
+		return true
+	})
+}
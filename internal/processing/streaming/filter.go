@@ -0,0 +1,62 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package streaming
+
+import (
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// hiddenByFilter returns true if the given status matched a filter of the streaming account
+// that hides statuses in the given filter context.
+func hiddenByFilter(s *apimodel.Status, filterContext gtsmodel.FilterContext) bool {
+	if s == nil || filterContext == "" {
+		return false
+	}
+
+	for _, result := range s.Filtered {
+		if result.Filter.FilterAction != string(gtsmodel.FilterActionHide) {
+			continue
+		}
+		for _, c := range result.Filter.Context {
+			if c == string(filterContext) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// timelineFilterContext returns the filter context that applies to statuses streamed to the given timeline,
+// or an empty string if filters don't apply to the timeline.
+func timelineFilterContext(timeline string) gtsmodel.FilterContext {
+	switch {
+	case timeline == stream.TimelineHome, timeline == stream.TimelineList, strings.HasPrefix(timeline, stream.TimelineList+":"):
+		return gtsmodel.FilterContextHome
+	case timeline == stream.TimelinePublic, timeline == stream.TimelineLocal, strings.HasPrefix(timeline, stream.TimelineHashtag+":"):
+		return gtsmodel.FilterContextPublic
+	case timeline == stream.TimelineNotifications:
+		return gtsmodel.FilterContextNotifications
+	}
+	return ""
+}
@@ -19,6 +19,7 @@
 package streaming
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -27,11 +28,37 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
-func (p *processor) StreamNotificationToAccount(n *apimodel.Notification, account *gtsmodel.Account) error {
+func (p *processor) StreamNotificationToAccount(ctx context.Context, n *apimodel.Notification, account *gtsmodel.Account) error {
+	// don't stream types of notification the account has asked not to receive
+	for _, excluded := range account.ExcludedNotifications {
+		if excluded == n.Type {
+			return nil
+		}
+	}
+
+	if hiddenByFilter(n.Status, gtsmodel.FilterContextNotifications) {
+		return nil
+	}
+
+	// don't stream notifications from accounts whose notifications have been muted
+	if n.Account != nil {
+		muted, err := p.db.IsMuted(ctx, account.ID, n.Account.ID, true)
+		if err != nil {
+			return fmt.Errorf("error checking mute of account %s: %s", n.Account.ID, err)
+		}
+		if muted {
+			return nil
+		}
+	}
+
 	bytes, err := json.Marshal(n)
 	if err != nil {
 		return fmt.Errorf("error marshalling notification to json: %s", err)
 	}
 
-	return p.streamToAccount(string(bytes), stream.EventTypeNotification, []string{stream.TimelineNotifications, stream.TimelineHome}, account.ID)
+	if err := p.streamToAccount(string(bytes), stream.EventTypeNotification, []string{stream.TimelineNotifications, stream.TimelineHome}, account.ID); err != nil {
+		return err
+	}
+
+	return p.pushNotificationToAccount(ctx, n, account)
 }
@@ -21,6 +21,7 @@ package streaming_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -34,7 +35,7 @@ type NotificationTestSuite struct {
 func (suite *NotificationTestSuite) TestStreamNotification() {
 	account := suite.testAccounts["local_account_1"]
 
-	openStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "user")
+	openStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", "user")
 	suite.NoError(errWithCode)
 
 	followAccount := suite.testAccounts["remote_account_1"]
@@ -48,13 +49,69 @@ func (suite *NotificationTestSuite) TestStreamNotification() {
 		Account:   followAccountAPIModel,
 	}
 
-	err = suite.streamingProcessor.StreamNotificationToAccount(notification, account)
+	err = suite.streamingProcessor.StreamNotificationToAccount(context.Background(), notification, account)
 	suite.NoError(err)
 
 	msg := <-openStream.Messages
 	suite.Equal(`{"id":"01FH57SJCMDWQGEAJ0X08CE3WV","type":"follow","created_at":"2021-10-04T10:52:36+02:00","account":{"id":"01F8MH5ZK5VRH73AKHQM6Y9VNX","username":"foss_satan","acct":"foss_satan@fossbros-anonymous.io","display_name":"big gerald","locked":false,"bot":false,"created_at":"2021-09-26T12:52:36+02:00","note":"i post about like, i dunno, stuff, or whatever!!!!","url":"http://fossbros-anonymous.io/@foss_satan","avatar":"","avatar_static":"","header":"","header_static":"","followers_count":0,"following_count":0,"statuses_count":1,"last_status_at":"2021-09-20T10:40:37Z","emojis":[],"fields":[]}}`, msg.Payload)
 }
 
+func (suite *NotificationTestSuite) TestStreamNotificationFromMutedAccount() {
+	// local_account_2 has muted remote_account_2, including notifications
+	account := suite.testAccounts["local_account_2"]
+
+	openStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", "user")
+	suite.NoError(errWithCode)
+
+	mutedAccount := suite.testAccounts["remote_account_2"]
+	mutedAccountAPIModel, err := testrig.NewTestTypeConverter(suite.db).AccountToAPIAccountPublic(context.Background(), mutedAccount)
+	suite.NoError(err)
+
+	notification := &apimodel.Notification{
+		ID:        "01FH57SJCMDWQGEAJ0X08CE3WV",
+		Type:      "follow",
+		CreatedAt: "2021-10-04T10:52:36+02:00",
+		Account:   mutedAccountAPIModel,
+	}
+
+	err = suite.streamingProcessor.StreamNotificationToAccount(context.Background(), notification, account)
+	suite.NoError(err)
+
+	select {
+	case msg := <-openStream.Messages:
+		suite.FailNow("", "expected no message on the stream, got %s", msg.Payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (suite *NotificationTestSuite) TestStreamExcludedNotificationType() {
+	account := suite.testAccounts["local_account_1"]
+	account.ExcludedNotifications = []string{"follow"}
+
+	openStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", "user")
+	suite.NoError(errWithCode)
+
+	followAccount := suite.testAccounts["remote_account_1"]
+	followAccountAPIModel, err := testrig.NewTestTypeConverter(suite.db).AccountToAPIAccountPublic(context.Background(), followAccount)
+	suite.NoError(err)
+
+	notification := &apimodel.Notification{
+		ID:        "01FH57SJCMDWQGEAJ0X08CE3WV",
+		Type:      "follow",
+		CreatedAt: "2021-10-04T10:52:36+02:00",
+		Account:   followAccountAPIModel,
+	}
+
+	err = suite.streamingProcessor.StreamNotificationToAccount(context.Background(), notification, account)
+	suite.NoError(err)
+
+	select {
+	case msg := <-openStream.Messages:
+		suite.FailNow("", "expected no message on the stream, got %s", msg.Payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, &NotificationTestSuite{})
 }
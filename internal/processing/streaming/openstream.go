@@ -22,15 +22,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
-func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamTimeline string) (*stream.Stream, gtserror.WithCode) {
+func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, accessToken string, streamTimeline string) (*stream.Stream, gtserror.WithCode) {
 	l := logrus.WithFields(logrus.Fields{
 		"func":       "OpenStreamForAccount",
 		"account":    account.ID,
@@ -38,6 +40,20 @@ func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.
 	})
 	l.Debug("received open stream request")
 
+	// list streams can only be opened for lists owned by the account
+	if listID := strings.TrimPrefix(streamTimeline, stream.TimelineList+":"); listID != streamTimeline {
+		list, err := p.db.GetListByID(ctx, listID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorNotFound(err)
+			}
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting list %s: %s", listID, err))
+		}
+		if list.AccountID != account.ID {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("list %s does not belong to account %s", listID, account.ID))
+		}
+	}
+
 	// each stream needs a unique ID so we know to close it
 	streamID, err := id.NewRandomULID()
 	if err != nil {
@@ -45,13 +61,14 @@ func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.
 	}
 
 	thisStream := &stream.Stream{
-		ID:        streamID,
-		Timeline:  streamTimeline,
-		Messages:  make(chan *stream.Message, 100),
-		Hangup:    make(chan interface{}, 1),
-		Connected: true,
+		ID:          streamID,
+		Timeline:    streamTimeline,
+		AccessToken: accessToken,
+		Messages:    make(chan *stream.Message, 100),
+		Hangup:      make(chan interface{}, 1),
+		Connected:   true,
 	}
-	go p.waitToCloseStream(account, thisStream)
+	go p.waitToCloseStream(account.ID, thisStream)
 
 	v, ok := p.streamMap.Load(account.ID)
 	if !ok || v == nil {
@@ -79,21 +96,33 @@ func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.
 	return thisStream, nil
 }
 
-// waitToCloseStream waits until the hangup channel is closed for the given stream.
-// It then iterates through the map of streams stored by the processor, removes the stream from it,
-// and then closes the messages channel of the stream to indicate that the channel should no longer be read from.
-func (p *processor) waitToCloseStream(account *gtsmodel.Account, thisStream *stream.Stream) {
+// waitToCloseStream waits until the hangup channel is closed for the given stream, and then closes the stream.
+func (p *processor) waitToCloseStream(accountID string, thisStream *stream.Stream) {
 	<-thisStream.Hangup // wait for a hangup message
+	p.closeStream(accountID, thisStream)
+}
 
+// closeStream removes the given stream from the map of streams stored by the processor, and then closes the
+// messages channel of the stream to indicate that the channel should no longer be read from. Streams that
+// have already been closed are left alone.
+func (p *processor) closeStream(accountID string, thisStream *stream.Stream) {
 	// lock the stream to prevent more messages being put in it while we work
 	thisStream.Lock()
 	defer thisStream.Unlock()
 
+	if !thisStream.Connected {
+		// already closed
+		return
+	}
+
 	// indicate the stream is no longer connected
 	thisStream.Connected = false
 
+	// finally close the messages channel so no more messages can be read from it
+	defer close(thisStream.Messages)
+
 	// load and parse the entry for this account from the stream map
-	v, ok := p.streamMap.Load(account.ID)
+	v, ok := p.streamMap.Load(accountID)
 	if !ok || v == nil {
 		return
 	}
@@ -114,7 +143,4 @@ func (p *processor) waitToCloseStream(account *gtsmodel.Account, thisStream *str
 		}
 	}
 	streamsForAccount.Streams = modifiedStreams
-
-	// finally close the messages channel so no more messages can be read from it
-	close(thisStream.Messages)
 }
@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// pushBodyLength is the maximum number of characters of status text included in a push message.
+const pushBodyLength = 140
+
+// pushNotificationToAccount sends the given notification to every push subscription
+// of the given account that wants to be alerted of notifications of its type.
+func (p *processor) pushNotificationToAccount(ctx context.Context, n *apimodel.Notification, account *gtsmodel.Account) error {
+	subscriptions, err := p.db.GetPushSubscriptionsForAccountID(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("error getting push subscriptions of account %s: %s", account.ID, err)
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Alerts(gtsmodel.NotificationType(n.Type)) {
+			continue
+		}
+
+		// the client uses the token to fetch the notification, so include it in the message
+		token := &gtsmodel.Token{}
+		if err := p.db.GetByID(ctx, subscription.TokenID, token); err != nil {
+			if err == db.ErrNoEntries {
+				// the token was revoked without its subscription being removed, so tidy up
+				if err := p.db.DeleteByID(ctx, subscription.ID, &gtsmodel.PushSubscription{}); err != nil {
+					logrus.Errorf("pushNotificationToAccount: error deleting push subscription %s: %s", subscription.ID, err)
+				}
+				continue
+			}
+			return fmt.Errorf("error getting token of push subscription %s: %s", subscription.ID, err)
+		}
+
+		payload, err := json.Marshal(webPushNotification(n, token.Access, account.Language))
+		if err != nil {
+			return fmt.Errorf("error marshalling push notification to json: %s", err)
+		}
+
+		if err := p.webPushSender.Send(ctx, subscription, payload); err != nil {
+			logrus.Errorf("pushNotificationToAccount: error sending to push subscription %s: %s", subscription.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// webPushNotification converts the given notification into the payload of a push message.
+func webPushNotification(n *apimodel.Notification, accessToken string, locale string) *apimodel.WebPushNotification {
+	pushNotification := &apimodel.WebPushNotification{
+		AccessToken:      accessToken,
+		PreferredLocale:  locale,
+		NotificationID:   n.ID,
+		NotificationType: n.Type,
+	}
+
+	var name string
+	if n.Account != nil {
+		pushNotification.Icon = n.Account.Avatar
+		name = n.Account.DisplayName
+		if name == "" {
+			name = "@" + n.Account.Acct
+		}
+	}
+
+	switch gtsmodel.NotificationType(n.Type) {
+	case gtsmodel.NotificationFollow:
+		pushNotification.Title = fmt.Sprintf("%s followed you", name)
+	case gtsmodel.NotificationFollowRequest:
+		pushNotification.Title = fmt.Sprintf("%s requested to follow you", name)
+	case gtsmodel.NotificationMention:
+		pushNotification.Title = fmt.Sprintf("%s mentioned you", name)
+	case gtsmodel.NotificationReblog:
+		pushNotification.Title = fmt.Sprintf("%s boosted your post", name)
+	case gtsmodel.NotificationFave:
+		pushNotification.Title = fmt.Sprintf("%s favourited your post", name)
+	case gtsmodel.NotificationPoll:
+		pushNotification.Title = "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		pushNotification.Title = fmt.Sprintf("%s just posted", name)
+	default:
+		pushNotification.Title = "New notification"
+	}
+
+	if n.Status != nil {
+		// only show the content warning of a status with one, since the notification may be seen by anyone nearby
+		body := n.Status.SpoilerText
+		if body == "" {
+			body = html.UnescapeString(text.RemoveHTML(n.Status.Content))
+		}
+		pushNotification.Body = truncate(strings.TrimSpace(body), pushBodyLength)
+	}
+
+	return pushNotification
+}
+
+// truncate cuts the given string down to at most length characters, marking where it was cut.
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length-1]) + "…"
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor wraps a bunch of functions for processing streaming.
@@ -35,26 +36,44 @@ type Processor interface {
 	// AuthorizeStreamingRequest returns an oauth2 token info in response to an access token query from the streaming API
 	AuthorizeStreamingRequest(ctx context.Context, accessToken string) (*gtsmodel.Account, error)
 	// OpenStreamForAccount returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
-	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, timeline string) (*stream.Stream, gtserror.WithCode)
+	// The stream is closed again if the given access token, which the stream was opened with, is revoked.
+	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, accessToken string, timeline string) (*stream.Stream, gtserror.WithCode)
+	// CloseStreamsForToken closes all open streams that were opened with the given access token, eg., because it's been revoked.
+	CloseStreamsForToken(accessToken string)
 	// StreamUpdateToAccount streams the given update to any open, appropriate streams belonging to the given account.
 	StreamUpdateToAccount(s *apimodel.Status, account *gtsmodel.Account, timeline string) error
+	// StreamStatusUpdateToAccount streams the given edited status to any open status streams belonging to the given account.
+	StreamStatusUpdateToAccount(s *apimodel.Status, account *gtsmodel.Account) error
+	// StreamConversationToAccount streams the given conversation to any open direct streams belonging to the given account.
+	StreamConversationToAccount(c *apimodel.Conversation, account *gtsmodel.Account) error
+	// StreamMarkerToAccount streams the given updated markers to any open user streams belonging to the given account,
+	// so that other sessions of the account can update their position in the timelines.
+	StreamMarkerToAccount(m *apimodel.Marker, account *gtsmodel.Account) error
 	// StreamNotificationToAccount streams the given notification to any open, appropriate streams belonging to the given account.
-	StreamNotificationToAccount(n *apimodel.Notification, account *gtsmodel.Account) error
+	//
+	// Notifications from accounts whose notifications have been muted by the given account won't be streamed,
+	// nor will notifications of any type that the account has excluded in its preferences.
+	// The notification is also sent to any push subscriptions of the account that want notifications of its type.
+	StreamNotificationToAccount(ctx context.Context, n *apimodel.Notification, account *gtsmodel.Account) error
 	// StreamDelete streams the delete of the given statusID to *ALL* open streams.
 	StreamDelete(statusID string) error
+	// StreamingAccountIDs returns the IDs of all accounts that currently have an open stream of the given timeline.
+	StreamingAccountIDs(timeline string) []string
 }
 
 type processor struct {
-	db          db.DB
-	oauthServer oauth.Server
-	streamMap   *sync.Map
+	db            db.DB
+	oauthServer   oauth.Server
+	webPushSender webpush.Sender
+	streamMap     *sync.Map
 }
 
 // New returns a new status processor.
-func New(db db.DB, oauthServer oauth.Server) Processor {
+func New(db db.DB, oauthServer oauth.Server, webPushSender webpush.Sender) Processor {
 	return &processor{
-		db:          db,
-		oauthServer: oauthServer,
-		streamMap:   &sync.Map{},
+		db:            db,
+		oauthServer:   oauthServer,
+		webPushSender: webPushSender,
+		streamMap:     &sync.Map{},
 	}
 }
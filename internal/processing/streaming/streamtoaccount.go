@@ -47,9 +47,9 @@ func (p *processor) streamToAccount(payload string, event string, timelines []st
 		}
 
 		for _, t := range timelines {
-			if s.Timeline == string(t) {
+			if stream.Matches(s.Timeline, t) {
 				s.Messages <- &stream.Message{
-					Stream:  []string{string(t)},
+					Stream:  stream.StreamNames(s.Timeline),
 					Event:   string(event),
 					Payload: payload,
 				}
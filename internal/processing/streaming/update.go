@@ -28,6 +28,10 @@ import (
 )
 
 func (p *processor) StreamUpdateToAccount(s *apimodel.Status, account *gtsmodel.Account, timeline string) error {
+	if hiddenByFilter(s, timelineFilterContext(timeline)) {
+		return nil
+	}
+
 	bytes, err := json.Marshal(s)
 	if err != nil {
 		return fmt.Errorf("error marshalling status to json: %s", err)
@@ -35,3 +39,19 @@ func (p *processor) StreamUpdateToAccount(s *apimodel.Status, account *gtsmodel.
 
 	return p.streamToAccount(string(bytes), stream.EventTypeUpdate, []string{timeline}, account.ID)
 }
+
+func (p *processor) StreamStatusUpdateToAccount(s *apimodel.Status, account *gtsmodel.Account) error {
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error marshalling status to json: %s", err)
+	}
+
+	timelines := []string{}
+	for _, t := range stream.AllStatusTimelines {
+		if !hiddenByFilter(s, timelineFilterContext(t)) {
+			timelines = append(timelines, t)
+		}
+	}
+
+	return p.streamToAccount(string(bytes), stream.EventTypeStatusUpdate, timelines, account.ID)
+}
@@ -0,0 +1,104 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package streaming_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type UpdateTestSuite struct {
+	StreamingTestSuite
+}
+
+func (suite *UpdateTestSuite) TestStreamStatusUpdate() {
+	account := suite.testAccounts["local_account_1"]
+
+	homeStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	publicStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", stream.TimelinePublic)
+	suite.NoError(errWithCode)
+
+	status := &apimodel.Status{
+		ID:        "01F8MHAMCHF6Y650WCRSCP4WMY",
+		CreatedAt: "2021-10-20T10:40:37Z",
+		EditedAt:  "2021-10-20T10:45:37Z",
+		Content:   "hello everyone! edited",
+	}
+
+	err := suite.streamingProcessor.StreamStatusUpdateToAccount(status, account)
+	suite.NoError(err)
+
+	// every status stream the account has open should get the edit
+	for _, s := range []*stream.Stream{homeStream, publicStream} {
+		msg := <-s.Messages
+		suite.Equal(stream.EventTypeStatusUpdate, msg.Event)
+		suite.Equal([]string{s.Timeline}, msg.Stream)
+		suite.Contains(msg.Payload, `"edited_at":"2021-10-20T10:45:37Z"`)
+	}
+}
+
+func (suite *UpdateTestSuite) TestStreamUpdateHiddenByFilter() {
+	account := suite.testAccounts["local_account_1"]
+
+	homeStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	publicStream, errWithCode := suite.streamingProcessor.OpenStreamForAccount(context.Background(), account, "", stream.TimelinePublic)
+	suite.NoError(errWithCode)
+
+	status := &apimodel.Status{
+		ID:        "01F8MHAMCHF6Y650WCRSCP4WMY",
+		CreatedAt: "2021-10-20T10:40:37Z",
+		Content:   "hello fnord!",
+		Filtered: []apimodel.FilterResult{
+			{
+				Filter: apimodel.FilterV2{
+					ID:           "01G1XMV0ARAWV4MT2SQ1W8GDM8",
+					Context:      []string{"home"},
+					FilterAction: "hide",
+				},
+				KeywordMatches: []string{"fnord"},
+			},
+		},
+	}
+
+	suite.NoError(suite.streamingProcessor.StreamUpdateToAccount(status, account, stream.TimelineHome))
+	suite.NoError(suite.streamingProcessor.StreamUpdateToAccount(status, account, stream.TimelinePublic))
+
+	// the filter only hides the status in the home context, so only the public stream should get it
+	msg := <-publicStream.Messages
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.Equal([]string{stream.TimelinePublic}, msg.Stream)
+
+	select {
+	case msg := <-homeStream.Messages:
+		suite.FailNow("home stream should not have received a message", msg.Payload)
+	default:
+	}
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &UpdateTestSuite{})
+}
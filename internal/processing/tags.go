@@ -0,0 +1,154 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) TagGet(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiTagForAccount(ctx, tag, authed.Account)
+}
+
+func (p *processor) TagFollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	following, err := p.db.IsFollowingTag(ctx, authed.Account.ID, tag.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: error checking existence of tag follow: %s", err))
+	}
+
+	// already following this tag, nothing to do
+	if following {
+		return p.apiTagForAccount(ctx, tag, authed.Account)
+	}
+
+	newTagFollowID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	tagFollow := &gtsmodel.TagFollow{
+		ID:        newTagFollowID,
+		AccountID: authed.Account.ID,
+		TagID:     tag.ID,
+	}
+
+	// tag follows are private to the account that created them, so there's nothing to federate
+	if err := p.db.Put(ctx, tagFollow); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: error creating tag follow in db: %s", err))
+	}
+
+	return p.apiTagForAccount(ctx, tag, authed.Account)
+}
+
+func (p *processor) TagUnfollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{
+		{Key: "account_id", Value: authed.Account.ID},
+		{Key: "tag_id", Value: tag.ID},
+	}, &[]*gtsmodel.TagFollow{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagUnfollow: error deleting tag follow from db: %s", err))
+	}
+
+	return p.apiTagForAccount(ctx, tag, authed.Account)
+}
+
+// getListableTag gets the tag with the given name, returning a not found error
+// if no status on this instance has used it yet, or if it's not allowed to be looked up.
+func (p *processor) getListableTag(ctx context.Context, tagName string) (*gtsmodel.Tag, gtserror.WithCode) {
+	tag, err := p.db.GetTagByName(ctx, tagName)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s not found", tagName))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting tag %s: %s", tagName, err))
+	}
+
+	if !tag.Listable {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s is not listable", tagName))
+	}
+
+	return tag, nil
+}
+
+// apiTagForAccount converts the given tag to its api representation,
+// including whether the given account follows it.
+func (p *processor) apiTagForAccount(ctx context.Context, tag *gtsmodel.Tag, account *gtsmodel.Account) (*apimodel.Tag, gtserror.WithCode) {
+	apiTag, err := p.tc.TagToAPITag(ctx, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting tag %s to api representation: %s", tag.ID, err))
+	}
+
+	following, err := p.db.IsFollowingTag(ctx, account.ID, tag.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking follow of tag %s: %s", tag.ID, err))
+	}
+	apiTag.Following = &following
+
+	return &apiTag, nil
+}
+
+// getTagIDs returns the IDs of the tags with the given names, leaving out duplicates
+// and any names that no status on this instance has used yet. The returned bool is
+// true if every one of the given names was found.
+func (p *processor) getTagIDs(ctx context.Context, tagNames []string) ([]string, bool, error) {
+	tagIDs := []string{}
+	allFound := true
+
+	seen := make(map[string]bool, len(tagNames))
+	for _, name := range tagNames {
+		tag, err := p.db.GetTagByName(ctx, name)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				allFound = false
+				continue
+			}
+			return nil, false, fmt.Errorf("getTagIDs: error getting tag %s: %s", name, err)
+		}
+
+		if seen[tag.ID] {
+			continue
+		}
+		seen[tag.ID] = true
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	return tagIDs, allFound, nil
+}
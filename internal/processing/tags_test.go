@@ -0,0 +1,107 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TagTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *TagTestSuite) TestTagGet() {
+	// tag names are matched without regard to case
+	tag, errWithCode := suite.processor.TagGet(context.Background(), suite.testAutheds["local_account_2"], "WELCOME")
+	suite.NoError(errWithCode)
+	suite.Equal("welcome", tag.Name)
+	suite.Equal("http://localhost:8080/tags/welcome", tag.URL)
+	suite.NotNil(tag.Following)
+	suite.True(*tag.Following)
+
+	tag, errWithCode = suite.processor.TagGet(context.Background(), suite.testAutheds["local_account_1"], "welcome")
+	suite.NoError(errWithCode)
+	suite.False(*tag.Following)
+
+	// nobody has used this one yet
+	_, errWithCode = suite.processor.TagGet(context.Background(), suite.testAutheds["local_account_1"], "neverused")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TagTestSuite) TestTagFollowUnfollow() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	tag, errWithCode := suite.processor.TagFollow(ctx, authed, "Hashtag")
+	suite.NoError(errWithCode)
+	suite.True(*tag.Following)
+
+	// following again is harmless
+	tag, errWithCode = suite.processor.TagFollow(ctx, authed, "hashtag")
+	suite.NoError(errWithCode)
+	suite.True(*tag.Following)
+
+	following, err := suite.db.IsFollowingTag(ctx, authed.Account.ID, suite.testTags["Hashtag"].ID)
+	suite.NoError(err)
+	suite.True(following)
+
+	tag, errWithCode = suite.processor.TagUnfollow(ctx, authed, "Hashtag")
+	suite.NoError(errWithCode)
+	suite.False(*tag.Following)
+
+	following, err = suite.db.IsFollowingTag(ctx, authed.Account.ID, suite.testTags["Hashtag"].ID)
+	suite.NoError(err)
+	suite.False(following)
+}
+
+func (suite *TagTestSuite) TestTagTimelineGet() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	welcomeStatusID := suite.testStatuses["admin_account_status_1"].ID
+
+	resp, errWithCode := suite.processor.TagTimelineGet(ctx, authed, "welcome", nil, nil, nil, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Statuses, 1)
+	suite.Equal(welcomeStatusID, resp.Statuses[0].ID)
+	suite.Contains(resp.LinkHeader, "/api/v1/timelines/tag/welcome?limit=20&max_id="+welcomeStatusID)
+
+	// statuses using any of the 'any' tags are included
+	resp, errWithCode = suite.processor.TagTimelineGet(ctx, authed, "neverused", []string{"welcome"}, nil, nil, "", "", "", 20, true)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Statuses, 1)
+
+	// a tag in 'all' that nobody has used means nothing can match
+	resp, errWithCode = suite.processor.TagTimelineGet(ctx, authed, "welcome", nil, []string{"neverused"}, nil, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Statuses)
+
+	resp, errWithCode = suite.processor.TagTimelineGet(ctx, authed, "welcome", nil, nil, []string{"Welcome"}, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Statuses)
+	suite.Empty(resp.LinkHeader)
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
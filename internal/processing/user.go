@@ -34,3 +34,23 @@ func (p *processor) UserChangePassword(ctx context.Context, authed *oauth.Auth,
 func (p *processor) UserConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
 	return p.userProcessor.ConfirmEmail(ctx, token)
 }
+
+func (p *processor) UserTwoFactorGet(ctx context.Context, authed *oauth.Auth) (*apimodel.TwoFactor, gtserror.WithCode) {
+	return p.userProcessor.TwoFactorGet(ctx, authed.User), nil
+}
+
+func (p *processor) UserTwoFactorEnroll(ctx context.Context, authed *oauth.Auth, form *apimodel.TwoFactorEnrollRequest) (*apimodel.TwoFactorEnrollment, gtserror.WithCode) {
+	return p.userProcessor.TwoFactorEnroll(ctx, authed.User, form.Password)
+}
+
+func (p *processor) UserTwoFactorConfirm(ctx context.Context, authed *oauth.Auth, form *apimodel.TwoFactorConfirmRequest) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode) {
+	return p.userProcessor.TwoFactorConfirm(ctx, authed.User, form.Code)
+}
+
+func (p *processor) UserTwoFactorDisable(ctx context.Context, authed *oauth.Auth, form *apimodel.TwoFactorDisableRequest) gtserror.WithCode {
+	return p.userProcessor.TwoFactorDisable(ctx, authed.User, form.Password, form.Code)
+}
+
+func (p *processor) UserTwoFactorVerify(ctx context.Context, user *gtsmodel.User, code string) (bool, error) {
+	return p.userProcessor.TwoFactorVerify(ctx, user, code)
+}
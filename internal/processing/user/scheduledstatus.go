@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) SendScheduledStatusFailedEmail(ctx context.Context, user *gtsmodel.User, username string, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	if user.Email == "" {
+		// user hasn't confirmed their email address so we can't mail them
+		return nil
+	}
+
+	instance, err := p.getInstance(ctx)
+	if err != nil {
+		return fmt.Errorf("SendScheduledStatusFailedEmail: %s", err)
+	}
+
+	scheduledStatusFailedData := email.ScheduledStatusFailedData{
+		Username:     username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		ScheduledAt:  scheduledStatus.ScheduledAt.UTC().Format(time.RFC1123),
+		Text:         scheduledStatus.Text,
+	}
+	if err := p.emailSender.SendScheduledStatusFailedEmail(user.Email, scheduledStatusFailedData); err != nil {
+		return fmt.Errorf("SendScheduledStatusFailedEmail: error sending to email address %s belonging to user %s: %s", user.Email, username, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ScheduledStatusEmailTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *ScheduledStatusEmailTestSuite) TestSendScheduledStatusFailedEmail() {
+	user := suite.testUsers["local_account_1"]
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ScheduledAt: time.Date(2022, 6, 13, 9, 30, 0, 0, time.UTC),
+		Text:        "good morning everyone",
+	}
+
+	err := suite.user.SendScheduledStatusFailedEmail(context.Background(), user, "the_mighty_zork", scheduledStatus)
+	suite.NoError(err)
+
+	suite.Len(suite.sentEmails, 1)
+	email, ok := suite.sentEmails["zork@example.org"]
+	suite.True(ok)
+
+	suite.Equal("To: zork@example.org\r\nSubject: GoToSocial Scheduled Status Not Published\r\n\r\nHello the_mighty_zork!\r\n\r\nYou are receiving this mail because a status that you scheduled on http://localhost:8080 for Mon, 13 Jun 2022 09:30:00 UTC couldn't be published, so it has been removed.\r\n\r\nThe status you scheduled was:\r\n\r\ngood morning everyone\r\n\r\nAny media that was attached to it can be used in another status. If you'd still like to post it, you can schedule it again, or post it right away.\r\n\r\n", email)
+}
+
+func (suite *ScheduledStatusEmailTestSuite) TestSendScheduledStatusFailedEmailUnconfirmed() {
+	user := suite.testUsers["unconfirmed_account"]
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ScheduledAt: time.Date(2022, 6, 13, 9, 30, 0, 0, time.UTC),
+		Text:        "good morning everyone",
+	}
+
+	err := suite.user.SendScheduledStatusFailedEmail(context.Background(), user, "weed_lord420", scheduledStatus)
+	suite.NoError(err)
+
+	// no confirmed address to send to
+	suite.Empty(suite.sentEmails)
+}
+
+func TestScheduledStatusEmailTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusEmailTestSuite))
+}
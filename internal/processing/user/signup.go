@@ -0,0 +1,130 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) SendNewSignupEmail(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error {
+	instance, err := p.getInstance(ctx)
+	if err != nil {
+		return fmt.Errorf("SendNewSignupEmail: %s", err)
+	}
+
+	admins := []*gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "admin", Value: true}, {Key: "disabled", Value: false}}, &admins); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("SendNewSignupEmail: error getting instance admins: %s", err)
+	}
+
+	newSignupData := email.NewSignupData{
+		InstanceURL:    instance.URI,
+		InstanceName:   instance.Title,
+		SignupUsername: account.Username,
+		SignupEmail:    signupAddress(user),
+		SignupReason:   account.Reason,
+	}
+
+	for _, admin := range admins {
+		if admin.Email == "" {
+			// admin hasn't confirmed their email address so we can't mail them
+			continue
+		}
+
+		// one admin's address being broken shouldn't stop the rest of them from hearing about the sign-up
+		if err := p.emailSender.SendNewSignupEmail(admin.Email, newSignupData); err != nil {
+			logrus.Errorf("SendNewSignupEmail: error sending to email address %s belonging to admin user %s: %s", admin.Email, admin.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *processor) SendSignupApprovedEmail(ctx context.Context, user *gtsmodel.User, username string) error {
+	toAddress := signupAddress(user)
+	if toAddress == "" {
+		return nil
+	}
+
+	instance, err := p.getInstance(ctx)
+	if err != nil {
+		return fmt.Errorf("SendSignupApprovedEmail: %s", err)
+	}
+
+	signupData := email.SignupData{
+		Username:     username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+	}
+	if err := p.emailSender.SendSignupApprovedEmail(toAddress, signupData); err != nil {
+		return fmt.Errorf("SendSignupApprovedEmail: error sending to email address %s belonging to user %s: %s", toAddress, username, err)
+	}
+
+	return nil
+}
+
+func (p *processor) SendSignupRejectedEmail(ctx context.Context, user *gtsmodel.User, username string) error {
+	toAddress := signupAddress(user)
+	if toAddress == "" {
+		return nil
+	}
+
+	instance, err := p.getInstance(ctx)
+	if err != nil {
+		return fmt.Errorf("SendSignupRejectedEmail: %s", err)
+	}
+
+	signupData := email.SignupData{
+		Username:     username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+	}
+	if err := p.emailSender.SendSignupRejectedEmail(toAddress, signupData); err != nil {
+		return fmt.Errorf("SendSignupRejectedEmail: error sending to email address %s belonging to user %s: %s", toAddress, username, err)
+	}
+
+	return nil
+}
+
+// signupAddress returns the address that the given user signed up with,
+// which may not have been confirmed yet.
+func signupAddress(user *gtsmodel.User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.UnconfirmedEmail
+}
+
+// getInstance pulls our instance entry from the database so we can greet users nicely in emails.
+func (p *processor) getInstance(ctx context.Context) (*gtsmodel.Instance, error) {
+	instance := &gtsmodel.Instance{}
+	host := viper.GetString(config.Keys.Host)
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: host}}, instance); err != nil {
+		return nil, fmt.Errorf("error getting instance: %s", err)
+	}
+	return instance, nil
+}
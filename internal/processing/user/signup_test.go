@@ -0,0 +1,77 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SignupEmailTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *SignupEmailTestSuite) TestSendNewSignupEmail() {
+	user := suite.testUsers["unconfirmed_account"]
+	account := testrig.NewTestAccounts()["unconfirmed_account"]
+
+	err := suite.user.SendNewSignupEmail(context.Background(), user, account)
+	suite.NoError(err)
+
+	// only the admin should have been emailed
+	suite.Len(suite.sentEmails, 1)
+	email, ok := suite.sentEmails["admin@example.org"]
+	suite.True(ok)
+
+	suite.Equal("To: admin@example.org\r\nSubject: GoToSocial New Sign-Up\r\n\r\nHello!\r\n\r\nYou are receiving this mail because someone has requested an account on http://localhost:8080, and their sign-up is waiting for approval by an admin.\r\n\r\nUsername: weed_lord420\r\nEmail: weed_lord420@example.org\r\nReason: hi, please let me in! I'm looking for somewhere neato bombeato to hang out.\r\n\r\nYou can see all sign-ups waiting for approval, and approve or reject them, through the admin accounts API of http://localhost:8080.\r\n\r\n", email)
+}
+
+func (suite *SignupEmailTestSuite) TestSendSignupApprovedEmail() {
+	user := suite.testUsers["unconfirmed_account"]
+
+	err := suite.user.SendSignupApprovedEmail(context.Background(), user, "weed_lord420")
+	suite.NoError(err)
+
+	// the email should go to the address the user signed up with, even though it's not confirmed yet
+	suite.Len(suite.sentEmails, 1)
+	email, ok := suite.sentEmails["weed_lord420@example.org"]
+	suite.True(ok)
+
+	suite.Equal("To: weed_lord420@example.org\r\nSubject: GoToSocial Sign-Up Approved\r\n\r\nHello weed_lord420!\r\n\r\nYou are receiving this mail because your request for an account on http://localhost:8080 has been approved by an admin.\r\n\r\nOnce you've confirmed your email address, you can sign in with the email address and password you signed up with.\r\n\r\n", email)
+}
+
+func (suite *SignupEmailTestSuite) TestSendSignupRejectedEmail() {
+	user := suite.testUsers["unconfirmed_account"]
+
+	err := suite.user.SendSignupRejectedEmail(context.Background(), user, "weed_lord420")
+	suite.NoError(err)
+
+	suite.Len(suite.sentEmails, 1)
+	email, ok := suite.sentEmails["weed_lord420@example.org"]
+	suite.True(ok)
+
+	suite.Equal("To: weed_lord420@example.org\r\nSubject: GoToSocial Sign-Up Rejected\r\n\r\nHello weed_lord420!\r\n\r\nYou are receiving this mail because your request for an account on http://localhost:8080 was not approved by an admin, so your sign-up has been removed.\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of http://localhost:8080.\r\n\r\n", email)
+}
+
+func TestSignupEmailTestSuite(t *testing.T) {
+	suite.Run(t, new(SignupEmailTestSuite))
+}
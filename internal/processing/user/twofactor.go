@@ -0,0 +1,288 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/qrcode"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes a user gets when they enable two-factor authentication.
+	recoveryCodeCount = 10
+	// recoveryCodeBytes is how many random bytes are in each recovery code, which is shown as twice as many hex characters.
+	recoveryCodeBytes = 5
+	// qrCodeScale is the width in pixels of each module of the provisioning QR code.
+	qrCodeScale = 6
+	// maxTwoFactorAttempts is how many wrong codes in a row a user can give before they're locked out.
+	maxTwoFactorAttempts = 5
+	// twoFactorLockout is how long a user who gave too many wrong codes has to wait before any code is accepted again.
+	twoFactorLockout = 15 * time.Minute
+)
+
+func (p *processor) TwoFactorGet(ctx context.Context, user *gtsmodel.User) *apimodel.TwoFactor {
+	twoFactor := &apimodel.TwoFactor{
+		Enabled: !user.TwoFactorEnabledAt.IsZero(),
+	}
+	if twoFactor.Enabled {
+		twoFactor.EnabledAt = user.TwoFactorEnabledAt.Format(time.RFC3339)
+		twoFactor.RecoveryCodesLeft = len(user.TwoFactorRecoveryCodes)
+	}
+	return twoFactor
+}
+
+func (p *processor) TwoFactorEnroll(ctx context.Context, user *gtsmodel.User, password string) (*apimodel.TwoFactorEnrollment, gtserror.WithCode) {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, "password did not match")
+	}
+
+	if !user.TwoFactorEnabledAt.IsZero() {
+		err := errors.New("two-factor authentication is already enabled")
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// starting again replaces the secret of any enrollment that wasn't finished
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	uri := totp.URI(viper.GetString(config.Keys.Host), account.Username, secret)
+	qrCode, err := qrCodeDataURI(uri)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorSecret = secret
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err, "database error")
+	}
+
+	return &apimodel.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    uri,
+		QRCode: qrCode,
+	}, nil
+}
+
+func (p *processor) TwoFactorConfirm(ctx context.Context, user *gtsmodel.User, code string) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode) {
+	if !user.TwoFactorEnabledAt.IsZero() {
+		err := errors.New("two-factor authentication is already enabled")
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if user.TwoFactorSecret == "" {
+		err := errors.New("two-factor enrollment hasn't been started")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	step, ok, err := totp.Validate(user.TwoFactorSecret, code, time.Now(), 0)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if !ok {
+		err := errors.New("code was incorrect")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorLastStep = step
+	user.TwoFactorRecoveryCodes = hashes
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err, "database error")
+	}
+
+	return &apimodel.TwoFactorRecoveryCodes{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (p *processor) TwoFactorDisable(ctx context.Context, user *gtsmodel.User, password string, code string) gtserror.WithCode {
+	if user.TwoFactorEnabledAt.IsZero() {
+		err := errors.New("two-factor authentication is not enabled")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return gtserror.NewErrorBadRequest(err, "password did not match")
+	}
+
+	ok, err := p.TwoFactorVerify(ctx, user, code)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+	if !ok {
+		err := errors.New("code was incorrect")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorLastStep = 0
+	user.TwoFactorRecoveryCodes = nil
+	user.TwoFactorAttempts = 0
+	user.TwoFactorLockedUntil = time.Time{}
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return gtserror.NewErrorInternalError(err, "database error")
+	}
+
+	return nil
+}
+
+func (p *processor) TwoFactorVerify(ctx context.Context, user *gtsmodel.User, code string) (bool, error) {
+	if user.TwoFactorEnabledAt.IsZero() {
+		return false, fmt.Errorf("TwoFactorVerify: two-factor authentication is not enabled for user %s", user.ID)
+	}
+
+	if time.Now().Before(user.TwoFactorLockedUntil) {
+		// don't even check the code, otherwise guessing could just carry on during the lockout
+		return false, nil
+	}
+
+	ok, err := checkTwoFactorCode(user, code)
+	if err != nil {
+		return false, fmt.Errorf("TwoFactorVerify: %s", err)
+	}
+
+	// the attempts are counted on the user rather than the sign in session,
+	// so that starting a new session doesn't give an attacker more guesses
+	if ok {
+		user.TwoFactorAttempts = 0
+		user.TwoFactorLockedUntil = time.Time{}
+	} else {
+		user.TwoFactorAttempts++
+		if user.TwoFactorAttempts >= maxTwoFactorAttempts {
+			user.TwoFactorAttempts = 0
+			user.TwoFactorLockedUntil = time.Now().Add(twoFactorLockout)
+		}
+	}
+
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return false, fmt.Errorf("TwoFactorVerify: error updating user: %s", err)
+	}
+
+	return ok, nil
+}
+
+// checkTwoFactorCode checks the given code against the user's TOTP secret and then their recovery codes. If it matches,
+// the user is updated so that the same code can't be used again, but it's up to the caller to store the change.
+func checkTwoFactorCode(user *gtsmodel.User, code string) (bool, error) {
+	step, ok, err := totp.Validate(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		user.TwoFactorLastStep = step
+		return true, nil
+	}
+
+	code = normalizeRecoveryCode(code)
+	if len(code) != recoveryCodeBytes*2 {
+		return false, nil
+	}
+	for i, hash := range user.TwoFactorRecoveryCodes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(code)) != nil {
+			continue
+		}
+
+		// each recovery code can only be used once
+		remaining := make([]string, 0, len(user.TwoFactorRecoveryCodes)-1)
+		remaining = append(remaining, user.TwoFactorRecoveryCodes[:i]...)
+		user.TwoFactorRecoveryCodes = append(remaining, user.TwoFactorRecoveryCodes[i+1:]...)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// newRecoveryCodes returns a fresh set of recovery codes to show to the user once, and the bcrypt hashes of them to store.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("newRecoveryCodes: error reading random bytes: %s", err)
+		}
+		code := hex.EncodeToString(b)
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newRecoveryCodes: error hashing code: %s", err)
+		}
+
+		// split the code in half so it's easier to copy down
+		codes = append(codes, code[:recoveryCodeBytes]+"-"+code[recoveryCodeBytes:])
+		hashes = append(hashes, string(hash))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode undoes the formatting that people are likely to add or change when typing in a recovery code.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
+
+// qrCodeDataURI renders the given content as a QR code, and returns it as a png data URI.
+func qrCodeDataURI(content string) (string, error) {
+	code, err := qrcode.Encode(content)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, code.Image(qrCodeScale)); err != nil {
+		return "", fmt.Errorf("qrCodeDataURI: error encoding png: %s", err)
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,215 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+// enroll goes through enrolling zork in two-factor authentication, returning their secret and recovery codes.
+func (suite *TwoFactorTestSuite) enroll(user *gtsmodel.User) (string, []string) {
+	ctx := context.Background()
+
+	enrollment, errWithCode := suite.user.TwoFactorEnroll(ctx, user, "password")
+	suite.NoError(errWithCode)
+
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	suite.NoError(err)
+
+	recoveryCodes, errWithCode := suite.user.TwoFactorConfirm(ctx, user, code)
+	suite.NoError(errWithCode)
+	return enrollment.Secret, recoveryCodes.RecoveryCodes
+}
+
+func (suite *TwoFactorTestSuite) TestEnroll() {
+	user := suite.testUsers["local_account_1"]
+
+	enrollment, errWithCode := suite.user.TwoFactorEnroll(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+	suite.Len(enrollment.Secret, 32)
+	suite.Equal("otpauth://totp/localhost:8080:the_mighty_zork?algorithm=SHA1&digits=6&issuer=localhost%3A8080&period=30&secret="+enrollment.Secret, enrollment.URI)
+	suite.True(strings.HasPrefix(enrollment.QRCode, "data:image/png;base64,"))
+
+	// the secret is stored, but two-factor authentication isn't on until it's confirmed
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), user.ID, dbUser))
+	suite.Equal(enrollment.Secret, dbUser.TwoFactorSecret)
+	suite.True(dbUser.TwoFactorEnabledAt.IsZero())
+	suite.False(suite.user.TwoFactorGet(context.Background(), dbUser).Enabled)
+}
+
+func (suite *TwoFactorTestSuite) TestEnrollWrongPassword() {
+	user := suite.testUsers["local_account_1"]
+
+	enrollment, errWithCode := suite.user.TwoFactorEnroll(context.Background(), user, "not the password")
+	suite.Nil(enrollment)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("bad request: password did not match", errWithCode.Safe())
+}
+
+func (suite *TwoFactorTestSuite) TestConfirm() {
+	user := suite.testUsers["local_account_1"]
+	_, recoveryCodes := suite.enroll(user)
+
+	suite.Len(recoveryCodes, 10)
+	suite.Regexp("^[0-9a-f]{5}-[0-9a-f]{5}$", recoveryCodes[0])
+
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), user.ID, dbUser))
+	suite.False(dbUser.TwoFactorEnabledAt.IsZero())
+	suite.NotZero(dbUser.TwoFactorLastStep)
+	suite.Len(dbUser.TwoFactorRecoveryCodes, 10)
+
+	// only hashes of the recovery codes are stored
+	for _, hash := range dbUser.TwoFactorRecoveryCodes {
+		suite.NotContains(recoveryCodes, hash)
+	}
+
+	twoFactor := suite.user.TwoFactorGet(context.Background(), dbUser)
+	suite.True(twoFactor.Enabled)
+	suite.Equal(10, twoFactor.RecoveryCodesLeft)
+
+	// enrolling again isn't possible once it's on
+	_, errWithCode := suite.user.TwoFactorEnroll(context.Background(), dbUser, "password")
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *TwoFactorTestSuite) TestConfirmWrongCode() {
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.TwoFactorEnroll(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+
+	recoveryCodes, errWithCode := suite.user.TwoFactorConfirm(context.Background(), user, "not a code")
+	suite.Nil(recoveryCodes)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("bad request: code was incorrect", errWithCode.Safe())
+	suite.True(user.TwoFactorEnabledAt.IsZero())
+}
+
+func (suite *TwoFactorTestSuite) TestConfirmWithoutEnroll() {
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.TwoFactorConfirm(context.Background(), user, "123456")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("bad request: two-factor enrollment hasn't been started", errWithCode.Safe())
+}
+
+func (suite *TwoFactorTestSuite) TestVerifyRecoveryCode() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	_, recoveryCodes := suite.enroll(user)
+
+	// recovery codes work whatever the case and dashes
+	ok, err := suite.user.TwoFactorVerify(ctx, user, strings.ToUpper(strings.ReplaceAll(recoveryCodes[3], "-", "")))
+	suite.NoError(err)
+	suite.True(ok)
+
+	// but only once
+	ok, err = suite.user.TwoFactorVerify(ctx, user, recoveryCodes[3])
+	suite.NoError(err)
+	suite.False(ok)
+
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(ctx, user.ID, dbUser))
+	suite.Len(dbUser.TwoFactorRecoveryCodes, 9)
+}
+
+func (suite *TwoFactorTestSuite) TestVerifyLockout() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	secret, recoveryCodes := suite.enroll(user)
+
+	// a right code clears the wrong ones before it
+	for i := 0; i < 4; i++ {
+		ok, err := suite.user.TwoFactorVerify(ctx, user, "000000")
+		suite.NoError(err)
+		suite.False(ok)
+	}
+	ok, err := suite.user.TwoFactorVerify(ctx, user, recoveryCodes[0])
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Zero(user.TwoFactorAttempts)
+
+	for i := 0; i < 5; i++ {
+		ok, err := suite.user.TwoFactorVerify(ctx, user, "000000")
+		suite.NoError(err)
+		suite.False(ok)
+	}
+
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(ctx, user.ID, dbUser))
+	suite.True(dbUser.TwoFactorLockedUntil.After(time.Now()))
+
+	// locked out, so neither kind of right code works, and neither is used up
+	code, err := totp.Code(secret, time.Now())
+	suite.NoError(err)
+	ok, err = suite.user.TwoFactorVerify(ctx, dbUser, code)
+	suite.NoError(err)
+	suite.False(ok)
+
+	ok, err = suite.user.TwoFactorVerify(ctx, dbUser, recoveryCodes[1])
+	suite.NoError(err)
+	suite.False(ok)
+	suite.Len(dbUser.TwoFactorRecoveryCodes, 9)
+
+	// once the lockout is over, the right code works again
+	dbUser.TwoFactorLockedUntil = time.Now().Add(-1 * time.Second)
+	ok, err = suite.user.TwoFactorVerify(ctx, dbUser, recoveryCodes[1])
+	suite.NoError(err)
+	suite.True(ok)
+	suite.True(dbUser.TwoFactorLockedUntil.IsZero())
+}
+
+func (suite *TwoFactorTestSuite) TestDisable() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	_, recoveryCodes := suite.enroll(user)
+
+	errWithCode := suite.user.TwoFactorDisable(ctx, user, "not the password", recoveryCodes[0])
+	suite.Equal("bad request: password did not match", errWithCode.Safe())
+
+	errWithCode = suite.user.TwoFactorDisable(ctx, user, "password", "99999-99999")
+	suite.Equal("bad request: code was incorrect", errWithCode.Safe())
+
+	errWithCode = suite.user.TwoFactorDisable(ctx, user, "password", recoveryCodes[0])
+	suite.NoError(errWithCode)
+
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(ctx, user.ID, dbUser))
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.True(dbUser.TwoFactorEnabledAt.IsZero())
+	suite.Empty(dbUser.TwoFactorRecoveryCodes)
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorTestSuite{})
+}
@@ -21,6 +21,7 @@ package user
 import (
 	"context"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -36,6 +37,30 @@ type Processor interface {
 	SendConfirmEmail(ctx context.Context, user *gtsmodel.User, username string) error
 	// ConfirmEmail confirms an email address using the given token.
 	ConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode)
+	// SendNewSignupEmail lets the admins of this instance know by email that the given user signed up,
+	// and is waiting for their sign-up to be approved.
+	SendNewSignupEmail(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error
+	// SendSignupApprovedEmail lets a user know by email that their sign-up was approved by an admin.
+	SendSignupApprovedEmail(ctx context.Context, user *gtsmodel.User, username string) error
+	// SendSignupRejectedEmail lets a user know by email that their sign-up was rejected by an admin.
+	SendSignupRejectedEmail(ctx context.Context, user *gtsmodel.User, username string) error
+	// SendScheduledStatusFailedEmail lets a user know by email that a status they scheduled couldn't be published, and was removed.
+	SendScheduledStatusFailedEmail(ctx context.Context, user *gtsmodel.User, username string, scheduledStatus *gtsmodel.ScheduledStatus) error
+
+	// TwoFactorGet returns whether the user has two-factor authentication enabled.
+	TwoFactorGet(ctx context.Context, user *gtsmodel.User) *apimodel.TwoFactor
+	// TwoFactorEnroll starts enrolling the user in two-factor authentication, by generating a new TOTP secret
+	// for them to add to their authenticator app. Two-factor authentication isn't enabled until it's confirmed.
+	TwoFactorEnroll(ctx context.Context, user *gtsmodel.User, password string) (*apimodel.TwoFactorEnrollment, gtserror.WithCode)
+	// TwoFactorConfirm enables two-factor authentication for the user, if the given code matches the secret from
+	// enrolling. The recovery codes that are returned are only ever shown this once.
+	TwoFactorConfirm(ctx context.Context, user *gtsmodel.User, code string) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode)
+	// TwoFactorDisable turns off two-factor authentication for the user, given their password and a current code or a recovery code.
+	TwoFactorDisable(ctx context.Context, user *gtsmodel.User, password string, code string) gtserror.WithCode
+	// TwoFactorVerify checks a code from the user's authenticator app, or one of their recovery codes, for signing in.
+	// Either kind of code is used up by a successful check, so it can't be used again. After too many wrong codes in a row,
+	// the user is locked out for a while, during which every code is refused; the user's TwoFactorLockedUntil shows until when.
+	TwoFactorVerify(ctx context.Context, user *gtsmodel.User, code string) (bool, error)
 }
 
 type processor struct {
@@ -0,0 +1,305 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package qrcode
+
+// matrix is a QR code symbol under construction.
+type matrix struct {
+	size       int
+	modules    [][]bool // true is dark, indexed by [y][x]
+	isFunction [][]bool // whether the module is part of a function pattern rather than data, indexed by [y][x]
+}
+
+func newMatrix(size int) *matrix {
+	m := &matrix{
+		size:       size,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+	for i := 0; i < size; i++ {
+		m.modules[i] = make([]bool, size)
+		m.isFunction[i] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *matrix) setFunction(x int, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns, and reserves the format and version areas.
+func (m *matrix) drawFunctionPatterns(version int) {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	positions := alignments[version-1]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// the corners that overlap the finder patterns don't get one
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			m.drawAlignment(x, y)
+		}
+	}
+
+	m.drawFormatBits(0)
+	m.drawVersion(version)
+}
+
+// drawFinder draws a finder pattern and its separator, centred on the given module.
+func (m *matrix) drawFinder(x int, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= m.size || yy >= m.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			m.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centred on the given module.
+func (m *matrix) drawAlignment(x int, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information for level M and the given mask.
+func (m *matrix) drawFormatBits(mask int) {
+	// level M is 00, so the data is just the mask
+	data := mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool {
+		return (bits>>uint(i))&1 == 1
+	}
+
+	// around the top left finder
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	// split between the other two finders
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true) // always dark
+}
+
+// drawVersion draws both copies of the version information, which only versions 7 and up have.
+func (m *matrix) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 == 1
+		a, b := m.size-11+i%3, i/3
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the zigzag pattern that runs up and down
+// two columns at a time from the bottom right, skipping the function patterns.
+func (m *matrix) drawCodewords(codewords []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// skip the vertical timing pattern
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if upward {
+					y = m.size - 1 - vert
+				}
+				if m.isFunction[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				m.modules[y][x] = (codewords[i/8]>>uint(7-i%8))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by the given mask pattern.
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.isFunction[y][x] {
+				continue
+			}
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is likely to be to scan, using the rules from section 7.8.3 of ISO/IEC 18004.
+func (m *matrix) penalty() int {
+	penalty := 0
+
+	line := make([]bool, m.size)
+	for _, horizontal := range []bool{true, false} {
+		for a := 0; a < m.size; a++ {
+			for b := 0; b < m.size; b++ {
+				if horizontal {
+					line[b] = m.modules[a][b]
+				} else {
+					line[b] = m.modules[b][a]
+				}
+			}
+			penalty += linePenalty(line)
+		}
+	}
+
+	// blocks of 2x2 modules of the same colour
+	dark := 0
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.modules[y][x] {
+				dark++
+			}
+			if x+1 < m.size && y+1 < m.size {
+				c := m.modules[y][x]
+				if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	// the further the proportion of dark modules strays from half, the worse
+	total := m.size * m.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	penalty += k * 10
+
+	return penalty
+}
+
+// finderLike is the dark/light pattern that looks like part of a finder pattern, with four light modules on one side.
+var finderLike = []bool{true, false, true, true, true, false, true, false, false, false, false}
+
+// linePenalty scores runs of five or more modules of the same colour, and patterns that look like a finder, in one row or column.
+func linePenalty(line []bool) int {
+	penalty := 0
+
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			penalty += 3 + run - 5
+		}
+		run = 1
+	}
+
+	for i := 0; i+len(finderLike) <= len(line); i++ {
+		forward, backward := true, true
+		for j, dark := range finderLike {
+			if line[i+j] != dark {
+				forward = false
+			}
+			if line[i+len(finderLike)-1-j] != dark {
+				backward = false
+			}
+		}
+		if forward {
+			penalty += 40
+		}
+		if backward {
+			penalty += 40
+		}
+	}
+
+	return penalty
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,284 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package qrcode implements a small QR code encoder, enough to turn short strings such as
+// otpauth:// URIs into a scannable image. It encodes in byte mode with medium error correction,
+// which is what authenticator apps expect, and supports versions 1 to 10 (up to 213 bytes).
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// MaxLength is the longest content, in bytes, that can be encoded.
+const MaxLength = 213
+
+// quietZone is the number of light modules that have to surround a code so that scanners can find it.
+const quietZone = 4
+
+// blockLayout describes how the codewords of a version are split into error correction blocks, at level M.
+type blockLayout struct {
+	ecPerBlock int // error correction codewords in each block
+	blocks1    int // number of blocks in the first group
+	data1      int // data codewords in each block of the first group
+	blocks2    int // number of blocks in the second group, which have one more data codeword each
+}
+
+// layouts is indexed by version - 1, see table 9 of ISO/IEC 18004.
+var layouts = []blockLayout{
+	{10, 1, 16, 0},
+	{16, 1, 28, 0},
+	{26, 1, 44, 0},
+	{18, 2, 32, 0},
+	{24, 2, 43, 0},
+	{16, 4, 27, 0},
+	{18, 4, 31, 0},
+	{22, 2, 38, 2},
+	{22, 3, 36, 2},
+	{26, 4, 43, 1},
+}
+
+// alignments is indexed by version - 1, and gives the row/column centres of the alignment patterns.
+var alignments = [][]int{
+	{},
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
+
+func (l blockLayout) dataCodewords() int {
+	return l.blocks1*l.data1 + l.blocks2*(l.data1+1)
+}
+
+// Code is an encoded QR code.
+type Code struct {
+	size    int
+	modules [][]bool // true is dark, indexed by [y][x]
+}
+
+// Encode encodes the given content into the smallest QR code that can hold it.
+func Encode(content string) (*Code, error) {
+	if len(content) > MaxLength {
+		return nil, fmt.Errorf("qrcode: content is %d bytes long, but at most %d bytes can be encoded", len(content), MaxLength)
+	}
+
+	version := 0
+	for i, l := range layouts {
+		if bitsNeeded(i+1, len(content)) <= l.dataCodewords()*8 {
+			version = i + 1
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errors.New("qrcode: content is too long")
+	}
+
+	layout := layouts[version-1]
+	data := encodeData(content, version, layout.dataCodewords())
+	codewords := interleave(data, layout)
+
+	size := version*4 + 17
+	m := newMatrix(size)
+	m.drawFunctionPatterns(version)
+	m.drawCodewords(codewords)
+
+	// use the mask that makes the code easiest to scan
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(mask)
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		m.applyMask(mask) // masking twice undoes it
+	}
+	m.applyMask(best)
+	m.drawFormatBits(best)
+
+	return &Code{size: size, modules: m.modules}, nil
+}
+
+// Size returns the width and height of the code in modules, not counting the quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark returns whether the module at the given position is dark.
+func (c *Code) Dark(x int, y int) bool {
+	return c.modules[y][x]
+}
+
+// Image renders the code with each module scale pixels wide, surrounded by the quiet zone.
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	width := (c.size + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, width, width))
+	for y := 0; y < width; y++ {
+		for x := 0; x < width; x++ {
+			mx, my := x/scale-quietZone, y/scale-quietZone
+			if mx >= 0 && my >= 0 && mx < c.size && my < c.size && c.modules[my][mx] {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return img
+}
+
+// bitsNeeded returns how many bits the content takes up in byte mode, including the mode and length header.
+func bitsNeeded(version int, length int) int {
+	return 4 + countBits(version) + length*8
+}
+
+// countBits returns the width of the character count field in byte mode.
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData encodes the content in byte mode, then pads it out to the capacity of the version.
+func encodeData(content string, version int, capacity int) []byte {
+	var bits []bool
+	appendBits := func(value int, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, (value>>uint(i))&1 == 1)
+		}
+	}
+
+	appendBits(0x4, 4) // byte mode
+	appendBits(len(content), countBits(version))
+	for i := 0; i < len(content); i++ {
+		appendBits(int(content[i]), 8)
+	}
+
+	// terminator, then pad to a whole byte
+	capacityBits := capacity * 8
+	for i := 0; i < 4 && len(bits) < capacityBits; i++ {
+		bits = append(bits, false)
+	}
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+
+	data := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << uint(7-j)
+			}
+		}
+		data = append(data, b)
+	}
+
+	// fill the remaining capacity with the alternating pad bytes
+	for pad := byte(0xEC); len(data) < capacity; pad ^= 0xEC ^ 0x11 {
+		data = append(data, pad)
+	}
+	return data
+}
+
+// interleave splits the data into blocks, adds error correction to each block,
+// and interleaves the result in the order that the codewords are placed in.
+func interleave(data []byte, layout blockLayout) []byte {
+	generator := rsGenerator(layout.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < layout.blocks1+layout.blocks2; i++ {
+		n := layout.data1
+		if i >= layout.blocks1 {
+			n++
+		}
+		block := data[offset : offset+n]
+		offset += n
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, generator))
+	}
+
+	result := make([]byte, 0, len(data)+len(ecBlocks)*layout.ecPerBlock)
+	for i := 0; i <= layout.data1; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(2^8), modulo the QR code polynomial x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x byte, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsGenerator returns the coefficients of the Reed-Solomon generator polynomial of the given degree,
+// from the highest power down, leaving out the leading coefficient which is always 1.
+func rsGenerator(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords for the given data.
+func rsRemainder(data []byte, generator []byte) []byte {
+	result := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range generator {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
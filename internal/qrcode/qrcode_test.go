@@ -0,0 +1,102 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QRCodeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *QRCodeTestSuite) TestErrorCorrection() {
+	// the version 1-M example from annex I of ISO/IEC 18004, which encodes "01234567" in numeric mode
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	ec := rsRemainder(data, rsGenerator(10))
+	suite.Equal([]byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}, ec)
+}
+
+func (suite *QRCodeTestSuite) TestEncodeData() {
+	data := encodeData("hi", 1, 16)
+	suite.Equal([]byte{0x40, 0x26, 0x86, 0x90, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}, data)
+}
+
+func (suite *QRCodeTestSuite) TestEncodeVersions() {
+	for length, expectedSize := range map[int]int{
+		1:         21, // version 1
+		14:        21,
+		15:        25, // version 2
+		106:       41, // version 6
+		107:       45, // version 7, which adds version information
+		180:       53, // version 9
+		181:       57, // version 10, which has a wider length field
+		MaxLength: 57,
+	} {
+		code, err := Encode(strings.Repeat("a", length))
+		suite.NoError(err)
+		suite.Equal(expectedSize, code.Size(), "content length %d", length)
+	}
+}
+
+func (suite *QRCodeTestSuite) TestEncodeTooLong() {
+	code, err := Encode(strings.Repeat("a", MaxLength+1))
+	suite.Error(err)
+	suite.Nil(code)
+}
+
+func (suite *QRCodeTestSuite) TestEncodeFinderPatterns() {
+	code, err := Encode("otpauth://totp/GoToSocial:zork@example.org?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	suite.NoError(err)
+
+	size := code.Size()
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		for dy := 0; dy < 7; dy++ {
+			for dx := 0; dx < 7; dx++ {
+				ring := dx == 0 || dy == 0 || dx == 6 || dy == 6
+				centre := dx >= 2 && dx <= 4 && dy >= 2 && dy <= 4
+				suite.Equal(ring || centre, code.Dark(corner[0]+dx, corner[1]+dy))
+			}
+		}
+	}
+
+	// the dark module next to the bottom left finder is always there
+	suite.True(code.Dark(8, size-8))
+}
+
+func (suite *QRCodeTestSuite) TestImage() {
+	code, err := Encode("hello")
+	suite.NoError(err)
+
+	img := code.Image(4)
+	suite.Equal((21+2*quietZone)*4, img.Bounds().Dx())
+
+	// quiet zone is light, top left corner of the finder is dark
+	r, _, _, _ := img.At(0, 0).RGBA()
+	suite.EqualValues(0xffff, r)
+	r, _, _, _ = img.At(quietZone*4, quietZone*4).RGBA()
+	suite.EqualValues(0, r)
+}
+
+func TestQRCodeTestSuite(t *testing.T) {
+	suite.Run(t, new(QRCodeTestSuite))
+}
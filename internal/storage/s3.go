@@ -0,0 +1,351 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-store/storage"
+)
+
+const (
+	// S3ServeModeProxy serves media from s3 by proxying the bytes through GoToSocial.
+	S3ServeModeProxy = "proxy"
+	// S3ServeModeRedirect serves media from s3 by redirecting clients to the object's URL.
+	// This requires the bucket to be publicly readable.
+	S3ServeModeRedirect = "redirect"
+	// S3ServeModePresigned serves media from s3 by redirecting clients to a presigned URL
+	// for the object, which is valid for a limited time. The bucket can be private.
+	S3ServeModePresigned = "presigned"
+
+	// s3PresignExpiry is how long presigned URLs remain valid for.
+	s3PresignExpiry = 1 * time.Hour
+	// s3ResponseHeaderTimeout is how long to wait for the object store to start responding to a request.
+	s3ResponseHeaderTimeout = 30 * time.Second
+	// s3RequestTimeout is how long a whole request to the object store, including reading or writing
+	// the object, may take; this is generous so that big media files can still be transferred.
+	s3RequestTimeout = 5 * time.Minute
+)
+
+// S3Config contains the settings for connecting to an s3-compatible object store.
+type S3Config struct {
+	// Endpoint of the s3 api, as host[:port], eg "s3.example.org" or "localhost:9000"
+	Endpoint string
+	// Region of the bucket, eg "us-east-1"
+	Region string
+	// Bucket in which to store media
+	Bucket string
+	// AccessKey to use when signing requests
+	AccessKey string
+	// SecretKey to use when signing requests
+	SecretKey string
+	// UseSSL determines whether to talk https or plain http to the endpoint
+	UseSSL bool
+	// ServeMode determines how stored media is served to clients, one of the S3ServeMode* constants
+	ServeMode string
+	// Client is the http client to use for requests; if nil, a client with sensible timeouts is used
+	Client *http.Client
+}
+
+// S3 implements the go-store storage.Storage interface on top of an s3-compatible
+// object store, using path-style addressing so that it works with self-hosted
+// implementations like minio as well as with AWS itself.
+type S3 struct {
+	base      *url.URL
+	serveMode string
+	client    *http.Client
+	signer    *s3Signer
+}
+
+// newS3Client returns an http client for talking to the object store, with timeouts
+// set so that a hung endpoint can't block storage reads and writes forever.
+func newS3Client() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = s3ResponseHeaderTimeout
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   s3RequestTimeout,
+	}
+}
+
+// NewS3 returns a new s3 storage backend for the given config,
+// checking first that the configured bucket can be reached.
+func NewS3(cfg *S3Config) (*S3, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("no s3 endpoint set")
+	}
+
+	if cfg.Bucket == "" {
+		return nil, errors.New("no s3 bucket set")
+	}
+
+	serveMode := cfg.ServeMode
+	switch serveMode {
+	case "":
+		serveMode = S3ServeModeProxy
+	case S3ServeModeProxy, S3ServeModeRedirect, S3ServeModePresigned:
+	default:
+		return nil, fmt.Errorf("s3 serve mode %s not recognized", serveMode)
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	scheme := "http"
+	if cfg.UseSSL {
+		scheme = "https"
+	}
+
+	client := cfg.Client
+	if client == nil {
+		client = newS3Client()
+	}
+
+	s3 := &S3{
+		base: &url.URL{
+			Scheme: scheme,
+			Host:   cfg.Endpoint,
+			Path:   "/" + cfg.Bucket,
+		},
+		serveMode: serveMode,
+		client:    client,
+		signer: &s3Signer{
+			accessKey: cfg.AccessKey,
+			secretKey: cfg.SecretKey,
+			region:    region,
+		},
+	}
+
+	resp, err := s3.do(http.MethodHead, "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reaching bucket %s: %s", cfg.Bucket, err)
+	}
+	resp.Body.Close()
+
+	return s3, nil
+}
+
+// ReadBytes implements storage.Storage.
+func (s *S3) ReadBytes(key string) ([]byte, error) {
+	rc, err := s.ReadStream(key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// ReadStream implements storage.Storage.
+func (s *S3) ReadStream(key string) (io.ReadCloser, error) {
+	resp, err := s.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// WriteBytes implements storage.Storage.
+func (s *S3) WriteBytes(key string, value []byte) error {
+	resp, err := s.do(http.MethodPut, key, nil, value)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// WriteStream implements storage.Storage.
+//
+// S3 requires the length of an object to be known before it is uploaded,
+// so the reader is buffered in memory first. Media is limited in size by
+// the media config, so this is fine.
+func (s *S3) WriteStream(key string, r io.Reader) error {
+	value, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return s.WriteBytes(key, value)
+}
+
+// Stat implements storage.Storage.
+func (s *S3) Stat(key string) (bool, error) {
+	resp, err := s.do(http.MethodHead, key, nil, nil)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// Remove implements storage.Storage.
+//
+// Note that s3 does not report whether the object existed in the first
+// place, so unlike the disk backend, removing a missing key is not an error.
+func (s *S3) Remove(key string) error {
+	resp, err := s.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Close implements storage.Storage. There's nothing to release for s3.
+func (s *S3) Close() error {
+	return nil
+}
+
+// Clean implements storage.Storage. There's nothing to clean up for s3,
+// since there are no directories left behind when objects are removed.
+func (s *S3) Clean() error {
+	return nil
+}
+
+// WalkKeys implements storage.Storage, paging through the bucket listing.
+func (s *S3) WalkKeys(opts storage.WalkKeysOptions) error {
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		resp, err := s.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return err
+		}
+
+		result := &s3ListBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error decoding bucket listing: %s", err)
+		}
+
+		for _, c := range result.Contents {
+			opts.WalkFn(s3Entry(c.Key))
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// URL implements URLer, returning a URL for the object at key according to the configured serve mode.
+func (s *S3) URL(ctx context.Context, key string) *url.URL {
+	switch s.serveMode {
+	case S3ServeModeRedirect:
+		return s.objectURL(key)
+	case S3ServeModePresigned:
+		return s.signer.presign(s.objectURL(key), s3PresignExpiry, time.Now())
+	default:
+		return nil
+	}
+}
+
+// objectURL returns the path-style URL of the object at key, or of the bucket itself if key is empty.
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.base
+	if key != "" {
+		u.Path = u.Path + "/" + key
+	}
+	u.RawPath = s3Escape(u.Path, true)
+	return &u
+}
+
+// do performs a signed request against the object at key (or the bucket, if key is empty),
+// returning the response if it was successful. On a 404, storage.ErrNotFound is returned;
+// on any other unsuccessful status code, an error is built from the s3 error response.
+func (s *S3) do(method string, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := s.objectURL(key)
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if method == http.MethodPut {
+		// Set the content type so that clients which are redirected
+		// to the object get served something sensible by the store.
+		req.Header.Set("Content-Type", http.DetectContentType(body))
+	}
+
+	s.signer.sign(req, s3PayloadHash(body), time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && key != "" {
+		return nil, storage.ErrNotFound
+	}
+
+	s3Err := &s3Error{}
+	if err := xml.NewDecoder(resp.Body).Decode(s3Err); err != nil || s3Err.Code == "" {
+		return nil, fmt.Errorf("%s %s: %s", method, u.Path, resp.Status)
+	}
+	return nil, fmt.Errorf("%s %s: %s: %s (%s)", method, u.Path, resp.Status, s3Err.Code, strings.TrimSpace(s3Err.Message))
+}
+
+// s3Entry implements storage.StorageEntry for s3 object keys.
+type s3Entry string
+
+func (e s3Entry) Key() string {
+	return string(e)
+}
+
+// s3Error is the body of an unsuccessful s3 response.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// s3ListBucketResult is the body of a ListObjectsV2 response.
+type s3ListBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
@@ -0,0 +1,212 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/gruf/go-store/storage"
+	"github.com/stretchr/testify/suite"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type S3TestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	host   string
+	s3     *gtsstorage.S3
+}
+
+func (suite *S3TestSuite) SetupTest() {
+	suite.server = testrig.NewTestS3Server("gotosocial")
+	suite.host = strings.TrimPrefix(suite.server.URL, "http://")
+	suite.s3 = suite.newS3(gtsstorage.S3ServeModeProxy)
+}
+
+func (suite *S3TestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *S3TestSuite) newS3(serveMode string) *gtsstorage.S3 {
+	s3, err := gtsstorage.NewS3(&gtsstorage.S3Config{
+		Endpoint:  suite.host,
+		Bucket:    "gotosocial",
+		AccessKey: "access",
+		SecretKey: "secret",
+		ServeMode: serveMode,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return s3
+}
+
+func (suite *S3TestSuite) TestNewS3NoSuchBucket() {
+	s3, err := gtsstorage.NewS3(&gtsstorage.S3Config{
+		Endpoint: suite.host,
+		Bucket:   "some-other-bucket",
+	})
+	suite.Nil(s3)
+	suite.EqualError(err, "error reaching bucket some-other-bucket: HEAD /some-other-bucket: 404 Not Found")
+}
+
+func (suite *S3TestSuite) TestNewS3BadServeMode() {
+	s3, err := gtsstorage.NewS3(&gtsstorage.S3Config{
+		Endpoint:  suite.host,
+		Bucket:    "gotosocial",
+		ServeMode: "teleport",
+	})
+	suite.Nil(s3)
+	suite.EqualError(err, "s3 serve mode teleport not recognized")
+}
+
+func (suite *S3TestSuite) TestWriteRead() {
+	suite.NoError(suite.s3.WriteStream("some/key.txt", strings.NewReader("hello world")))
+
+	b, err := suite.s3.ReadBytes("some/key.txt")
+	suite.NoError(err)
+	suite.Equal("hello world", string(b))
+
+	rc, err := suite.s3.ReadStream("some/key.txt")
+	suite.NoError(err)
+	b, err = io.ReadAll(rc)
+	suite.NoError(err)
+	suite.NoError(rc.Close())
+	suite.Equal("hello world", string(b))
+}
+
+func (suite *S3TestSuite) TestReadNotFound() {
+	b, err := suite.s3.ReadBytes("not/a/key.txt")
+	suite.Nil(b)
+	suite.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (suite *S3TestSuite) TestReadTimeout() {
+	// an object store that can reach the bucket but then never answers reads
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	s3, err := gtsstorage.NewS3(&gtsstorage.S3Config{
+		Endpoint: strings.TrimPrefix(server.URL, "http://"),
+		Bucket:   "gotosocial",
+		Client:   &http.Client{Timeout: 100 * time.Millisecond},
+	})
+	suite.NoError(err)
+
+	b, err := s3.ReadBytes("some/key.txt")
+	suite.Nil(b)
+	suite.Error(err)
+}
+
+func (suite *S3TestSuite) TestStatRemove() {
+	suite.NoError(suite.s3.WriteBytes("some/key.txt", []byte("hello world")))
+
+	exists, err := suite.s3.Stat("some/key.txt")
+	suite.NoError(err)
+	suite.True(exists)
+
+	suite.NoError(suite.s3.Remove("some/key.txt"))
+
+	exists, err = suite.s3.Stat("some/key.txt")
+	suite.NoError(err)
+	suite.False(exists)
+}
+
+func (suite *S3TestSuite) TestWalkKeys() {
+	keys := []string{"a/1.jpeg", "a/2.jpeg", "b/1.png", "b/2.png", "c/1.gif"}
+	for _, k := range keys {
+		suite.NoError(suite.s3.WriteBytes(k, []byte(k)))
+	}
+
+	walked := []string{}
+	err := suite.s3.WalkKeys(storage.WalkKeysOptions{
+		WalkFn: func(entry storage.StorageEntry) {
+			walked = append(walked, entry.Key())
+		},
+	})
+	suite.NoError(err)
+
+	sort.Strings(walked)
+	suite.Equal(keys, walked)
+}
+
+func (suite *S3TestSuite) TestDriver() {
+	driver, err := gtsstorage.NewDriver(suite.s3)
+	suite.NoError(err)
+
+	suite.NoError(driver.Put("some/key.txt", []byte("hello world")))
+	b, err := driver.Get("some/key.txt")
+	suite.NoError(err)
+	suite.Equal("hello world", string(b))
+
+	// proxy mode, so no url should be given out
+	suite.Nil(driver.URL(context.Background(), "some/key.txt"))
+}
+
+func (suite *S3TestSuite) TestURLRedirect() {
+	s3 := suite.newS3(gtsstorage.S3ServeModeRedirect)
+
+	u := s3.URL(context.Background(), "some/key.txt")
+	suite.Equal("http://"+suite.host+"/gotosocial/some/key.txt", u.String())
+}
+
+func (suite *S3TestSuite) TestURLPresigned() {
+	s3 := suite.newS3(gtsstorage.S3ServeModePresigned)
+	suite.NoError(s3.WriteBytes("some/key.txt", []byte("hello world")))
+
+	u := s3.URL(context.Background(), "some/key.txt")
+	suite.Equal("/gotosocial/some/key.txt", u.Path)
+	suite.Equal("AWS4-HMAC-SHA256", u.Query().Get("X-Amz-Algorithm"))
+	suite.Equal("3600", u.Query().Get("X-Amz-Expires"))
+	suite.Equal("host", u.Query().Get("X-Amz-SignedHeaders"))
+	suite.NotEmpty(u.Query().Get("X-Amz-Signature"))
+
+	// the presigned url should be usable without any further credentials
+	resp, err := http.Get(u.String())
+	suite.NoError(err)
+	defer resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+	b, err := io.ReadAll(resp.Body)
+	suite.NoError(err)
+	suite.Equal("hello world", string(b))
+}
+
+func TestS3TestSuite(t *testing.T) {
+	suite.Run(t, new(S3TestSuite))
+}
@@ -0,0 +1,195 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3DateFormat       = "20060102T150405Z"
+	s3ShortDateFormat  = "20060102"
+)
+
+// s3Signer signs requests to s3 using AWS signature version 4.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+type s3Signer struct {
+	accessKey string
+	secretKey string
+	region    string
+}
+
+// sign adds the x-amz-date, x-amz-content-sha256 and Authorization headers to req.
+// All headers already set on req are included in the signature, along with the host.
+func (s *s3Signer) sign(req *http.Request, payloadHash string, t time.Time) {
+	t = t.UTC()
+	req.Header.Set("X-Amz-Date", t.Format(s3DateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	canonicalHeaders, signedHeaders := s3CanonicalHeaders(headers)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3Escape(req.URL.Path, true),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(t)
+	signature := s.signature(t, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, s.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// presign returns a copy of u which can be used to GET the object for the given expiry duration,
+// without any further credentials, by putting the signature in the query string.
+func (s *s3Signer) presign(u *url.URL, expiry time.Duration, t time.Time) *url.URL {
+	t = t.UTC()
+	scope := s.scope(t)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", s3SigningAlgorithm)
+	query.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", t.Format(s3DateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalHeaders, signedHeaders := s3CanonicalHeaders(map[string]string{"host": u.Host})
+	canonicalQuery := s3CanonicalQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		s3Escape(u.Path, true),
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	presigned := *u
+	presigned.RawQuery = canonicalQuery + "&X-Amz-Signature=" + s.signature(t, scope, canonicalRequest)
+	return &presigned
+}
+
+// scope returns the credential scope for requests signed at time t.
+func (s *s3Signer) scope(t time.Time) string {
+	return t.Format(s3ShortDateFormat) + "/" + s.region + "/s3/aws4_request"
+}
+
+// signature derives the signing key for time t, and uses it to sign the given canonical request.
+func (s *s3Signer) signature(t time.Time, scope string, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		t.Format(s3DateFormat),
+		scope,
+		s3PayloadHash([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.secretKey), t.Format(s3ShortDateFormat))
+	key = s3HMAC(key, s.region)
+	key = s3HMAC(key, "s3")
+	key = s3HMAC(key, "aws4_request")
+
+	return hex.EncodeToString(s3HMAC(key, stringToSign))
+}
+
+// s3CanonicalHeaders returns the canonical headers string and the
+// signed headers list for the given lowercased header names and values.
+func s3CanonicalHeaders(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonical := strings.Builder{}
+	for _, name := range names {
+		canonical.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// s3CanonicalQuery returns the given query values escaped and sorted by key, as required for signing.
+func s3CanonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for k, vs := range query {
+		for _, v := range vs {
+			pairs = append(pairs, s3Escape(k, false)+"="+s3Escape(v, false))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes s the way AWS expects: every byte except the unreserved
+// characters A-Z, a-z, 0-9, '-', '.', '_' and '~' is encoded, and '/' is kept as-is
+// only if keepSlash is true.
+func s3Escape(s string, keepSlash bool) string {
+	const hexChars = "0123456789ABCDEF"
+
+	escaped := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~',
+			c == '/' && keepSlash:
+			escaped.WriteByte(c)
+		default:
+			escaped.WriteByte('%')
+			escaped.WriteByte(hexChars[c>>4])
+			escaped.WriteByte(hexChars[c&0xF])
+		}
+	}
+	return escaped.String()
+}
+
+// s3PayloadHash returns the hex-encoded sha256 hash of payload.
+func s3PayloadHash(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"codeberg.org/gruf/go-store/kv"
+	"codeberg.org/gruf/go-store/storage"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	// BackendLocal stores media on the local filesystem.
+	BackendLocal = "local"
+	// BackendS3 stores media in an S3-compatible object store.
+	BackendS3 = "s3"
+)
+
+// URLer is implemented by storage backends which are able to give out
+// a URL at which a stored value can be fetched directly by a client,
+// without GoToSocial having to proxy the bytes itself.
+type URLer interface {
+	// URL returns a URL for the given key, or nil if the
+	// value should be proxied through GoToSocial instead.
+	URL(ctx context.Context, key string) *url.URL
+}
+
+// Driver wraps a key-value store over the configured storage backend.
+// The embedded KVStore provides access to stored values; the Storage
+// field gives access to the underlying backend for backend-specific
+// functionality, such as serving values directly from object storage.
+type Driver struct {
+	*kv.KVStore
+	Storage storage.Storage
+}
+
+// NewDriver returns a new Driver wrapping the given storage backend.
+func NewDriver(st storage.Storage) (*Driver, error) {
+	kvs, err := kv.OpenStorage(st)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Driver{
+		KVStore: kvs,
+		Storage: st,
+	}, nil
+}
+
+// URL returns a URL at which the value stored under key can be fetched
+// directly, if the underlying backend supports this and is configured to
+// do so. If nil is returned, the value should be served from the KVStore.
+func (d *Driver) URL(ctx context.Context, key string) *url.URL {
+	urler, ok := d.Storage.(URLer)
+	if !ok {
+		return nil
+	}
+	return urler.URL(ctx, key)
+}
+
+// AutoConfig opens the storage backend specified in the config.
+func AutoConfig() (*Driver, error) {
+	switch backend := viper.GetString(config.Keys.StorageBackend); backend {
+	case BackendLocal:
+		basePath := viper.GetString(config.Keys.StorageLocalBasePath)
+		disk, err := storage.OpenFile(basePath, &storage.DiskConfig{
+			// Put the store lockfile in the storage dir itself.
+			// Normally this would not be safe, since we could end up
+			// overwriting the lockfile if we store a file called 'store.lock'.
+			// However, in this case it's OK because the keys are set by
+			// GtS and not the user, so we know we're never going to overwrite it.
+			LockFile: path.Join(basePath, "store.lock"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error opening local storage: %s", err)
+		}
+		return NewDriver(disk)
+	case BackendS3:
+		s3, err := NewS3(&S3Config{
+			Endpoint:  viper.GetString(config.Keys.StorageS3Endpoint),
+			Region:    viper.GetString(config.Keys.StorageS3Region),
+			Bucket:    viper.GetString(config.Keys.StorageS3Bucket),
+			AccessKey: viper.GetString(config.Keys.StorageS3AccessKey),
+			SecretKey: viper.GetString(config.Keys.StorageS3SecretKey),
+			UseSSL:    viper.GetBool(config.Keys.StorageS3UseSSL),
+			ServeMode: viper.GetString(config.Keys.StorageS3ServeMode),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error opening s3 storage: %s", err)
+		}
+		return NewDriver(s3)
+	default:
+		return nil, fmt.Errorf("storage backend %s not recognized", backend)
+	}
+}
@@ -1,6 +1,9 @@
 package stream
 
-import "sync"
+import (
+	"strings"
+	"sync"
+)
 
 const (
 	// EventTypeNotification -- a user should be shown a notification
@@ -9,6 +12,12 @@ const (
 	EventTypeUpdate string = "update"
 	// EventTypeDelete -- something should be deleted from a user
 	EventTypeDelete string = "delete"
+	// EventTypeStatusUpdate -- something in the user's timeline has been edited
+	EventTypeStatusUpdate string = "status.update"
+	// EventTypeConversation -- a direct message conversation has been created or updated
+	EventTypeConversation string = "conversation"
+	// EventTypeMarker -- the user's position in one of their timelines has been updated
+	EventTypeMarker string = "marker"
 )
 
 const (
@@ -22,6 +31,18 @@ const (
 	TimelineNotifications string = "user:notification"
 	// TimelineDirect -- statuses sent to a user directly.
 	TimelineDirect string = "direct"
+	// TimelineList -- statuses for a user's list timeline.
+	//
+	// Streams for a particular list use ListTimeline to include the list ID.
+	TimelineList string = "list"
+	// TimelineHashtag -- public statuses that use a hashtag.
+	//
+	// Streams for a particular hashtag use HashtagTimeline to include the hashtag.
+	TimelineHashtag string = "hashtag"
+	// TimelineHashtagLocal -- public statuses from the LOCAL timeline that use a hashtag.
+	//
+	// Streams for a particular hashtag use LocalHashtagTimeline to include the hashtag.
+	TimelineHashtagLocal string = "hashtag:local"
 )
 
 // AllStatusTimelines contains all Timelines that a status could conceivably be delivered to -- useful for doing deletes.
@@ -30,6 +51,57 @@ var AllStatusTimelines = []string{
 	TimelinePublic,
 	TimelineHome,
 	TimelineDirect,
+	TimelineList,
+	TimelineHashtag,
+}
+
+// ListTimeline returns the timeline of a stream for the list with the given ID.
+func ListTimeline(listID string) string {
+	return TimelineList + ":" + listID
+}
+
+// HashtagTimeline returns the timeline of a stream for the given hashtag.
+func HashtagTimeline(tagName string) string {
+	return TimelineHashtag + ":" + strings.ToLower(tagName)
+}
+
+// LocalHashtagTimeline returns the timeline of a stream for local statuses that use the given hashtag.
+func LocalHashtagTimeline(tagName string) string {
+	return TimelineHashtagLocal + ":" + strings.ToLower(tagName)
+}
+
+// Matches returns true if a message sent to the given timeline should be put in a stream of the given streamTimeline.
+//
+// Messages sent to TimelineList go to the streams of all lists, and messages
+// sent to TimelineHashtag go to the streams of all hashtags, local or not.
+func Matches(streamTimeline string, timeline string) bool {
+	if streamTimeline == timeline {
+		return true
+	}
+	switch timeline {
+	case TimelineList:
+		return strings.HasPrefix(streamTimeline, TimelineList+":")
+	case TimelineHashtag:
+		return strings.HasPrefix(streamTimeline, TimelineHashtag+":")
+	}
+	return false
+}
+
+// StreamNames returns the stream names that a message sent to the given stream timeline
+// should be labelled with. List streams are labelled with the list type and the list ID,
+// and hashtag streams with the hashtag type and the hashtag.
+func StreamNames(streamTimeline string) []string {
+	if listID := strings.TrimPrefix(streamTimeline, TimelineList+":"); listID != streamTimeline {
+		return []string{TimelineList, listID}
+	}
+	// check for local hashtag streams first, since they share a prefix with hashtag streams
+	if tagName := strings.TrimPrefix(streamTimeline, TimelineHashtagLocal+":"); tagName != streamTimeline {
+		return []string{TimelineHashtagLocal, tagName}
+	}
+	if tagName := strings.TrimPrefix(streamTimeline, TimelineHashtag+":"); tagName != streamTimeline {
+		return []string{TimelineHashtag, tagName}
+	}
+	return []string{streamTimeline}
 }
 
 // StreamsForAccount is a wrapper for the multiple streams that one account can have running at the same time.
@@ -47,6 +119,8 @@ type Stream struct {
 	ID string
 	// Timeline of this stream: user/public/etc
 	Timeline string
+	// Access token that the stream was opened with, so that the stream can be closed when the token is revoked
+	AccessToken string
 	// Channel of messages for the client to read from
 	Messages chan *Message
 	// Channel to close when the client drops away
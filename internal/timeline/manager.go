@@ -74,8 +74,12 @@ type Manager interface {
 	Remove(ctx context.Context, timelineAccountID string, itemID string) (int, error)
 	// WipeItemFromAllTimelines removes one item from the index and prepared items of all timelines
 	WipeItemFromAllTimelines(ctx context.Context, itemID string) error
+	// RefreshItemInAllTimelines re-prepares one item, and any boosts of it, in the prepared items of all timelines
+	RefreshItemInAllTimelines(ctx context.Context, itemID string) error
 	// WipeStatusesFromAccountID removes all items by the given accountID from the timelineAccountID's timelines.
 	WipeItemsFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error
+	// UnloadTimeline removes the timeline of the given timelineAccountID from memory, so that it will be rebuilt from the database next time it's used.
+	UnloadTimeline(ctx context.Context, timelineAccountID string)
 }
 
 // NewManager returns a new timeline manager.
@@ -217,6 +221,29 @@ func (m *manager) WipeItemFromAllTimelines(ctx context.Context, statusID string)
 	return err
 }
 
+func (m *manager) RefreshItemInAllTimelines(ctx context.Context, itemID string) error {
+	errors := []string{}
+	m.accountTimelines.Range(func(k interface{}, i interface{}) bool {
+		t, ok := i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+
+		if _, err := t.Refresh(ctx, itemID); err != nil {
+			errors = append(errors, err.Error())
+		}
+
+		return true
+	})
+
+	var err error
+	if len(errors) > 0 {
+		err = fmt.Errorf("one or more errors refreshing item %s in all timelines: %s", itemID, strings.Join(errors, ";"))
+	}
+
+	return err
+}
+
 func (m *manager) WipeItemsFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error {
 	t, err := m.getOrCreateTimeline(ctx, timelineAccountID)
 	if err != nil {
@@ -227,6 +254,10 @@ func (m *manager) WipeItemsFromAccountID(ctx context.Context, timelineAccountID
 	return err
 }
 
+func (m *manager) UnloadTimeline(ctx context.Context, timelineAccountID string) {
+	m.accountTimelines.Delete(timelineAccountID)
+}
+
 func (m *manager) getOrCreateTimeline(ctx context.Context, timelineAccountID string) (Timeline, error) {
 	var t Timeline
 	i, ok := m.accountTimelines.Load(timelineAccountID)
@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (t *timeline) Refresh(ctx context.Context, itemID string) (int, error) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":            "Refresh",
+		"accountTimeline": t.accountID,
+		"itemID":          itemID,
+	})
+	t.Lock()
+	defer t.Unlock()
+	var refreshed int
+
+	if t.preparedItems == nil || t.preparedItems.data == nil {
+		// nothing prepared yet so nothing to refresh
+		return refreshed, nil
+	}
+
+	// re-prepare entr(ies) for the item, or for boosts of the item
+	for e := t.preparedItems.data.Front(); e != nil; e = e.Next() {
+		entry, ok := e.Value.(*preparedItemsEntry)
+		if !ok {
+			return refreshed, errors.New("Refresh: could not parse e as a preparedItemsEntry")
+		}
+
+		if entry.itemID != itemID && entry.boostOfID != itemID {
+			continue
+		}
+
+		l.Debug("found item in preparedItems")
+		prepared, err := t.prepareFunction(ctx, t.accountID, entry.itemID)
+		if err != nil {
+			return refreshed, err
+		}
+		entry.prepared = prepared
+		refreshed++
+	}
+
+	l.Debugf("refreshed %d entries", refreshed)
+	return refreshed, nil
+}
@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RefreshTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *RefreshTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *RefreshTestSuite) SetupTest() {
+	testrig.InitTestLog()
+	testrig.InitTestConfig()
+
+	suite.db = testrig.NewTestDB()
+	suite.tc = testrig.NewTestTypeConverter(suite.db)
+	suite.filter = visibility.NewFilter(suite.db)
+
+	testrig.StandardDBSetup(suite.db, nil)
+
+	// let's take local_account_1 as the timeline owner
+	tl, err := timeline.NewTimeline(
+		context.Background(),
+		suite.testAccounts["local_account_1"].ID,
+		processing.StatusGrabFunction(suite.db),
+		processing.StatusFilterFunction(suite.db, suite.filter),
+		processing.StatusPrepareFunction(suite.db, suite.tc),
+		processing.StatusSkipInsertFunction(),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// prepare the timeline by just shoving all test statuses in it -- let's not be fussy about who sees what
+	for _, s := range suite.testStatuses {
+		_, err := tl.IndexAndPrepareOne(context.Background(), s.GetID(), s.BoostOfID, s.AccountID, s.BoostOfAccountID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suite.timeline = tl
+}
+
+func (suite *RefreshTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *RefreshTestSuite) TestRefreshEditedStatus() {
+	ctx := context.Background()
+
+	// edit a status in the database
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	status.Content = "hello everyone! this has been edited"
+	status.EditedAt = time.Now()
+	if err := suite.db.UpdateStatus(ctx, status, nil); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	refreshed, err := suite.timeline.Refresh(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, refreshed)
+
+	// the prepared status should now be the edited version
+	statuses, err := suite.timeline.Get(ctx, 20, "", "", "", false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var found bool
+	for _, s := range statuses {
+		if s.GetID() != status.ID {
+			continue
+		}
+		found = true
+		apiStatus, ok := s.(*apimodel.Status)
+		if !ok {
+			suite.FailNow("prepared item was not an *apimodel.Status")
+		}
+		suite.Equal("hello everyone! this has been edited", apiStatus.Content)
+		suite.NotEmpty(apiStatus.EditedAt)
+	}
+	suite.True(found)
+}
+
+func (suite *RefreshTestSuite) TestRefreshNotInTimeline() {
+	refreshed, err := suite.timeline.Refresh(context.Background(), "01G0CH2ZD5RMWQ3VPTNY5V8S9Y")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(0, refreshed)
+}
+
+func TestRefreshTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTestSuite))
+}
@@ -149,6 +149,11 @@ type Timeline interface {
 	//
 	// The returned int indicates the amount of entries that were removed.
 	Remove(ctx context.Context, itemID string) (int, error)
+	// Refresh re-prepares any prepared entries for the given item ID, or for boosts of it,
+	// so that the next Get returns the latest version of the item.
+	//
+	// The returned int indicates the amount of entries that were refreshed.
+	Refresh(ctx context.Context, itemID string) (int, error)
 	// RemoveAllBy removes all items by the given accountID, from both the index and prepared items.
 	//
 	// The returned int indicates the amount of entries that were removed.
@@ -0,0 +1,151 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package totp implements the time-based one-time passwords described in RFC 6238,
+// as used by authenticator apps for two-factor authentication.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- RFC 6238 defaults to HMAC-SHA1, and it's the only algorithm most authenticator apps support
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds that each code is valid for.
+	Period = 30
+	// Digits is the number of digits in each code.
+	Digits = 6
+
+	// skew is the number of periods either side of the current one whose codes are still accepted, to allow for clock drift.
+	skew = 1
+	// secretLength is the number of random bytes in a secret, as recommended by RFC 4226 for HMAC-SHA1.
+	secretLength = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret returns a new random secret, encoded as unpadded base32 so that it can be typed into an authenticator app.
+func NewSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("NewSecret: error reading random bytes: %s", err)
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth:// key URI for the given secret, which authenticator apps read from a QR code.
+// The issuer is shown in the app next to the account name, so users can tell their accounts apart.
+func URI(issuer string, accountName string, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(Period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// Code returns the code for the given secret at time t.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, step(t)), nil
+}
+
+// Validate checks whether the given code is valid for the secret at time t, allowing for a
+// little clock drift between the server and the authenticator app.
+//
+// Codes can only be used once, so lastStep should be the step returned by the previous successful
+// validation for this secret, or 0 if there wasn't one. Codes from that step or earlier are rejected.
+// If the code is valid, the step it belongs to is returned along with true.
+func Validate(secret string, c string, t time.Time, lastStep int64) (int64, bool, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false, err
+	}
+
+	c = strings.ReplaceAll(strings.TrimSpace(c), " ", "")
+	if len(c) != Digits {
+		return 0, false, nil
+	}
+
+	current := step(t)
+	for s := current - skew; s <= current+skew; s++ {
+		if s <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(code(key, s)), []byte(c)) == 1 {
+			return s, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// step returns the number of periods since the unix epoch at time t.
+func step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// code computes the HOTP value from RFC 4226 for the given key and counter.
+func code(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.New("totp: secret was empty")
+	}
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, fmt.Errorf("totp: error decoding secret: %s", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,146 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+// rfcSecret is the ASCII secret "12345678901234567890" from the test vectors in RFC 6238, encoded as base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type TOTPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TOTPTestSuite) TestCodeRFCVectors() {
+	// the last six digits of the SHA1 test vectors in appendix B of RFC 6238
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+		suite.NoError(err)
+		suite.Equal(expected, code, "unix time %d", unix)
+	}
+}
+
+func (suite *TOTPTestSuite) TestNewSecret() {
+	secret1, err := totp.NewSecret()
+	suite.NoError(err)
+	suite.Len(secret1, 32)
+
+	secret2, err := totp.NewSecret()
+	suite.NoError(err)
+	suite.NotEqual(secret1, secret2)
+
+	_, err = totp.Code(secret1, time.Now())
+	suite.NoError(err)
+}
+
+func (suite *TOTPTestSuite) TestValidate() {
+	now := time.Unix(1234567890, 0)
+
+	step, ok, err := totp.Validate(rfcSecret, "005924", now, 0)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.EqualValues(1234567890/totp.Period, step)
+
+	// spaces are ignored
+	_, ok, err = totp.Validate(rfcSecret, "005 924", now, 0)
+	suite.NoError(err)
+	suite.True(ok)
+
+	// the code can't be used again once its step has been used
+	_, ok, err = totp.Validate(rfcSecret, "005924", now, step)
+	suite.NoError(err)
+	suite.False(ok)
+
+	// wrong code
+	_, ok, err = totp.Validate(rfcSecret, "123456", now, 0)
+	suite.NoError(err)
+	suite.False(ok)
+
+	// not enough digits
+	_, ok, err = totp.Validate(rfcSecret, "5924", now, 0)
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *TOTPTestSuite) TestValidateClockDrift() {
+	now := time.Unix(1234567890, 0)
+
+	previous, err := totp.Code(rfcSecret, now.Add(-totp.Period*time.Second))
+	suite.NoError(err)
+	_, ok, err := totp.Validate(rfcSecret, previous, now, 0)
+	suite.NoError(err)
+	suite.True(ok)
+
+	next, err := totp.Code(rfcSecret, now.Add(totp.Period*time.Second))
+	suite.NoError(err)
+	_, ok, err = totp.Validate(rfcSecret, next, now, 0)
+	suite.NoError(err)
+	suite.True(ok)
+
+	tooOld, err := totp.Code(rfcSecret, now.Add(-3*totp.Period*time.Second))
+	suite.NoError(err)
+	_, ok, err = totp.Validate(rfcSecret, tooOld, now, 0)
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *TOTPTestSuite) TestValidateBadSecret() {
+	_, ok, err := totp.Validate("not base32!", "123456", time.Now(), 0)
+	suite.Error(err)
+	suite.False(ok)
+
+	_, ok, err = totp.Validate("", "123456", time.Now(), 0)
+	suite.Error(err)
+	suite.False(ok)
+}
+
+func (suite *TOTPTestSuite) TestURI() {
+	uri := totp.URI("GoToSocial localhost:8080", "zork@example.org", rfcSecret)
+
+	u, err := url.Parse(uri)
+	suite.NoError(err)
+	suite.Equal("otpauth", u.Scheme)
+	suite.Equal("totp", u.Host)
+	suite.Equal("/GoToSocial localhost:8080:zork@example.org", u.Path)
+	suite.Equal(rfcSecret, u.Query().Get("secret"))
+	suite.Equal("GoToSocial localhost:8080", u.Query().Get("issuer"))
+	suite.Equal("SHA1", u.Query().Get("algorithm"))
+	suite.Equal("6", u.Query().Get("digits"))
+	suite.Equal("30", u.Query().Get("period"))
+}
+
+func TestTOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(TOTPTestSuite))
+}
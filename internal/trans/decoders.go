@@ -79,6 +79,15 @@ func (i *importer) blockDecode(e transmodel.Entry) (*transmodel.Block, error) {
 	return b, nil
 }
 
+func (i *importer) domainAllowDecode(e transmodel.Entry) (*transmodel.DomainAllow, error) {
+	a := &transmodel.DomainAllow{}
+	if err := i.simpleDecode(e, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
 func (i *importer) domainBlockDecode(e transmodel.Entry) (*transmodel.DomainBlock, error) {
 	b := &transmodel.DomainBlock{}
 	if err := i.simpleDecode(e, b); err != nil {
@@ -102,6 +102,23 @@ func (e *exporter) exportDomainBlocks(ctx context.Context, file *os.File) ([]*tr
 	return domainBlocks, nil
 }
 
+func (e *exporter) exportDomainAllows(ctx context.Context, file *os.File) ([]*transmodel.DomainAllow, error) {
+	domainAllows := []*transmodel.DomainAllow{}
+
+	if err := e.db.GetAll(ctx, &domainAllows); err != nil {
+		return nil, fmt.Errorf("exportDomainAllows: error selecting domain allows: %s", err)
+	}
+
+	for _, a := range domainAllows {
+		a.Type = transmodel.TransDomainAllow
+		if err := e.simpleEncode(ctx, file, a, a.ID); err != nil {
+			return nil, fmt.Errorf("exportDomainAllows: error encoding domain allow: %s", err)
+		}
+	}
+
+	return domainAllows, nil
+}
+
 func (e *exporter) exportFollows(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.Follow, error) {
 	followsUnique := make(map[string]*transmodel.Follow)
 
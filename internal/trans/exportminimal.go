@@ -126,6 +126,11 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		return fmt.Errorf("ExportMinimal: error exporting domain blocks: %s", err)
 	}
 
+	// export all domain allows
+	if _, err := e.exportDomainAllows(ctx, file); err != nil {
+		return fmt.Errorf("ExportMinimal: error exporting domain allows: %s", err)
+	}
+
 	// export all users
 	if _, err := e.exportUsers(ctx, file); err != nil {
 		return fmt.Errorf("ExportMinimal: error exporting users: %s", err)
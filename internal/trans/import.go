@@ -87,6 +87,16 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		}
 		logrus.Infof("inputEntry: added block with id %s", block.ID)
 		return nil
+	case transmodel.TransDomainAllow:
+		allow, err := i.domainAllowDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputEntry: error decoding entry into domain allow: %s", err)
+		}
+		if err := i.putInDB(ctx, allow); err != nil {
+			return fmt.Errorf("inputEntry: error adding domain allow to database: %s", err)
+		}
+		logrus.Infof("inputEntry: added domain allow with id %s", allow.ID)
+		return nil
 	case transmodel.TransDomainBlock:
 		block, err := i.domainBlockDecode(entry)
 		if err != nil {
@@ -84,6 +84,12 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	err = newDB.GetAll(ctx, &domainBlocks)
 	suite.NoError(err)
 	suite.NotEmpty(domainBlocks)
+
+	// and some domain allows
+	domainAllows := []*gtsmodel.DomainAllow{}
+	err = newDB.GetAll(ctx, &domainAllows)
+	suite.NoError(err)
+	suite.NotEmpty(domainAllows)
 }
 
 func TestImportMinimalTestSuite(t *testing.T) {
@@ -28,6 +28,7 @@ type Type string
 const (
 	TransAccount          Type = "account"
 	TransBlock            Type = "block"
+	TransDomainAllow      Type = "domainAllow"
 	TransDomainBlock      Type = "domainBlock"
 	TransEmailDomainBlock Type = "emailDomainBlock"
 	TransFollow           Type = "follow"
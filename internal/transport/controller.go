@@ -33,12 +33,17 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 // Controller generates transports for use in making federation requests to other servers.
 type Controller interface {
 	NewTransport(pubKeyID string, privkey crypto.PrivateKey) (Transport, error)
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+	// Start starts sending queued outgoing deliveries in the background.
+	Start(ctx context.Context) error
+	// Stop stops sending queued outgoing deliveries. Pending deliveries stay in the database, and will be sent after the next Start.
+	Stop() error
 }
 
 type controller struct {
@@ -46,6 +51,7 @@ type controller struct {
 	clock    pub.Clock
 	client   pub.HttpClient
 	appAgent string
+	queue    *deliveryQueue
 
 	// dereferenceFollowersShortcut is a shortcut to dereference followers of an
 	// account on this instance, without making any external api/http calls.
@@ -98,7 +104,7 @@ func NewController(db db.DB, federatingDB federatingdb.DB, clock pub.Clock, clie
 	host := viper.GetString(config.Keys.Host)
 	appAgent := fmt.Sprintf("%s %s", applicationName, host)
 
-	return &controller{
+	c := &controller{
 		db:                           db,
 		clock:                        clock,
 		client:                       client,
@@ -106,6 +112,19 @@ func NewController(db db.DB, federatingDB federatingdb.DB, clock pub.Clock, clie
 		dereferenceFollowersShortcut: dereferenceFollowersShortcut(federatingDB),
 		dereferenceUserShortcut:      dereferenceUserShortcut(federatingDB),
 	}
+	c.queue = newDeliveryQueue(db, c.newTransportForPubKeyID)
+
+	return c
+}
+
+func (c *controller) Start(ctx context.Context) error {
+	c.queue.start(ctx)
+	return nil
+}
+
+func (c *controller) Stop() error {
+	c.queue.stopQueue()
+	return nil
 }
 
 // NewTransport returns a new http signature transport with the given public key id (a URL), and the given private key.
@@ -128,6 +147,7 @@ func (c *controller) NewTransport(pubKeyID string, privkey crypto.PrivateKey) (T
 	sigTransport := pub.NewHttpSigTransport(c.client, c.appAgent, c.clock, getSigner, postSigner, pubKeyID, privkey)
 
 	return &transport{
+		db:                           c.db,
 		client:                       c.client,
 		appAgent:                     c.appAgent,
 		gofedAgent:                   "(go-fed/activity v1.0.0)",
@@ -139,6 +159,7 @@ func (c *controller) NewTransport(pubKeyID string, privkey crypto.PrivateKey) (T
 		getSignerMu:                  &sync.Mutex{},
 		dereferenceFollowersShortcut: c.dereferenceFollowersShortcut,
 		dereferenceUserShortcut:      c.dereferenceUserShortcut,
+		queue:                        c.queue,
 	}, nil
 }
 
@@ -164,3 +185,17 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 	}
 	return transport, nil
 }
+
+// newTransportForPubKeyID returns a transport for the local account with the given public key id.
+func (c *controller) newTransportForPubKeyID(ctx context.Context, pubKeyID string) (Transport, error) {
+	ourAccount := &gtsmodel.Account{}
+	if err := c.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: pubKeyID}}, ourAccount); err != nil {
+		return nil, fmt.Errorf("error getting account with public key %s from db: %s", pubKeyID, err)
+	}
+
+	if ourAccount.Domain != "" || ourAccount.PrivateKey == nil {
+		return nil, fmt.Errorf("account with public key %s is not a local account", pubKeyID)
+	}
+
+	return c.NewTransport(ourAccount.PublicKeyURI, ourAccount.PrivateKey)
+}
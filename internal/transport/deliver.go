@@ -20,10 +20,7 @@ package transport
 
 import (
 	"context"
-	"fmt"
 	"net/url"
-	"strings"
-	"sync"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -31,39 +28,8 @@ import (
 )
 
 func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
-	// concurrently deliver to recipients; for each delivery, buffer the error if it fails
-	wg := sync.WaitGroup{}
-	errCh := make(chan error, len(recipients))
-	for _, recipient := range recipients {
-		wg.Add(1)
-		go func(r *url.URL) {
-			defer wg.Done()
-			if err := t.Deliver(ctx, b, r); err != nil {
-				errCh <- err
-			}
-		}(recipient)
-	}
-
-	// wait until all deliveries have succeeded or failed
-	wg.Wait()
-
-	// receive any buffered errors
-	errs := make([]string, 0, len(recipients))
-outer:
-	for {
-		select {
-		case e := <-errCh:
-			errs = append(errs, e.Error())
-		default:
-			break outer
-		}
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("BatchDeliver: at least one failure: %s", strings.Join(errs, "; "))
-	}
-
-	return nil
+	// rather than delivering straight away, queue the deliveries so they can be retried if they fail
+	return t.queue.enqueue(ctx, t.pubKeyID, b, recipients)
 }
 
 func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
@@ -72,6 +38,10 @@ func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
 		return nil
 	}
 
+	if err := t.checkURI(ctx, to); err != nil {
+		return err
+	}
+
 	logrus.Debugf("Deliver: posting as %s to %s", t.pubKeyID, to.String())
 	return t.sigTransport.Deliver(ctx, b, to)
 }
@@ -46,6 +46,9 @@ func (t *transport) Dereference(ctx context.Context, iri *url.URL) ([]byte, erro
 	}
 
 	// the request is either for a remote host or for us but we don't have a shortcut, so continue as normal
+	if err := t.checkURI(ctx, iri); err != nil {
+		return nil, err
+	}
 	l.Debugf("performing GET to %s", iri.String())
 	return t.sigTransport.Dereference(ctx, iri)
 }
@@ -39,6 +39,9 @@ import (
 
 func (t *transport) DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error) {
 	l := logrus.WithField("func", "DereferenceInstance")
+	if err := t.checkURI(ctx, iri); err != nil {
+		return nil, err
+	}
 
 	var i *gtsmodel.Instance
 	var err error
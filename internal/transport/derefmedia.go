@@ -30,6 +30,10 @@ import (
 
 func (t *transport) DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int, error) {
 	l := logrus.WithField("func", "DereferenceMedia")
+	if err := t.checkURI(ctx, iri); err != nil {
+		return nil, 0, err
+	}
+
 	l.Debugf("performing GET to %s", iri.String())
 	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
 	if err != nil {
@@ -38,6 +38,10 @@ func (t *transport) Finger(ctx context.Context, targetUsername string, targetDom
 		return nil, fmt.Errorf("Finger: error parsing url %s: %s", urlString, err)
 	}
 
+	if err := t.checkURI(ctx, iri); err != nil {
+		return nil, err
+	}
+
 	l.Debugf("performing GET to %s", iri.String())
 
 	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
@@ -0,0 +1,403 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+const (
+	// deliveryInterval is how often the queue checks for due deliveries when it isn't woken up by a new one.
+	deliveryInterval = 30 * time.Second
+	// deliveryBatchSize is the maximum number of due deliveries selected from the database in one go.
+	deliveryBatchSize = 200
+	// deliveryWorkers is the maximum number of hosts that will be delivered to at the same time.
+	deliveryWorkers = 16
+	// deliveryBackoffBase is how long to wait before retrying a host after its first failure; this doubles with every consecutive failure.
+	deliveryBackoffBase = 30 * time.Second
+	// deliveryBackoffMax is the longest a host will be backed off from before the circuit is broken.
+	deliveryBackoffMax = 6 * time.Hour
+	// deliveryCircuitBreakThreshold is the number of consecutive failures after which a host is considered dead.
+	deliveryCircuitBreakThreshold = 10
+	// deliveryCircuitBreakCooldown is how long dead hosts are left alone before delivery is tried again.
+	deliveryCircuitBreakCooldown = 24 * time.Hour
+	// deliveryMaxAttempts is the number of times one delivery is attempted before it's given up on.
+	deliveryMaxAttempts = 15
+	// deliveryMaxAge is how long after being queued a delivery will be given up on, no matter how often it's been attempted.
+	deliveryMaxAge = 7 * 24 * time.Hour
+)
+
+// statusCodeRegex extracts the http status code from an error returned by a failed delivery.
+var statusCodeRegex = regexp.MustCompile(`failed \((\d{3})\)`)
+
+// deliveryQueue stores outgoing deliveries in the database, and works through them in the background.
+//
+// Deliveries to each host are attempted in order. When one fails, the whole host is backed off from
+// exponentially, and after enough consecutive failures the host is treated as dead and left alone for
+// a while. Deliveries that can't be made after enough attempts, or for long enough, are marked as failed
+// and kept in the database so that admins can see what didn't make it.
+type deliveryQueue struct {
+	db           db.DB
+	newTransport func(ctx context.Context, pubKeyID string) (Transport, error)
+
+	hosts   map[string]*deliveryHost
+	hostsMu sync.Mutex
+
+	wake    chan struct{}
+	stop    chan struct{}
+	running sync.WaitGroup // the worker goroutine, and any host goroutines it has started
+}
+
+// deliveryHost records the recent delivery history of one remote host.
+type deliveryHost struct {
+	failures int       // number of consecutive failed deliveries to this host
+	retryAt  time.Time // deliveries to this host won't be attempted before this time
+}
+
+func newDeliveryQueue(db db.DB, newTransport func(ctx context.Context, pubKeyID string) (Transport, error)) *deliveryQueue {
+	return &deliveryQueue{
+		db:           db,
+		newTransport: newTransport,
+		hosts:        make(map[string]*deliveryHost),
+		wake:         make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+	}
+}
+
+// enqueue stores one delivery of the given payload per recipient, to be sent by the queue as soon as possible.
+func (q *deliveryQueue) enqueue(ctx context.Context, pubKeyID string, b []byte, recipients []*url.URL) error {
+	deliveries := make([]*gtsmodel.Delivery, 0, len(recipients))
+	now := time.Now()
+
+	for _, r := range recipients {
+		// if the recipient host is our own, just skip this delivery since we by definition already have the message!
+		if r.Host == viper.GetString(config.Keys.Host) || r.Host == viper.GetString(config.Keys.AccountDomain) {
+			continue
+		}
+
+		// don't bother storing deliveries to domains we don't federate with
+		blocked, err := q.db.IsURIBlocked(ctx, r)
+		if err != nil {
+			return fmt.Errorf("enqueue: error checking whether domain %s is blocked: %s", r.Host, err)
+		}
+		if blocked {
+			continue
+		}
+
+		deliveryID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		deliveries = append(deliveries, &gtsmodel.Delivery{
+			ID:            deliveryID,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+			PubKeyID:      pubKeyID,
+			InboxURI:      r.String(),
+			Host:          r.Host,
+			Payload:       b,
+			State:         gtsmodel.DeliveryStatePending,
+			NextAttemptAt: q.nextAttempt(r.Host, now),
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := q.db.PutDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("enqueue: error storing deliveries: %s", err)
+	}
+
+	// let the worker know there's something new to do, without blocking if it already knows
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// start works through the queue in the background until stop is called.
+func (q *deliveryQueue) start(ctx context.Context) {
+	q.running.Add(1)
+	go func() {
+		defer q.running.Done()
+
+		ticker := time.NewTicker(deliveryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-q.wake:
+			case <-q.stop:
+				return
+			}
+
+			if err := q.process(ctx); err != nil {
+				logrus.Errorf("deliveryQueue: error processing deliveries: %s", err)
+			}
+		}
+	}()
+}
+
+// stopQueue stops the queue, and waits for any deliveries that are in flight to finish,
+// so that nothing is still using the database by the time it returns.
+func (q *deliveryQueue) stopQueue() {
+	close(q.stop)
+	q.running.Wait()
+}
+
+// process attempts all deliveries that are currently due, grouped by host.
+func (q *deliveryQueue) process(ctx context.Context) error {
+	for {
+		// once we're stopping, host goroutines won't touch any deliveries,
+		// so the same batch would just keep coming back if we carried on
+		select {
+		case <-q.stop:
+			return nil
+		default:
+		}
+
+		now := time.Now()
+
+		due, err := q.db.GetDueDeliveries(ctx, now, deliveryBatchSize)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil
+			}
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		// group deliveries by host, keeping them in the order they were due
+		byHost := make(map[string][]*gtsmodel.Delivery)
+		for _, d := range due {
+			byHost[d.Host] = append(byHost[d.Host], d)
+		}
+
+		wg := sync.WaitGroup{}
+		sem := make(chan struct{}, deliveryWorkers)
+		for host, deliveries := range byHost {
+			wg.Add(1)
+			q.running.Add(1)
+			sem <- struct{}{}
+			go func(host string, deliveries []*gtsmodel.Delivery) {
+				defer func() {
+					<-sem
+					wg.Done()
+					q.running.Done()
+				}()
+				q.deliverToHost(ctx, host, deliveries)
+			}(host, deliveries)
+		}
+		wg.Wait()
+
+		// if we got a whole batch there might be more waiting, otherwise we're done for now
+		if len(due) < deliveryBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliverToHost attempts the given deliveries to one host in order, stopping at the first failure.
+func (q *deliveryQueue) deliverToHost(ctx context.Context, host string, deliveries []*gtsmodel.Delivery) {
+	transports := make(map[string]Transport)
+
+	for _, d := range deliveries {
+		select {
+		case <-q.stop:
+			return
+		default:
+		}
+
+		now := time.Now()
+
+		// the host may have been backed off from since these deliveries were selected
+		if retryAt := q.nextAttempt(host, now); retryAt.After(now) {
+			if err := q.db.DeferDeliveries(ctx, host, retryAt); err != nil {
+				logrus.Errorf("deliverToHost: error deferring deliveries to %s: %s", host, err)
+			}
+			return
+		}
+
+		if now.Sub(d.CreatedAt) > deliveryMaxAge {
+			q.giveUp(ctx, d, errors.New("delivery expired before it could be made"))
+			continue
+		}
+
+		t, ok := transports[d.PubKeyID]
+		if !ok {
+			var err error
+			t, err = q.newTransport(ctx, d.PubKeyID)
+			if err != nil {
+				// not the host's fault, so just give up on this one
+				q.giveUp(ctx, d, err)
+				continue
+			}
+			transports[d.PubKeyID] = t
+		}
+
+		inbox, err := url.Parse(d.InboxURI)
+		if err != nil {
+			q.giveUp(ctx, d, err)
+			continue
+		}
+
+		d.Attempts++
+		d.LastAttemptAt = now
+		deliverErr := t.Deliver(ctx, d.Payload, inbox)
+		if deliverErr == nil {
+			q.succeeded(host)
+			if err := q.db.DeleteByID(ctx, d.ID, &gtsmodel.Delivery{}); err != nil {
+				logrus.Errorf("deliverToHost: error deleting successful delivery %s: %s", d.ID, err)
+			}
+			continue
+		}
+
+		logrus.Debugf("deliverToHost: attempt %d of delivery %s to %s failed: %s", d.Attempts, d.ID, d.InboxURI, deliverErr)
+
+		// the host responded but refused this particular delivery, so
+		// there's no point retrying it, but the host is clearly alive
+		if permanentDeliveryError(deliverErr) {
+			q.succeeded(host)
+			q.giveUp(ctx, d, deliverErr)
+			continue
+		}
+
+		if d.Attempts >= deliveryMaxAttempts {
+			q.giveUp(ctx, d, deliverErr)
+		} else {
+			d.LastError = deliverErr.Error()
+			d.NextAttemptAt = q.failed(host, now)
+			d.UpdatedAt = now
+			if err := q.db.UpdateByPrimaryKey(ctx, d); err != nil {
+				logrus.Errorf("deliverToHost: error updating delivery %s: %s", d.ID, err)
+			}
+		}
+
+		// back off from the whole host, not just this delivery
+		if err := q.db.DeferDeliveries(ctx, host, q.nextAttempt(host, now)); err != nil {
+			logrus.Errorf("deliverToHost: error deferring deliveries to %s: %s", host, err)
+		}
+		return
+	}
+}
+
+// giveUp marks the given delivery as failed, so that it won't be attempted again.
+func (q *deliveryQueue) giveUp(ctx context.Context, d *gtsmodel.Delivery, reason error) {
+	logrus.Infof("giveUp: giving up on delivery %s to %s after %d attempt(s): %s", d.ID, d.InboxURI, d.Attempts, reason)
+
+	d.State = gtsmodel.DeliveryStateFailed
+	d.LastError = reason.Error()
+	d.UpdatedAt = time.Now()
+	if err := q.db.UpdateByPrimaryKey(ctx, d); err != nil {
+		logrus.Errorf("giveUp: error updating delivery %s: %s", d.ID, err)
+	}
+}
+
+// nextAttempt returns the earliest time at or after now that a delivery to the given host may be attempted.
+func (q *deliveryQueue) nextAttempt(host string, now time.Time) time.Time {
+	q.hostsMu.Lock()
+	defer q.hostsMu.Unlock()
+
+	if h, ok := q.hosts[host]; ok && h.retryAt.After(now) {
+		return h.retryAt
+	}
+	return now
+}
+
+// succeeded resets the failure count of the given host.
+func (q *deliveryQueue) succeeded(host string) {
+	q.hostsMu.Lock()
+	defer q.hostsMu.Unlock()
+
+	delete(q.hosts, host)
+}
+
+// failed records a failed delivery to the given host, and returns the time until which the host should be backed off from.
+func (q *deliveryQueue) failed(host string, now time.Time) time.Time {
+	q.hostsMu.Lock()
+	defer q.hostsMu.Unlock()
+
+	h, ok := q.hosts[host]
+	if !ok {
+		h = &deliveryHost{}
+		q.hosts[host] = h
+	}
+	h.failures++
+
+	var backoff time.Duration
+	if h.failures >= deliveryCircuitBreakThreshold {
+		// this host has been failing for a long time, so leave it alone for a while
+		if h.failures == deliveryCircuitBreakThreshold {
+			logrus.Warnf("failed: %d consecutive deliveries to %s failed, not trying again for %s", h.failures, host, deliveryCircuitBreakCooldown)
+		}
+		backoff = deliveryCircuitBreakCooldown
+	} else {
+		backoff = deliveryBackoffBase << (h.failures - 1)
+		if backoff > deliveryBackoffMax {
+			backoff = deliveryBackoffMax
+		}
+	}
+
+	h.retryAt = now.Add(backoff)
+	return h.retryAt
+}
+
+// permanentDeliveryError returns true if the given delivery error was caused by the remote host rejecting the
+// delivery, or by the domain not being federated with, in a way that won't change if it's retried.
+func permanentDeliveryError(err error) bool {
+	// the domain might have been blocked since the delivery was queued
+	if errors.Is(err, errDomainBlocked) {
+		return true
+	}
+
+	match := statusCodeRegex.FindStringSubmatch(err.Error())
+	if len(match) != 2 {
+		return false
+	}
+
+	code, err := strconv.Atoi(match[1])
+	if err != nil {
+		return false
+	}
+
+	// 401 unauthorized can happen if the remote couldn't fetch our public key, and
+	// 408 request timeout and 429 too many requests are worth retrying too, but other client errors aren't
+	return code >= 400 && code < 500 && code != 401 && code != 408 && code != 429
+}
@@ -0,0 +1,259 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type QueueTestSuite struct {
+	suite.Suite
+	db           db.DB
+	testAccounts map[string]*gtsmodel.Account
+
+	requestsMu sync.Mutex
+	requests   []*http.Request
+}
+
+func (suite *QueueTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *QueueTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB()
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+	suite.requests = nil
+}
+
+func (suite *QueueTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// startController returns a started transport controller whose remote hosts always respond with the given status code.
+func (suite *QueueTestSuite) startController(statusCode int) transport.Controller {
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		suite.requestsMu.Lock()
+		suite.requests = append(suite.requests, req)
+		suite.requestsMu.Unlock()
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	})
+
+	controller := testrig.NewTestTransportController(client, suite.db)
+	suite.NoError(controller.Start(context.Background()))
+	return controller
+}
+
+func (suite *QueueTestSuite) batchDeliver(controller transport.Controller) {
+	t, err := controller.NewTransportForUsername(context.Background(), "the_mighty_zork")
+	suite.NoError(err)
+
+	recipients := []*url.URL{
+		testrig.URLMustParse("https://fossbros-anonymous.io/users/foss_satan/inbox"),
+		// deliveries to ourselves should be skipped entirely
+		testrig.URLMustParse("http://localhost:8080/users/1happyturtle/inbox"),
+	}
+	err = t.BatchDeliver(context.Background(), []byte(`{"type":"Create"}`), recipients)
+	suite.NoError(err)
+}
+
+func (suite *QueueTestSuite) numRequests() int {
+	suite.requestsMu.Lock()
+	defer suite.requestsMu.Unlock()
+	return len(suite.requests)
+}
+
+func (suite *QueueTestSuite) deliveries() []*gtsmodel.Delivery {
+	deliveries, err := suite.db.GetDeliveries(context.Background(), "", "", 0)
+	suite.NoError(err)
+	return deliveries
+}
+
+func (suite *QueueTestSuite) TestDeliverSuccess() {
+	controller := suite.startController(http.StatusOK)
+	defer controller.Stop()
+
+	suite.batchDeliver(controller)
+
+	suite.Eventually(func() bool {
+		return suite.numRequests() == 1 && len(suite.deliveries()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Equal("fossbros-anonymous.io", suite.requests[0].URL.Host)
+}
+
+func (suite *QueueTestSuite) TestStopWithFullBatch() {
+	// queue up more deliveries than fit in one batch
+	now := time.Now()
+	deliveries := []*gtsmodel.Delivery{}
+	for i := 0; i < 250; i++ {
+		deliveryID, err := id.NewULID()
+		suite.NoError(err)
+		deliveries = append(deliveries, &gtsmodel.Delivery{
+			ID:            deliveryID,
+			PubKeyID:      "http://localhost:8080/users/the_mighty_zork/main-key",
+			InboxURI:      "https://fossbros-anonymous.io/users/foss_satan/inbox",
+			Host:          "fossbros-anonymous.io",
+			Payload:       []byte(`{"type":"Create"}`),
+			State:         gtsmodel.DeliveryStatePending,
+			NextAttemptAt: now.Add(-1 * time.Minute),
+		})
+	}
+	suite.NoError(suite.db.PutDeliveries(context.Background(), deliveries))
+
+	// hold up the first request until the queue has been told to stop
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		once.Do(func() {
+			close(started)
+			<-release
+		})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	})
+	controller := testrig.NewTestTransportController(client, suite.db)
+	suite.NoError(controller.Start(context.Background()))
+	suite.batchDeliver(controller)
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		suite.NoError(controller.Stop())
+		close(stopped)
+	}()
+
+	// give stop a moment to close the queue before the request finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for the queue to stop")
+	}
+
+	// most deliveries were never attempted, so they're still waiting for next time
+	suite.Greater(len(suite.deliveries()), 200)
+}
+
+func (suite *QueueTestSuite) TestDeliverRetry() {
+	controller := suite.startController(http.StatusBadGateway)
+	defer controller.Stop()
+
+	suite.batchDeliver(controller)
+
+	suite.Eventually(func() bool {
+		deliveries := suite.deliveries()
+		return len(deliveries) == 1 && deliveries[0].Attempts == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	delivery := suite.deliveries()[0]
+	suite.Equal(gtsmodel.DeliveryStatePending, delivery.State)
+	suite.Equal("fossbros-anonymous.io", delivery.Host)
+	suite.Contains(delivery.LastError, "502")
+	suite.True(delivery.NextAttemptAt.After(time.Now()))
+
+	// the host is backed off from, so a new delivery to it shouldn't be attempted straight away
+	suite.batchDeliver(controller)
+	time.Sleep(100 * time.Millisecond)
+	suite.Equal(1, suite.numRequests())
+	suite.Len(suite.deliveries(), 2)
+}
+
+func (suite *QueueTestSuite) TestDeliverPermanentFailure() {
+	controller := suite.startController(http.StatusGone)
+	defer controller.Stop()
+
+	suite.batchDeliver(controller)
+
+	suite.Eventually(func() bool {
+		deliveries := suite.deliveries()
+		return len(deliveries) == 1 && deliveries[0].State == gtsmodel.DeliveryStateFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Equal(1, suite.numRequests())
+}
+
+func (suite *QueueTestSuite) TestDeliverAllowlist() {
+	viper.Set(config.Keys.FederationMode, config.FederationModeAllowlist)
+	controller := suite.startController(http.StatusOK)
+	defer controller.Stop()
+
+	t, err := controller.NewTransportForUsername(context.Background(), "the_mighty_zork")
+	suite.NoError(err)
+
+	// only fossbros-anonymous.io is allowed, so nothing should be queued for example.org
+	recipients := []*url.URL{
+		testrig.URLMustParse("https://fossbros-anonymous.io/users/foss_satan/inbox"),
+		testrig.URLMustParse("https://example.org/users/some_user/inbox"),
+	}
+	suite.NoError(t.BatchDeliver(context.Background(), []byte(`{"type":"Create"}`), recipients))
+
+	suite.Eventually(func() bool {
+		return suite.numRequests() == 1 && len(suite.deliveries()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Equal("fossbros-anonymous.io", suite.requests[0].URL.Host)
+}
+
+func (suite *QueueTestSuite) TestDereferenceNoFederation() {
+	viper.Set(config.Keys.FederationMode, config.FederationModeNone)
+	controller := suite.startController(http.StatusOK)
+	defer controller.Stop()
+
+	t, err := controller.NewTransportForUsername(context.Background(), "the_mighty_zork")
+	suite.NoError(err)
+
+	_, err = t.Dereference(context.Background(), testrig.URLMustParse("https://fossbros-anonymous.io/users/foss_satan"))
+	suite.Error(err)
+	_, err = t.Finger(context.Background(), "foss_satan", "fossbros-anonymous.io")
+	suite.Error(err)
+	suite.NoError(t.Deliver(context.Background(), []byte(`{"type":"Create"}`), testrig.URLMustParse("http://localhost:8080/users/1happyturtle/inbox")))
+	suite.Error(t.Deliver(context.Background(), []byte(`{"type":"Create"}`), testrig.URLMustParse("https://fossbros-anonymous.io/users/foss_satan/inbox")))
+	suite.Equal(0, suite.numRequests())
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
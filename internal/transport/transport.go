@@ -21,15 +21,22 @@ package transport
 import (
 	"context"
 	"crypto"
+	"errors"
+	"fmt"
 	"io"
 	"net/url"
 	"sync"
 
 	"github.com/go-fed/httpsig"
 	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// errDomainBlocked is returned when a request would be made to a domain that this instance doesn't
+// federate with, either because the domain is blocked, or because the federation mode doesn't allow it.
+var errDomainBlocked = errors.New("domain is blocked")
+
 // Transport wraps the pub.Transport interface with some additional functionality for fetching remote media.
 //
 // Since the transport has the concept of 'shortcuts' for fetching data locally rather than remotely, it is
@@ -49,6 +56,7 @@ type Transport interface {
 
 // transport implements the Transport interface
 type transport struct {
+	db           db.DB
 	client       pub.HttpClient
 	appAgent     string
 	gofedAgent   string
@@ -63,8 +71,23 @@ type transport struct {
 
 	dereferenceFollowersShortcut func(ctx context.Context, iri *url.URL) ([]byte, error)
 	dereferenceUserShortcut      func(ctx context.Context, iri *url.URL) ([]byte, error)
+
+	// queue stores outgoing batch deliveries so they can be retried if they fail
+	queue *deliveryQueue
 }
 
 func (t *transport) SigTransport() pub.Transport {
 	return t.sigTransport
 }
+
+// checkURI returns errDomainBlocked if this instance doesn't federate with the host of the given URI.
+func (t *transport) checkURI(ctx context.Context, uri *url.URL) error {
+	blocked, err := t.db.IsURIBlocked(ctx, uri)
+	if err != nil {
+		return fmt.Errorf("error checking whether domain %s is blocked: %s", uri.Host, err)
+	}
+	if blocked {
+		return fmt.Errorf("%w: %s", errDomainBlocked, uri.Host)
+	}
+	return nil
+}
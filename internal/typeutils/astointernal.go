@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable ap.Accountable, update bool) (*gtsmodel.Account, error) {
@@ -158,7 +159,16 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 
 	// TODO: FeaturedTagsURI
 
-	// TODO: alsoKnownAs
+	// alsoKnownAs
+	for _, alias := range ap.ExtractAlsoKnownAs(accountable) {
+		acct.AlsoKnownAsURIs = append(acct.AlsoKnownAsURIs, alias.String())
+	}
+
+	// movedTo
+	// the database ID of the account moved to is only set once the move has been processed
+	if movedTo := ap.ExtractMovedTo(accountable); movedTo != nil {
+		acct.MovedToURI = movedTo.String()
+	}
 
 	// publicKey
 	pkey, pkeyURL, err := ap.ExtractPublicKeyForOwner(accountable, uri)
@@ -241,6 +251,11 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		status.UpdatedAt = published
 	}
 
+	// edited time of the status, if it's been edited since it was published
+	if updated := ap.ExtractUpdated(statusable); !updated.IsZero() && updated.After(status.CreatedAt) {
+		status.EditedAt = updated
+	}
+
 	// which account posted this status?
 	// if we don't know the account yet we can dereference it later
 	attributedTo, err := ap.ExtractAttributedTo(statusable)
@@ -303,6 +318,22 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
 
+	// poll, if this status is a question
+	if pollable, ok := statusable.(ap.Pollable); ok {
+		poll, err := ap.ExtractPoll(pollable)
+		if err != nil {
+			return nil, fmt.Errorf("ASStatusToStatus: error extracting poll: %s", err)
+		}
+
+		pollID, err := id.NewULID()
+		if err != nil {
+			return nil, err
+		}
+		poll.ID = pollID
+		status.PollID = pollID
+		status.Poll = poll
+	}
+
 	return status, nil
 }
 
@@ -457,6 +488,85 @@ func (c *converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable)
 	}, nil
 }
 
+func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable) (*gtsmodel.Report, error) {
+	idProp := flaggable.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return nil, errors.New("ASFlagToReport: no id property set on flag, or was not an iri")
+	}
+	uri := idProp.GetIRI().String()
+
+	origin, err := ap.ExtractActor(flaggable)
+	if err != nil {
+		return nil, errors.New("ASFlagToReport: error extracting actor property from flag")
+	}
+	originAccount, err := c.db.GetAccountByURI(ctx, origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("ASFlagToReport: error extracting account with uri %s from the database: %s", origin.String(), err)
+	}
+
+	objects, err := ap.ExtractObjects(flaggable)
+	if err != nil {
+		return nil, errors.New("ASFlagToReport: error extracting object property from flag")
+	}
+
+	// the flag should contain exactly one of our accounts, and optionally some of its statuses;
+	// anything we don't know about is ignored, since it can't be about us anyway
+	var targetAccount *gtsmodel.Account
+	statuses := []*gtsmodel.Status{}
+	for _, object := range objects {
+		if a, err := c.db.GetAccountByURI(ctx, object.String()); err == nil {
+			if a.Domain != "" {
+				continue
+			}
+			if targetAccount != nil && targetAccount.ID != a.ID {
+				return nil, errors.New("ASFlagToReport: flag targets more than one local account")
+			}
+			targetAccount = a
+			continue
+		}
+
+		if s, err := c.db.GetStatusByURI(ctx, object.String()); err == nil && s.Local {
+			statuses = append(statuses, s)
+		}
+	}
+
+	if targetAccount == nil {
+		// a flag might only contain statuses, so take the target from those
+		if len(statuses) == 0 {
+			return nil, errors.New("ASFlagToReport: flag did not target any local account")
+		}
+		if targetAccount, err = c.db.GetAccountByID(ctx, statuses[0].AccountID); err != nil {
+			return nil, fmt.Errorf("ASFlagToReport: error getting account %s from the database: %s", statuses[0].AccountID, err)
+		}
+	}
+
+	statusIDs := []string{}
+	reportStatuses := []*gtsmodel.Status{}
+	for _, s := range statuses {
+		if s.AccountID != targetAccount.ID {
+			// only statuses by the reported account are relevant
+			continue
+		}
+		statusIDs = append(statusIDs, s.ID)
+		reportStatuses = append(reportStatuses, s)
+	}
+
+	// content is optional, so ignore any error here
+	comment, _ := ap.ExtractContent(flaggable)
+
+	return &gtsmodel.Report{
+		URI:             uri,
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusIDs:       statusIDs,
+		Statuses:        reportStatuses,
+		Comment:         comment,
+		Category:        gtsmodel.ReportCategoryOther,
+	}, nil
+}
+
 func (c *converter) ASAnnounceToStatus(ctx context.Context, announceable ap.Announceable) (*gtsmodel.Status, bool, error) {
 	status := &gtsmodel.Status{}
 	isNew := true
@@ -72,6 +72,18 @@ type TypeConverter interface {
 	//
 	// Requesting account can be nil.
 	StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*model.Status, error)
+	// StatusEditToAPIStatusEdit converts a previous revision of the given status into its api (frontend) representation for serialization on the API.
+	StatusEditToAPIStatusEdit(ctx context.Context, e *gtsmodel.StatusEdit, s *gtsmodel.Status) (*model.StatusEdit, error)
+	// FilterToAPIFilterV2 converts a gts model filter, with its keywords, into its api (frontend) representation for serving at /api/v2/filters
+	FilterToAPIFilterV2(ctx context.Context, f *gtsmodel.Filter) (*model.FilterV2, error)
+	// FilterKeywordToAPIFilterKeyword converts a gts model filter keyword into its api (frontend) representation for serving at /api/v2/filters/keywords
+	FilterKeywordToAPIFilterKeyword(ctx context.Context, k *gtsmodel.FilterKeyword) (*model.FilterKeyword, error)
+	// FilterKeywordToAPIFilterV1 converts a gts model filter keyword, and the filter it belongs to, into a v1 api filter for serving at /api/v1/filters
+	FilterKeywordToAPIFilterV1(ctx context.Context, k *gtsmodel.FilterKeyword, f *gtsmodel.Filter) (*model.FilterV1, error)
+	// PollToAPIPoll converts a gts model poll into its api (frontend) representation for serialization on the API.
+	//
+	// Requesting account can be nil.
+	PollToAPIPoll(ctx context.Context, p *gtsmodel.Poll, requestingAccount *gtsmodel.Account) (*model.Poll, error)
 	// VisToAPIVis converts a gts visibility into its api equivalent
 	VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) model.Visibility
 	// InstanceToAPIInstance converts a gts instance into its api equivalent for serving at /api/v1/instance
@@ -82,6 +94,31 @@ type TypeConverter interface {
 	NotificationToAPINotification(ctx context.Context, n *gtsmodel.Notification) (*model.Notification, error)
 	// DomainBlockToAPIDomainBlock converts a gts model domin block into a api domain block, for serving at /api/v1/admin/domain_blocks
 	DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel.DomainBlock, export bool) (*model.DomainBlock, error)
+	// DomainAllowToAPIDomainAllow converts a gts model domain allow into an api domain allow, for serving at /api/v1/admin/domain_allows
+	DomainAllowToAPIDomainAllow(ctx context.Context, a *gtsmodel.DomainAllow, export bool) (*model.DomainAllow, error)
+	// DeliveryToAPIDelivery converts a gts model delivery into an api delivery, for serving at /api/v1/admin/deliveries
+	DeliveryToAPIDelivery(ctx context.Context, d *gtsmodel.Delivery) (*model.Delivery, error)
+	// ListToAPIList converts a gts model list into an api list, for serving at /api/v1/lists
+	ListToAPIList(ctx context.Context, l *gtsmodel.List) (*model.List, error)
+	// ReportToAPIReport converts a gts model report into an api report, for serving to the account that made it at /api/v1/reports
+	ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*model.Report, error)
+	// AccountToAdminAPIAccount converts a gts model account into an admin api account, for serving at /api/v1/admin/accounts.
+	// For local accounts, the details of the account's user are included too.
+	AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Account) (*model.AdminAccountInfo, error)
+	// ReportToAdminAPIReport converts a gts model report into an admin api report, for serving at /api/v1/admin/reports.
+	// Attached statuses are converted from the point of view of the given requesting account.
+	ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*model.AdminReportInfo, error)
+	// ScheduledStatusToAPIScheduledStatus converts a gts model scheduled status into its api representation, for serving at /api/v1/scheduled_statuses
+	ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*model.ScheduledStatus, error)
+	// ConversationToAPIConversation converts a gts model conversation into an api conversation, for serving at /api/v1/conversations.
+	// The last status is converted from the point of view of the given requesting account.
+	ConversationToAPIConversation(ctx context.Context, c *gtsmodel.Conversation, requestingAccount *gtsmodel.Account) (*model.Conversation, error)
+	// MarkersToAPIMarker converts the given timeline markers of one account into an api marker, for serving at /api/v1/markers.
+	MarkersToAPIMarker(ctx context.Context, markers []*gtsmodel.Marker) (*model.Marker, error)
+	// PushSubscriptionToAPIPushSubscription converts a push subscription into its api representation, including the instance VAPID key.
+	PushSubscriptionToAPIPushSubscription(ctx context.Context, s *gtsmodel.PushSubscription) (*model.PushSubscription, error)
+	// TokenToAPIAuthorizedApp converts an oauth token into an api authorized app, for serving at /api/v1/user/authorized_apps.
+	TokenToAPIAuthorizedApp(ctx context.Context, t *gtsmodel.Token) (*model.AuthorizedApp, error)
 
 	/*
 		FRONTEND (api) MODEL TO INTERNAL (gts) MODEL
@@ -110,6 +147,9 @@ type TypeConverter interface {
 	ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusFave, error)
 	// ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 	ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error)
+	// ASFlagToReport converts a remote activitystreams 'flag' representation into a gts model report.
+	// The returned report has no ID set; that's up to the caller.
+	ASFlagToReport(ctx context.Context, flaggable ap.Flaggable) (*gtsmodel.Report, error)
 	// ASAnnounceToStatus converts an activitystreams 'announce' into a status.
 	//
 	// The returned bool indicates whether this status is new (true) or not new (false).
@@ -138,6 +178,12 @@ type TypeConverter interface {
 	AccountToASMinimal(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsPerson, error)
 	// StatusToAS converts a gts model status into an activity streams note, suitable for federation
 	StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error)
+	// StatusToASQuestion converts a gts model status with a poll into an activity streams question, suitable for federation.
+	// Unlike StatusToAS, the result is not cached, since the vote counts of the poll change over time.
+	StatusToASQuestion(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsQuestion, error)
+	// PollVoteToASCreates converts a gts model poll vote into one Create activity per chosen option, suitable for federation.
+	// Each Create wraps a Note with the name of the chosen option, in reply to the status that the poll is attached to.
+	PollVoteToASCreates(ctx context.Context, vote *gtsmodel.PollVote) ([]vocab.ActivityStreamsCreate, error)
 	// FollowToASFollow converts a gts model Follow into an activity streams Follow, suitable for federation
 	FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsFollow, error)
 	// MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
@@ -150,6 +196,10 @@ type TypeConverter interface {
 	BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error)
 	// BlockToAS converts a gts model block into an activityStreams BLOCK, suitable for federation.
 	BlockToAS(ctx context.Context, block *gtsmodel.Block) (vocab.ActivityStreamsBlock, error)
+	// AccountToASMove converts a gts model account that has moved into an activityStreams MOVE, suitable for federation.
+	AccountToASMove(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsMove, error)
+	// ReportToASFlag converts a gts model report into an activityStreams FLAG, sent from the instance actor, suitable for federation.
+	ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error)
 	// StatusToASRepliesCollection converts a gts model status into an activityStreams REPLIES collection.
 	StatusToASRepliesCollection(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool) (vocab.ActivityStreamsCollection, error)
 	// StatusURIsToASRepliesPage returns a collection page with appropriate next/part of pagination.
@@ -166,6 +216,9 @@ type TypeConverter interface {
 	//
 	// Appropriate 'next' and 'prev' fields will be created based on the highest and lowest IDs present in the statuses slice.
 	StatusesToASOutboxPage(ctx context.Context, outboxID string, maxID string, minID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollectionPage, error)
+	// StatusesToASFeaturedCollection returns an ordered collection containing the given pinned statuses,
+	// suitable for serving as an account's featured collection.
+	StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error)
 
 	/*
 		INTERNAL (gts) MODEL TO INTERNAL MODEL
@@ -175,6 +228,8 @@ type TypeConverter interface {
 	FollowRequestToFollow(ctx context.Context, f *gtsmodel.FollowRequest) *gtsmodel.Follow
 	// StatusToBoost wraps the given status into a boosting status.
 	StatusToBoost(ctx context.Context, s *gtsmodel.Status, boostingAccount *gtsmodel.Account) (*gtsmodel.Status, error)
+	// StatusToStatusEdit snapshots the current version of the given status into a new revision, so it can be stored before the status is edited.
+	StatusToStatusEdit(ctx context.Context, s *gtsmodel.Status) (*gtsmodel.StatusEdit, error)
 
 	/*
 		WRAPPER CONVENIENCE FUNCTIONS
@@ -188,6 +243,14 @@ type TypeConverter interface {
 	// but just the AP URI of the note. This is useful in cases where you want to give a remote server something to dereference,
 	// and still have control over whether or not they're allowed to actually see the contents.
 	WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
+	// WrapQuestionInCreate wraps a Question with a Create activity.
+	//
+	// The objectIRIOnly parameter behaves the same as it does for WrapNoteInCreate.
+	WrapQuestionInCreate(question vocab.ActivityStreamsQuestion, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
+	// WrapNoteInUpdate wraps a Note with an Update activity, so that remote instances can refresh their copy of an edited status.
+	WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
+	// WrapQuestionInUpdate wraps a Question with an Update activity, so that remote instances can refresh their copy of a poll.
+	WrapQuestionInUpdate(question vocab.ActivityStreamsQuestion, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
 }
 
 type converter struct {
@@ -83,3 +83,29 @@ func (c *converter) StatusToBoost(ctx context.Context, s *gtsmodel.Status, boost
 
 	return boostWrapperStatus, nil
 }
+
+func (c *converter) StatusToStatusEdit(ctx context.Context, s *gtsmodel.Status) (*gtsmodel.StatusEdit, error) {
+	editID, err := id.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	// this version of the status was written either when it was last edited, or when it was created
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+
+	return &gtsmodel.StatusEdit{
+		ID:             editID,
+		CreatedAt:      createdAt,
+		StatusID:       s.ID,
+		Content:        s.Content,
+		ContentWarning: s.ContentWarning,
+		Text:           s.Text,
+		Sensitive:      s.Sensitive,
+		Language:       s.Language,
+		AttachmentIDs:  s.AttachmentIDs,
+		EmojiIDs:       s.EmojiIDs,
+	}, nil
+}
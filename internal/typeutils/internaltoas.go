@@ -24,15 +24,19 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // const (
@@ -280,6 +284,20 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 		}
 	}
 
+	// alsoKnownAs and movedTo
+	// These aren't part of the vocabulary, so they're set as unknown properties,
+	// which the person will serialize along with everything else.
+	if len(a.AlsoKnownAsURIs) != 0 {
+		aliases := make([]interface{}, 0, len(a.AlsoKnownAsURIs))
+		for _, alias := range a.AlsoKnownAsURIs {
+			aliases = append(aliases, alias)
+		}
+		person.GetUnknownProperties()[ap.PropertyAlsoKnownAs] = aliases
+	}
+	if a.MovedToURI != "" {
+		person.GetUnknownProperties()[ap.PropertyMovedTo] = a.MovedToURI
+	}
+
 	return person, nil
 }
 
@@ -349,14 +367,238 @@ func (c *converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 }
 
 func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error) {
-	// first check if we have this note in our asCache already
-	if noteI, err := c.asCache.Fetch(s.ID); err == nil {
+	// first check if we have this version of the note in our asCache already
+	if noteI, err := c.asCache.Fetch(statusCacheKey(s)); err == nil {
 		if note, ok := noteI.(vocab.ActivityStreamsNote); ok {
 			// we have it, so just return it as-is
 			return note, nil
 		}
 	}
 
+	// create the Note!
+	status := streams.NewActivityStreamsNote()
+	if err := c.populateASStatus(ctx, s, status); err != nil {
+		return nil, err
+	}
+
+	// put the note in our cache in case we need it again soon
+	if err := c.asCache.Store(statusCacheKey(s), status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// statusCacheKey returns the key under which the AS representation of s is cached.
+// Edited statuses get a different key for each edit, so that stale versions are never served.
+func statusCacheKey(s *gtsmodel.Status) string {
+	if s.EditedAt.IsZero() {
+		return s.ID
+	}
+	return s.ID + "@" + strconv.FormatInt(s.EditedAt.UnixNano(), 10)
+}
+
+func (c *converter) StatusToASQuestion(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsQuestion, error) {
+	// questions aren't cached, since their vote counts change often
+
+	if s.PollID == "" {
+		return nil, fmt.Errorf("StatusToASQuestion: status %s has no poll", s.ID)
+	}
+
+	poll := s.Poll
+	if poll == nil {
+		p, err := c.db.GetPollByID(ctx, s.PollID)
+		if err != nil {
+			return nil, fmt.Errorf("StatusToASQuestion: error retrieving poll from db: %s", err)
+		}
+		poll = p
+	}
+
+	// create the Question!
+	question := streams.NewActivityStreamsQuestion()
+	if err := c.populateASStatus(ctx, s, question); err != nil {
+		return nil, err
+	}
+
+	// options -- each one is a Note with a name and a replies collection containing the vote count
+	oneOfProp := streams.NewActivityStreamsOneOfProperty()
+	anyOfProp := streams.NewActivityStreamsAnyOfProperty()
+	for i, option := range poll.Options {
+		optionNote := streams.NewActivityStreamsNote()
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(option)
+		optionNote.SetActivityStreamsName(nameProp)
+
+		var votes int
+		if i < len(poll.Votes) {
+			votes = poll.Votes[i]
+		}
+		votesCollection := streams.NewActivityStreamsCollection()
+		totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+		totalItemsProp.Set(votes)
+		votesCollection.SetActivityStreamsTotalItems(totalItemsProp)
+		repliesProp := streams.NewActivityStreamsRepliesProperty()
+		repliesProp.SetActivityStreamsCollection(votesCollection)
+		optionNote.SetActivityStreamsReplies(repliesProp)
+
+		if poll.Multiple {
+			anyOfProp.AppendActivityStreamsNote(optionNote)
+		} else {
+			oneOfProp.AppendActivityStreamsNote(optionNote)
+		}
+	}
+
+	if poll.Multiple {
+		question.SetActivityStreamsAnyOf(anyOfProp)
+	} else {
+		question.SetActivityStreamsOneOf(oneOfProp)
+	}
+
+	// endTime
+	if !poll.ExpiresAt.IsZero() {
+		endTimeProp := streams.NewActivityStreamsEndTimeProperty()
+		endTimeProp.Set(poll.ExpiresAt)
+		question.SetActivityStreamsEndTime(endTimeProp)
+	}
+
+	// closed
+	if !poll.ClosedAt.IsZero() {
+		closedProp := streams.NewActivityStreamsClosedProperty()
+		closedProp.AppendXMLSchemaDateTime(poll.ClosedAt)
+		question.SetActivityStreamsClosed(closedProp)
+	}
+
+	// votersCount
+	votersCountProp := streams.NewTootVotersCountProperty()
+	votersCountProp.Set(poll.Voters)
+	question.SetTootVotersCount(votersCountProp)
+
+	return question, nil
+}
+
+func (c *converter) PollVoteToASCreates(ctx context.Context, vote *gtsmodel.PollVote) ([]vocab.ActivityStreamsCreate, error) {
+	// check if the voting account is already pinned to this vote, and fetch it if not
+	if vote.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, vote.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error fetching voting account from database: %s", err)
+		}
+		vote.Account = a
+	}
+
+	// check if the poll is already pinned to this vote, and fetch it if not
+	if vote.Poll == nil {
+		p, err := c.db.GetPollByID(ctx, vote.PollID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error fetching poll from database: %s", err)
+		}
+		vote.Poll = p
+	}
+
+	// check if the poll status is already pinned to the poll, and fetch it if not
+	if vote.Poll.Status == nil {
+		s, err := c.db.GetStatusByID(ctx, vote.Poll.StatusID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error fetching poll status from database: %s", err)
+		}
+		vote.Poll.Status = s
+	}
+
+	// check if the poll author is already pinned to the status, and fetch it if not
+	pollAuthor := vote.Poll.Status.Account
+	if pollAuthor == nil {
+		a, err := c.db.GetAccountByID(ctx, vote.Poll.Status.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error fetching poll author from database: %s", err)
+		}
+		pollAuthor = a
+	}
+
+	actorIRI, err := url.Parse(vote.Account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error parsing uri %s: %s", vote.Account.URI, err)
+	}
+
+	statusIRI, err := url.Parse(vote.Poll.Status.URI)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error parsing uri %s: %s", vote.Poll.Status.URI, err)
+	}
+
+	toIRI, err := url.Parse(pollAuthor.URI)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error parsing uri %s: %s", pollAuthor.URI, err)
+	}
+
+	creates := make([]vocab.ActivityStreamsCreate, 0, len(vote.Choices))
+	for _, choice := range vote.Choices {
+		if choice < 0 || choice >= len(vote.Poll.Options) {
+			return nil, fmt.Errorf("PollVoteToASCreates: choice %d out of range for poll %s", choice, vote.Poll.ID)
+		}
+
+		// each choice is represented by a Note with the option as its name
+		note := streams.NewActivityStreamsNote()
+
+		noteID := fmt.Sprintf("%s#votes/%s/%d", vote.Account.URI, vote.ID, choice)
+		noteIRI, err := url.Parse(noteID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error parsing uri %s: %s", noteID, err)
+		}
+		idProp := streams.NewJSONLDIdProperty()
+		idProp.SetIRI(noteIRI)
+		note.SetJSONLDId(idProp)
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(vote.Poll.Options[choice])
+		note.SetActivityStreamsName(nameProp)
+
+		inReplyToProp := streams.NewActivityStreamsInReplyToProperty()
+		inReplyToProp.AppendIRI(statusIRI)
+		note.SetActivityStreamsInReplyTo(inReplyToProp)
+
+		attributedToProp := streams.NewActivityStreamsAttributedToProperty()
+		attributedToProp.AppendIRI(actorIRI)
+		note.SetActivityStreamsAttributedTo(attributedToProp)
+
+		toProp := streams.NewActivityStreamsToProperty()
+		toProp.AppendIRI(toIRI)
+		note.SetActivityStreamsTo(toProp)
+
+		publishedProp := streams.NewActivityStreamsPublishedProperty()
+		publishedProp.Set(vote.CreatedAt)
+		note.SetActivityStreamsPublished(publishedProp)
+
+		create, err := c.WrapNoteInCreate(note, false)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error wrapping note in create: %s", err)
+		}
+		creates = append(creates, create)
+	}
+
+	return creates, nil
+}
+
+// asStatus is the set of setters shared by Note and Question,
+// which is used to build the AS representation of a status.
+type asStatus interface {
+	SetJSONLDId(vocab.JSONLDIdProperty)
+	SetActivityStreamsSummary(vocab.ActivityStreamsSummaryProperty)
+	SetActivityStreamsInReplyTo(vocab.ActivityStreamsInReplyToProperty)
+	SetActivityStreamsPublished(vocab.ActivityStreamsPublishedProperty)
+	SetActivityStreamsUpdated(vocab.ActivityStreamsUpdatedProperty)
+	SetActivityStreamsUrl(vocab.ActivityStreamsUrlProperty)
+	SetActivityStreamsAttributedTo(vocab.ActivityStreamsAttributedToProperty)
+	SetActivityStreamsTag(vocab.ActivityStreamsTagProperty)
+	SetActivityStreamsTo(vocab.ActivityStreamsToProperty)
+	SetActivityStreamsCc(vocab.ActivityStreamsCcProperty)
+	SetActivityStreamsContent(vocab.ActivityStreamsContentProperty)
+	SetActivityStreamsAttachment(vocab.ActivityStreamsAttachmentProperty)
+	SetActivityStreamsReplies(vocab.ActivityStreamsRepliesProperty)
+	SetActivityStreamsSensitive(vocab.ActivityStreamsSensitiveProperty)
+}
+
+// populateASStatus sets all the common status properties of s on the given Note or Question.
+func (c *converter) populateASStatus(ctx context.Context, s *gtsmodel.Status, status asStatus) error {
 	// ensure prerequisites here before we get stuck in
 
 	// check if author account is already attached to status and attach it if not
@@ -364,18 +606,15 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	if s.Account == nil {
 		a, err := c.db.GetAccountByID(ctx, s.AccountID)
 		if err != nil {
-			return nil, fmt.Errorf("StatusToAS: error retrieving author account from db: %s", err)
+			return fmt.Errorf("StatusToAS: error retrieving author account from db: %s", err)
 		}
 		s.Account = a
 	}
 
-	// create the Note!
-	status := streams.NewActivityStreamsNote()
-
 	// id
 	statusURI, err := url.Parse(s.URI)
 	if err != nil {
-		return nil, fmt.Errorf("StatusToAS: error parsing url %s: %s", s.URI, err)
+		return fmt.Errorf("StatusToAS: error parsing url %s: %s", s.URI, err)
 	}
 	statusIDProp := streams.NewJSONLDIdProperty()
 	statusIDProp.SetIRI(statusURI)
@@ -395,13 +634,13 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		if s.InReplyTo == nil {
 			rs := &gtsmodel.Status{}
 			if err := c.db.GetByID(ctx, s.InReplyToID, rs); err != nil {
-				return nil, fmt.Errorf("StatusToAS: error retrieving replied-to status from db: %s", err)
+				return fmt.Errorf("StatusToAS: error retrieving replied-to status from db: %s", err)
 			}
 			s.InReplyTo = rs
 		}
 		rURI, err := url.Parse(s.InReplyTo.URI)
 		if err != nil {
-			return nil, fmt.Errorf("StatusToAS: error parsing url %s: %s", s.InReplyTo.URI, err)
+			return fmt.Errorf("StatusToAS: error parsing url %s: %s", s.InReplyTo.URI, err)
 		}
 
 		inReplyToProp := streams.NewActivityStreamsInReplyToProperty()
@@ -414,11 +653,18 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		updatedProp := streams.NewActivityStreamsUpdatedProperty()
+		updatedProp.Set(s.EditedAt)
+		status.SetActivityStreamsUpdated(updatedProp)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
 		if err != nil {
-			return nil, fmt.Errorf("StatusToAS: error parsing url %s: %s", s.URL, err)
+			return fmt.Errorf("StatusToAS: error parsing url %s: %s", s.URL, err)
 		}
 
 		urlProp := streams.NewActivityStreamsUrlProperty()
@@ -429,7 +675,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	// attributedTo
 	authorAccountURI, err := url.Parse(s.Account.URI)
 	if err != nil {
-		return nil, fmt.Errorf("StatusToAS: error parsing url %s: %s", s.Account.URI, err)
+		return fmt.Errorf("StatusToAS: error parsing url %s: %s", s.Account.URI, err)
 	}
 	attributedToProp := streams.NewActivityStreamsAttributedToProperty()
 	attributedToProp.AppendIRI(authorAccountURI)
@@ -442,7 +688,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	for _, m := range s.Mentions {
 		asMention, err := c.MentionToAS(ctx, m)
 		if err != nil {
-			return nil, fmt.Errorf("StatusToAS: error converting mention to AS mention: %s", err)
+			return fmt.Errorf("StatusToAS: error converting mention to AS mention: %s", err)
 		}
 		tagProp.AppendActivityStreamsMention(asMention)
 	}
@@ -458,12 +704,12 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	// parse out some URIs we need here
 	authorFollowersURI, err := url.Parse(s.Account.FollowersURI)
 	if err != nil {
-		return nil, fmt.Errorf("StatusToAS: error parsing url %s: %s", s.Account.FollowersURI, err)
+		return fmt.Errorf("StatusToAS: error parsing url %s: %s", s.Account.FollowersURI, err)
 	}
 
 	publicURI, err := url.Parse(pub.PublicActivityPubIRI)
 	if err != nil {
-		return nil, fmt.Errorf("StatusToAS: error parsing url %s: %s", pub.PublicActivityPubIRI, err)
+		return fmt.Errorf("StatusToAS: error parsing url %s: %s", pub.PublicActivityPubIRI, err)
 	}
 
 	// to and cc
@@ -475,7 +721,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		for _, m := range s.Mentions {
 			iri, err := url.Parse(m.TargetAccount.URI)
 			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
+				return fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
 			}
 			toProp.AppendIRI(iri)
 		}
@@ -487,7 +733,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		for _, m := range s.Mentions {
 			iri, err := url.Parse(m.TargetAccount.URI)
 			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
+				return fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
 			}
 			ccProp.AppendIRI(iri)
 		}
@@ -498,7 +744,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		for _, m := range s.Mentions {
 			iri, err := url.Parse(m.TargetAccount.URI)
 			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
+				return fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
 			}
 			ccProp.AppendIRI(iri)
 		}
@@ -509,7 +755,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		for _, m := range s.Mentions {
 			iri, err := url.Parse(m.TargetAccount.URI)
 			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
+				return fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
 			}
 			ccProp.AppendIRI(iri)
 		}
@@ -530,7 +776,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	for _, a := range s.Attachments {
 		doc, err := c.AttachmentToAS(ctx, a)
 		if err != nil {
-			return nil, fmt.Errorf("StatusToAS: error converting attachment: %s", err)
+			return fmt.Errorf("StatusToAS: error converting attachment: %s", err)
 		}
 		attachmentProp.AppendActivityStreamsDocument(doc)
 	}
@@ -539,7 +785,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	// replies
 	repliesCollection, err := c.StatusToASRepliesCollection(ctx, s, false)
 	if err != nil {
-		return nil, fmt.Errorf("error creating repliesCollection: %s", err)
+		return fmt.Errorf("error creating repliesCollection: %s", err)
 	}
 
 	repliesProp := streams.NewActivityStreamsRepliesProperty()
@@ -551,12 +797,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	sensitiveProp.AppendXMLSchemaBoolean(s.Sensitive)
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
-	// put the note in our cache in case we need it again soon
-	if err := c.asCache.Store(s.ID, status); err != nil {
-		return nil, err
-	}
-
-	return status, nil
+	return nil
 }
 
 func (c *converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsFollow, error) {
@@ -904,6 +1145,139 @@ func (c *converter) BlockToAS(ctx context.Context, b *gtsmodel.Block) (vocab.Act
 	return block, nil
 }
 
+func (c *converter) AccountToASMove(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsMove, error) {
+	if a.MovedToURI == "" {
+		return nil, fmt.Errorf("AccountToASMove: account %s hasn't moved", a.ID)
+	}
+
+	// create the move
+	move := streams.NewActivityStreamsMove()
+
+	// set the ID property to a new move URI
+	moveID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idString := uris.GenerateURIForMove(a.Username, moveID)
+	idIRI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing uri %s: %s", idString, err)
+	}
+	idProp.Set(idIRI)
+	move.SetJSONLDId(idProp)
+
+	// the actor and object are both the moving account
+	accountIRI, err := url.Parse(a.URI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing uri %s: %s", a.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(accountIRI)
+	move.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(accountIRI)
+	move.SetActivityStreamsObject(objectProp)
+
+	// the target is the account being moved to
+	targetIRI, err := url.Parse(a.MovedToURI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing uri %s: %s", a.MovedToURI, err)
+	}
+	targetProp := streams.NewActivityStreamsTargetProperty()
+	targetProp.AppendIRI(targetIRI)
+	move.SetActivityStreamsTarget(targetProp)
+
+	// the move is addressed to followers, since they're the ones who need to act on it
+	followersIRI, err := url.Parse(a.FollowersURI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing uri %s: %s", a.FollowersURI, err)
+	}
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(followersIRI)
+	move.SetActivityStreamsTo(toProp)
+
+	return move, nil
+}
+
+func (c *converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error getting report target account from database: %s", err)
+		}
+		r.TargetAccount = a
+	}
+
+	if r.Statuses == nil {
+		for _, statusID := range r.StatusIDs {
+			s, err := c.db.GetStatusByID(ctx, statusID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToASFlag: error getting report status %s from database: %s", statusID, err)
+			}
+			r.Statuses = append(r.Statuses, s)
+		}
+	}
+
+	// the flag is sent by the instance actor rather than the reporting account, so that the reporter stays anonymous
+	instanceAccount, err := c.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error getting instance account from database: %s", err)
+	}
+
+	// create the flag
+	flag := streams.NewActivityStreamsFlag()
+
+	// set the actor property to the instance account's URI
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorIRI, err := url.Parse(instanceAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", instanceAccount.URI, err)
+	}
+	actorProp.AppendIRI(actorIRI)
+	flag.SetActivityStreamsActor(actorProp)
+
+	// set the ID property to the report's URI
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.URI, err)
+	}
+	idProp.Set(idIRI)
+	flag.SetJSONLDId(idProp)
+
+	// set the object property to the target account's URI, followed by the URIs of any reported statuses
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	targetIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.TargetAccount.URI, err)
+	}
+	objectProp.AppendIRI(targetIRI)
+	for _, s := range r.Statuses {
+		statusIRI, err := url.Parse(s.URI)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", s.URI, err)
+		}
+		objectProp.AppendIRI(statusIRI)
+	}
+	flag.SetActivityStreamsObject(objectProp)
+
+	// set the content property to the comment, if there is one
+	if r.Comment != "" {
+		contentProp := streams.NewActivityStreamsContentProperty()
+		contentProp.AppendXMLSchemaString(r.Comment)
+		flag.SetActivityStreamsContent(contentProp)
+	}
+
+	// set the TO property to the target account's IRI
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(targetIRI)
+	flag.SetActivityStreamsTo(toProp)
+
+	return flag, nil
+}
+
 /*
 	the goal is to end up with something like this:
 
@@ -1175,3 +1549,34 @@ func (c *converter) OutboxToASCollection(ctx context.Context, outboxID string) (
 
 	return collection, nil
 }
+
+func (c *converter) StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error) {
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	// .id
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	featuredCollectionIDURI, err := url.Parse(featuredCollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", featuredCollectionID)
+	}
+	collectionIDProp.SetIRI(featuredCollectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	// .totalItems
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(statuses))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	// .orderedItems
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, s := range statuses {
+		note, err := c.StatusToAS(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		itemsProp.AppendActivityStreamsNote(note)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	return collection, nil
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type InternalToASTestSuite struct {
@@ -49,6 +52,73 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 	// TODO: write assertions here, rn we're just eyeballing the output
 }
 
+func (suite *InternalToASTestSuite) TestAccountToASWithMove() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.AlsoKnownAsURIs = []string{"http://fossbros-anonymous.io/users/foss_satan"}
+	testAccount.MovedToURI = "http://fossbros-anonymous.io/users/foss_satan"
+
+	asPerson, err := suite.typeconverter.AccountToAS(context.Background(), testAccount)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asPerson)
+	suite.NoError(err)
+
+	suite.Equal([]interface{}{"http://fossbros-anonymous.io/users/foss_satan"}, ser["alsoKnownAs"])
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan", ser["movedTo"])
+
+	// the aliases should survive a round trip through our own parsing
+	t, err := streams.ToType(context.Background(), ser)
+	suite.NoError(err)
+
+	accountable, ok := t.(ap.Accountable)
+	suite.True(ok)
+
+	parsed, err := suite.typeconverter.ASRepresentationToAccount(context.Background(), accountable, true)
+	suite.NoError(err)
+	suite.Equal(testAccount.AlsoKnownAsURIs, parsed.AlsoKnownAsURIs)
+	suite.Equal(testAccount.MovedToURI, parsed.MovedToURI)
+}
+
+func (suite *InternalToASTestSuite) TestAccountToASMove() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.MovedToURI = "http://fossbros-anonymous.io/users/foss_satan"
+
+	asMove, err := suite.typeconverter.AccountToASMove(context.Background(), testAccount)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asMove)
+	suite.NoError(err)
+
+	suite.Equal("Move", ser["type"])
+	suite.Equal(testAccount.URI, ser["actor"])
+	suite.Equal(testAccount.URI, ser["object"])
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan", ser["target"])
+	suite.Equal(testAccount.FollowersURI, ser["to"])
+	suite.Contains(ser["id"], "http://localhost:8080/users/the_mighty_zork#moves/")
+}
+
+func (suite *InternalToASTestSuite) TestReportToASFlag() {
+	testReport := testrig.NewTestReports()["local_account_2_report_remote_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	asFlag, err := suite.typeconverter.ReportToASFlag(context.Background(), testReport)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asFlag)
+	suite.NoError(err)
+
+	// the flag should come from the instance actor, not the reporting account
+	suite.Equal("Flag", ser["type"])
+	suite.Equal("http://localhost:8080/users/localhost:8080", ser["actor"])
+	suite.Equal(testReport.URI, ser["id"])
+	suite.Equal([]interface{}{targetAccount.URI, reportedStatus.URI}, ser["object"])
+	suite.Equal(testReport.Comment, ser["content"])
+	suite.Equal(targetAccount.URI, ser["to"])
+}
+
 func (suite *InternalToASTestSuite) TestOutboxToASCollection() {
 	testAccount := suite.testAccounts["admin_account"]
 	ctx := context.Background()
@@ -127,6 +197,40 @@ func (suite *InternalToASTestSuite) TestStatusToASNotSensitive() {
 	suite.Equal(`{"@context":"https://www.w3.org/ns/activitystreams","attachment":[],"attributedTo":"http://localhost:8080/users/admin","cc":"http://localhost:8080/users/admin/followers","content":"hello world! #welcome ! first post on the instance :rainbow: !","id":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R","published":"2021-10-20T11:36:45Z","replies":{"first":{"id":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies?page=true","next":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies?only_other_accounts=false\u0026page=true","partOf":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies","type":"CollectionPage"},"id":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies","type":"Collection"},"sensitive":false,"summary":"","tag":[],"to":"https://www.w3.org/ns/activitystreams#Public","type":"Note","url":"http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R"}`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASQuestion() {
+	// take a copy of the test status so we don't modify it for other tests
+	testStatus := *suite.testStatuses["local_account_1_status_1"]
+	testStatus.PollID = "01FZTVA6PVB3YHJBCBXYVKZ5XS"
+	testStatus.Poll = &gtsmodel.Poll{
+		ID:        "01FZTVA6PVB3YHJBCBXYVKZ5XS",
+		StatusID:  testStatus.ID,
+		Multiple:  true,
+		Options:   []string{"yes", "no", "maybe"},
+		Votes:     []int{3, 1, 0},
+		Voters:    3,
+		ExpiresAt: testrig.TimeMustParse("2022-04-05T12:00:00+02:00"),
+	}
+	ctx := context.Background()
+
+	asQuestion, err := suite.typeconverter.StatusToASQuestion(ctx, &testStatus)
+	suite.NoError(err)
+	suite.Equal("Question", asQuestion.GetTypeName())
+
+	// we should be able to get the same poll back out of the question
+	poll, err := ap.ExtractPoll(asQuestion)
+	suite.NoError(err)
+	suite.True(poll.Multiple)
+	suite.Equal([]string{"yes", "no", "maybe"}, poll.Options)
+	suite.Equal([]int{3, 1, 0}, poll.Votes)
+	suite.Equal(3, poll.Voters)
+	suite.True(poll.ExpiresAt.Equal(testStatus.Poll.ExpiresAt))
+	suite.True(poll.ClosedAt.IsZero())
+
+	create, err := suite.typeconverter.WrapQuestionInCreate(asQuestion, false)
+	suite.NoError(err)
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/activity", create.GetJSONLDId().GetIRI().String())
+}
+
 func (suite *InternalToASTestSuite) TestStatusesToASOutboxPage() {
 	testAccount := suite.testAccounts["admin_account"]
 	ctx := context.Background()
@@ -21,6 +21,7 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -55,18 +56,45 @@ func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 	}
 
 	apiAccount.Source = &model.Source{
-		Privacy:             c.VisToAPIVis(ctx, a.Privacy),
-		Sensitive:           a.Sensitive,
-		Language:            a.Language,
-		Note:                a.Note,
-		Fields:              apiAccount.Fields,
-		FollowRequestsCount: frc,
+		Privacy:               c.VisToAPIVis(ctx, a.Privacy),
+		Sensitive:             a.Sensitive,
+		Language:              a.Language,
+		Note:                  a.Note,
+		Fields:                apiAccount.Fields,
+		FollowRequestsCount:   frc,
+		AlsoKnownAsURIs:       a.AlsoKnownAsURIs,
+		ExcludedNotifications: a.ExcludedNotifications,
 	}
 
 	return apiAccount, nil
 }
 
 func (c *converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.Account) (*model.Account, error) {
+	apiAccount, err := c.accountToAPIAccountPublic(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	// if the account has moved, include the account it moved to;
+	// this is only done one level deep so that chains of moves
+	// (or an account and its alias pointing at each other) can't loop
+	if a.MovedToAccountID != "" {
+		movedTo, err := c.db.GetAccountByID(ctx, a.MovedToAccountID)
+		if err != nil {
+			logrus.Errorf("AccountToAPIAccountPublic: error getting moved to account with id %s: %s", a.MovedToAccountID, err)
+			return apiAccount, nil
+		}
+
+		apiAccount.Moved, err = c.accountToAPIAccountPublic(ctx, movedTo)
+		if err != nil {
+			return nil, fmt.Errorf("error converting moved to account: %s", err)
+		}
+	}
+
+	return apiAccount, nil
+}
+
+func (c *converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.Account) (*model.Account, error) {
 	if a == nil {
 		return nil, fmt.Errorf("given account was nil")
 	}
@@ -501,7 +529,26 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 	}
 
 	var apiCard *model.Card
+
 	var apiPoll *model.Poll
+	if s.PollID != "" {
+		// the poll might have been set on this struct already so check first before doing db calls
+		poll := s.Poll
+		if poll == nil {
+			p, err := c.db.GetPollByID(ctx, s.PollID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting poll with id %s: %s", s.PollID, err)
+			}
+			p.Status = s
+			s.Poll = p
+			poll = p
+		}
+
+		apiPoll, err = c.PollToAPIPoll(ctx, poll, requestingAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error converting poll to apitype: %s", err)
+		}
+	}
 
 	statusInteractions := &statusInteractions{}
 	si, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount)
@@ -509,9 +556,20 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		statusInteractions = si
 	}
 
+	apiFiltered, err := c.filterResultsForAccount(ctx, s, requestingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error getting filter results: %s", err)
+	}
+
+	var editedAt string
+	if !s.EditedAt.IsZero() {
+		editedAt = s.EditedAt.Format(time.RFC3339)
+	}
+
 	apiStatus := &model.Status{
 		ID:                 s.ID,
 		CreatedAt:          s.CreatedAt.Format(time.RFC3339),
+		EditedAt:           editedAt,
 		InReplyToID:        s.InReplyToID,
 		InReplyToAccountID: s.InReplyToAccountID,
 		Sensitive:          s.Sensitive,
@@ -536,7 +594,8 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
 		Card:               apiCard, // TODO: implement cards
-		Poll:               apiPoll, // TODO: implement polls
+		Poll:               apiPoll,
+		Filtered:           apiFiltered,
 		Text:               s.Text,
 	}
 
@@ -547,6 +606,61 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 	return apiStatus, nil
 }
 
+func (c *converter) StatusEditToAPIStatusEdit(ctx context.Context, e *gtsmodel.StatusEdit, s *gtsmodel.Status) (*model.StatusEdit, error) {
+	if s.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, s.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting status author: %s", err)
+		}
+		s.Account = a
+	}
+
+	apiAuthorAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account of status author: %s", err)
+	}
+
+	apiAttachments := []model.Attachment{}
+	for _, aID := range e.AttachmentIDs {
+		gtsAttachment, err := c.db.GetAttachmentByID(ctx, aID)
+		if err != nil {
+			logrus.Errorf("error getting attachment with id %s: %s", aID, err)
+			continue
+		}
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, gtsAttachment)
+		if err != nil {
+			logrus.Errorf("error converting attachment with id %s: %s", aID, err)
+			continue
+		}
+		apiAttachments = append(apiAttachments, apiAttachment)
+	}
+
+	apiEmojis := []model.Emoji{}
+	for _, eID := range e.EmojiIDs {
+		gtsEmoji := &gtsmodel.Emoji{}
+		if err := c.db.GetByID(ctx, eID, gtsEmoji); err != nil {
+			logrus.Errorf("error getting emoji with id %s: %s", eID, err)
+			continue
+		}
+		apiEmoji, err := c.EmojiToAPIEmoji(ctx, gtsEmoji)
+		if err != nil {
+			logrus.Errorf("error converting emoji with id %s: %s", eID, err)
+			continue
+		}
+		apiEmojis = append(apiEmojis, apiEmoji)
+	}
+
+	return &model.StatusEdit{
+		Content:          e.Content,
+		SpoilerText:      e.ContentWarning,
+		Sensitive:        e.Sensitive,
+		CreatedAt:        e.CreatedAt.Format(time.RFC3339),
+		Account:          apiAuthorAccount,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	}, nil
+}
+
 // VisToapi converts a gts visibility into its api equivalent
 func (c *converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) model.Visibility {
 	switch m {
@@ -691,6 +805,12 @@ func (c *converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 		if err != nil {
 			return nil, fmt.Errorf("NotificationToapi: error converting status to api: %s", err)
 		}
+
+		// the notification is seen by the target account, so their filters apply to it
+		apiStatus.Filtered, err = c.filterResultsForAccount(ctx, n.Status, n.TargetAccount)
+		if err != nil {
+			return nil, fmt.Errorf("NotificationToapi: error getting filter results: %s", err)
+		}
 	}
 
 	return &model.Notification{
@@ -721,3 +841,519 @@ func (c *converter) DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel
 
 	return domainBlock, nil
 }
+
+func (c *converter) DomainAllowToAPIDomainAllow(ctx context.Context, a *gtsmodel.DomainAllow, export bool) (*model.DomainAllow, error) {
+	domainAllow := &model.DomainAllow{
+		Domain:        a.Domain,
+		PublicComment: a.PublicComment,
+	}
+
+	// if we're exporting a domain allow, return it with minimal information attached
+	if !export {
+		domainAllow.ID = a.ID
+		domainAllow.PrivateComment = a.PrivateComment
+		domainAllow.CreatedBy = a.CreatedByAccountID
+		domainAllow.CreatedAt = a.CreatedAt.Format(time.RFC3339)
+	}
+
+	return domainAllow, nil
+}
+
+func (c *converter) DeliveryToAPIDelivery(ctx context.Context, d *gtsmodel.Delivery) (*model.Delivery, error) {
+	delivery := &model.Delivery{
+		ID:        d.ID,
+		CreatedAt: d.CreatedAt.Format(time.RFC3339),
+		Inbox:     d.InboxURI,
+		Host:      d.Host,
+		KeyID:     d.PubKeyID,
+		State:     string(d.State),
+		Attempts:  d.Attempts,
+		LastError: d.LastError,
+		Payload:   string(d.Payload),
+	}
+
+	if !d.LastAttemptAt.IsZero() {
+		delivery.LastAttemptAt = d.LastAttemptAt.Format(time.RFC3339)
+	}
+
+	// failed deliveries won't be attempted again
+	if d.State == gtsmodel.DeliveryStatePending {
+		delivery.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+
+	return delivery, nil
+}
+
+func (c *converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*model.List, error) {
+	return &model.List{
+		ID:            l.ID,
+		Title:         l.Title,
+		RepliesPolicy: string(l.RepliesPolicy),
+	}, nil
+}
+
+func (c *converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*model.Report, error) {
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAPIReport: error getting report target account from database: %s", err)
+		}
+		r.TargetAccount = a
+	}
+
+	apiTargetAccount, err := c.AccountToAPIAccountPublic(ctx, r.TargetAccount)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToAPIReport: error converting target account: %s", err)
+	}
+
+	report := &model.Report{
+		ID:            r.ID,
+		ActionTaken:   r.Resolved(),
+		Category:      string(r.Category),
+		Comment:       r.Comment,
+		Forwarded:     r.Forwarded,
+		CreatedAt:     r.CreatedAt.Format(time.RFC3339),
+		StatusIDs:     []string{},
+		RuleIDs:       []string{},
+		TargetAccount: apiTargetAccount,
+	}
+
+	if r.Resolved() {
+		actionTakenAt := r.ActionTakenAt.Format(time.RFC3339)
+		report.ActionTakenAt = &actionTakenAt
+	}
+
+	if r.StatusIDs != nil {
+		report.StatusIDs = r.StatusIDs
+	}
+
+	return report, nil
+}
+
+func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*model.AdminReportInfo, error) {
+	// convert an account that the report points to, fetching it if necessary
+	convertAccount := func(accountID string, account *gtsmodel.Account) (*model.Account, error) {
+		if accountID == "" {
+			return nil, nil
+		}
+		if account == nil {
+			a, err := c.db.GetAccountByID(ctx, accountID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToAdminAPIReport: error getting account %s from database: %s", accountID, err)
+			}
+			account = a
+		}
+		return c.AccountToAPIAccountPublic(ctx, account)
+	}
+
+	apiAccount, err := convertAccount(r.AccountID, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTargetAccount, err := convertAccount(r.TargetAccountID, r.TargetAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	apiAssignedAccount, err := convertAccount(r.AssignedAccountID, r.AssignedAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	apiActionTakenByAccount, err := convertAccount(r.ActionTakenByAccountID, r.ActionTakenByAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.AdminReportInfo{
+		ID:                   r.ID,
+		ActionTaken:          r.Resolved(),
+		Category:             string(r.Category),
+		Comment:              r.Comment,
+		Forwarded:            r.Forwarded,
+		CreatedAt:            r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            r.UpdatedAt.Format(time.RFC3339),
+		Account:              apiAccount,
+		TargetAccount:        apiTargetAccount,
+		AssignedAccount:      apiAssignedAccount,
+		ActionTakenByAccount: apiActionTakenByAccount,
+		Statuses:             []*model.Status{},
+		Rules:                []interface{}{},
+	}
+
+	if r.Resolved() {
+		actionTakenAt := r.ActionTakenAt.Format(time.RFC3339)
+		report.ActionTakenAt = &actionTakenAt
+	}
+
+	for _, statusID := range r.StatusIDs {
+		s, err := c.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// the status has been deleted since the report was made
+				continue
+			}
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error getting status %s from database: %s", statusID, err)
+		}
+
+		apiStatus, err := c.StatusToAPIStatus(ctx, s, requestingAccount)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting status %s: %s", statusID, err)
+		}
+		report.Statuses = append(report.Statuses, apiStatus)
+	}
+
+	return report, nil
+}
+
+func (c *converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Account) (*model.AdminAccountInfo, error) {
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToAdminAPIAccount: error converting account %s: %s", a.ID, err)
+	}
+
+	adminAccount := &model.AdminAccountInfo{
+		ID:            a.ID,
+		Username:      a.Username,
+		Domain:        a.Domain,
+		CreatedAt:     a.CreatedAt.Format(time.RFC3339),
+		InviteRequest: a.Reason,
+		Role:          "user",
+		IPs:           []model.AdminAccountIP{},
+		Silenced:      !a.SilencedAt.IsZero(),
+		Suspended:     !a.SuspendedAt.IsZero(),
+		Account:       apiAccount,
+	}
+
+	if a.Domain != "" {
+		// remote accounts don't have a user
+		return adminAccount, nil
+	}
+
+	user := &gtsmodel.User{}
+	if err := c.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			// the user of a local account is removed when the account is suspended,
+			// and the instance account never had one
+			return adminAccount, nil
+		}
+		return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting user of account %s: %s", a.ID, err)
+	}
+
+	adminAccount.Email = user.Email
+	if adminAccount.Email == "" {
+		adminAccount.Email = user.UnconfirmedEmail
+	}
+	adminAccount.Locale = user.Locale
+	adminAccount.Confirmed = !user.ConfirmedAt.IsZero()
+	adminAccount.Approved = user.Approved
+	adminAccount.Disabled = user.Disabled
+	adminAccount.CreatedByApplicationID = user.CreatedByApplicationID
+
+	switch {
+	case user.Admin:
+		adminAccount.Role = "admin"
+	case user.Moderator:
+		adminAccount.Role = "moderator"
+	}
+
+	// list ips most recently used first, so the first one is the one the user is currently signed in from
+	for _, ip := range []struct {
+		ip     net.IP
+		usedAt time.Time
+	}{
+		{user.CurrentSignInIP, user.CurrentSignInAt},
+		{user.LastSignInIP, user.LastSignInAt},
+		{user.SignUpIP, user.CreatedAt},
+	} {
+		if ip.ip == nil {
+			continue
+		}
+		adminAccount.IPs = append(adminAccount.IPs, model.AdminAccountIP{
+			IP:     ip.ip.String(),
+			UsedAt: ip.usedAt.Format(time.RFC3339),
+		})
+	}
+	if len(adminAccount.IPs) != 0 {
+		adminAccount.IP = adminAccount.IPs[0].IP
+	}
+
+	return adminAccount, nil
+}
+
+func (c *converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*model.ScheduledStatus, error) {
+	params := &model.StatusParams{
+		Text:          s.Text,
+		InReplyToID:   s.InReplyToID,
+		MediaIDs:      s.AttachmentIDs,
+		Sensitive:     s.Sensitive,
+		SpoilerText:   s.SpoilerText,
+		Visibility:    c.VisToAPIVis(ctx, s.Visibility),
+		ApplicationID: s.ApplicationID,
+		Language:      s.Language,
+	}
+
+	if len(s.PollOptions) != 0 {
+		params.Poll = &model.PollRequest{
+			Options:    s.PollOptions,
+			ExpiresIn:  s.PollExpiresIn,
+			Multiple:   s.PollMultiple,
+			HideTotals: s.PollHideTotals,
+		}
+	}
+
+	apiAttachments := make([]model.Attachment, 0, len(s.Attachments))
+	for _, a := range s.Attachments {
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, a)
+		if err != nil {
+			return nil, fmt.Errorf("ScheduledStatusToAPIScheduledStatus: error converting attachment %s: %s", a.ID, err)
+		}
+		apiAttachments = append(apiAttachments, apiAttachment)
+	}
+
+	return &model.ScheduledStatus{
+		ID:               s.ID,
+		ScheduledAt:      s.ScheduledAt.Format(time.RFC3339),
+		Params:           params,
+		MediaAttachments: apiAttachments,
+	}, nil
+}
+
+func (c *converter) FilterToAPIFilterV2(ctx context.Context, f *gtsmodel.Filter) (*model.FilterV2, error) {
+	apiFilter := &model.FilterV2{
+		ID:           f.ID,
+		Title:        f.Title,
+		Context:      filterContexts(f),
+		FilterAction: string(f.Action),
+		Keywords:     []model.FilterKeyword{},
+		Statuses:     []model.FilterStatus{},
+	}
+
+	if !f.ExpiresAt.IsZero() {
+		expiresAt := f.ExpiresAt.Format(time.RFC3339)
+		apiFilter.ExpiresAt = &expiresAt
+	}
+
+	for _, k := range f.Keywords {
+		apiKeyword, err := c.FilterKeywordToAPIFilterKeyword(ctx, k)
+		if err != nil {
+			return nil, err
+		}
+		apiFilter.Keywords = append(apiFilter.Keywords, *apiKeyword)
+	}
+
+	return apiFilter, nil
+}
+
+func (c *converter) FilterKeywordToAPIFilterKeyword(ctx context.Context, k *gtsmodel.FilterKeyword) (*model.FilterKeyword, error) {
+	return &model.FilterKeyword{
+		ID:        k.ID,
+		Keyword:   k.Keyword,
+		WholeWord: k.WholeWord,
+	}, nil
+}
+
+func (c *converter) FilterKeywordToAPIFilterV1(ctx context.Context, k *gtsmodel.FilterKeyword, f *gtsmodel.Filter) (*model.FilterV1, error) {
+	apiFilter := &model.FilterV1{
+		ID:           k.ID,
+		Phrase:       k.Keyword,
+		Context:      filterContexts(f),
+		WholeWord:    k.WholeWord,
+		Irreversible: f.Action == gtsmodel.FilterActionHide,
+	}
+
+	if !f.ExpiresAt.IsZero() {
+		expiresAt := f.ExpiresAt.Format(time.RFC3339)
+		apiFilter.ExpiresAt = &expiresAt
+	}
+
+	return apiFilter, nil
+}
+
+func (c *converter) PollToAPIPoll(ctx context.Context, p *gtsmodel.Poll, requestingAccount *gtsmodel.Account) (*model.Poll, error) {
+	expired := !p.ClosedAt.IsZero() || (!p.ExpiresAt.IsZero() && p.ExpiresAt.Before(time.Now()))
+
+	var expiresAt string
+	if !p.ExpiresAt.IsZero() {
+		expiresAt = p.ExpiresAt.Format(time.RFC3339)
+	}
+
+	// if the poll author asked for totals to be hidden,
+	// only show them once the poll is over
+	showCounts := !p.HideCounts || expired
+
+	votesCount := 0
+	apiOptions := make([]model.PollOptions, 0, len(p.Options))
+	for i, title := range p.Options {
+		var votes int
+		if i < len(p.Votes) {
+			votes = p.Votes[i]
+		}
+		votesCount += votes
+
+		apiOption := model.PollOptions{
+			Title: title,
+		}
+		if showCounts {
+			apiOption.VotesCount = votes
+		}
+		apiOptions = append(apiOptions, apiOption)
+	}
+
+	apiPoll := &model.Poll{
+		ID:         p.ID,
+		ExpiresAt:  expiresAt,
+		Expired:    expired,
+		Multiple:   p.Multiple,
+		VotesCount: votesCount,
+		Options:    apiOptions,
+		Emojis:     []model.Emoji{},
+	}
+
+	if p.Multiple {
+		apiPoll.VotersCount = p.Voters
+	}
+
+	if requestingAccount != nil {
+		if p.Status == nil {
+			s, err := c.db.GetStatusByID(ctx, p.StatusID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting status for poll %s: %s", p.ID, err)
+			}
+			p.Status = s
+		}
+
+		vote, err := c.db.GetPollVoteBy(ctx, p.ID, requestingAccount.ID)
+		switch err {
+		case nil:
+			apiPoll.Voted = true
+			apiPoll.OwnVotes = vote.Choices
+		case db.ErrNoEntries:
+			// the author of a poll counts as having voted in it, since they can't vote themselves
+			apiPoll.Voted = p.Status.AccountID == requestingAccount.ID
+		default:
+			return nil, fmt.Errorf("error getting poll vote for account %s: %s", requestingAccount.ID, err)
+		}
+	}
+
+	return apiPoll, nil
+}
+
+func (c *converter) ConversationToAPIConversation(ctx context.Context, conversation *gtsmodel.Conversation, requestingAccount *gtsmodel.Account) (*model.Conversation, error) {
+	if conversation.OtherAccounts == nil {
+		otherAccounts := make([]*gtsmodel.Account, 0, len(conversation.OtherAccountIDs))
+		for _, id := range conversation.OtherAccountIDs {
+			a, err := c.db.GetAccountByID(ctx, id)
+			if err != nil {
+				if err == db.ErrNoEntries {
+					// the account has been deleted since the conversation was last updated
+					continue
+				}
+				return nil, fmt.Errorf("ConversationToAPIConversation: error getting account %s from database: %s", id, err)
+			}
+			otherAccounts = append(otherAccounts, a)
+		}
+		conversation.OtherAccounts = otherAccounts
+	}
+
+	apiAccounts := make([]model.Account, 0, len(conversation.OtherAccounts))
+	for _, a := range conversation.OtherAccounts {
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
+		if err != nil {
+			return nil, fmt.Errorf("ConversationToAPIConversation: error converting account %s: %s", a.ID, err)
+		}
+		apiAccounts = append(apiAccounts, *apiAccount)
+	}
+
+	if conversation.LastStatus == nil {
+		s, err := c.db.GetStatusByID(ctx, conversation.LastStatusID)
+		if err != nil {
+			return nil, fmt.Errorf("ConversationToAPIConversation: error getting status %s from database: %s", conversation.LastStatusID, err)
+		}
+		conversation.LastStatus = s
+	}
+
+	apiLastStatus, err := c.StatusToAPIStatus(ctx, conversation.LastStatus, requestingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("ConversationToAPIConversation: error converting status %s: %s", conversation.LastStatusID, err)
+	}
+
+	return &model.Conversation{
+		ID:         conversation.ID,
+		Accounts:   apiAccounts,
+		Unread:     !conversation.Read,
+		LastStatus: apiLastStatus,
+	}, nil
+}
+
+func (c *converter) MarkersToAPIMarker(ctx context.Context, markers []*gtsmodel.Marker) (*model.Marker, error) {
+	apiMarker := &model.Marker{}
+
+	for _, m := range markers {
+		timelineMarker := &model.TimelineMarker{
+			LastReadID: m.LastReadID,
+			UpdatedAt:  m.UpdatedAt.Format(time.RFC3339),
+			Version:    m.Version,
+		}
+
+		switch m.Name {
+		case gtsmodel.MarkerNameHome:
+			apiMarker.Home = timelineMarker
+		case gtsmodel.MarkerNameNotifications:
+			apiMarker.Notifications = timelineMarker
+		default:
+			return nil, fmt.Errorf("MarkersToAPIMarker: marker name %s not recognized", m.Name)
+		}
+	}
+
+	return apiMarker, nil
+}
+
+func (c *converter) PushSubscriptionToAPIPushSubscription(ctx context.Context, s *gtsmodel.PushSubscription) (*model.PushSubscription, error) {
+	keyPair, err := c.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PushSubscriptionToAPIPushSubscription: error getting vapid keypair: %s", err)
+	}
+
+	return &model.PushSubscription{
+		ID:        s.ID,
+		Endpoint:  s.Endpoint,
+		ServerKey: keyPair.PublicKey,
+		Alerts: &model.PushSubscriptionAlerts{
+			Follow:        s.AlertFollow,
+			FollowRequest: s.AlertFollowRequest,
+			Favourite:     s.AlertFavourite,
+			Mention:       s.AlertMention,
+			Reblog:        s.AlertReblog,
+			Poll:          s.AlertPoll,
+			Status:        s.AlertStatus,
+		},
+	}, nil
+}
+
+func (c *converter) TokenToAPIAuthorizedApp(ctx context.Context, t *gtsmodel.Token) (*model.AuthorizedApp, error) {
+	app := &gtsmodel.Application{}
+	if err := c.db.GetWhere(ctx, []db.Where{{Key: "client_id", Value: t.ClientID}}, app); err != nil {
+		return nil, fmt.Errorf("TokenToAPIAuthorizedApp: error getting application for client %s: %s", t.ClientID, err)
+	}
+
+	apiApp, err := c.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, fmt.Errorf("TokenToAPIAuthorizedApp: error converting application %s: %s", app.ID, err)
+	}
+
+	var lastUsedAt *string
+	if !t.LastUsedAt.IsZero() {
+		l := t.LastUsedAt.Format(time.RFC3339)
+		lastUsedAt = &l
+	}
+
+	return &model.AuthorizedApp{
+		ID:          t.ID,
+		Application: apiApp,
+		Scopes:      strings.Fields(t.Scope),
+		CreatedAt:   t.CreatedAt.Format(time.RFC3339),
+		LastUsedAt:  lastUsedAt,
+	}, nil
+}
@@ -3,8 +3,11 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 func (c *converter) interactionsWithStatusForAccount(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*statusInteractions, error) {
@@ -45,3 +48,51 @@ type statusInteractions struct {
 	Bookmarked bool
 	Reblogged  bool
 }
+
+// filterResultsForAccount returns the filters of the requesting account that match the given status, in their api representation.
+// The requesting account's own statuses are never filtered.
+func (c *converter) filterResultsForAccount(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) ([]model.FilterResult, error) {
+	if requestingAccount == nil || s.AccountID == requestingAccount.ID {
+		return nil, nil
+	}
+
+	filters, err := c.db.GetFiltersForAccountID(ctx, requestingAccount.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	results := []model.FilterResult{}
+	for _, match := range visibility.MatchFilters(filters, s, time.Now()) {
+		apiFilter, err := c.FilterToAPIFilterV2(ctx, match.Filter)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.FilterResult{
+			Filter:         *apiFilter,
+			KeywordMatches: match.Keywords,
+			StatusMatches:  []string{},
+		})
+	}
+
+	return results, nil
+}
+
+// filterContexts returns the contexts that the given filter applies to, as strings.
+func filterContexts(f *gtsmodel.Filter) []string {
+	contexts := []string{}
+	for _, fc := range []gtsmodel.FilterContext{
+		gtsmodel.FilterContextHome,
+		gtsmodel.FilterContextNotifications,
+		gtsmodel.FilterContextPublic,
+		gtsmodel.FilterContextThread,
+		gtsmodel.FilterContextAccount,
+	} {
+		if f.AppliesTo(fc) {
+			contexts = append(contexts, string(fc))
+		}
+	}
+	return contexts
+}
@@ -69,20 +69,46 @@ func (c *converter) WrapPersonInUpdate(person vocab.ActivityStreamsPerson, origi
 }
 
 func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error) {
+	return wrapStatusableInCreate(note, objectIRIOnly, func(objectProp vocab.ActivityStreamsObjectProperty) {
+		objectProp.AppendActivityStreamsNote(note)
+	})
+}
+
+func (c *converter) WrapQuestionInCreate(question vocab.ActivityStreamsQuestion, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error) {
+	return wrapStatusableInCreate(question, objectIRIOnly, func(objectProp vocab.ActivityStreamsObjectProperty) {
+		objectProp.AppendActivityStreamsQuestion(question)
+	})
+}
+
+func (c *converter) WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
+	return wrapStatusableInUpdate(note, originAccount, func(objectProp vocab.ActivityStreamsObjectProperty) {
+		objectProp.AppendActivityStreamsNote(note)
+	})
+}
+
+func (c *converter) WrapQuestionInUpdate(question vocab.ActivityStreamsQuestion, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
+	return wrapStatusableInUpdate(question, originAccount, func(objectProp vocab.ActivityStreamsObjectProperty) {
+		objectProp.AppendActivityStreamsQuestion(question)
+	})
+}
+
+// wrapStatusableInCreate wraps the given statusable in a Create activity,
+// using appendObject to set the full statusable as the object if required.
+func wrapStatusableInCreate(statusable ap.Statusable, objectIRIOnly bool, appendObject func(vocab.ActivityStreamsObjectProperty)) (vocab.ActivityStreamsCreate, error) {
 	create := streams.NewActivityStreamsCreate()
 
 	// Object property
 	objectProp := streams.NewActivityStreamsObjectProperty()
 	if objectIRIOnly {
-		objectProp.AppendIRI(note.GetJSONLDId().GetIRI())
+		objectProp.AppendIRI(statusable.GetJSONLDId().GetIRI())
 	} else {
-		objectProp.AppendActivityStreamsNote(note)
+		appendObject(objectProp)
 	}
 	create.SetActivityStreamsObject(objectProp)
 
 	// ID property
 	idProp := streams.NewJSONLDIdProperty()
-	createID := fmt.Sprintf("%s/activity", note.GetJSONLDId().GetIRI().String())
+	createID := fmt.Sprintf("%s/activity", statusable.GetJSONLDId().GetIRI().String())
 	createIDIRI, err := url.Parse(createID)
 	if err != nil {
 		return nil, err
@@ -92,25 +118,25 @@ func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOn
 
 	// Actor Property
 	actorProp := streams.NewActivityStreamsActorProperty()
-	actorIRI, err := ap.ExtractAttributedTo(note)
+	actorIRI, err := ap.ExtractAttributedTo(statusable)
 	if err != nil {
-		return nil, fmt.Errorf("WrapNoteInCreate: couldn't extract AttributedTo: %s", err)
+		return nil, fmt.Errorf("wrapStatusableInCreate: couldn't extract AttributedTo: %s", err)
 	}
 	actorProp.AppendIRI(actorIRI)
 	create.SetActivityStreamsActor(actorProp)
 
 	// Published Property
 	publishedProp := streams.NewActivityStreamsPublishedProperty()
-	published, err := ap.ExtractPublished(note)
+	published, err := ap.ExtractPublished(statusable)
 	if err != nil {
-		return nil, fmt.Errorf("WrapNoteInCreate: couldn't extract Published: %s", err)
+		return nil, fmt.Errorf("wrapStatusableInCreate: couldn't extract Published: %s", err)
 	}
 	publishedProp.Set(published)
 	create.SetActivityStreamsPublished(publishedProp)
 
 	// To Property
 	toProp := streams.NewActivityStreamsToProperty()
-	tos, err := ap.ExtractTos(note)
+	tos, err := ap.ExtractTos(statusable)
 	if err == nil {
 		for _, to := range tos {
 			toProp.AppendIRI(to)
@@ -120,7 +146,7 @@ func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOn
 
 	// Cc Property
 	ccProp := streams.NewActivityStreamsCcProperty()
-	ccs, err := ap.ExtractCCs(note)
+	ccs, err := ap.ExtractCCs(statusable)
 	if err == nil {
 		for _, cc := range ccs {
 			ccProp.AppendIRI(cc)
@@ -130,3 +156,57 @@ func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOn
 
 	return create, nil
 }
+
+// wrapStatusableInUpdate wraps the given statusable in an Update activity from originAccount,
+// using appendObject to set the full statusable as the object.
+func wrapStatusableInUpdate(statusable ap.Statusable, originAccount *gtsmodel.Account, appendObject func(vocab.ActivityStreamsObjectProperty)) (vocab.ActivityStreamsUpdate, error) {
+	update := streams.NewActivityStreamsUpdate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("wrapStatusableInUpdate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	update.SetActivityStreamsActor(actorProp)
+
+	// set the ID
+	newID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	idString := uris.GenerateURIForUpdate(originAccount.Username, newID)
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("wrapStatusableInUpdate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	update.SetJSONLDId(idProp)
+
+	// set the statusable as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	appendObject(objectProp)
+	update.SetActivityStreamsObject(objectProp)
+
+	// to and cc should be the same as the statusable
+	if tos, err := ap.ExtractTos(statusable); err == nil {
+		toProp := streams.NewActivityStreamsToProperty()
+		for _, to := range tos {
+			toProp.AppendIRI(to)
+		}
+		update.SetActivityStreamsTo(toProp)
+	}
+
+	if ccs, err := ap.ExtractCCs(statusable); err == nil {
+		ccProp := streams.NewActivityStreamsCcProperty()
+		for _, cc := range ccs {
+			ccProp.AppendIRI(cc)
+		}
+		update.SetActivityStreamsCc(ccProp)
+	}
+
+	return update, nil
+}
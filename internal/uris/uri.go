@@ -41,7 +41,9 @@ const (
 	PublicKeyPath    = "main-key"      // PublicKeyPath is for serving an account's public key
 	FollowPath       = "follow"        // FollowPath used to generate the URI for an individual follow or follow request
 	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
+	MovePath         = "moves"         // MovePath is used to generate the URI for an account move
 	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
+	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
 	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
@@ -100,6 +102,24 @@ func GenerateURIForUpdate(username string, thisUpdateID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, UpdatePath, thisUpdateID)
 }
 
+// GenerateURIForMove returns the AP URI for a new move activity -- something like:
+// https://example.org/users/whatever_user#moves/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForMove(username string, thisMoveID string) string {
+	protocol := viper.GetString(config.Keys.Protocol)
+	host := viper.GetString(config.Keys.Host)
+	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, MovePath, thisMoveID)
+}
+
+// GenerateURIForReport returns the AP URI for a new flag activity -- something like:
+// https://example.org/reports/01F7XTH1QGBAPMGF49WJZ91XGC
+//
+// Reports aren't owned by any one user, since they're sent as the instance actor.
+func GenerateURIForReport(thisReportID string) string {
+	protocol := viper.GetString(config.Keys.Protocol)
+	host := viper.GetString(config.Keys.Host)
+	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, ReportsPath, thisReportID)
+}
+
 // GenerateURIForBlock returns the AP URI for a new block activity -- something like:
 // https://example.org/users/whatever_user/blocks/01F7XTH1QGBAPMGF49WJZ91XGC
 func GenerateURIForBlock(username string, thisBlockID string) string {
@@ -54,7 +54,6 @@ func happyAccount() *gtsmodel.Account {
 		Fields:                  []gtsmodel.Field{},
 		Note:                    "hey yo this is my profile!",
 		Memorial:                false,
-		AlsoKnownAs:             "",
 		MovedToAccountID:        "",
 		Bot:                     false,
 		Reason:                  "I wanna be on this damned webbed site so bad! Please! Wow",
@@ -24,6 +24,7 @@ import (
 	"net/mail"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
@@ -137,6 +138,21 @@ func Privacy(privacy string) error {
 	return fmt.Errorf("privacy %s was not recognized", privacy)
 }
 
+// NotificationType checks that the given notification type is one that this server has
+func NotificationType(notificationType string) error {
+	switch gtsmodel.NotificationType(notificationType) {
+	case gtsmodel.NotificationFollow,
+		gtsmodel.NotificationFollowRequest,
+		gtsmodel.NotificationMention,
+		gtsmodel.NotificationReblog,
+		gtsmodel.NotificationFave,
+		gtsmodel.NotificationPoll,
+		gtsmodel.NotificationStatus:
+		return nil
+	}
+	return fmt.Errorf("notification type %s was not recognized", notificationType)
+}
+
 // EmojiShortcode just runs the given shortcode through the regular expression
 // for emoji shortcodes, to figure out whether it's a valid shortcode, ie., 2-30 characters,
 // lowercase a-z, numbers, and underscores.
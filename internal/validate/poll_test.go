@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package validate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func happyPoll() *gtsmodel.Poll {
+	return &gtsmodel.Poll{
+		ID:         "01FZTVA6PVB3YHJBCBXYVKZ5XS",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		StatusID:   "01FZTVAPZ1ZXNAW4XHY1XKM8ZG",
+		Status:     nil,
+		Multiple:   false,
+		HideCounts: false,
+		Options:    []string{"yes", "no"},
+		Votes:      []int{0, 0},
+		Voters:     0,
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+	}
+}
+
+func happyPollVote() *gtsmodel.PollVote {
+	return &gtsmodel.PollVote{
+		ID:        "01FZTVB5JBMYXN7PZ27PMQ5M5T",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Choices:   []int{1},
+		AccountID: "01FZTVBGKZFTDDGT6Y3MGMRE0M",
+		PollID:    "01FZTVA6PVB3YHJBCBXYVKZ5XS",
+	}
+}
+
+type PollValidateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PollValidateTestSuite) TestValidatePollHappyPath() {
+	// no problem here
+	p := happyPoll()
+	err := validate.Struct(p)
+	suite.NoError(err)
+}
+
+func (suite *PollValidateTestSuite) TestValidatePollBadStatusID() {
+	p := happyPoll()
+
+	p.StatusID = ""
+	err := validate.Struct(p)
+	suite.EqualError(err, "Key: 'Poll.StatusID' Error:Field validation for 'StatusID' failed on the 'required' tag")
+
+	p.StatusID = "9HZJ76B6VXSKF"
+	err = validate.Struct(p)
+	suite.EqualError(err, "Key: 'Poll.StatusID' Error:Field validation for 'StatusID' failed on the 'ulid' tag")
+}
+
+func (suite *PollValidateTestSuite) TestValidatePollOptions() {
+	p := happyPoll()
+
+	p.Options = []string{"yes"}
+	err := validate.Struct(p)
+	suite.EqualError(err, "Key: 'Poll.Options' Error:Field validation for 'Options' failed on the 'min' tag")
+
+	p.Options = []string{"yes", ""}
+	err = validate.Struct(p)
+	suite.EqualError(err, "Key: 'Poll.Options[1]' Error:Field validation for 'Options[1]' failed on the 'required' tag")
+}
+
+func (suite *PollValidateTestSuite) TestValidatePollVoteHappyPath() {
+	// no problem here
+	v := happyPollVote()
+	err := validate.Struct(v)
+	suite.NoError(err)
+}
+
+func (suite *PollValidateTestSuite) TestValidatePollVoteChoices() {
+	v := happyPollVote()
+
+	v.Choices = []int{}
+	err := validate.Struct(v)
+	suite.EqualError(err, "Key: 'PollVote.Choices' Error:Field validation for 'Choices' failed on the 'min' tag")
+
+	v.Choices = []int{-1}
+	err = validate.Struct(v)
+	suite.EqualError(err, "Key: 'PollVote.Choices[0]' Error:Field validation for 'Choices[0]' failed on the 'min' tag")
+}
+
+func TestPollValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(PollValidateTestSuite))
+}
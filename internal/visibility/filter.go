@@ -45,6 +45,28 @@ type Filter interface {
 	//
 	// This function will call StatusVisible internally, so it's not necessary to call it beforehand.
 	StatusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error)
+
+	// StatusTagtimelineable returns true if targetStatus should be in a hashtag timeline of the requesting account.
+	// This works like StatusPublictimelineable, except that replies are allowed.
+	//
+	// This function will call StatusVisible internally, so it's not necessary to call it beforehand.
+	StatusTagtimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error)
+
+	// StatusMuted returns true if targetStatus should be left out of the timelines of requestingAccount, because
+	// requestingAccount has muted the author of the status, the author of the status it boosts, or the account it replies to.
+	//
+	// This function doesn't call StatusVisible, so visibility should be checked separately.
+	StatusMuted(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
+
+	// NotificationMuted returns true if the target account of notification has muted the origin account of
+	// notification, including its notifications, so the notification shouldn't be shown or streamed.
+	NotificationMuted(ctx context.Context, notification *gtsmodel.Notification) (bool, error)
+
+	// StatusFilteredOut returns true if targetStatus matches one of the keyword filters of requestingAccount
+	// that applies in the given context, and that has the hide action, so the status shouldn't be shown at all.
+	//
+	// This function doesn't call StatusVisible, so visibility should be checked separately.
+	StatusFilteredOut(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account, filterContext gtsmodel.FilterContext) (bool, error)
 }
 
 type filter struct {
@@ -0,0 +1,174 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// FilterMatch is one filter that matched a status, along with those of its keywords that matched.
+type FilterMatch struct {
+	Filter   *gtsmodel.Filter
+	Keywords []string
+}
+
+// nonWord matches characters that can't be part of a word, for whole word keyword matching.
+const nonWord = `[^\p{L}\p{M}\p{N}\p{Pc}]`
+
+// paragraphBreaks are replaced with spaces before html is removed, so that words either side of them don't run together.
+var paragraphBreaks = strings.NewReplacer("<br>", " ", "<br/>", " ", "<br />", " ", "</p>", " ")
+
+// MatchFilters returns those of the given filters that match the given status, ignoring any that have expired.
+//
+// Matching is case-insensitive, and is done against the content warning, text, media descriptions,
+// and poll options of the status. Boosts are matched using the status that was boosted, which should
+// already be set on the status if it's a boost.
+func MatchFilters(filters []*gtsmodel.Filter, status *gtsmodel.Status, now time.Time) []*FilterMatch {
+	if status.BoostOf != nil {
+		status = status.BoostOf
+	}
+
+	var statusText string
+	matches := []*FilterMatch{}
+	for _, f := range filters {
+		if f.Expired(now) {
+			continue
+		}
+
+		keywords := []string{}
+		for _, k := range f.Keywords {
+			if statusText == "" {
+				statusText = filterableText(status)
+			}
+			if keywordMatches(k, statusText) {
+				keywords = append(keywords, k.Keyword)
+			}
+		}
+
+		if len(keywords) != 0 {
+			matches = append(matches, &FilterMatch{
+				Filter:   f,
+				Keywords: keywords,
+			})
+		}
+	}
+
+	return matches
+}
+
+func (f *filter) StatusFilteredOut(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account, filterContext gtsmodel.FilterContext) (bool, error) {
+	// filters don't apply to logged-out users, or to the requester's own statuses
+	if requestingAccount == nil || targetStatus.AccountID == requestingAccount.ID {
+		return false, nil
+	}
+
+	filters, err := f.db.GetFiltersForAccountID(ctx, requestingAccount.ID)
+	if err != nil {
+		return false, fmt.Errorf("StatusFilteredOut: error getting filters for account %s: %s", requestingAccount.ID, err)
+	}
+
+	hideFilters := []*gtsmodel.Filter{}
+	for _, filter := range filters {
+		if filter.Action == gtsmodel.FilterActionHide && filter.AppliesTo(filterContext) {
+			hideFilters = append(hideFilters, filter)
+		}
+	}
+
+	if len(hideFilters) == 0 {
+		return false, nil
+	}
+
+	// pin the boosted status on to this status if it hasn't been done already
+	if targetStatus.BoostOfID != "" && targetStatus.BoostOf == nil {
+		bs, err := f.db.GetStatusByID(ctx, targetStatus.BoostOfID)
+		if err != nil {
+			return false, fmt.Errorf("StatusFilteredOut: error getting boosted status with id %s: %s", targetStatus.BoostOfID, err)
+		}
+		targetStatus.BoostOf = bs
+	}
+
+	return len(MatchFilters(hideFilters, targetStatus, time.Now())) != 0, nil
+}
+
+// filterableText returns all the user-provided text of the given status, without html, in lower case.
+func filterableText(status *gtsmodel.Status) string {
+	fields := []string{
+		status.ContentWarning,
+		html.UnescapeString(text.RemoveHTML(paragraphBreaks.Replace(status.Content))),
+	}
+
+	for _, a := range status.Attachments {
+		fields = append(fields, a.Description)
+	}
+
+	if status.Poll != nil {
+		fields = append(fields, status.Poll.Options...)
+	}
+
+	return strings.ToLower(strings.Join(fields, "\n"))
+}
+
+// keywordMatches returns true if the given keyword is found in the given lower case text.
+//
+// For whole word keywords, the keyword must not be directly preceded or followed by a word
+// character, unless the keyword itself starts or ends with something that isn't part of a word.
+func keywordMatches(keyword *gtsmodel.FilterKeyword, lowerText string) bool {
+	k := strings.ToLower(keyword.Keyword)
+	if k == "" {
+		return false
+	}
+
+	if !keyword.WholeWord {
+		return strings.Contains(lowerText, k)
+	}
+
+	pattern := regexp.QuoteMeta(k)
+	if isWordy(k, true) {
+		pattern = `(?:^|` + nonWord + `)` + pattern
+	}
+	if isWordy(k, false) {
+		pattern = pattern + `(?:$|` + nonWord + `)`
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(lowerText)
+}
+
+var nonWordRegexp = regexp.MustCompile(`^` + nonWord + `$`)
+
+// isWordy returns true if the first (or last) character of the given string is a word character.
+func isWordy(s string, first bool) bool {
+	r := []rune(s)
+	c := r[len(r)-1]
+	if first {
+		c = r[0]
+	}
+	return !nonWordRegexp.MatchString(string(c))
+}
@@ -0,0 +1,151 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+type StatusFilteredTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusFilteredTestSuite) filterWithKeyword(keyword string, wholeWord bool) *gtsmodel.Filter {
+	return &gtsmodel.Filter{
+		ID:          "01G1XWJ8Q8ZV5W9J4P4S3QB6K1",
+		Title:       keyword,
+		Action:      gtsmodel.FilterActionHide,
+		ContextHome: true,
+		Keywords: []*gtsmodel.FilterKeyword{
+			{
+				ID:        "01G1XWJRM1KQ3ZC0JDWH8YNN0M",
+				Keyword:   keyword,
+				WholeWord: wholeWord,
+			},
+		},
+	}
+}
+
+func (suite *StatusFilteredTestSuite) TestMatchWholeWord() {
+	filters := []*gtsmodel.Filter{suite.filterWithKeyword("fnord", true)}
+
+	for content, shouldMatch := range map[string]bool{
+		"<p>there is a fnord here</p>":        true,
+		"<p>FNORD!</p>":                       true,
+		"<p>look at all the fnords</p>":       false,
+		"<p>a fnord<br>on the next line</p>":  true,
+		"<p>nothing to see</p><p>fnord</p>":   true,
+		"<p>unfnordly</p>":                    false,
+		"<p>définitivement pas fnordé</p>":    false,
+		"<p>#fnord is a hashtag, sort of</p>": true,
+	} {
+		matches := visibility.MatchFilters(filters, &gtsmodel.Status{Content: content}, time.Now())
+		if shouldMatch {
+			suite.Len(matches, 1, content)
+		} else {
+			suite.Empty(matches, content)
+		}
+	}
+}
+
+func (suite *StatusFilteredTestSuite) TestMatchPartialWord() {
+	filters := []*gtsmodel.Filter{suite.filterWithKeyword("fnord", false)}
+
+	matches := visibility.MatchFilters(filters, &gtsmodel.Status{Content: "<p>look at all the fnords</p>"}, time.Now())
+	suite.Len(matches, 1)
+	suite.Equal([]string{"fnord"}, matches[0].Keywords)
+}
+
+func (suite *StatusFilteredTestSuite) TestMatchContentWarningAndMedia() {
+	filters := []*gtsmodel.Filter{suite.filterWithKeyword("spiders", true)}
+
+	matches := visibility.MatchFilters(filters, &gtsmodel.Status{ContentWarning: "spiders"}, time.Now())
+	suite.Len(matches, 1)
+
+	matches = visibility.MatchFilters(filters, &gtsmodel.Status{
+		Attachments: []*gtsmodel.MediaAttachment{{Description: "a photo of some spiders"}},
+	}, time.Now())
+	suite.Len(matches, 1)
+}
+
+func (suite *StatusFilteredTestSuite) TestMatchBoost() {
+	filters := []*gtsmodel.Filter{suite.filterWithKeyword("fnord", true)}
+
+	boost := &gtsmodel.Status{
+		BoostOf: &gtsmodel.Status{Content: "<p>fnord</p>"},
+	}
+
+	matches := visibility.MatchFilters(filters, boost, time.Now())
+	suite.Len(matches, 1)
+}
+
+func (suite *StatusFilteredTestSuite) TestExpiredFilterDoesNotMatch() {
+	filter := suite.filterWithKeyword("fnord", true)
+	filter.ExpiresAt = time.Now().Add(-1 * time.Minute)
+
+	matches := visibility.MatchFilters([]*gtsmodel.Filter{filter}, &gtsmodel.Status{Content: "<p>fnord</p>"}, time.Now())
+	suite.Empty(matches)
+}
+
+func (suite *StatusFilteredTestSuite) TestStatusFilteredOut() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	testStatus := &gtsmodel.Status{
+		ID:        "01G1XX04RFE0BCDCVBMHH0PP6S",
+		AccountID: suite.testAccounts["admin_account"].ID,
+		Content:   "<p>here's a fnord for you</p>",
+	}
+
+	// the test filter only warns, so the status shouldn't be filtered out
+	filteredOut, err := suite.filter.StatusFilteredOut(ctx, testStatus, requestingAccount, gtsmodel.FilterContextHome)
+	suite.NoError(err)
+	suite.False(filteredOut)
+
+	filter, err := suite.db.GetFilterByID(ctx, "01G1XMV0ARAWV4MT2SQ1W8GDM8")
+	suite.NoError(err)
+	filter.Action = gtsmodel.FilterActionHide
+	suite.NoError(suite.db.UpdateFilter(ctx, filter, nil))
+
+	// now it hides, so the status should be filtered out in the home context...
+	filteredOut, err = suite.filter.StatusFilteredOut(ctx, testStatus, requestingAccount, gtsmodel.FilterContextHome)
+	suite.NoError(err)
+	suite.True(filteredOut)
+
+	// ...but not in contexts that the filter doesn't apply to
+	filteredOut, err = suite.filter.StatusFilteredOut(ctx, testStatus, requestingAccount, gtsmodel.FilterContextNotifications)
+	suite.NoError(err)
+	suite.False(filteredOut)
+
+	// and never for the account's own statuses
+	testStatus.AccountID = requestingAccount.ID
+	filteredOut, err = suite.filter.StatusFilteredOut(ctx, testStatus, requestingAccount, gtsmodel.FilterContextHome)
+	suite.NoError(err)
+	suite.False(filteredOut)
+}
+
+func TestStatusFilteredTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusFilteredTestSuite))
+}
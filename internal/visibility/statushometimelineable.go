@@ -47,6 +47,16 @@ func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmo
 		return false, nil
 	}
 
+	muted, err := f.StatusMuted(ctx, targetStatus, timelineOwnerAccount)
+	if err != nil {
+		return false, fmt.Errorf("StatusHometimelineable: error checking mutes of status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if muted {
+		l.Debug("status is not hometimelineable because the requester has muted an account involved in it")
+		return false, nil
+	}
+
 	for _, m := range targetStatus.Mentions {
 		if m.TargetAccountID == timelineOwnerAccount.ID {
 			// if we're mentioned we should be able to see the post
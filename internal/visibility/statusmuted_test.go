@@ -0,0 +1,115 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusMutedTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusMutedTestSuite) muteAccount(account *gtsmodel.Account, targetAccount *gtsmodel.Account, notifications bool, expiresAt time.Time) {
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.UserMute{
+		ID:              "01G3GKPV8Z0A7S3Q4X0QJ5ED1T",
+		ExpiresAt:       expiresAt,
+		AccountID:       account.ID,
+		TargetAccountID: targetAccount.ID,
+		Notifications:   notifications,
+	}))
+}
+
+func (suite *StatusMutedTestSuite) TestMutedStatusNotHometimelineable() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	timelineOwner := suite.testAccounts["local_account_1"]
+
+	timelineable, err := suite.filter.StatusHometimelineable(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.True(timelineable)
+
+	suite.muteAccount(timelineOwner, suite.testAccounts["admin_account"], false, time.Time{})
+
+	muted, err := suite.filter.StatusMuted(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.True(muted)
+
+	timelineable, err = suite.filter.StatusHometimelineable(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	timelineable, err = suite.filter.StatusPublictimelineable(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// the status is still visible when looked at directly
+	visible, err := suite.filter.StatusVisible(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.True(visible)
+}
+
+func (suite *StatusMutedTestSuite) TestExpiredMuteIgnored() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	timelineOwner := suite.testAccounts["local_account_1"]
+
+	suite.muteAccount(timelineOwner, suite.testAccounts["admin_account"], false, time.Now().Add(-1*time.Minute))
+
+	timelineable, err := suite.filter.StatusHometimelineable(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
+func (suite *StatusMutedTestSuite) TestNotificationMuted() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["admin_account"]
+
+	notification := &gtsmodel.Notification{
+		NotificationType: gtsmodel.NotificationFave,
+		TargetAccountID:  account.ID,
+		OriginAccountID:  targetAccount.ID,
+	}
+
+	// a mute that only covers statuses doesn't hide notifications
+	suite.muteAccount(account, targetAccount, false, time.Time{})
+
+	muted, err := suite.filter.NotificationMuted(ctx, notification)
+	suite.NoError(err)
+	suite.False(muted)
+
+	mute, err := suite.db.GetMute(ctx, account.ID, targetAccount.ID)
+	suite.NoError(err)
+	mute.Notifications = true
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, mute))
+
+	muted, err = suite.filter.NotificationMuted(ctx, notification)
+	suite.NoError(err)
+	suite.True(muted)
+}
+
+func TestStatusMutedTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusMutedTestSuite))
+}
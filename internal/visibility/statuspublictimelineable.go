@@ -27,6 +27,14 @@ import (
 )
 
 func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
+	return f.statusPublictimelineable(ctx, targetStatus, timelineOwnerAccount, false)
+}
+
+func (f *filter) StatusTagtimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
+	return f.statusPublictimelineable(ctx, targetStatus, timelineOwnerAccount, true)
+}
+
+func (f *filter) statusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account, allowReplies bool) (bool, error) {
 	l := logrus.WithFields(logrus.Fields{
 		"func":     "StatusPublictimelineable",
 		"statusID": targetStatus.ID,
@@ -37,8 +45,8 @@ func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gts
 		return false, nil
 	}
 
-	// Don't timeline a reply
-	if targetStatus.InReplyToURI != "" || targetStatus.InReplyToID != "" || targetStatus.InReplyToAccountID != "" {
+	// Don't timeline a reply, unless this is a timeline where replies are shown
+	if !allowReplies && (targetStatus.InReplyToURI != "" || targetStatus.InReplyToID != "" || targetStatus.InReplyToAccountID != "") {
 		return false, nil
 	}
 
@@ -47,6 +55,18 @@ func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gts
 		return true, nil
 	}
 
+	// statuses of accounts that have been silenced by an admin are kept out of public timelines;
+	// get the account fresh rather than using the one on the status, since it might be out of date
+	targetAccount, err := f.db.GetAccountByID(ctx, targetStatus.AccountID)
+	if err != nil {
+		return false, fmt.Errorf("StatusPublictimelineable: error getting account of status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if !targetAccount.SilencedAt.IsZero() {
+		l.Debug("status is not publicTimelineable because its account has been silenced")
+		return false, nil
+	}
+
 	v, err := f.StatusVisible(ctx, targetStatus, timelineOwnerAccount)
 	if err != nil {
 		return false, fmt.Errorf("StatusPublictimelineable: error checking visibility of status with id %s: %s", targetStatus.ID, err)
@@ -57,5 +77,15 @@ func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gts
 		return false, nil
 	}
 
+	muted, err := f.StatusMuted(ctx, targetStatus, timelineOwnerAccount)
+	if err != nil {
+		return false, fmt.Errorf("StatusPublictimelineable: error checking mutes of status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if muted {
+		l.Debug("status is not publicTimelineable because the requester has muted an account involved in it")
+		return false, nil
+	}
+
 	return true, nil
 }
@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusPublictimelineableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusPublictimelineableTestSuite) TestPublicStatusPublictimelineable() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	timelineOwner := suite.testAccounts["local_account_2"]
+
+	timelineable, err := suite.filter.StatusPublictimelineable(context.Background(), testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
+func (suite *StatusPublictimelineableTestSuite) TestSilencedAccountStatusNotPublictimelineable() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	timelineOwner := suite.testAccounts["local_account_2"]
+
+	silenced := suite.testAccounts["local_account_1"]
+	silenced.SilencedAt = time.Now()
+	if _, err := suite.db.UpdateAccount(ctx, silenced); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	timelineable, err := suite.filter.StatusPublictimelineable(ctx, testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// the silenced account should still see its own statuses
+	timelineable, err = suite.filter.StatusPublictimelineable(ctx, testStatus, silenced)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
+func (suite *StatusPublictimelineableTestSuite) TestReplyTagtimelineable() {
+	// this is a public reply to one of zork's statuses
+	testStatus := suite.testStatuses["admin_account_status_3"]
+	timelineOwner := suite.testAccounts["local_account_2"]
+
+	// replies are left out of public timelines...
+	timelineable, err := suite.filter.StatusPublictimelineable(context.Background(), testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// ...but shown in hashtag timelines
+	timelineable, err = suite.filter.StatusTagtimelineable(context.Background(), testStatus, timelineOwner)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
+func TestStatusPublictimelineableTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPublictimelineableTestSuite))
+}
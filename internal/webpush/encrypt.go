@@ -0,0 +1,182 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// recordSize is the record size used for the aes128gcm content coding.
+	// Every message is sent as a single record, so this is also the maximum ciphertext size.
+	recordSize = 4096
+	// headerSize is the size of the aes128gcm header: salt, record size, key id length and a P-256 public key.
+	headerSize = 16 + 4 + 1 + 65
+	// MaxPayloadSize is the largest payload that can be sent in a single push message,
+	// leaving room for the header, the padding delimiter and the AEAD tag within 4096 bytes.
+	MaxPayloadSize = recordSize - headerSize - 1 - 16
+)
+
+// ValidateKeys checks that the given base64 encoded public key and authentication
+// secret of a client can be used to encrypt push messages for that client.
+func ValidateKeys(p256dh string, auth string) error {
+	uaPublic, err := decodeBase64(p256dh)
+	if err != nil {
+		return fmt.Errorf("p256dh is not valid base64: %s", err)
+	}
+
+	if x, _ := elliptic.Unmarshal(elliptic.P256(), uaPublic); x == nil {
+		return errors.New("p256dh is not an uncompressed P-256 public key")
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return fmt.Errorf("auth is not valid base64: %s", err)
+	}
+
+	if len(authSecret) != 16 {
+		return errors.New("auth must be 16 bytes")
+	}
+
+	return nil
+}
+
+// encrypt encrypts the given payload for a user agent with the given base64url encoded
+// public key and authentication secret, as described in RFC 8291, and returns the
+// resulting aes128gcm (RFC 8188) message body.
+func encrypt(payload []byte, p256dh string, auth string) ([]byte, error) {
+	uaPublic, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: error decoding p256dh key: %s", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: error decoding auth secret: %s", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("encrypt: error generating salt: %s", err)
+	}
+
+	// every message is encrypted with a fresh application server keypair
+	asKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: error generating ephemeral key: %s", err)
+	}
+
+	return encryptWithKeys(payload, uaPublic, authSecret, salt, asKey.D.FillBytes(make([]byte, 32)))
+}
+
+// encryptWithKeys does the actual work of encrypt, with the salt and the application
+// server private key supplied by the caller so that the output is deterministic.
+func encryptWithKeys(payload []byte, uaPublic []byte, authSecret []byte, salt []byte, asPrivate []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, fmt.Errorf("encrypt: payload of %d bytes exceeds the maximum of %d bytes", len(payload), MaxPayloadSize)
+	}
+
+	if len(authSecret) != 16 {
+		return nil, errors.New("encrypt: auth secret must be 16 bytes")
+	}
+
+	curve := elliptic.P256()
+
+	uaX, uaY := elliptic.Unmarshal(curve, uaPublic)
+	if uaX == nil {
+		return nil, errors.New("encrypt: p256dh is not an uncompressed P-256 public key")
+	}
+
+	asX, asY := curve.ScalarBaseMult(asPrivate)
+	asPublic := elliptic.Marshal(curve, asX, asY)
+
+	sharedX, _ := curve.ScalarMult(uaX, uaY, asPrivate)
+	ecdhSecret := sharedX.FillBytes(make([]byte, 32))
+
+	// combine the shared secret with the auth secret (RFC 8291 section 3.3)
+	keyInfo := []byte("WebPush: info\x00")
+	keyInfo = append(keyInfo, uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdfExpand(hkdfExtract(authSecret, ecdhSecret), keyInfo, 32)
+
+	// derive the content encryption key and nonce (RFC 8188 section 2.2 and 2.3)
+	prk := hkdfExtract(salt, ikm)
+	cek := hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: error creating cipher: %s", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: error creating gcm: %s", err)
+	}
+
+	// a single record, so it's terminated with the last record padding delimiter
+	plaintext := make([]byte, 0, len(payload)+1)
+	plaintext = append(plaintext, payload...)
+	plaintext = append(plaintext, 0x02)
+
+	rs := make([]byte, 4)
+	binary.BigEndian.PutUint32(rs, recordSize)
+
+	body := make([]byte, 0, headerSize+len(plaintext)+gcm.Overhead())
+	body = append(body, salt...)
+	body = append(body, rs...)
+	body = append(body, byte(len(asPublic)))
+	body = append(body, asPublic...)
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+// hkdfExtract is the HKDF extract step from RFC 5869, using SHA-256.
+func hkdfExtract(salt []byte, ikm []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+// hkdfExpand is the HKDF expand step from RFC 5869, using SHA-256.
+// It only produces a single block of output, which is all that Web Push ever needs.
+func hkdfExpand(prk []byte, info []byte, length int) []byte {
+	mac := hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+// decodeBase64 decodes keys sent by clients, which are meant to be base64url
+// without padding, but which are sent in other base64 flavours often enough.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
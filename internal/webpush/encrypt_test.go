@@ -0,0 +1,167 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EncryptTestSuite checks message encryption against the example given in RFC 8291 appendix A:
+// https://www.rfc-editor.org/rfc/rfc8291#appendix-A
+type EncryptTestSuite struct {
+	suite.Suite
+}
+
+const (
+	rfcPlaintext = "When I grow up, I want to be a watermelon"
+	rfcASPrivate = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfcUAPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	rfcUAPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfcAuth      = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfcSalt      = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfcMessage   = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func (suite *EncryptTestSuite) decode(s string) []byte {
+	b, err := decodeBase64(s)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return b
+}
+
+// decrypt does what a user agent would do on receiving a message encrypted for it.
+func (suite *EncryptTestSuite) decrypt(message []byte, uaPrivate []byte, auth []byte) []byte {
+	salt := message[:16]
+	suite.Equal(uint32(recordSize), binary.BigEndian.Uint32(message[16:20]))
+	idLen := int(message[20])
+	asPublic := message[21 : 21+idLen]
+	ciphertext := message[21+idLen:]
+
+	curve := elliptic.P256()
+	uaX, uaY := curve.ScalarBaseMult(uaPrivate)
+	uaPublic := elliptic.Marshal(curve, uaX, uaY)
+	asX, asY := elliptic.Unmarshal(curve, asPublic)
+	sharedX, _ := curve.ScalarMult(asX, asY, uaPrivate)
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm := hkdfExpand(hkdfExtract(auth, sharedX.FillBytes(make([]byte, 32))), keyInfo, 32)
+	prk := hkdfExtract(salt, ikm)
+
+	block, err := aes.NewCipher(hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	plaintext, err := gcm.Open(nil, hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12), ciphertext, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// strip the padding delimiter
+	suite.Equal(byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func (suite *EncryptTestSuite) TestEncryptRFCExample() {
+	message, err := encryptWithKeys([]byte(rfcPlaintext), suite.decode(rfcUAPublic), suite.decode(rfcAuth), suite.decode(rfcSalt), suite.decode(rfcASPrivate))
+	suite.NoError(err)
+	suite.Equal(rfcMessage, base64.RawURLEncoding.EncodeToString(message))
+}
+
+func (suite *EncryptTestSuite) TestEncryptRoundTrip() {
+	// padded standard base64 should be accepted just as well as base64url
+	p256dh := base64.StdEncoding.EncodeToString(suite.decode(rfcUAPublic))
+	auth := base64.StdEncoding.EncodeToString(suite.decode(rfcAuth))
+
+	message, err := encrypt([]byte(rfcPlaintext), p256dh, auth)
+	suite.NoError(err)
+	suite.Len(message, headerSize+len(rfcPlaintext)+1+16)
+
+	// a fresh salt is used every time
+	suite.NotEqual(rfcSalt, base64.RawURLEncoding.EncodeToString(message[:16]))
+
+	suite.Equal(rfcPlaintext, string(suite.decrypt(message, suite.decode(rfcUAPrivate), suite.decode(rfcAuth))))
+}
+
+func (suite *EncryptTestSuite) TestEncryptTooLarge() {
+	_, err := encrypt(make([]byte, MaxPayloadSize+1), rfcUAPublic, rfcAuth)
+	suite.EqualError(err, "encrypt: payload of 3994 bytes exceeds the maximum of 3993 bytes")
+}
+
+func (suite *EncryptTestSuite) TestEncryptBadKey() {
+	_, err := encrypt([]byte(rfcPlaintext), rfcAuth, rfcAuth)
+	suite.EqualError(err, "encrypt: p256dh is not an uncompressed P-256 public key")
+}
+
+func (suite *EncryptTestSuite) TestVAPIDAuthorization() {
+	publicKey, privateKey, err := generateVAPIDKeys()
+	suite.NoError(err)
+
+	keyPair := &gtsmodel.VAPIDKeyPair{
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+	}
+	now := time.Date(2022, time.May, 30, 14, 15, 23, 0, time.UTC)
+
+	authorization, err := vapidAuthorization("https://push.example.org/send/some-token?foo=bar", keyPair, "https://localhost:8080", now)
+	suite.NoError(err)
+	suite.True(strings.HasPrefix(authorization, "vapid t="))
+	suite.True(strings.HasSuffix(authorization, ", k="+publicKey))
+
+	token := strings.TrimSuffix(strings.TrimPrefix(authorization, "vapid t="), ", k="+publicKey)
+	parts := strings.Split(token, ".")
+	suite.Len(parts, 3)
+
+	claims := map[string]interface{}{}
+	suite.NoError(json.Unmarshal(suite.decode(parts[1]), &claims))
+	suite.Equal("https://push.example.org", claims["aud"])
+	suite.Equal("https://localhost:8080", claims["sub"])
+	suite.EqualValues(now.Add(12*time.Hour).Unix(), claims["exp"])
+
+	// the signature should verify with the public key
+	curve := elliptic.P256()
+	x, y := elliptic.Unmarshal(curve, suite.decode(publicKey))
+	signature := suite.decode(parts[2])
+	suite.Len(signature, 64)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	suite.True(ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])))
+}
+
+func TestEncryptTestSuite(t *testing.T) {
+	suite.Run(t, &EncryptTestSuite{})
+}
@@ -0,0 +1,232 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+const (
+	// messageTTL is how long, in seconds, a push service should hold on to a
+	// message for a user agent that isn't currently reachable.
+	messageTTL = 48 * 60 * 60
+	// maxAttempts is the maximum number of times delivery of a message is attempted.
+	maxAttempts = 5
+	// requestTimeout is how long one delivery attempt may take before it's abandoned,
+	// so that a stalled push service can't hold up delivery forever.
+	requestTimeout = 30 * time.Second
+)
+
+// Sender contains functions for delivering Web Push messages to push subscriptions.
+type Sender interface {
+	// VAPIDPublicKey returns the base64url encoded public VAPID key of this instance,
+	// which clients need in order to create push subscriptions.
+	//
+	// If the instance doesn't have a VAPID keypair yet, one will be generated and stored.
+	VAPIDPublicKey(ctx context.Context) (string, error)
+
+	// Send encrypts the given payload for the given subscription, and delivers it to the push service of the subscription.
+	//
+	// Delivery happens asynchronously and is retried if the push service is unavailable. An error will only be
+	// returned if the message couldn't be prepared. If the push service reports that the subscription no longer
+	// exists, the subscription will be deleted.
+	Send(ctx context.Context, subscription *gtsmodel.PushSubscription, payload []byte) error
+}
+
+// HTTPClient is the subset of *http.Client used for delivering push messages.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NewSender returns a new Web Push Sender, which will use the given db to store
+// the instance VAPID keypair, and the given client to deliver push messages.
+func NewSender(db db.DB, client HTTPClient) Sender {
+	return &sender{
+		db:      db,
+		client:  client,
+		backoff: 5 * time.Second,
+		timeout: requestTimeout,
+	}
+}
+
+type sender struct {
+	db      db.DB
+	client  HTTPClient
+	backoff time.Duration
+	timeout time.Duration
+
+	keyPair   *gtsmodel.VAPIDKeyPair
+	keyPairMu sync.Mutex
+}
+
+func (s *sender) VAPIDPublicKey(ctx context.Context) (string, error) {
+	keyPair, err := s.getKeyPair(ctx)
+	if err != nil {
+		return "", err
+	}
+	return keyPair.PublicKey, nil
+}
+
+func (s *sender) Send(ctx context.Context, subscription *gtsmodel.PushSubscription, payload []byte) error {
+	keyPair, err := s.getKeyPair(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return fmt.Errorf("Send: error encrypting payload for subscription %s: %s", subscription.ID, err)
+	}
+
+	subject := viper.GetString(config.Keys.Protocol) + "://" + viper.GetString(config.Keys.Host)
+	authorization, err := vapidAuthorization(subscription.Endpoint, keyPair, subject, time.Now())
+	if err != nil {
+		return fmt.Errorf("Send: error creating vapid authorization for subscription %s: %s", subscription.ID, err)
+	}
+
+	go s.deliver(subscription, body, authorization)
+	return nil
+}
+
+// getKeyPair returns the VAPID keypair of this instance, generating and storing a new one if there isn't one yet.
+func (s *sender) getKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error) {
+	s.keyPairMu.Lock()
+	defer s.keyPairMu.Unlock()
+
+	if s.keyPair != nil {
+		return s.keyPair, nil
+	}
+
+	keyPair, err := s.db.GetVAPIDKeyPair(ctx)
+	if err == nil {
+		s.keyPair = keyPair
+		return keyPair, nil
+	}
+	if err != db.ErrNoEntries {
+		return nil, fmt.Errorf("getKeyPair: db error getting vapid keypair: %s", err)
+	}
+
+	publicKey, privateKey, err := generateVAPIDKeys()
+	if err != nil {
+		return nil, fmt.Errorf("getKeyPair: error generating vapid keypair: %s", err)
+	}
+
+	keyPairID, err := id.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Put(ctx, &gtsmodel.VAPIDKeyPair{
+		ID:         keyPairID,
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+	}); err != nil {
+		return nil, fmt.Errorf("getKeyPair: db error putting vapid keypair: %s", err)
+	}
+
+	// get the keypair again rather than using the one we just made, so that
+	// if another instance process raced us to it we both end up with the oldest
+	keyPair, err = s.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getKeyPair: db error getting vapid keypair: %s", err)
+	}
+
+	s.keyPair = keyPair
+	return keyPair, nil
+}
+
+// deliver posts the encrypted body to the subscription endpoint, retrying with increasing backoff
+// while the push service is unreachable or overloaded.
+func (s *sender) deliver(subscription *gtsmodel.PushSubscription, body []byte, authorization string) {
+	l := logrus.WithFields(logrus.Fields{
+		"func":         "deliver",
+		"subscription": subscription.ID,
+	})
+
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(subscription, body, authorization)
+		if err == nil {
+			return
+		}
+
+		if !retry || attempt >= maxAttempts {
+			l.Errorf("giving up delivering push message after %d attempt(s): %s", attempt, err)
+			return
+		}
+
+		l.Debugf("attempt %d at delivering push message failed, will retry: %s", attempt, err)
+		time.Sleep(s.backoff * time.Duration(attempt))
+	}
+}
+
+// post does a single delivery attempt, and returns whether it's worth trying again if it failed.
+func (s *sender) post(subscription *gtsmodel.PushSubscription, body []byte, authorization string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(messageTTL))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// this includes the attempt timing out, which is worth retrying like any other network error
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body) //nolint
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// the user agent unsubscribed, so the subscription is no use anymore
+		if err := s.db.DeleteByID(context.Background(), subscription.ID, &gtsmodel.PushSubscription{}); err != nil {
+			return false, fmt.Errorf("subscription has expired, but there was a db error deleting it: %s", err)
+		}
+		logrus.Debugf("post: push subscription %s has expired and was deleted", subscription.ID)
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("push service returned %s", resp.Status)
+	default:
+		return false, fmt.Errorf("push service returned %s", resp.Status)
+	}
+}
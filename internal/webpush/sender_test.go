@@ -0,0 +1,228 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// SenderTestSuite delivers push messages to a local stand-in for a push service.
+type SenderTestSuite struct {
+	suite.Suite
+	db db.DB
+
+	// status codes for the push service to respond with, in order; 201 when they run out
+	statusCodes []int
+	// requests received by the push service, with their bodies
+	received chan *http.Request
+	bodies   chan []byte
+
+	server       *httptest.Server
+	sender       webpush.Sender
+	subscription *gtsmodel.PushSubscription
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB()
+	testrig.CreateTestTables(suite.db)
+
+	suite.statusCodes = nil
+	suite.received = make(chan *http.Request, 10)
+	suite.bodies = make(chan []byte, 10)
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			panic(err)
+		}
+		suite.received <- r
+		suite.bodies <- body
+
+		statusCode := http.StatusCreated
+		if len(suite.statusCodes) > 0 {
+			statusCode = suite.statusCodes[0]
+			suite.statusCodes = suite.statusCodes[1:]
+		}
+		w.WriteHeader(statusCode)
+	}))
+
+	suite.sender = webpush.NewSender(suite.db, suite.server.Client())
+	webpush.SetBackoff(suite.sender, time.Millisecond)
+
+	suite.subscription = &gtsmodel.PushSubscription{
+		ID:        "01G4A7GNP8ZE4R4QMCDQ9SKVF8",
+		AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+		TokenID:   "01F8MGTQW4DKTDF8SW5CT9HYGA",
+		Endpoint:  suite.server.URL + "/send/some-token",
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	}
+	if err := suite.db.Put(context.Background(), suite.subscription); err != nil {
+		panic(err)
+	}
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	suite.server.Close()
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// receive waits for the push service to receive a request.
+func (suite *SenderTestSuite) receive() (*http.Request, []byte) {
+	select {
+	case r := <-suite.received:
+		return r, <-suite.bodies
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for push message")
+	}
+	return nil, nil
+}
+
+func (suite *SenderTestSuite) TestVAPIDPublicKey() {
+	publicKey, err := suite.sender.VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+	suite.NotEmpty(publicKey)
+
+	// the generated keypair is stored, so other senders use the same one
+	otherPublicKey, err := webpush.NewSender(suite.db, suite.server.Client()).VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+	suite.Equal(publicKey, otherPublicKey)
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	publicKey, err := suite.sender.VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+
+	err = suite.sender.Send(context.Background(), suite.subscription, []byte(`{"title":"hello"}`))
+	suite.NoError(err)
+
+	r, body := suite.receive()
+	suite.Equal(http.MethodPost, r.Method)
+	suite.Equal("/send/some-token", r.URL.Path)
+	suite.Equal("aes128gcm", r.Header.Get("Content-Encoding"))
+	suite.Equal("application/octet-stream", r.Header.Get("Content-Type"))
+	suite.Equal("172800", r.Header.Get("TTL"))
+	suite.Equal("normal", r.Header.Get("Urgency"))
+	suite.True(strings.HasPrefix(r.Header.Get("Authorization"), "vapid t="))
+	suite.True(strings.HasSuffix(r.Header.Get("Authorization"), ", k="+publicKey))
+
+	// header, then the encrypted payload and delimiter, then the tag
+	suite.Len(body, 86+len(`{"title":"hello"}`)+1+16)
+}
+
+func (suite *SenderTestSuite) TestSendRetry() {
+	suite.statusCodes = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+
+	err := suite.sender.Send(context.Background(), suite.subscription, []byte(`{"title":"hello"}`))
+	suite.NoError(err)
+
+	// the same message is tried again until the push service accepts it
+	_, first := suite.receive()
+	_, second := suite.receive()
+	_, third := suite.receive()
+	suite.Equal(first, second)
+	suite.Equal(first, third)
+
+	// the subscription is still there
+	_, err = suite.db.GetPushSubscriptionByTokenID(context.Background(), suite.subscription.TokenID)
+	suite.NoError(err)
+}
+
+func (suite *SenderTestSuite) TestSendTimeout() {
+	// this push service stalls on the first request, and then behaves
+	attempts := make(chan struct{}, 10)
+	release := make(chan struct{})
+	first := make(chan struct{}, 1)
+	first <- struct{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			panic(err)
+		}
+		attempts <- struct{}{}
+		select {
+		case <-first:
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		default:
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sender := webpush.NewSender(suite.db, server.Client())
+	webpush.SetBackoff(sender, time.Millisecond)
+	webpush.SetTimeout(sender, 50*time.Millisecond)
+
+	subscription := *suite.subscription
+	subscription.Endpoint = server.URL + "/send/some-token"
+
+	err := sender.Send(context.Background(), &subscription, []byte(`{"title":"hello"}`))
+	suite.NoError(err)
+
+	// the stalled attempt is abandoned, and the message is tried again
+	for i := 0; i < 2; i++ {
+		select {
+		case <-attempts:
+		case <-time.After(5 * time.Second):
+			suite.FailNow("timed out waiting for push message")
+		}
+	}
+}
+
+func (suite *SenderTestSuite) TestSendExpired() {
+	suite.statusCodes = []int{http.StatusGone}
+
+	err := suite.sender.Send(context.Background(), suite.subscription, []byte(`{"title":"hello"}`))
+	suite.NoError(err)
+	suite.receive()
+
+	// the subscription gets deleted, and there are no retries
+	suite.Eventually(func() bool {
+		_, err := suite.db.GetPushSubscriptionByTokenID(context.Background(), suite.subscription.TokenID)
+		return err == db.ErrNoEntries
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Empty(suite.received)
+}
+
+func (suite *SenderTestSuite) TestSendTooLarge() {
+	err := suite.sender.Send(context.Background(), suite.subscription, make([]byte, webpush.MaxPayloadSize+1))
+	suite.Error(err)
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, &SenderTestSuite{})
+}
@@ -0,0 +1,108 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// vapidTokenLifetime is how long a signed VAPID token stays valid.
+// RFC 8292 says this must not be more than 24 hours.
+const vapidTokenLifetime = 12 * time.Hour
+
+// generateVAPIDKeys generates a new P-256 keypair, and returns the uncompressed
+// public key and the private key scalar, both encoded as base64url without padding.
+func generateVAPIDKeys() (publicKey string, privateKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey = base64.RawURLEncoding.EncodeToString(elliptic.Marshal(key.Curve, key.X, key.Y))
+	privateKey = base64.RawURLEncoding.EncodeToString(key.D.FillBytes(make([]byte, 32)))
+	return publicKey, privateKey, nil
+}
+
+// vapidAuthorization returns the value of the Authorization header for delivering a push
+// message to the given endpoint, using the given keypair and subject, as described in RFC 8292.
+func vapidAuthorization(endpoint string, keyPair *gtsmodel.VAPIDKeyPair, subject string, now time.Time) (string, error) {
+	if keyPair.PublicKey == "" {
+		return "", errors.New("vapidAuthorization: keypair has no public key")
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("vapidAuthorization: error parsing endpoint: %s", err)
+	}
+
+	privateKeyBytes, err := base64.RawURLEncoding.DecodeString(keyPair.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("vapidAuthorization: error decoding private key: %s", err)
+	}
+
+	curve := elliptic.P256()
+	privateKey := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(privateKeyBytes)}
+	privateKey.Curve = curve
+	privateKey.X, privateKey.Y = curve.ScalarBaseMult(privateKeyBytes)
+
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "ES256",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("vapidAuthorization: error signing token: %s", err)
+	}
+
+	// ES256 signatures are the raw r and s values, not ASN.1
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return fmt.Sprintf("vapid t=%s, k=%s", token, keyPair.PublicKey), nil
+}
@@ -91,12 +91,20 @@ var TestDefaults = config.Values{
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
+	StorageS3Endpoint:    "",
+	StorageS3Region:      "us-east-1",
+	StorageS3Bucket:      "",
+	StorageS3AccessKey:   "",
+	StorageS3SecretKey:   "",
+	StorageS3UseSSL:      true,
+	StorageS3ServeMode:   "proxy",
 
 	StatusesMaxChars:           5000,
 	StatusesCWMaxChars:         100,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesMaxPinned:          5,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         0,
@@ -120,4 +128,13 @@ var TestDefaults = config.Values{
 	SyslogEnabled:  false,
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
+
+	RateLimitEnabled:        true,
+	RateLimitRequests:       300,
+	RateLimitDomainRequests: 1500,
+	RateLimitSignInRequests: 25,
+	RateLimitSignUpRequests: 25,
+	RateLimitMediaRequests:  30,
+
+	FederationMode: config.FederationModeBlocklist,
 }
@@ -36,6 +36,7 @@ var testModels = []interface{}{
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainAllow{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
@@ -47,11 +48,28 @@ var testModels = []interface{}{
 	&gtsmodel.StatusFave{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.StatusMute{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
+	&gtsmodel.Marker{},
+	&gtsmodel.PushSubscription{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.UserMute{},
 	&gtsmodel.Tag{},
+	&gtsmodel.TagFollow{},
 	&gtsmodel.User{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
+	&gtsmodel.Poll{},
+	&gtsmodel.PollVote{},
+	&gtsmodel.StatusEdit{},
+	&gtsmodel.Delivery{},
+	&gtsmodel.List{},
+	&gtsmodel.ListEntry{},
+	&gtsmodel.Filter{},
+	&gtsmodel.FilterKeyword{},
+	&gtsmodel.Report{},
+	&gtsmodel.ScheduledStatus{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
@@ -142,12 +160,24 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestUserMutes() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
 	for _, v := range NewTestDomainBlocks() {
 		if err := db.Put(ctx, v); err != nil {
 			logrus.Panic(err)
 		}
 	}
 
+	for _, v := range NewTestDomainAllows() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
 	for _, v := range NewTestUsers() {
 		if err := db.Put(ctx, v); err != nil {
 			logrus.Panic(err)
@@ -192,6 +222,30 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestTagFollows() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestConversations() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestConversationToStatuses() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestMarkers() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
 	for _, v := range NewTestMentions() {
 		if err := db.Put(ctx, v); err != nil {
 			logrus.Panic(err)
@@ -204,12 +258,54 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestBookmarks() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
 	for _, v := range NewTestFollows() {
 		if err := db.Put(ctx, v); err != nil {
 			logrus.Panic(err)
 		}
 	}
 
+	for _, v := range NewTestLists() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestListEntries() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestFilters() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestFilterKeywords() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestReports() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestScheduledStatuses() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
 	for _, v := range NewTestNotifications() {
 		if err := db.Put(ctx, v); err != nil {
 			logrus.Panic(err)
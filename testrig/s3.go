@@ -0,0 +1,164 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testrig
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// testS3PageSize is the maximum number of keys returned per bucket listing
+// by the test s3 server. It's deliberately small so that pagination gets exercised.
+const testS3PageSize = 2
+
+// NewTestS3Server returns an in-process stand-in for an s3-compatible object store,
+// which keeps the objects of the given bucket in memory. It supports just enough of
+// the s3 api for the s3 storage backend to work against it: heading the bucket,
+// putting, getting, heading and deleting objects, and listing the bucket (v2).
+//
+// Requests are required to be signed or presigned, but signatures aren't checked.
+//
+// Call Close on the returned server when you're done with it.
+func NewTestS3Server(bucket string) *httptest.Server {
+	s := &testS3{
+		bucket:  bucket,
+		objects: make(map[string]testS3Object),
+	}
+	return httptest.NewServer(s)
+}
+
+type testS3Object struct {
+	contentType string
+	data        []byte
+}
+
+type testS3 struct {
+	bucket  string
+	objects map[string]testS3Object
+	mu      sync.Mutex
+}
+
+func (s *testS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") && r.URL.Query().Get("X-Amz-Signature") == "" {
+		s.error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.bucket {
+		s.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			s.list(w, r)
+		default:
+			s.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		s.objects[key] = testS3Object{
+			contentType: r.Header.Get("Content-Type"),
+			data:        data,
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		object, ok := s.objects[key]
+		if !ok {
+			s.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(object.data)
+		}
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// list writes a ListObjectsV2 response, using the last key of the previous page as continuation token.
+func (s *testS3) list(w http.ResponseWriter, r *http.Request) {
+	keys := make([]string, 0, len(s.objects))
+	for k := range s.objects {
+		if k > r.URL.Query().Get("continuation-token") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	type contents struct {
+		Key string `xml:"Key"`
+	}
+	result := struct {
+		XMLName               xml.Name   `xml:"ListBucketResult"`
+		IsTruncated           bool       `xml:"IsTruncated"`
+		NextContinuationToken string     `xml:"NextContinuationToken,omitempty"`
+		Contents              []contents `xml:"Contents"`
+	}{}
+
+	if len(keys) > testS3PageSize {
+		keys = keys[:testS3PageSize]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, k := range keys {
+		result.Contents = append(result.Contents, contents{Key: k})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (s *testS3) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{
+		Code:    code,
+		Message: http.StatusText(status),
+	})
+}
@@ -22,13 +22,13 @@ import (
 	"fmt"
 	"os"
 
-	"codeberg.org/gruf/go-store/kv"
 	"codeberg.org/gruf/go-store/storage"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // NewTestStorage returns a new in memory storage with the default test config
-func NewTestStorage() *kv.KVStore {
-	storage, err := kv.OpenStorage(storage.OpenMemory(200, false))
+func NewTestStorage() *gtsstorage.Driver {
+	storage, err := gtsstorage.NewDriver(storage.OpenMemory(200, false))
 	if err != nil {
 		panic(err)
 	}
@@ -36,7 +36,7 @@ func NewTestStorage() *kv.KVStore {
 }
 
 // StandardStorageSetup populates the storage with standard test entries from the given directory.
-func StandardStorageSetup(s *kv.KVStore, relativePath string) {
+func StandardStorageSetup(s *gtsstorage.Driver, relativePath string) {
 	storedA := newTestStoredAttachments()
 	a := NewTestAttachments()
 	for k, paths := range storedA {
@@ -93,7 +93,7 @@ func StandardStorageSetup(s *kv.KVStore, relativePath string) {
 }
 
 // StandardStorageTeardown deletes everything in storage so that it's clean for the next test
-func StandardStorageTeardown(s *kv.KVStore) {
+func StandardStorageTeardown(s *gtsstorage.Driver) {
 	iter, err := s.Iterator(nil)
 	if err != nil {
 		panic(err)
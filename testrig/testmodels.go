@@ -115,7 +115,7 @@ func NewTestApplications() map[string]*gtsmodel.Application {
 			RedirectURI:  "http://localhost:8080",
 			ClientID:     "01F8MGWSJCND9BWBD4WGJXBM93",           // admin client
 			ClientSecret: "dda8e835-2c9c-4bd2-9b8b-77c2e26d7a7a", // admin client
-			Scopes:       "read write follow push",
+			Scopes:       "read write follow push admin",
 		},
 		"application_1": {
 			ID:           "01F8MGY43H3N2C8EWPR2FPYEXG",
@@ -298,7 +298,6 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/weed_lord420/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/weed_lord420/collections/featured",
 			ActorType:               ap.ActorPerson,
-			AlsoKnownAs:             "",
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			PublicKeyURI:            "http://localhost:8080/users/weed_lord420#main-key",
@@ -337,7 +336,6 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/admin/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/admin/collections/featured",
 			ActorType:               ap.ActorPerson,
-			AlsoKnownAs:             "",
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			SensitizedAt:            time.Time{},
@@ -374,7 +372,6 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/the_mighty_zork/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/the_mighty_zork/collections/featured",
 			ActorType:               ap.ActorPerson,
-			AlsoKnownAs:             "",
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			PublicKeyURI:            "http://localhost:8080/users/the_mighty_zork/main-key",
@@ -412,7 +409,6 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/1happyturtle/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/1happyturtle/collections/featured",
 			ActorType:               ap.ActorPerson,
-			AlsoKnownAs:             "",
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			PublicKeyURI:            "http://localhost:8080/users/1happyturtle#main-key",
@@ -447,7 +443,6 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:          "http://fossbros-anonymous.io/users/foss_satan/following",
 			FeaturedCollectionURI: "http://fossbros-anonymous.io/users/foss_satan/collections/featured",
 			ActorType:             ap.ActorPerson,
-			AlsoKnownAs:           "",
 			PrivateKey:            &rsa.PrivateKey{},
 			PublicKey:             &rsa.PublicKey{},
 			PublicKeyURI:          "http://fossbros-anonymous.io/users/foss_satan/main-key",
@@ -482,7 +477,6 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:          "http://example.org/users/some_user/following",
 			FeaturedCollectionURI: "http://example.org/users/some_user/collections/featured",
 			ActorType:             ap.ActorPerson,
-			AlsoKnownAs:           "",
 			PrivateKey:            &rsa.PrivateKey{},
 			PublicKey:             &rsa.PublicKey{},
 			PublicKeyURI:          "http://example.org/users/some_user#main-key",
@@ -873,6 +867,18 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 	}
 }
 
+func NewTestDomainAllows() map[string]*gtsmodel.DomainAllow {
+	return map[string]*gtsmodel.DomainAllow{
+		"fossbros-anonymous.io": {
+			ID:                 "01G58KBW6Q2M0ZJ2W2NWQ3XVG0",
+			Domain:             "fossbros-anonymous.io",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			PrivateComment:     "they're loud but they're mostly harmless",
+			PublicComment:      "open source enthusiasts",
+		},
+	}
+}
+
 type filenames struct {
 	Original string
 	Small    string
@@ -1318,6 +1324,74 @@ func NewTestTags() map[string]*gtsmodel.Tag {
 	}
 }
 
+// NewTestTagFollows returns a map of gts model tag follows keyed by the account and tag.
+func NewTestTagFollows() map[string]*gtsmodel.TagFollow {
+	return map[string]*gtsmodel.TagFollow{
+		"local_account_2_welcome": {
+			ID:        "01G3PK0R6SCRN7CAXM5FQ7TKGY",
+			CreatedAt: time.Now().Add(-1 * time.Hour),
+			UpdatedAt: time.Now().Add(-1 * time.Hour),
+			AccountID: "01F8MH5NBDF2MV7CTC4Q5128HF",
+			TagID:     "01F8MHA1A2NF9MJ3WCCQ3K8BSZ",
+		},
+	}
+}
+
+// NewTestConversations returns a map of gts model conversations keyed by the account that owns them and the status they end with.
+func NewTestConversations() map[string]*gtsmodel.Conversation {
+	return map[string]*gtsmodel.Conversation{
+		"local_account_1_local_account_2_status_6": {
+			ID:               "01G3V9DG7Y3PEFS2S9C3PYZ7N3",
+			CreatedAt:        TimeMustParse("2021-11-20T12:39:25+01:00"),
+			UpdatedAt:        TimeMustParse("2021-11-20T12:39:25+01:00"),
+			AccountID:        "01F8MH1H7YV1Z7D2C8K2730QBF",
+			OtherAccountIDs:  []string{"01F8MH5NBDF2MV7CTC4Q5128HF"},
+			OtherAccountsKey: "01F8MH5NBDF2MV7CTC4Q5128HF",
+			ThreadID:         "01FN3VJGFH10KR7S2PB0GFJZYG",
+			LastStatusID:     "01FN3VJGFH10KR7S2PB0GFJZYG",
+			Read:             false,
+		},
+		"local_account_2_local_account_2_status_6": {
+			ID:               "01G3V9E0M0QW8TJ3YH0FXK9CDR",
+			CreatedAt:        TimeMustParse("2021-11-20T12:39:25+01:00"),
+			UpdatedAt:        TimeMustParse("2021-11-20T12:39:25+01:00"),
+			AccountID:        "01F8MH5NBDF2MV7CTC4Q5128HF",
+			OtherAccountIDs:  []string{"01F8MH1H7YV1Z7D2C8K2730QBF"},
+			OtherAccountsKey: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			ThreadID:         "01FN3VJGFH10KR7S2PB0GFJZYG",
+			LastStatusID:     "01FN3VJGFH10KR7S2PB0GFJZYG",
+			Read:             true,
+		},
+	}
+}
+
+// NewTestConversationToStatuses returns a map of the statuses in test conversations, keyed by conversation and status.
+func NewTestConversationToStatuses() map[string]*gtsmodel.ConversationToStatus {
+	return map[string]*gtsmodel.ConversationToStatus{
+		"local_account_1_local_account_2_status_6": {
+			ConversationID: "01G3V9DG7Y3PEFS2S9C3PYZ7N3",
+			StatusID:       "01FN3VJGFH10KR7S2PB0GFJZYG",
+		},
+		"local_account_2_local_account_2_status_6": {
+			ConversationID: "01G3V9E0M0QW8TJ3YH0FXK9CDR",
+			StatusID:       "01FN3VJGFH10KR7S2PB0GFJZYG",
+		},
+	}
+}
+
+// NewTestMarkers returns a map of gts model timeline markers keyed by their account and timeline.
+func NewTestMarkers() map[string]*gtsmodel.Marker {
+	return map[string]*gtsmodel.Marker{
+		"local_account_1_home": {
+			AccountID:  "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Name:       gtsmodel.MarkerNameHome,
+			UpdatedAt:  time.Now().Add(-1 * time.Minute),
+			Version:    1,
+			LastReadID: "01F8MHAMCHF6Y650WCRSCP4WMY",
+		},
+	}
+}
+
 // NewTestMentions returns a map of gts model mentions keyed by their name.
 func NewTestMentions() map[string]*gtsmodel.Mention {
 	return map[string]*gtsmodel.Mention{
@@ -1394,6 +1468,19 @@ func NewTestFaves() map[string]*gtsmodel.StatusFave {
 	}
 }
 
+// NewTestBookmarks returns a map of gts model bookmarks, keyed in the format [bookmarking_account]_[target_status]
+func NewTestBookmarks() map[string]*gtsmodel.StatusBookmark {
+	return map[string]*gtsmodel.StatusBookmark{
+		"local_account_1_admin_account_status_1": {
+			ID:              "01G3AQTRYT0CXT7SF9X4KBCNYQ",
+			CreatedAt:       time.Now().Add(-47 * time.Hour),
+			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF", // local account 1
+			TargetAccountID: "01F8MH17FWEB39HZJ76B6VXSKF", // admin account
+			StatusID:        "01F8MH75CBF9JFX4ZAD54N0W0R", // admin account status 1
+		},
+	}
+}
+
 // NewTestNotifications returns some notifications for use in testing.
 func NewTestNotifications() map[string]*gtsmodel.Notification {
 	return map[string]*gtsmodel.Notification{
@@ -1435,6 +1522,104 @@ func NewTestFollows() map[string]*gtsmodel.Follow {
 	}
 }
 
+// NewTestLists returns some lists for use in testing.
+func NewTestLists() map[string]*gtsmodel.List {
+	return map[string]*gtsmodel.List{
+		"local_account_1_list_1": {
+			ID:            "01G1CNBTNXGQNRHCM3WVSQHP8T",
+			CreatedAt:     time.Now().Add(-30 * time.Minute),
+			UpdatedAt:     time.Now().Add(-30 * time.Minute),
+			Title:         "cool people",
+			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
+			RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+		},
+	}
+}
+
+// NewTestListEntries returns some list entries for use in testing.
+func NewTestListEntries() map[string]*gtsmodel.ListEntry {
+	return map[string]*gtsmodel.ListEntry{
+		"local_account_1_list_1_entry_1": {
+			ID:        "01G1CNCG5ZCR1PVH8HAK6SN9DM",
+			CreatedAt: time.Now().Add(-30 * time.Minute),
+			UpdatedAt: time.Now().Add(-30 * time.Minute),
+			ListID:    "01G1CNBTNXGQNRHCM3WVSQHP8T",
+			FollowID:  "01F8PYDCE8XE23GRE5DPZJDZDP",
+		},
+	}
+}
+
+// NewTestFilters returns a map of filters keyed according to which account owns them.
+func NewTestFilters() map[string]*gtsmodel.Filter {
+	return map[string]*gtsmodel.Filter{
+		"local_account_1_filter_1": {
+			ID:            "01G1XMV0ARAWV4MT2SQ1W8GDM8",
+			CreatedAt:     time.Now().Add(-20 * time.Minute),
+			UpdatedAt:     time.Now().Add(-20 * time.Minute),
+			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Title:         "fnords",
+			Action:        gtsmodel.FilterActionWarn,
+			ContextHome:   true,
+			ContextPublic: true,
+		},
+	}
+}
+
+// NewTestFilterKeywords returns a map of filter keywords keyed according to which filter they belong to.
+func NewTestFilterKeywords() map[string]*gtsmodel.FilterKeyword {
+	return map[string]*gtsmodel.FilterKeyword{
+		"local_account_1_filter_1_keyword_1": {
+			ID:        "01G1XMVDHYQ50PWG7C7N2ZHWTZ",
+			CreatedAt: time.Now().Add(-20 * time.Minute),
+			UpdatedAt: time.Now().Add(-20 * time.Minute),
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			FilterID:  "01G1XMV0ARAWV4MT2SQ1W8GDM8",
+			Keyword:   "fnord",
+			WholeWord: true,
+		},
+	}
+}
+
+// NewTestReports returns a map of reports keyed according to which account made the report.
+func NewTestReports() map[string]*gtsmodel.Report {
+	return map[string]*gtsmodel.Report{
+		"local_account_2_report_remote_account_1": {
+			ID:              "01G2HMGBG0CYS1QVSFP6KHTN8T",
+			CreatedAt:       time.Now().Add(-15 * time.Minute),
+			UpdatedAt:       time.Now().Add(-15 * time.Minute),
+			URI:             "http://localhost:8080/reports/01G2HMGBG0CYS1QVSFP6KHTN8T",
+			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
+			TargetAccountID: "01F8MH5ZK5VRH73AKHQM6Y9VNX",
+			StatusIDs:       []string{"01FVW7JHQFSFK166WWKR8CBA6M"},
+			Comment:         "dark souls sucks, please yeet this nerd",
+			Category:        gtsmodel.ReportCategoryOther,
+			Forwarded:       true,
+		},
+	}
+}
+
+// NewTestScheduledStatuses returns a map of scheduled statuses keyed according to which account scheduled them.
+func NewTestScheduledStatuses() map[string]*gtsmodel.ScheduledStatus {
+	return map[string]*gtsmodel.ScheduledStatus{
+		"local_account_1_scheduled_status_1": {
+			ID:            "01G36SF3V6Y6V5BF9P4R7PQG7G",
+			CreatedAt:     time.Now().Add(-30 * time.Minute),
+			UpdatedAt:     time.Now().Add(-30 * time.Minute),
+			ScheduledAt:   TimeMustParse("2040-06-01T12:00:00Z"),
+			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
+			ApplicationID: "01F8MGY43H3N2C8EWPR2FPYEXG",
+			Text:          "this is a post from the future!",
+			AttachmentIDs: []string{},
+			Visibility:    gtsmodel.VisibilityPublic,
+			Federated:     true,
+			Boostable:     true,
+			Replyable:     true,
+			Likeable:      true,
+			Language:      "en",
+		},
+	}
+}
+
 func NewTestBlocks() map[string]*gtsmodel.Block {
 	return map[string]*gtsmodel.Block{
 		"local_account_2_block_remote_account_1": {
@@ -1448,6 +1633,19 @@ func NewTestBlocks() map[string]*gtsmodel.Block {
 	}
 }
 
+func NewTestUserMutes() map[string]*gtsmodel.UserMute {
+	return map[string]*gtsmodel.UserMute{
+		"local_account_2_mute_remote_account_2": {
+			ID:              "01G3GJ0Z7WQ8JB3P4N1SF4B4H2",
+			CreatedAt:       time.Now().Add(-1 * time.Hour),
+			UpdatedAt:       time.Now().Add(-1 * time.Hour),
+			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
+			TargetAccountID: "01FHMQX3GAABWSM0S2VZEC2SWC",
+			Notifications:   true,
+		},
+	}
+}
+
 // ActivityWithSignature wraps a pub.Activity along with its signature headers, for testing.
 type ActivityWithSignature struct {
 	Activity        pub.Activity
@@ -1775,6 +1973,14 @@ func NewTestDereferenceRequests(accounts map[string]*gtsmodel.Account) map[strin
 		DateHeader:      date,
 	}
 
+	target = URLMustParse(accounts["local_account_1"].FeaturedCollectionURI)
+	sig, digest, date = GetSignatureForDereference(accounts["remote_account_1"].PublicKeyURI, accounts["remote_account_1"].PrivateKey, target)
+	fossSatanDereferenceZorkFeatured := ActivityWithSignature{
+		SignatureHeader: sig,
+		DigestHeader:    digest,
+		DateHeader:      date,
+	}
+
 	return map[string]ActivityWithSignature{
 		"foss_satan_dereference_zork":                                  fossSatanDereferenceZork,
 		"foss_satan_dereference_zork_public_key":                       fossSatanDereferenceZorkPublicKey,
@@ -1784,6 +1990,7 @@ func NewTestDereferenceRequests(accounts map[string]*gtsmodel.Account) map[strin
 		"foss_satan_dereference_zork_outbox":                           fossSatanDereferenceZorkOutbox,
 		"foss_satan_dereference_zork_outbox_first":                     fossSatanDereferenceZorkOutboxFirst,
 		"foss_satan_dereference_zork_outbox_next":                      fossSatanDereferenceZorkOutboxNext,
+		"foss_satan_dereference_zork_featured":                         fossSatanDereferenceZorkFeatured,
 	}
 }
 
@@ -0,0 +1,35 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testrig
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// NewWebPushSender returns a web push sender that delivers push messages using the given client.
+//
+// Pass NewMockHTTPClient to record deliveries, or nil to use a mock client that accepts everything,
+// so that no remote calls will be made.
+func NewWebPushSender(db db.DB, client webpush.HTTPClient) webpush.Sender {
+	if client == nil {
+		client = NewMockHTTPClient(nil)
+	}
+	return webpush.NewSender(db, client)
+}